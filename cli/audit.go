@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/audit"
+)
+
+// runAudit implements "docfinder audit <openapi-file>".
+func (a *app) runAudit(args []string) error {
+	fs := a.newFlagSet("audit")
+	jsonOutput := fs.Bool("json", false, "Print findings as JSON.")
+	minSeverity := fs.String("min-severity", "low", "Only report findings at or above this severity: low, medium, or high.")
+	failOn := fs.String("fail-on", "high", "Exit with an error if any finding is at or above this severity: low, medium, high, or none.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s audit [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Flags risky patterns: credentials in server URLs, plain HTTP servers, API keys in query strings, overly broad OAuth scopes, and secrets in examples.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	min, err := audit.ParseSeverity(*minSeverity)
+	if err != nil {
+		return err
+	}
+
+	var threshold audit.Severity
+	if *failOn != "none" {
+		if threshold, err = audit.ParseSeverity(*failOn); err != nil {
+			return err
+		}
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	findings := audit.AtLeast(audit.Run(doc), min)
+
+	if *jsonOutput {
+		if findings == nil {
+			findings = []audit.Finding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+	} else if len(findings) == 0 {
+		fmt.Fprintln(a.stdout, "No findings.")
+	} else {
+		for _, f := range findings {
+			fmt.Fprintln(a.stdout, f)
+		}
+	}
+
+	if threshold != 0 {
+		if failing := audit.AtLeast(findings, threshold); len(failing) > 0 {
+			return fmt.Errorf("%d finding(s) at or above %s severity", len(failing), threshold)
+		}
+	}
+
+	return nil
+}
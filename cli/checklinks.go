@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/links"
+)
+
+// runCheckLinks implements "docfinder check-links <openapi-file>".
+func (a *app) runCheckLinks(args []string) error {
+	fs := a.newFlagSet("check-links")
+	allow := fs.String("allow", "", "Comma-separated hosts to check, e.g. docs.example.com,*.example.org; links to other hosts are skipped (added to links.allow in config).")
+	deny := fs.String("deny", "", "Comma-separated hosts to skip (added to links.deny in config).")
+	concurrency := fs.Int("concurrency", 0, fmt.Sprintf("Maximum number of links checked at once (default %d).", links.DefaultConcurrency))
+	timeout := fs.Duration("timeout", 0, fmt.Sprintf("Timeout for checking each link (default %s).", links.DefaultTimeout))
+	all := fs.Bool("all", false, "Report every link, not only dead ones.")
+	jsonOutput := fs.Bool("json", false, "Print the results as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s check-links [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Checks the URLs in descriptions, externalDocs, and the API's terms of service, license, and contact, and reports dead links with where the spec mentions them. Exits with an error if any link is dead.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	checkCfg := cfg.Links
+	if *allow != "" {
+		checkCfg.Allow = append(checkCfg.Allow, strings.Split(*allow, ",")...)
+	}
+	if *deny != "" {
+		checkCfg.Deny = append(checkCfg.Deny, strings.Split(*deny, ",")...)
+	}
+	if *concurrency > 0 {
+		checkCfg.Concurrency = *concurrency
+	}
+	if *timeout > 0 {
+		checkCfg.Timeout = *timeout
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	results := links.Check(context.Background(), links.Extract(doc), checkCfg, nil)
+	dead := links.Dead(results)
+	shown := dead
+	if *all {
+		shown = results
+	}
+
+	if *jsonOutput {
+		if shown == nil {
+			shown = []links.Result{}
+		}
+		data, err := json.MarshalIndent(shown, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+	} else {
+		for _, r := range shown {
+			fmt.Fprintln(a.stdout, r)
+		}
+		skipped := 0
+		for _, r := range results {
+			if r.Status == links.StatusSkipped {
+				skipped++
+			}
+		}
+		fmt.Fprintf(a.stdout, "%d link(s): %d dead, %d skipped.\n", len(results), len(dead), skipped)
+	}
+
+	if len(dead) > 0 {
+		return fmt.Errorf("%d dead link(s)", len(dead))
+	}
+	return nil
+}
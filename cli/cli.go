@@ -0,0 +1,1074 @@
+// Package cli implements the docfinder command line. Run executes it with
+// explicit arguments and output streams and returns the exit code, so the
+// whole CLI can be driven from tests and embedded in other tools.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/alias"
+	"github.com/arthur-s/docfinder/internal/config"
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/fuzzy"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/har"
+	"github.com/arthur-s/docfinder/internal/manifest"
+	"github.com/arthur-s/docfinder/internal/notes"
+	"github.com/arthur-s/docfinder/internal/owners"
+	"github.com/arthur-s/docfinder/internal/pager"
+	"github.com/arthur-s/docfinder/internal/plugin"
+	"github.com/arthur-s/docfinder/internal/policy"
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/arthur-s/docfinder/internal/tags"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const maxFileSize = 100 * 1024 * 1024 // 100MB limit
+
+// programName is the command name shown in usage messages.
+const programName = "docfinder"
+
+// flags holds the values of the top-level command-line flags.
+type flags struct {
+	methodFlag              *string
+	badgesFlag              *bool
+	operationIDFlag         *string
+	tagFlag                 *string
+	pathRegexFlag           *string
+	allFlag                 *bool
+	fullFlag                *bool
+	outDirFlag              *string
+	fuzzyFlag               *bool
+	specFlag                *string
+	serviceFlag             *string
+	apiVersionFlag          *string
+	minVersionFlag          *string
+	maxExampleLinesFlag     *int
+	attachDirFlag           *string
+	trimExamplesFlag        *bool
+	sectionsFlag            *string
+	maxDepthFlag            *int
+	foldDepthFlag           *int
+	formatFlag              *string
+	fieldsFlag              *string
+	schemaPathFlag          *string
+	contentTypeFlag         *string
+	preferFlag              *string
+	propertyOrderFlag       *string
+	nullableFlag            *string
+	markdownDescFlag        *bool
+	diagramFlag             *string
+	snippetsFlag            *string
+	outlineFlag             *bool
+	extensionsFlag          *bool
+	typeCaveatsFlag         *bool
+	refAllowFlag            *string
+	offlineFlag             *bool
+	refTimeoutFlag          *time.Duration
+	specHeaderFlag          headerFlags
+	specTimeoutFlag         *time.Duration
+	insecureFlag            *bool
+	envFlag                 *string
+	paramGroupsFlag         *int
+	quickRefFlag            *bool
+	tocMinFlag              *int
+	notesFlag               *string
+	ownersFlag              *string
+	noPagerFlag             *bool
+	failOnMissingFlag       *bool
+	requireFlag             *string
+	rendererFlag            *string
+	verifyDeterministicFlag *bool
+	trafficFlag             *string
+	profileFlag             *string
+	configFlag              *string
+}
+
+// app is a single invocation of the command line. Flag values, the ref
+// policy, and the output streams live here rather than in package state, so
+// Runs don't leak into each other.
+type app struct {
+	flags
+	stdout io.Writer
+	stderr io.Writer
+	// fs parses the top-level flags.
+	fs *flag.FlagSet
+	// refPolicy is the external $ref policy applied by loadOpenAPISpec.
+	refPolicy spec.RefPolicy
+	// canonicalTags renames tags of every spec loaded by loadSpec.
+	canonicalTags map[string]string
+	// fetchOptions configures fetching specs given as URLs.
+	fetchOptions spec.FetchOptions
+	// configApplied is set by applyConfig; specs must not be loaded before.
+	configApplied bool
+}
+
+// newApp returns an app writing to stdout and stderr, with the top-level
+// flags defined.
+func newApp(stdout, stderr io.Writer) *app {
+	a := &app{stdout: stdout, stderr: stderr}
+	fs := a.newFlagSet(programName)
+	a.methodFlag = fs.String("method", "", "HTTP method to filter (GET, POST, PUT, DELETE, PATCH, etc.). If not specified, shows all methods.")
+	a.badgesFlag = fs.Bool("badges", false, "Emit shields.io badges (stability, auth, deprecated, since) at the top of each operation.")
+	a.operationIDFlag = fs.String("operation-id", "", "Select the endpoint by operationId instead of path (follows "+alias.ExtensionPreviousOperationIDs+" renames).")
+	a.tagFlag = fs.String("tag", "", "Render every operation with this tag, across all paths, as one document introduced by the tag's description.")
+	a.pathRegexFlag = fs.String("path-regex", "", "Render every operation of the paths matching this regular expression, e.g. '^/v1/(events|subscriptions)', as one document.")
+	a.allFlag = fs.Bool("all", false, "Render every operation of the spec to its own file in -out-dir, as the export command does, with the other rendering flags given.")
+	a.fullFlag = fs.Bool("full", false, "Render every operation of the spec as one document, grouped by tag, with a table of contents.")
+	a.outDirFlag = fs.String("out-dir", "docs", "Directory -all writes files and "+export.IndexFile+" to.")
+	a.fuzzyFlag = fs.Bool("fuzzy", false, "If the endpoint path matches no path of the spec but exactly one is close, e.g. differing by a typo, render that one.")
+	a.specFlag = fs.String("spec", "", "Service to render from when an endpoint looked up without a spec file is in several specs of the "+manifest.FileName+" registry.")
+	a.serviceFlag = fs.String("service", "", "Service name to look up in the nearest "+manifest.FileName+" manifest instead of passing a spec file.")
+	a.apiVersionFlag = fs.String("api-version", "", "Render the endpoint as it existed in this API version (requires -service with versions in the manifest).")
+	a.minVersionFlag = fs.String("min-version", "", "API version the client is pinned to; hides operations, parameters, and properties not available in it (x-since/x-removed-in).")
+	a.maxExampleLinesFlag = fs.Int("max-example-lines", generator.DefaultMaxExampleLines, "Truncate examples longer than this many lines (0 disables truncation).")
+	a.attachDirFlag = fs.String("attach-dir", "", "Write full payloads of truncated or trimmed examples as .json files into this directory and link them.")
+	a.trimExamplesFlag = fs.Bool("trim-examples", false, "Remove null-valued fields and boilerplate fields (trim_examples in config, default _links and meta.debug) from rendered examples.")
+	a.sectionsFlag = fs.String("sections", "", "Comma-separated sections to render: metadata, parameters, request-body, responses, security, examples (default: all).")
+	a.maxDepthFlag = fs.Int("max-depth", generator.MaxRecursionDepth, "Maximum schema nesting depth to render.")
+	a.foldDepthFlag = fs.Int("fold-depth", 0, "Fold schemas nested deeper than this many levels into collapsible <details> blocks (0 disables).")
+	a.formatFlag = fs.String("format", string(generator.FormatMarkdown), "Output format: markdown; json with the JSON pointer of each element's source location; github-comment, collapsible and sized for a GitHub comment; mdx for Docusaurus; or term, text for a terminal, colored unless piped or NO_COLOR is set.")
+	a.fieldsFlag = fs.String("fields", "", "Comma-separated fields of each operation to print with -format json, e.g. summary,parameters.name,responses.200.schema (default: all).")
+	a.schemaPathFlag = fs.String("schema-path", "", "Render only the sub-schema of each request and response body at this JSONPath-like expression, e.g. $.data.items[*].attributes.")
+	a.contentTypeFlag = fs.String("content-type", "", "Comma-separated media types to render, e.g. application/json or application/* (default: all).")
+	a.preferFlag = fs.String("prefer", "", "Accept-style content preference, e.g. \"application/json;q=1, application/xml;q=0.5\"; renders only the negotiated content type of each response.")
+	a.nullableFlag = fs.String("nullable", string(generator.NullableSuffix), "How to display types that accept null, from 3.0 nullable or a 3.1 \"null\" type: suffix, e.g. string (nullable); field, a separate Nullable line; or union, e.g. string | null.")
+	a.propertyOrderFlag = fs.String("property-order", string(generator.PropertyOrderSpec), "Order of schema properties: spec, as the spec declares them, or alpha.")
+	a.markdownDescFlag = fs.Bool("markdown-descriptions", false, "Render descriptions as sanitized markdown blocks, preserving their tables, lists, and links.")
+	a.diagramFlag = fs.String("diagram", "", "Comma-separated Mermaid diagrams to embed in each operation: sequence, schema.")
+	a.snippetsFlag = fs.String("snippets", "", "Comma-separated languages to show each operation's request in: curl, go, python, js.")
+	a.outlineFlag = fs.Bool("outline", false, "Print only the headings that would be rendered (operations, sections, response codes, content types), to preview before choosing -sections.")
+	a.extensionsFlag = fs.Bool("extensions", false, "Render x- vendor extensions of each operation.")
+	a.typeCaveatsFlag = fs.Bool("type-caveats", false, "Flag body fields whose examples contradict their declared types, e.g. \"123\" for an integer, in a Type Caveats section.")
+	a.refAllowFlag = fs.String("ref-allow", "", "Comma-separated hosts (e.g. schemas.example.com, *.example.com) and path prefixes (e.g. ./shared) external $refs may resolve from (default: any).")
+	a.offlineFlag = fs.Bool("offline", false, "Do not fetch remote $refs; resolve them only from the local ref cache.")
+	a.refTimeoutFlag = fs.Duration("ref-timeout", 0, "Timeout for each remote $ref fetch (default 30s).")
+	a.specHeaderFlag = headerFlags{}
+	fs.Var(a.specHeaderFlag, "spec-header", "Header sent when the spec is an http(s) URL, as \"Name: value\", e.g. for credentials; $VARS are expanded. Repeatable.")
+	a.specTimeoutFlag = fs.Duration("spec-timeout", 0, "Timeout for fetching a spec given as an http(s) URL, redirects included (default 30s).")
+	a.insecureFlag = fs.Bool("insecure", false, "Skip TLS certificate verification when fetching a spec given as an https URL.")
+	a.envFlag = fs.String("env", "", "Only list servers tagged with this "+generator.ExtensionEnvironment+" (e.g. prod).")
+	a.quickRefFlag = fs.Bool("quick-reference", true, "List the required parameters and request body fields at the start of each operation.")
+	a.paramGroupsFlag = fs.Int("param-groups", generator.DefaultParamGroupMin, "Group query parameters into filtering, sorting, pagination, and field selection from this many (0 disables).")
+	a.tocMinFlag = fs.Int("toc-min", generator.DefaultTOCMinOperations, "Add a table of contents when at least this many operations are rendered (0 disables).")
+	a.notesFlag = fs.String("notes", "", "Team notes file rendered with each operation (default: nearest "+notes.FileName+").")
+	a.ownersFlag = fs.String("owners", "", "Owners file mapping path globs to teams, for operations without "+owners.ExtensionOwner+" (default: nearest "+owners.FileName+").")
+	a.noPagerFlag = fs.Bool("no-pager", false, "Never pipe output through a pager, even when it is taller than the terminal.")
+	a.failOnMissingFlag = fs.Bool("fail-on-missing", false, "Exit non-zero if the selected operations lack required documentation (see -require).")
+	a.requireFlag = fs.String("require", "", "Comma-separated documentation requirements for -fail-on-missing: summary, description, operation-id, tags, 4xx-response, request-example, response-example, parameter-descriptions (default: policy.require from config, else summary,4xx-response,request-example).")
+	a.rendererFlag = fs.String("renderer", "", "Render with the "+plugin.Prefix+"<name> plugin instead of the built-in markdown generator.")
+	a.verifyDeterministicFlag = fs.Bool("verify-deterministic", false, "Render twice from independently loaded copies of the spec and fail if the outputs differ.")
+	a.trafficFlag = fs.String("traffic", "", "Comma-separated HAR files; enum values are annotated with how often they appear in the recorded traffic.")
+	a.profileFlag = fs.String("profile", "", "Render profile bundling flags: support, integration, or one of profiles in config. Flags given on the command line override it.")
+	a.configFlag = fs.String("config", "", "Path to configuration file (default: "+config.DefaultFile+" if present).")
+	fs.Usage = a.usage
+	a.fs = fs
+	return a
+}
+
+// newFlagSet returns a flag set reporting errors to stderr instead of
+// exiting the process.
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// Common HTTP methods for validation
+var httpMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"PATCH":   true,
+	"HEAD":    true,
+	"OPTIONS": true,
+	"TRACE":   true,
+	"CONNECT": true,
+}
+
+// subcommands maps subcommand names to their entry points. Each receives the
+// arguments following the subcommand name.
+var subcommands = map[string]func(a *app, args []string) error{
+	"again":         (*app).runAgain,
+	"audit":         (*app).runAudit,
+	"check-links":   (*app).runCheckLinks,
+	"compare":       (*app).runCompare,
+	"complexity":    (*app).runComplexity,
+	"deprecations":  (*app).runDeprecations,
+	"diff":          (*app).runDiff,
+	"doctor":        (*app).runDoctor,
+	"export":        (*app).runExport,
+	"headers":       (*app).runHeaders,
+	"history":       (*app).runHistory,
+	"insomnia":      (*app).runInsomnia,
+	"lint-examples": (*app).runLintExamples,
+	"list":          (*app).runList,
+	"plugins":       (*app).runPlugins,
+	"probe":         (*app).runProbe,
+	"middleware":    (*app).runMiddleware,
+	"migrate":       (*app).runMigrate,
+	"note":          (*app).runNote,
+	"owned-by":      (*app).runOwnedBy,
+	"pack":          (*app).runPack,
+	"returns":       (*app).runReturns,
+	"schema-diff":   (*app).runSchemaDiff,
+	"security":      (*app).runSecurity,
+	"serve":         (*app).runServe,
+	"star":          (*app).runStar,
+	"stats":         (*app).runStats,
+	"sunset":        (*app).runSunset,
+	"tags":          (*app).runTags,
+	"testplan":      (*app).runTestPlan,
+	"watch":         (*app).runWatch,
+}
+
+// Run executes the docfinder command line with args, which exclude the
+// program name, writing output to stdout and diagnostics to stderr. It
+// returns the exit code: 0 on success, 2 for invalid flags, 1 otherwise.
+func Run(args []string, stdout, stderr io.Writer) int {
+	a := newApp(stdout, stderr)
+	return a.exitCode(a.main(args))
+}
+
+// exitCode reports err to stderr and returns the matching exit code. Usage
+// and flag errors have already been reported by the flag set.
+func (a *app) exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errFlags):
+		return 2
+	case errors.Is(err, errUsage):
+		return 1
+	}
+	fmt.Fprintf(a.stderr, "Error: %v\n", err)
+	return 1
+}
+
+func (a *app) usage() {
+	fmt.Fprintf(a.stderr, "Usage:\n")
+	fmt.Fprintf(a.stderr, "  %s [METHOD] <endpoint-path> <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -method METHOD <endpoint-path> <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "  %s [METHOD] <endpoint-path> -service NAME\n", programName)
+	fmt.Fprintf(a.stderr, "  %s [METHOD] <endpoint-path> [-spec NAME]\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -operation-id ID <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -tag TAG <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -path-regex REGEX <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -all [-out-dir DIR] <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -full <openapi-file>\n", programName)
+	fmt.Fprintf(a.stderr, "\nExamples:\n")
+	fmt.Fprintf(a.stderr, "  %s /events/{event_id} openapi.yaml                    # All methods\n", programName)
+	fmt.Fprintf(a.stderr, "  %s GET /events/{event_id} openapi.yaml                # GET only\n", programName)
+	fmt.Fprintf(a.stderr, "  %s PUT /events/{event_id} openapi.yaml                # PUT only\n", programName)
+	fmt.Fprintf(a.stderr, "  %s -method DELETE /events/{event_id} openapi.yaml     # DELETE only\n", programName)
+	fmt.Fprintf(a.stderr, "  %s GET /v1/events -service notify                     # Spec from specs.yaml\n", programName)
+	fmt.Fprintf(a.stderr, "  %s GET /v1/events -service notify -api-version 2023-10 # Historical version\n", programName)
+	fmt.Fprintf(a.stderr, "  %s GET /v1/events                                     # Search every spec in specs.yaml\n", programName)
+	fmt.Fprintf(a.stderr, "\nFlags:\n")
+	a.fs.PrintDefaults()
+	fmt.Fprintf(a.stderr, "\nArguments:\n")
+	fmt.Fprintf(a.stderr, "  METHOD          Optional HTTP method (GET, POST, PUT, DELETE, PATCH, etc.)\n")
+	fmt.Fprintf(a.stderr, "  endpoint-path   API endpoint path to extract documentation for\n")
+	fmt.Fprintf(a.stderr, "  openapi-file    Path to OpenAPI YAML specification file, a directory containing one, or an http(s) URL\n")
+	fmt.Fprintf(a.stderr, "\nCommands:\n")
+	fmt.Fprintf(a.stderr, "  again           Repeat a lookup from the history\n")
+	fmt.Fprintf(a.stderr, "  audit           Scan the spec for leaked credentials and insecure patterns\n")
+	fmt.Fprintf(a.stderr, "  check-links     Report dead links in descriptions and externalDocs\n")
+	fmt.Fprintf(a.stderr, "  compare         Show two operations of an endpoint side by side\n")
+	fmt.Fprintf(a.stderr, "  complexity      Rank operations by schema and parameter complexity\n")
+	fmt.Fprintf(a.stderr, "  deprecations    List deprecated operations with upcoming sunsets by date\n")
+	fmt.Fprintf(a.stderr, "  diff            Report how an endpoint changed between two spec versions\n")
+	fmt.Fprintf(a.stderr, "  doctor          Diagnose the configuration, ref cache, and registered specs\n")
+	fmt.Fprintf(a.stderr, "  export          Write markdown for every operation plus an index.json manifest\n")
+	fmt.Fprintf(a.stderr, "  headers         Tabulate custom request headers across operations\n")
+	fmt.Fprintf(a.stderr, "  history         List recent and favorite lookups\n")
+	fmt.Fprintf(a.stderr, "  insomnia        Export operations as an Insomnia v4 collection\n")
+	fmt.Fprintf(a.stderr, "  lint-examples   Flag defaults and examples that contradict their schema\n")
+	fmt.Fprintf(a.stderr, "  list            List every operation with its operationId, tags, and summary\n")
+	fmt.Fprintf(a.stderr, "  middleware      Generate Go request validation middleware for chi, echo, or gin\n")
+	fmt.Fprintf(a.stderr, "  migrate         Map a deprecated operation's parameters and fields to its replacement\n")
+	fmt.Fprintf(a.stderr, "  note            Add, list, or remove team notes rendered with an operation\n")
+	fmt.Fprintf(a.stderr, "  owned-by        List the endpoints a team owns\n")
+	fmt.Fprintf(a.stderr, "  pack            Archive the docs, search index, and assets for air-gapped use\n")
+	fmt.Fprintf(a.stderr, "  plugins         List installed %s<name> plugins\n", plugin.Prefix)
+	fmt.Fprintf(a.stderr, "  probe           Compare a live server's response with the documented contract\n")
+	fmt.Fprintf(a.stderr, "  returns         List the operations whose success responses return a schema\n")
+	fmt.Fprintf(a.stderr, "  schema-diff     Compare a component schema across two spec versions\n")
+	fmt.Fprintf(a.stderr, "  security        Document security schemes, OAuth2 flows and scopes, and who requires them\n")
+	fmt.Fprintf(a.stderr, "  serve           Serve rendered docs and a JSON API over HTTP\n")
+	fmt.Fprintf(a.stderr, "  star            Star a lookup from the history as a favorite\n")
+	fmt.Fprintf(a.stderr, "  stats           Count a spec's operations and schemas, and track their growth\n")
+	fmt.Fprintf(a.stderr, "  sunset          Report deprecated elements each consumer still uses, from HAR traffic\n")
+	fmt.Fprintf(a.stderr, "  tags            List tags and check them against the top-level tags list\n")
+	fmt.Fprintf(a.stderr, "  testplan        Derive a checklist of test cases for an endpoint from its contract\n")
+	fmt.Fprintf(a.stderr, "  watch           Watch specs and notify webhooks of endpoint changes\n")
+	fmt.Fprintf(a.stderr, "\nRun '%s <command> -h' for command-specific help.\n", programName)
+}
+
+// main runs a subcommand, or renders the endpoint selected by args.
+func (a *app) main(args []string) error {
+	if len(args) > 0 {
+		if cmd, ok := subcommands[args[0]]; ok {
+			return cmd(a, args[1:])
+		}
+	}
+	return a.runLookup(args)
+}
+
+// runLookup renders the endpoint selected by args and records it in the
+// history.
+func (a *app) runLookup(args []string) error {
+	cmdline := args
+	args, err := parseInterspersed(a.fs, args)
+	if err != nil {
+		return err
+	}
+
+	var method, endpointPath, openapiFile string
+	var lookup *registryLookup
+	if m, p, ok := lookupArgs(args); ok && *a.serviceFlag == "" && *a.operationIDFlag == "" && !a.selecting() && !*a.allFlag && !*a.fullFlag {
+		// No spec given: search every spec registered in the manifest
+		method, endpointPath = m, p
+		lookupMethod := method
+		if *a.methodFlag != "" {
+			lookupMethod = *a.methodFlag
+		}
+		if lookup, err = a.lookupRegistry(lookupMethod, endpointPath, *a.specFlag); err == nil {
+			openapiFile = lookup.used.path
+			*a.serviceFlag = lookup.used.service
+		}
+	} else if *a.operationIDFlag != "" || a.selecting() || *a.allFlag || *a.fullFlag {
+		openapiFile, err = resolveOperationArgs(args, *a.serviceFlag)
+	} else {
+		method, endpointPath, openapiFile, err = resolveArgs(args, *a.serviceFlag)
+	}
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			a.fs.Usage()
+		}
+		return err
+	}
+
+	// Flag takes precedence over positional method
+	if *a.methodFlag != "" {
+		method = *a.methodFlag
+	}
+
+	if err := a.run(endpointPath, openapiFile, method, lookup); err != nil {
+		return err
+	}
+	a.recordLookup(cmdline)
+	return nil
+}
+
+// errUsage indicates that the command line does not match any supported form.
+var errUsage = errors.New("invalid arguments")
+
+// errFlags indicates that flags failed to parse.
+var errFlags = errors.New("invalid flags")
+
+// parseInterspersed parses flags that may appear before, between, or after
+// positional arguments, e.g. "GET /events -service notify".
+// Everything after a "--" terminator is treated as positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %w", errFlags, err)
+		}
+
+		rest := fs.Args()
+		consumed := len(args) - len(rest)
+		if consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// resolveArgs maps positional arguments to method, endpoint path, and spec file.
+// When service is set, the spec file comes from the specs.yaml manifest instead
+// of the last positional argument.
+func resolveArgs(args []string, service string) (method, endpointPath, openapiFile string, err error) {
+	if service != "" {
+		switch {
+		case len(args) == 2 && isHTTPMethod(args[0]):
+			method, endpointPath = args[0], args[1]
+		case len(args) == 1:
+			endpointPath = args[0]
+		default:
+			return "", "", "", errUsage
+		}
+
+		openapiFile, err = resolveService(service)
+		return method, endpointPath, openapiFile, err
+	}
+
+	switch {
+	case len(args) == 3 && isHTTPMethod(args[0]):
+		// Positional method syntax
+		// Example: docfinder GET /events/{id} openapi.yaml
+		method, endpointPath, openapiFile = args[0], args[1], args[2]
+	case len(args) == 2:
+		// Standard format
+		// Example: docfinder /events/{id} openapi.yaml
+		// Or: docfinder -method GET /events/{id} openapi.yaml
+		endpointPath, openapiFile = args[0], args[1]
+	default:
+		return "", "", "", errUsage
+	}
+
+	return method, endpointPath, openapiFile, nil
+}
+
+// resolveOperationArgs maps positional arguments to the spec file when the
+// operation is selected with -operation-id, or operations with -tag,
+// -path-regex, -all, or -full.
+func resolveOperationArgs(args []string, service string) (string, error) {
+	if service != "" {
+		if len(args) != 0 {
+			return "", errUsage
+		}
+		return resolveService(service)
+	}
+
+	if len(args) != 1 {
+		return "", errUsage
+	}
+	return args[0], nil
+}
+
+// resolveService looks up the spec location for a service name in the
+// nearest specs.yaml manifest.
+func resolveService(service string) (string, error) {
+	m, err := loadManifest()
+	if err != nil {
+		return "", err
+	}
+
+	return m.SpecPath(service)
+}
+
+// loadManifest loads the nearest specs.yaml manifest above the working directory.
+func loadManifest() (*manifest.Manifest, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	path, err := manifest.Find(wd)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest.Load(path)
+}
+
+// isHTTPMethod checks if a string is a valid HTTP method
+func isHTTPMethod(s string) bool {
+	return httpMethods[strings.ToUpper(s)]
+}
+
+// lookup is set when the spec was found by searching the manifest registry.
+func (a *app) run(endpointPath, openapiFile, method string, lookup *registryLookup) error {
+	cfg, err := config.Load(*a.configFlag)
+	if err != nil {
+		return err
+	}
+
+	// The profile may set flags that the spec loading settings depend on
+	if err := a.applyProfile(cfg); err != nil {
+		return err
+	}
+	a.applyConfig(cfg)
+
+	// Swap in the historical snapshot when a specific API version is requested
+	var laterSnapshots []manifest.Snapshot
+	if *a.apiVersionFlag != "" {
+		openapiFile, laterSnapshots, err = resolveAPIVersion(*a.serviceFlag, *a.apiVersionFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Load OpenAPI specification from a file, directory, or source plugin
+	doc, err := a.loadSpec(openapiFile)
+	if err != nil {
+		return err
+	}
+
+	if a.selecting() {
+		return a.renderSelection(cfg, doc, method)
+	}
+	if *a.allFlag {
+		return a.exportAll(cfg, doc, openapiFile)
+	}
+	if *a.fullFlag {
+		return a.renderAPI(cfg, doc, method)
+	}
+
+	// Select the endpoint by operationId, following renames
+	if *a.operationIDFlag != "" {
+		match, err := alias.Operation(doc, *a.operationIDFlag, cfg.Aliases)
+		if err != nil {
+			return err
+		}
+		if match.Renamed {
+			fmt.Fprintf(a.stderr, "Note: operation '%s' was renamed to '%s' (%s %s)\n",
+				*a.operationIDFlag, match.OperationID, match.Method, match.Path)
+		}
+		endpointPath, method = match.Path, match.Method
+	}
+
+	// Normalize the endpoint path (add leading slash if missing)
+	endpointPath = normalizeEndpointPath(endpointPath)
+
+	// Find the path item, falling back to renamed paths and, with -fuzzy,
+	// a unique close match
+	pathItem, err := findPathItem(doc, endpointPath)
+	if err != nil {
+		if current, ok := alias.Path(doc, endpointPath, cfg.Aliases); ok {
+			fmt.Fprintf(a.stderr, "Note: path '%s' has moved to '%s'\n", endpointPath, current)
+			endpointPath = current
+		} else if closest, ok := a.fuzzyMatch(doc, endpointPath); ok {
+			fmt.Fprintf(a.stderr, "Note: using '%s', the closest match to '%s'\n", closest, endpointPath)
+			endpointPath = closest
+		} else {
+			return err
+		}
+		pathItem = doc.Paths.Value(endpointPath)
+	}
+
+	// Normalize method (convert to uppercase for comparison with OpenAPI operations)
+	method = strings.ToUpper(strings.TrimSpace(method))
+
+	// Validate method if specified
+	if method != "" {
+		if err := validateMethod(pathItem, method); err != nil {
+			return err
+		}
+	}
+
+	opts, err := a.generateOptions(cfg, doc, method)
+	if err != nil {
+		return err
+	}
+
+	// Generate markdown documentation, or hand rendering to a plugin
+	render := func(doc *openapi3.T, pathItem *openapi3.PathItem) (string, error) {
+		var markdown string
+		var err error
+		if *a.rendererFlag != "" {
+			var renderer *plugin.Plugin
+			if renderer, err = a.findPlugin(*a.rendererFlag); err != nil {
+				return "", err
+			}
+			if markdown, err = renderer.Render(context.Background(), doc, endpointPath, method); err != nil {
+				return "", err
+			}
+		} else {
+			gen := generator.New(doc, opts...)
+			if markdown, err = gen.Generate(endpointPath, pathItem); err != nil {
+				return "", err
+			}
+		}
+		if *a.apiVersionFlag != "" && *a.formatFlag != string(generator.FormatJSONDocument) {
+			markdown += a.laterChangesNote(*a.apiVersionFlag, endpointPath, method, pathItem, laterSnapshots)
+		}
+		return markdown, nil
+	}
+
+	markdown, err := render(doc, pathItem)
+	if err != nil {
+		return err
+	}
+
+	// Render again from a fresh copy of the spec, whose maps iterate in a
+	// different order, before showing anything
+	if *a.verifyDeterministicFlag {
+		err := verifyDeterministic(markdown, func() (string, error) {
+			doc, err := a.loadSpec(openapiFile)
+			if err != nil {
+				return "", err
+			}
+			pathItem, err := findPathItem(doc, endpointPath)
+			if err != nil {
+				return "", err
+			}
+			return render(doc, pathItem)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Say which registered spec the endpoint came from
+	if lookup != nil {
+		if *a.formatFlag == string(generator.FormatJSONDocument) || *a.rendererFlag != "" {
+			fmt.Fprintln(a.stderr, lookup.provenanceSummary())
+		} else {
+			markdown = lookup.provenanceNote() + markdown
+		}
+	}
+
+	if err := pager.Page(a.stdout, a.stderr, markdown, *a.noPagerFlag); err != nil {
+		return err
+	}
+
+	// Enforce the documentation policy after the docs are shown, so CI logs
+	// contain both the rendered output and the explanation
+	if *a.failOnMissingFlag {
+		reqs, err := a.policyRequirements(cfg)
+		if err != nil {
+			return err
+		}
+		if violations := policy.CheckPathItem(endpointPath, pathItem, method, reqs); len(violations) > 0 {
+			return &policy.Error{Violations: violations}
+		}
+	}
+
+	return nil
+}
+
+// policyRequirements returns the requirements enforced by -fail-on-missing:
+// those given with -require, else those from config, else the defaults.
+func (a *app) policyRequirements(cfg *config.Config) ([]policy.Requirement, error) {
+	if *a.requireFlag != "" {
+		return policy.ParseRequirements(*a.requireFlag)
+	}
+	return cfg.Policy.Requirements()
+}
+
+// generateOptions builds generator options from command-line flags and config.
+func (a *app) generateOptions(cfg *config.Config, doc *openapi3.T, method string) ([]generator.Option, error) {
+	format, err := generator.ParseFormat(*a.formatFlag)
+	if err != nil {
+		return nil, err
+	}
+	propertyOrder, err := generator.ParsePropertyOrder(*a.propertyOrderFlag)
+	if err != nil {
+		return nil, err
+	}
+	nullable, err := generator.ParseNullableStyle(*a.nullableFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []generator.Option{
+		generator.WithMethod(method),
+		generator.WithFormat(format),
+		generator.WithMaxDepth(*a.maxDepthFlag),
+		generator.WithFoldDepth(*a.foldDepthFlag),
+		generator.WithPropertyOrder(propertyOrder),
+		generator.WithNullable(nullable),
+		generator.WithColor(pager.IsTerminal(a.stdout) && os.Getenv("NO_COLOR") == ""),
+		generator.WithMaxExampleLines(*a.maxExampleLinesFlag),
+		generator.WithTOCMinOperations(*a.tocMinFlag),
+		generator.WithParamGroupMin(*a.paramGroupsFlag),
+		generator.WithQuickReference(*a.quickRefFlag),
+		generator.WithOutline(*a.outlineFlag),
+		generator.WithExtensions(*a.extensionsFlag),
+		generator.WithTypeCaveats(*a.typeCaveatsFlag),
+		generator.WithMarkdownDescriptions(*a.markdownDescFlag),
+		generator.WithMinVersion(*a.minVersionFlag),
+		generator.WithEnvironment(*a.envFlag),
+		generator.WithSchemaPath(*a.schemaPathFlag),
+		generator.WithVocabulary(cfg.Vocabulary),
+		generator.WithPlatformHeaders(cfg.PlatformHeaders),
+	}
+
+	if *a.sectionsFlag != "" {
+		sections, err := generator.ParseSections(*a.sectionsFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithSections(sections...))
+	}
+
+	if *a.fieldsFlag != "" {
+		opts = append(opts, generator.WithFields(strings.Split(*a.fieldsFlag, ",")...))
+	}
+
+	if *a.diagramFlag != "" {
+		diagrams, err := generator.ParseDiagrams(*a.diagramFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithDiagrams(diagrams...))
+	}
+
+	if *a.snippetsFlag != "" {
+		languages, err := generator.ParseSnippets(*a.snippetsFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithSnippets(languages...))
+	}
+
+	if *a.contentTypeFlag != "" {
+		opts = append(opts, generator.WithContentTypes(strings.Split(*a.contentTypeFlag, ",")...))
+	}
+
+	if *a.preferFlag != "" {
+		prefer, err := generator.ParsePreference(*a.preferFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithPrefer(prefer...))
+	}
+
+	if *a.badgesFlag {
+		opts = append(opts, generator.WithBadges(cfg.Badges))
+	}
+
+	ownerships, err := a.ownerships(doc)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, generator.WithOwners(owners.Map(ownerships)))
+
+	notesFile, err := notesPath(*a.notesFlag, false)
+	if err != nil {
+		return nil, err
+	}
+	if notesFile != "" {
+		f, err := notes.Load(notesFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithTeamNotes(f.TeamNotes()))
+	}
+
+	if *a.trafficFlag != "" {
+		entries, err := loadTraffic(cfg, strings.Split(*a.trafficFlag, ","))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, generator.WithEnumUsage(har.EnumUsage(doc, entries)))
+	}
+
+	if *a.trimExamplesFlag {
+		opts = append(opts, generator.WithTrimExamples(cfg.TrimExamples...))
+	}
+
+	if *a.attachDirFlag != "" {
+		opts = append(opts, generator.WithExampleAttacher(&generator.DirAttacher{Dir: *a.attachDirFlag}))
+	}
+
+	return opts, nil
+}
+
+// loadTraffic loads HAR files and masks the secrets and personal data in
+// them as cfg.Masking says, before any recorded value can reach the output.
+func loadTraffic(cfg *config.Config, paths []string) ([]har.Entry, error) {
+	masker, err := har.NewMasker(cfg.Masking)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []har.Entry
+	for _, path := range paths {
+		loaded, err := har.Load(strings.TrimSpace(path))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, loaded...)
+	}
+	masker.Entries(entries)
+	return entries, nil
+}
+
+// loadConfig loads the configuration file and applies its spec loading
+// settings. Commands call it before loading any spec.
+func (a *app) loadConfig() (*config.Config, error) {
+	cfg, err := config.Load(*a.configFlag)
+	if err != nil {
+		return nil, err
+	}
+	a.applyConfig(cfg)
+	return cfg, nil
+}
+
+// applyConfig sets the $ref policy, canonical tags, and fetch options specs
+// are loaded with from cfg and the flags.
+func (a *app) applyConfig(cfg *config.Config) {
+	a.refPolicy = a.buildRefPolicy(cfg)
+	a.canonicalTags = cfg.Tags.Canonical
+	a.fetchOptions = a.buildFetchOptions(cfg)
+	a.configApplied = true
+}
+
+// checkConfigApplied fails if a spec is about to be loaded without the $ref
+// policy and fetch limits of the configuration, which commands must apply
+// with loadConfig first.
+func (a *app) checkConfigApplied() error {
+	if !a.configApplied {
+		return errors.New("internal error: spec loaded before the configuration was applied")
+	}
+	return nil
+}
+
+// buildRefPolicy merges the ref policy from config with command-line flags.
+// Flags extend the allowlist and override the offline mode and timeout.
+func (a *app) buildRefPolicy(cfg *config.Config) spec.RefPolicy {
+	policy := cfg.Refs
+
+	if *a.refAllowFlag != "" {
+		policy.Allow = append(policy.Allow, strings.Split(*a.refAllowFlag, ",")...)
+	}
+	if *a.offlineFlag {
+		policy.Offline = true
+	}
+	if *a.refTimeoutFlag > 0 {
+		policy.Timeout = *a.refTimeoutFlag
+	}
+
+	return policy
+}
+
+// buildFetchOptions combines the fetch settings from cfg with the
+// command-line flags, which take precedence.
+func (a *app) buildFetchOptions(cfg *config.Config) spec.FetchOptions {
+	opts := cfg.Fetch
+	if len(a.specHeaderFlag) > 0 {
+		headers := make(map[string]string, len(opts.Headers)+len(a.specHeaderFlag))
+		for name, value := range opts.Headers {
+			headers[name] = value
+		}
+		for name := range a.specHeaderFlag {
+			headers[name] = http.Header(a.specHeaderFlag).Get(name)
+		}
+		opts.Headers = headers
+	}
+	if *a.specTimeoutFlag > 0 {
+		opts.Timeout = *a.specTimeoutFlag
+	}
+	if *a.insecureFlag {
+		opts.Insecure = true
+	}
+	return opts
+}
+
+// validateMethod checks if the specified HTTP method exists for the path item.
+func validateMethod(pathItem *openapi3.PathItem, method string) error {
+	operations := pathItem.Operations()
+
+	// The operations map keys are already lowercase
+	if operations[method] == nil {
+		// Build a list of available methods (sorted for consistency)
+		var available []string
+		for m := range operations {
+			available = append(available, m)
+		}
+		sort.Strings(available)
+		return fmt.Errorf("method '%s' not found for this endpoint. Available methods: %s",
+			method, strings.Join(available, ", "))
+	}
+	return nil
+}
+
+// validateInputFile validates that the input file exists and is reasonable.
+func validateInputFile(filePath string) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist: %s", filePath)
+		}
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("path is a directory, not a file: %s", filePath)
+	}
+
+	if info.Size() > maxFileSize {
+		return fmt.Errorf("file too large: %d bytes (max %d)", info.Size(), maxFileSize)
+	}
+
+	// Check file extension
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return fmt.Errorf("unsupported file extension: %s (expected .yaml, .yml, or .json)", ext)
+	}
+
+	return nil
+}
+
+// resolveSpecPath returns path itself, or the root spec within it when path
+// is a directory of spec fragments.
+func resolveSpecPath(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		// Let validateInputFile report missing files
+		return path, nil
+	}
+	return spec.FindRoot(path)
+}
+
+// loadSpec validates and loads an OpenAPI specification file, the root
+// spec of a directory, or a document served by a source plugin
+// ("plugin:<name>:<location>").
+func (a *app) loadSpec(filePath string) (*openapi3.T, error) {
+	if err := a.checkConfigApplied(); err != nil {
+		return nil, err
+	}
+	if name, location, ok := plugin.ParseSource(filePath); ok {
+		source, err := a.findPlugin(name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := source.Source(context.Background(), location)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := spec.LoadData(data, spec.LoadOptions{Refs: a.refPolicy})
+		if err != nil {
+			return nil, err
+		}
+		tags.Normalize(doc, a.canonicalTags)
+		return doc, nil
+	}
+
+	if !spec.IsURL(filePath) {
+		var err error
+		if filePath, err = resolveSpecPath(filePath); err != nil {
+			return nil, err
+		}
+		if err := validateInputFile(filePath); err != nil {
+			return nil, err
+		}
+	}
+	doc, err := a.loadOpenAPISpec(filePath)
+	if err != nil {
+		return nil, err
+	}
+	tags.Normalize(doc, a.canonicalTags)
+	return doc, nil
+}
+
+// openSpec opens a local spec file, or the root spec of a directory, lazily:
+// its paths are indexed now and the document is loaded on first use. Specs
+// that aren't local files, such as URLs and plugin sources, are loaded now.
+func (a *app) openSpec(filePath string) (*spec.Lazy, error) {
+	if err := a.checkConfigApplied(); err != nil {
+		return nil, err
+	}
+	if _, _, ok := plugin.ParseSource(filePath); ok || spec.IsURL(filePath) {
+		doc, err := a.loadSpec(filePath)
+		if err != nil {
+			return nil, err
+		}
+		return spec.FromDocument(filePath, doc), nil
+	}
+
+	filePath, err := resolveSpecPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInputFile(filePath); err != nil {
+		return nil, err
+	}
+	lazy, err := spec.Open(filePath, spec.LoadOptions{Refs: a.refPolicy, Fetch: a.fetchOptions})
+	if err != nil {
+		return nil, err
+	}
+	canonical := a.canonicalTags
+	lazy.Prepare = func(doc *openapi3.T) { tags.Normalize(doc, canonical) }
+	return lazy, nil
+}
+
+// loadOpenAPISpec loads and parses the OpenAPI specification file, or
+// fetches it when filePath is an http(s) URL.
+func (a *app) loadOpenAPISpec(filePath string) (*openapi3.T, error) {
+	if err := a.checkConfigApplied(); err != nil {
+		return nil, err
+	}
+	opts := spec.LoadOptions{Refs: a.refPolicy, Fetch: a.fetchOptions}
+	load := spec.Load
+	if spec.IsURL(filePath) {
+		load = spec.LoadURL
+	}
+	doc, err := load(filePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Note: We skip validation because some OpenAPI files may have minor
+	// spec violations but are still usable. We rely on the structure being
+	// present rather than strict spec compliance.
+
+	return doc, nil
+}
+
+// normalizeEndpointPath ensures the endpoint path starts with a slash.
+func normalizeEndpointPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
+
+// findPathItem finds the path item for the given endpoint path.
+func findPathItem(doc *openapi3.T, endpointPath string) (*openapi3.PathItem, error) {
+	if doc.Paths == nil {
+		return nil, fmt.Errorf("OpenAPI document has no paths defined")
+	}
+
+	pathItem := doc.Paths.Find(endpointPath)
+	if pathItem == nil {
+		return nil, fmt.Errorf("endpoint not found: %s%s", endpointPath, didYouMean(doc, endpointPath))
+	}
+
+	return pathItem, nil
+}
+
+// fuzzyMatch returns the unique path of doc close to endpointPath, if
+// -fuzzy is set.
+func (a *app) fuzzyMatch(doc *openapi3.T, endpointPath string) (string, bool) {
+	if !*a.fuzzyFlag {
+		return "", false
+	}
+	return fuzzy.Unique(doc.Paths.InMatchingOrder(), endpointPath)
+}
+
+// didYouMean suggests the paths of doc closest to endpointPath, for the
+// error reporting it not found.
+func didYouMean(doc *openapi3.T, endpointPath string) string {
+	var paths []string
+	for _, m := range fuzzy.Rank(doc.Paths.InMatchingOrder(), endpointPath, fuzzy.MaxSuggestions) {
+		paths = append(paths, m.Path)
+	}
+	return fuzzy.DidYouMean(paths)
+}
+
+// findPlugin looks up the named plugin, passing what it writes to stderr
+// through to a.stderr.
+func (a *app) findPlugin(name string) (*plugin.Plugin, error) {
+	p, err := plugin.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	p.Stderr = a.stderr
+	return p, nil
+}
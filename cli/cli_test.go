@@ -0,0 +1,620 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/history"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestMain(m *testing.M) {
+	// Keep test lookups out of the user's history
+	os.Setenv(history.EnvPath, "off")
+	os.Exit(m.Run())
+}
+
+// TestMultiMethodEndpoint_RealWorldSpec tests the /events/{event_id} endpoint
+// from openapi-notify.yaml which has GET, PUT, and DELETE methods
+func TestMultiMethodEndpoint_RealWorldSpec(t *testing.T) {
+	a := newApp(io.Discard, io.Discard)
+	if _, err := a.loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := a.loadOpenAPISpec("../openapi-notify.yaml")
+	if err != nil {
+		t.Skipf("Skipping test: openapi-notify.yaml not found: %v", err)
+		return
+	}
+
+	endpointPath := "/events/{event_id}"
+	pathItem, err := findPathItem(doc, endpointPath)
+	if err != nil {
+		t.Fatalf("Failed to find endpoint %s: %v", endpointPath, err)
+	}
+
+	// Test 1: No method filter - should include all methods
+	t.Run("AllMethods", func(t *testing.T) {
+		gen := generator.New(doc)
+		markdown := gen.GenerateMarkdown(endpointPath, pathItem, "")
+
+		// Verify all three methods are present
+		if !strings.Contains(markdown, "## GET /events/{event_id}") {
+			t.Error("Expected GET method in output")
+		}
+		if !strings.Contains(markdown, "## PUT /events/{event_id}") {
+			t.Error("Expected PUT method in output")
+		}
+		if !strings.Contains(markdown, "## DELETE /events/{event_id}") {
+			t.Error("Expected DELETE method in output")
+		}
+
+		// Verify operation summaries
+		if !strings.Contains(markdown, "Get event details") {
+			t.Error("Expected GET operation summary")
+		}
+		if !strings.Contains(markdown, "Update an event") {
+			t.Error("Expected PUT operation summary")
+		}
+		if !strings.Contains(markdown, "Delete an event") {
+			t.Error("Expected DELETE operation summary")
+		}
+	})
+
+	// Test 2: Filter by GET method only
+	t.Run("FilterGET", func(t *testing.T) {
+		gen := generator.New(doc)
+		markdown := gen.GenerateMarkdown(endpointPath, pathItem, "GET")
+
+		// Should only have GET
+		if !strings.Contains(markdown, "## GET /events/{event_id}") {
+			t.Error("Expected GET method in output")
+		}
+		if strings.Contains(markdown, "## PUT /events/{event_id}") {
+			t.Error("Did not expect PUT method when filtering by GET")
+		}
+		if strings.Contains(markdown, "## DELETE /events/{event_id}") {
+			t.Error("Did not expect DELETE method when filtering by GET")
+		}
+
+		// Verify only GET operation details
+		if !strings.Contains(markdown, "Get event details") {
+			t.Error("Expected GET operation summary")
+		}
+		if strings.Contains(markdown, "Update an event") {
+			t.Error("Did not expect PUT operation summary when filtering by GET")
+		}
+	})
+
+	// Test 3: Filter by PUT method only
+	t.Run("FilterPUT", func(t *testing.T) {
+		gen := generator.New(doc)
+		markdown := gen.GenerateMarkdown(endpointPath, pathItem, "PUT")
+
+		// Should only have PUT
+		if strings.Contains(markdown, "## GET /events/{event_id}") {
+			t.Error("Did not expect GET method when filtering by PUT")
+		}
+		if !strings.Contains(markdown, "## PUT /events/{event_id}") {
+			t.Error("Expected PUT method in output")
+		}
+		if strings.Contains(markdown, "## DELETE /events/{event_id}") {
+			t.Error("Did not expect DELETE method when filtering by PUT")
+		}
+
+		// Verify PUT has request body (unlike GET and DELETE)
+		if !strings.Contains(markdown, "Request Body") {
+			t.Error("Expected PUT to have request body documentation")
+		}
+		if !strings.Contains(markdown, "Update an event") {
+			t.Error("Expected PUT operation summary")
+		}
+	})
+
+	// Test 4: Filter by DELETE method only
+	t.Run("FilterDELETE", func(t *testing.T) {
+		gen := generator.New(doc)
+		markdown := gen.GenerateMarkdown(endpointPath, pathItem, "DELETE")
+
+		// Should only have DELETE
+		if strings.Contains(markdown, "## GET /events/{event_id}") {
+			t.Error("Did not expect GET method when filtering by DELETE")
+		}
+		if strings.Contains(markdown, "## PUT /events/{event_id}") {
+			t.Error("Did not expect PUT method when filtering by DELETE")
+		}
+		if !strings.Contains(markdown, "## DELETE /events/{event_id}") {
+			t.Error("Expected DELETE method in output")
+		}
+
+		if !strings.Contains(markdown, "Delete an event") {
+			t.Error("Expected DELETE operation summary")
+		}
+	})
+}
+
+func TestValidateMethod(t *testing.T) {
+	// Create a path item with GET, PUT, DELETE
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary: "Get item",
+		},
+		Put: &openapi3.Operation{
+			Summary: "Update item",
+		},
+		Delete: &openapi3.Operation{
+			Summary: "Delete item",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		expectError bool
+	}{
+		{"Valid GET", "GET", false},
+		{"Valid PUT", "PUT", false},
+		{"Valid DELETE", "DELETE", false},
+		{"Invalid POST", "POST", true},
+		{"Invalid PATCH", "PATCH", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMethod(pathItem, tt.method)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for method %s, got nil", tt.method)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Did not expect error for method %s, got: %v", tt.method, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeEndpointPath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"/events/{id}", "/events/{id}"},
+		{"events/{id}", "/events/{id}"},
+		{"/", "/"},
+		{"", "/"},
+		{"users", "/users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := normalizeEndpointPath(tt.input)
+			if result != tt.expected {
+				t.Errorf("normalizeEndpointPath(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateInputFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		filePath    string
+		expectError bool
+	}{
+		{"Valid YAML file", "../openapi-notify.yaml", false},
+		{"Valid yaml extension", "test.yaml", true}, // doesn't exist but extension is valid
+		{"Valid yml extension", "test.yml", true},   // doesn't exist but extension is valid
+		{"Invalid extension", "test.txt", true},
+		{"Directory", "../", true},
+		{"Non-existent file", "nonexistent.yaml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateInputFile(tt.filePath)
+			if tt.name == "Valid YAML file" {
+				// Only check existence for the real file
+				if err != nil {
+					t.Skipf("Skipping: file not found: %v", err)
+				}
+			} else if tt.name == "Invalid extension" || tt.name == "Directory" {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+			}
+		})
+	}
+}
+
+func TestIsHTTPMethod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"GET", true},
+		{"POST", true},
+		{"PUT", true},
+		{"DELETE", true},
+		{"PATCH", true},
+		{"HEAD", true},
+		{"OPTIONS", true},
+		{"get", true},      // lowercase
+		{"Post", true},     // mixed case
+		{"delete", true},   // lowercase
+		{"/events", false}, // path, not method
+		{"users", false},   // not a method
+		{"INVALID", false}, // not a valid HTTP method
+		{"", false},        // empty
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := isHTTPMethod(tt.input)
+			if result != tt.expected {
+				t.Errorf("isHTTPMethod(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+		service  string
+	}{
+		{"Flags first", []string{"-service", "notify", "GET", "/events"}, []string{"GET", "/events"}, "notify"},
+		{"Flags last", []string{"GET", "/events", "--service", "notify"}, []string{"GET", "/events"}, "notify"},
+		{"Flags between", []string{"GET", "-service=notify", "/events"}, []string{"GET", "/events"}, "notify"},
+		{"Terminator", []string{"GET", "--", "-odd", "spec.yaml"}, []string{"GET", "-odd", "spec.yaml"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			service := fs.String("service", "", "")
+
+			result, err := parseInterspersed(fs, tt.args)
+			if err != nil {
+				t.Fatalf("parseInterspersed() error: %v", err)
+			}
+			if strings.Join(result, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("parseInterspersed() = %v, want %v", result, tt.expected)
+			}
+			if *service != tt.service {
+				t.Errorf("service = %q, want %q", *service, tt.service)
+			}
+		})
+	}
+}
+
+func TestResolveArgs(t *testing.T) {
+	tests := []struct {
+		name                   string
+		args                   []string
+		method, path, specFile string
+		expectError            bool
+	}{
+		{"Positional method", []string{"get", "/events", "spec.yaml"}, "get", "/events", "spec.yaml", false},
+		{"No method", []string{"/events", "spec.yaml"}, "", "/events", "spec.yaml", false},
+		{"Too few", []string{"/events"}, "", "", "", true},
+		{"Unknown method", []string{"FETCH", "/events", "spec.yaml"}, "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, path, specFile, err := resolveArgs(tt.args, "")
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Did not expect error, got: %v", err)
+			}
+			if method != tt.method || path != tt.path || specFile != tt.specFile {
+				t.Errorf("resolveArgs() = (%q, %q, %q), want (%q, %q, %q)",
+					method, path, specFile, tt.method, tt.path, tt.specFile)
+			}
+		})
+	}
+}
+
+func TestFirstDifference(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   string
+		line   int
+		differ bool
+	}{
+		{"Identical", "a\nb\n", "a\nb\n", 0, false},
+		{"Changed line", "a\nb\nc", "a\nx\nc", 2, true},
+		{"Extra line", "a\nb", "a\nb\nc", 3, true},
+		{"Trailing newline", "a\nb", "a\nb\n", 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, _, _, differ := firstDifference(tt.a, tt.b)
+			if line != tt.line || differ != tt.differ {
+				t.Errorf("firstDifference() = %d, %v, want %d, %v", line, differ, tt.line, tt.differ)
+			}
+		})
+	}
+}
+
+func TestVerifyDeterministic(t *testing.T) {
+	if err := verifyDeterministic("same", func() (string, error) { return "same", nil }); err != nil {
+		t.Errorf("Did not expect error for identical renders, got: %v", err)
+	}
+
+	err := verifyDeterministic("## GET /a\n## PUT /a", func() (string, error) { return "## GET /a\n## DELETE /a", nil })
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error naming line 2, got: %v", err)
+	}
+}
+
+func TestLookupRegistry(t *testing.T) {
+	dir := t.TempDir()
+	spec := func(title string) string {
+		return "openapi: 3.0.3\ninfo: {title: " + title + ", version: 1.0.0}\npaths:\n  /events:\n    get:\n      responses:\n        '200': {description: OK}\n"
+	}
+	files := map[string]string{
+		"specs.yaml":  "services:\n  notify: notify.yaml\n  audit: audit.yaml\n  billing: billing.yaml\n",
+		"notify.yaml": spec("Notify API"),
+		"audit.yaml":  spec("Audit API"),
+		// The broken ref only fails a lookup that loads billing
+		"billing.yaml": "openapi: 3.0.3\ninfo: {title: Billing API, version: 1.0.0}\npaths:\n  /invoices:\n" +
+			"    get:\n      responses:\n        '200': {description: OK, content: {application/json: {schema: {$ref: missing.yaml}}}}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	var stderr bytes.Buffer
+	a := newApp(io.Discard, &stderr)
+
+	lookup, err := a.lookupRegistry("GET", "events", "")
+	if err != nil {
+		t.Fatalf("lookupRegistry() error: %v", err)
+	}
+	if lookup.used.service != "audit" || len(lookup.others) != 1 || lookup.others[0].service != "notify" {
+		t.Errorf("Expected audit used and notify listed, got %+v", lookup)
+	}
+	note := lookup.provenanceNote()
+	for _, s := range []string{"**Source:** Audit API 1.0.0 (audit.yaml), service `audit`", "- `notify`: Notify API 1.0.0 (notify.yaml)", "--spec <service>"} {
+		if !strings.Contains(note, s) {
+			t.Errorf("Expected %q in output:\n%s", s, note)
+		}
+	}
+
+	if lookup, err = a.lookupRegistry("", "/events", "notify"); err != nil || lookup.used.service != "notify" {
+		t.Errorf("Expected -spec to select notify, got %+v, %v", lookup, err)
+	}
+
+	if _, err := a.lookupRegistry("", "/events", "billing"); err == nil || !strings.Contains(err.Error(), "`audit`, `notify`") {
+		t.Errorf("Expected error listing the specs containing /events, got %v", err)
+	}
+	if _, err := a.lookupRegistry("POST", "/events", ""); err == nil {
+		t.Error("Expected error for a method no spec has")
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("Expected specs without the endpoint not to be loaded, got:\n%s", stderr.String())
+	}
+
+	if _, err := a.lookupRegistry("GET", "/invoices", ""); err == nil || !strings.Contains(stderr.String(), "skipping service 'billing'") {
+		t.Errorf("Expected billing to be loaded and skipped, got %v:\n%s", err, stderr.String())
+	}
+}
+
+func TestListAllServices(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"specs.yaml": "services:\n  notify: notify.yaml\n  broken: missing.yaml\n  billing: billing.yaml\n",
+		"notify.yaml": "openapi: 3.0.3\ninfo: {title: Notify API, version: 1.0.0}\npaths:\n  /events:\n" +
+			"    get:\n      summary: List events\n      responses:\n        '200': {description: OK}\n",
+		"billing.yaml": "openapi: 3.0.3\ninfo: {title: Billing API, version: 1.0.0}\npaths:\n  /invoices:\n" +
+			"    get:\n      summary: List invoices\n      responses:\n        '200': {description: OK}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+
+	var stdout, stderr strings.Builder
+	if code := Run([]string{"list", "-all-services", "-concurrency", "2"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run() = %d; stderr:\n%s", code, stderr.String())
+	}
+	for _, s := range []string{"SERVICE  METHOD", "billing  GET     /invoices", "notify   GET     /events"} {
+		if !strings.Contains(stdout.String(), s) {
+			t.Errorf("Expected %q in output:\n%s", s, stdout.String())
+		}
+	}
+	for _, s := range []string{"Warning: skipping service 'broken'", "Warning: 1 of 3 services failed to load"} {
+		if !strings.Contains(stderr.String(), s) {
+			t.Errorf("Expected %q in stderr:\n%s", s, stderr.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := Run([]string{"list", "-all-services", "notify.yaml"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected a usage error for a spec with -all-services, got %d", code)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "openapi.yaml")
+	spec := "openapi: 3.0.3\ninfo: {title: Events API, version: 1.0.0}\npaths:\n  /events:\n" +
+		"    get:\n      summary: List events\n      tags: [Events]\n      responses:\n        '200': {description: OK}\n" +
+		"    post:\n      summary: Create an event\n      responses:\n        '201': {description: Created}\n"
+	if err := os.WriteFile(specFile, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		code      int
+		stdout    []string
+		notStdout []string
+		stderr    []string
+	}{
+		{"all methods", []string{"/events", specFile}, 0, []string{"List events", "Create an event"}, nil, nil},
+		{"positional method", []string{"POST", "/events", specFile}, 0, []string{"Create an event"}, []string{"List events"}, nil},
+		{"flags after arguments", []string{"/events", specFile, "-method", "get"}, 0, []string{"List events"}, []string{"Create an event"}, nil},
+		{"subcommand", []string{"headers", specFile}, 0, nil, nil, nil},
+		{"list", []string{"list", specFile}, 0, []string{"GET     /events", "POST    /events", "Create an event"}, nil, nil},
+		{"help", []string{"-h"}, 0, nil, nil, []string{"Usage:", "docfinder [METHOD] <endpoint-path> <openapi-file>"}},
+		{"unknown flag", []string{"-bogus", "/events", specFile}, 2, nil, nil, []string{"flag provided but not defined: -bogus"}},
+		{"invalid arguments", []string{"a", "b", "c", "d"}, 1, nil, nil, []string{"Usage:"}},
+		{"subcommand arguments", []string{"audit"}, 1, nil, nil, []string{"docfinder audit [flags] <openapi-file>"}},
+		{"error", []string{"/missing", specFile}, 1, nil, nil, []string{"Error: endpoint not found: /missing"}},
+		{"suggestion", []string{"/event", specFile}, 1, nil, nil, []string{"Error: endpoint not found: /event (did you mean /events?)"}},
+		{"fuzzy", []string{"-fuzzy", "GET", "/evnts", specFile}, 0, []string{"List events"}, nil, []string{"Note: using '/events', the closest match to '/evnts'"}},
+		{"tag", []string{"-tag", "events", specFile}, 0, []string{"# Tag: Events", "List events"}, []string{"Create an event"}, nil},
+		{"unknown tag", []string{"-tag", "Event", specFile}, 1, nil, nil, []string{"Error: no operations tagged Event (did you mean Events?)"}},
+		{"path regex", []string{"-path-regex", "^/ev", "-method", "post", specFile}, 0, []string{"# API Endpoints: `^/ev`", "Create an event"}, []string{"List events"}, nil},
+		{"invalid path regex", []string{"-path-regex", "[", specFile}, 1, nil, nil, []string{"Error: invalid -path-regex"}},
+		{"all", []string{"-all", "-out-dir", filepath.Join(dir, "docs"), specFile}, 0, []string{"Exported 2 operations to " + filepath.Join(dir, "docs", "index.json")}, nil, nil},
+		{"full", []string{"-full", specFile}, 0, []string{"# API Reference", "- [Events](#events)\n  - [GET /events](#get-events)", "## Events\n\n### GET /events", "## Other Operations\n\n### POST /events"}, nil, nil},
+		{"testplan", []string{"testplan", "POST", "/events", specFile}, 0, []string{"# Test Plan: /events\n\n## POST /events\n\n- [ ] Get a `201 Created` response: Created → `201` (status)\n"}, []string{"GET /events"}, nil},
+		{"testplan csv", []string{"testplan", "-format", "csv", "/events", specFile}, 0, []string{"method,path,kind,case,expect\nGET,/events,status,Get a `200 OK` response: OK,200\n"}, nil, nil},
+		{"testplan format", []string{"testplan", "-format", "html", "/events", specFile}, 1, nil, nil, []string{"Error: unsupported format: html"}},
+		{"pack", []string{"pack", "-o", filepath.Join(dir, "docs.tar.gz"), specFile}, 0, []string{"Packed 2 operations into " + filepath.Join(dir, "docs.tar.gz")}, nil, nil},
+		{"security", []string{"security", specFile}, 0, []string{"# Security Schemes\n\nNo security schemes are defined or used.\n"}, nil, nil},
+		{"unknown security scheme", []string{"security", "-scheme", "oauth", specFile}, 1, nil, nil, []string{"Error: unknown security scheme oauth"}},
+		{"diff", []string{"diff", "GET", "/events", specFile, specFile}, 0, []string{"# Changes to `GET /events`\n\nNo changes.\n"}, nil, nil},
+		{"diff missing endpoint", []string{"diff", "/event", specFile, specFile}, 1, nil, nil, []string{"Error: endpoint not found in either spec: /event (did you mean /events?)"}},
+		{"deprecations", []string{"deprecations", specFile}, 0, []string{"# Deprecations\n\nNo deprecated operations.\n"}, nil, nil},
+		{"serve arguments", []string{"serve"}, 1, nil, nil, []string{"docfinder serve [flags] <openapi-file>"}},
+		{"returns unknown schema", []string{"returns", "EventList", specFile}, 1, nil, nil, []string{"Error: schema 'EventList' not found"}},
+		{"profile", []string{"-profile", "support", "GET", "/events", specFile}, 0, []string{"List events", "**curl**"}, []string{"### Responses"}, nil},
+		{"profile overridden", []string{"-profile", "support", "-sections", "responses", "GET", "/events", specFile}, 0, []string{"### Responses"}, nil, nil},
+		{"unknown profile", []string{"-profile", "brief", "/events", specFile}, 1, nil, nil, []string{`Error: unknown profile "brief" (available: integration, support)`}},
+		{"fuzzy without close match", []string{"-fuzzy", "/users", specFile}, 1, nil, nil, []string{"Error: endpoint not found: /users"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			if code := Run(tt.args, &stdout, &stderr); code != tt.code {
+				t.Fatalf("Run() = %d, want %d; stderr:\n%s", code, tt.code, stderr.String())
+			}
+			for _, s := range tt.stdout {
+				if !strings.Contains(stdout.String(), s) {
+					t.Errorf("Expected %q in output:\n%s", s, stdout.String())
+				}
+			}
+			for _, s := range tt.notStdout {
+				if strings.Contains(stdout.String(), s) {
+					t.Errorf("Did not expect %q in output:\n%s", s, stdout.String())
+				}
+			}
+			for _, s := range tt.stderr {
+				if !strings.Contains(stderr.String(), s) {
+					t.Errorf("Expected %q in stderr:\n%s", s, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRun_SpecURL(t *testing.T) {
+	spec := "openapi: 3.0.3\ninfo: {title: Events API, version: 1.0.0}\npaths:\n  /events:\n" +
+		"    get:\n      summary: List events\n      responses:\n        '200': {description: OK}\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(spec))
+	}))
+	defer server.Close()
+
+	var stdout, stderr strings.Builder
+	if code := Run([]string{"-spec-header", "X-Api-Key: secret", "GET", "/events", server.URL + "/openapi.yaml"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run() = %d; stderr:\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "List events") {
+		t.Errorf("Expected %q in output:\n%s", "List events", stdout.String())
+	}
+
+	stderr.Reset()
+	if code := Run([]string{"GET", "/events", server.URL + "/openapi.yaml"}, io.Discard, &stderr); code != 1 {
+		t.Fatalf("Run() without header = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "status code 403") {
+		t.Errorf("Expected %q in stderr:\n%s", "status code 403", stderr.String())
+	}
+}
+
+func TestRun_History(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(history.EnvPath, filepath.Join(t.TempDir(), "history.json"))
+	t.Chdir(dir)
+	spec := "openapi: 3.0.3\ninfo: {title: Events API, version: 1.0.0}\npaths:\n  /events:\n" +
+		"    get:\n      summary: List events\n      responses:\n        '200': {description: OK}\n" +
+		"  /users:\n    get:\n      summary: List users\n      responses:\n        '200': {description: OK}\n"
+	if err := os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr strings.Builder
+		if code := Run(args, &stdout, &stderr); code != 0 {
+			t.Fatalf("Run(%v) = %d; stderr:\n%s", args, code, stderr.String())
+		}
+		return stdout.String()
+	}
+	run("GET", "/events", "openapi.yaml")
+	run("GET", "/users", "openapi.yaml")
+	if code := Run([]string{"GET", "/missing", "openapi.yaml"}, io.Discard, io.Discard); code != 1 {
+		t.Fatalf("Expected failed lookup to exit 1, got %d", code)
+	}
+
+	out := run("history")
+	if strings.Index(out, "/users") > strings.Index(out, "/events") || strings.Contains(out, "/missing") {
+		t.Errorf("Expected successful lookups, most recent first:\n%s", out)
+	}
+
+	run("star", "2")
+	out = run("history")
+	if !strings.HasPrefix(out, "1  *") || !strings.Contains(strings.Split(out, "\n")[0], "/events") {
+		t.Errorf("Expected favorite listed first:\n%s", out)
+	}
+
+	// Run from elsewhere: again returns to the lookup's directory
+	t.Chdir(t.TempDir())
+	if out := run("again", "2"); !strings.Contains(out, "List users") {
+		t.Errorf("Expected %q in output:\n%s", "List users", out)
+	}
+	if out := run("again", "-sections", "metadata"); !strings.Contains(out, "List events") || strings.Contains(out, "Responses") {
+		t.Errorf("Expected favorite repeated with -sections:\n%s", out)
+	}
+
+	// Header values may carry credentials and are not recorded
+	t.Chdir(dir)
+	run("-spec-header", "Authorization: Bearer s3cret", "--spec-header=X-Key: k3y", "GET", "/users", "openapi.yaml")
+	data, err := os.ReadFile(os.Getenv(history.EnvPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "s3cret") || strings.Contains(string(data), "k3y") {
+		t.Errorf("Expected spec headers left out of the history:\n%s", data)
+	}
+}
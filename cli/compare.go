@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/compare"
+	"github.com/arthur-s/docfinder/internal/generator"
+)
+
+// runCompare implements "docfinder compare <method-a> <method-b> <endpoint-path> <openapi-file>".
+func (a *app) runCompare(args []string) error {
+	fs := a.newFlagSet("compare")
+	maxDepth := fs.Int("max-depth", generator.MaxRecursionDepth, "Maximum nesting depth of body fields to compare.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s compare [flags] <method-a> <method-b> <endpoint-path> <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Renders two operations of an endpoint side by side, showing which body fields are writable and which are read-only.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 4 || !isHTTPMethod(rest[0]) || !isHTTPMethod(rest[1]) {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[3])
+	if err != nil {
+		return err
+	}
+
+	endpointPath := normalizeEndpointPath(rest[2])
+	pathItem, err := findPathItem(doc, endpointPath)
+	if err != nil {
+		return err
+	}
+
+	markdown, err := compare.Render(endpointPath, pathItem, rest[0], rest[1], *maxDepth)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(a.stdout, markdown)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/complexity"
+)
+
+// runComplexity implements "docfinder complexity <openapi-file>".
+func (a *app) runComplexity(args []string) error {
+	fs := a.newFlagSet("complexity")
+	format := fs.String("format", "table", "Output format: table or mermaid.")
+	top := fs.Int("top", 0, "Only show the N most complex operations (0 shows all).")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s complexity [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Ranks operations by complexity (schema depth, property counts, oneOf/anyOf branches, parameter counts).\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	scores := complexity.Analyze(doc)
+
+	switch *format {
+	case "table":
+		fmt.Fprint(a.stdout, complexity.FormatTable(scores, *top))
+	case "mermaid":
+		fmt.Fprint(a.stdout, complexity.FormatMermaid(scores, *top))
+	default:
+		return fmt.Errorf("unsupported format: %s (expected table or mermaid)", *format)
+	}
+
+	return nil
+}
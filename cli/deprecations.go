@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/deprecations"
+	"github.com/arthur-s/docfinder/internal/generator"
+)
+
+// runDeprecations implements "docfinder deprecations <openapi-file>".
+func (a *app) runDeprecations(args []string) error {
+	fs := a.newFlagSet("deprecations")
+	jsonOutput := fs.Bool("json", false, "Print the deprecated operations as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s deprecations [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Reports deprecated operations with the dates they are removed, from their %s and %s response headers or %s extension, upcoming sunsets sorted by date.\n\nFlags:\n",
+			generator.HeaderSunset, generator.HeaderDeprecation, generator.ExtensionSunset)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+	entries := deprecations.Report(doc)
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+	fmt.Fprint(a.stdout, deprecations.Format(entries, time.Now()))
+	return nil
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// verifyDeterministic compares first with a second render and reports the
+// first line where they differ. The second render should start from freshly
+// built maps, so any output depending on map iteration order shows up.
+func verifyDeterministic(first string, renderAgain func() (string, error)) error {
+	second, err := renderAgain()
+	if err != nil {
+		return fmt.Errorf("failed to render again: %w", err)
+	}
+
+	line, want, got, differ := firstDifference(first, second)
+	if !differ {
+		return nil
+	}
+	return fmt.Errorf("output is not deterministic: renders differ at line %d:\n  first:  %q\n  second: %q", line, want, got)
+}
+
+// firstDifference returns the 1-based number of the first line that differs
+// between a and b, along with the line from each. A missing line is empty.
+func firstDifference(a, b string) (line int, lineA, lineB string, differ bool) {
+	if a == b {
+		return 0, "", "", false
+	}
+
+	linesA := strings.Split(a, "\n")
+	linesB := strings.Split(b, "\n")
+	for i := 0; i < len(linesA) || i < len(linesB); i++ {
+		var la, lb string
+		if i < len(linesA) {
+			la = linesA[i]
+		}
+		if i < len(linesB) {
+			lb = linesB[i]
+		}
+		if la != lb || i >= len(linesA) || i >= len(linesB) {
+			return i + 1, la, lb, true
+		}
+	}
+	return 0, "", "", false
+}
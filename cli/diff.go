@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/diff"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// runDiff implements "docfinder diff [METHOD] <endpoint-path> <old-file> <new-file>".
+func (a *app) runDiff(args []string) error {
+	fs := a.newFlagSet("diff")
+	breakingOnly := fs.Bool("breaking", false, "Only report breaking changes.")
+	failOnBreaking := fs.Bool("fail-on-breaking", false, "Exit with an error if any breaking change is found.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s diff [flags] [METHOD] <endpoint-path> <old-openapi-file> <new-openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Compares an endpoint across two spec versions and reports added, removed, and changed operations, parameters, body and response fields, response codes, and required flags as markdown.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	method := ""
+	if len(rest) == 4 && isHTTPMethod(rest[0]) {
+		method, rest = strings.ToUpper(rest[0]), rest[1:]
+	}
+	if len(rest) != 3 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	oldDoc, err := a.loadSpec(rest[1])
+	if err != nil {
+		return err
+	}
+	newDoc, err := a.loadSpec(rest[2])
+	if err != nil {
+		return err
+	}
+
+	endpointPath := normalizeEndpointPath(rest[0])
+	oldItem := lookupPathItem(oldDoc, endpointPath)
+	newItem := lookupPathItem(newDoc, endpointPath)
+	if oldItem == nil && newItem == nil {
+		return fmt.Errorf("endpoint not found in either spec: %s%s", endpointPath, didYouMean(newDoc, endpointPath))
+	}
+
+	changes := diff.Endpoint(oldItem, newItem, method)
+	breaking := diff.Breaking(changes)
+	if *breakingOnly {
+		changes = breaking
+	}
+
+	title := endpointPath
+	if method != "" {
+		title = method + " " + endpointPath
+	}
+	fmt.Fprint(a.stdout, formatEndpointDiff(title, oldItem, newItem, changes, len(breaking)))
+
+	if *failOnBreaking && len(breaking) > 0 {
+		return fmt.Errorf("%d breaking change(s) to %s", len(breaking), title)
+	}
+	return nil
+}
+
+// lookupPathItem returns the path item matching endpointPath, or nil if the
+// spec doesn't document it.
+func lookupPathItem(doc *openapi3.T, endpointPath string) *openapi3.PathItem {
+	if doc.Paths == nil {
+		return nil
+	}
+	return doc.Paths.Find(endpointPath)
+}
+
+// formatEndpointDiff renders endpoint changes as a markdown report with a
+// section per kind of change.
+func formatEndpointDiff(title string, oldItem, newItem *openapi3.PathItem, changes []diff.Change, breaking int) string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "# Changes to `%s`\n\n", title)
+	switch {
+	case oldItem == nil:
+		out.WriteString("The endpoint is new in this version.\n\n")
+	case newItem == nil:
+		out.WriteString("The endpoint was removed in this version.\n\n")
+	}
+	if len(changes) == 0 {
+		out.WriteString("No changes.\n")
+		return out.String()
+	}
+
+	fmt.Fprintf(&out, "%d change(s), %d breaking.\n", len(changes), breaking)
+	for _, section := range []struct {
+		kind    diff.Kind
+		heading string
+	}{
+		{diff.Added, "Added"},
+		{diff.Removed, "Removed"},
+		{diff.Changed, "Changed"},
+	} {
+		var lines strings.Builder
+		for _, change := range changes {
+			if change.Kind != section.kind {
+				continue
+			}
+			line := change.Location
+			if change.Detail != "" {
+				line += ": " + change.Detail
+			}
+			if change.Breaking {
+				line = "**Breaking:** " + line
+			}
+			fmt.Fprintf(&lines, "- %s\n", line)
+		}
+		if lines.Len() > 0 {
+			fmt.Fprintf(&out, "\n## %s\n\n%s", section.heading, lines.String())
+		}
+	}
+
+	return out.String()
+}
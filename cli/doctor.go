@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/doctor"
+	"github.com/arthur-s/docfinder/internal/manifest"
+)
+
+// runDoctor implements "docfinder doctor [openapi-file]".
+func (a *app) runDoctor(args []string) error {
+	fs := a.newFlagSet("doctor")
+	jsonOutput := fs.Bool("json", false, "Print the checks as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s doctor [flags] [openapi-file]\n\n", programName)
+		fmt.Fprintf(a.stderr, "Checks the configuration file, the ref cache, the specs registered in %s and whether they load, and the given spec, suggesting a fix for each problem.\n\nFlags:\n", manifest.FileName)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	checks := []doctor.Check{doctor.Version()}
+	cfg, configChecks := doctor.Config(*a.configFlag)
+	checks = append(checks, configChecks...)
+	a.applyConfig(cfg)
+	checks = append(checks, doctor.Cache(a.refPolicy), doctor.Plugins())
+
+	sources, manifestChecks := doctor.Manifest(".")
+	checks = append(checks, manifestChecks...)
+	if len(rest) == 1 {
+		sources = append(sources, doctor.Source{Name: rest[0], Location: rest[0]})
+	}
+	for _, source := range sources {
+		checks = append(checks, doctor.Spec(source, a.loadSpec))
+	}
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+	} else {
+		for _, check := range checks {
+			fmt.Fprintln(a.stdout, check)
+		}
+	}
+
+	if failed := doctor.Failed(checks); failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
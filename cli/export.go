@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+
+	"github.com/arthur-s/docfinder/internal/config"
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/owners"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// runExport implements "docfinder export <openapi-file>".
+func (a *app) runExport(args []string) error {
+	fs := a.newFlagSet("export")
+	outDir := fs.String("out-dir", "docs", "Directory to write markdown files and "+export.IndexFile+" to.")
+	force := fs.Bool("force", false, "Rewrite every file, even those unchanged since the previous export.")
+	site := fs.String("site", "", "Lay the files out for a documentation site: docusaurus writes MDX in a directory per tag with "+export.CategoryFile+" metadata and a "+export.SidebarFile+" fragment.")
+	docIDPrefix := fs.String("doc-id-prefix", "", "With -site docusaurus, the path of -out-dir inside the Docusaurus docs directory, prefixed to the doc ids in "+export.SidebarFile+".")
+	fs.StringVar(a.snippetsFlag, "snippets", "", "Comma-separated languages to show each operation's request in: curl, go, python, js. Tabbed with -site docusaurus.")
+	name := fs.String("name", "", "File name template overriding -split-by, e.g. {tag}/{method}-{path}.md, with placeholders {method}, {METHOD}, {path}, {operationId}, and {tag} (default: export.name from config).")
+	concurrency := fs.Int("concurrency", 0, "Number of operations rendered at once (default: export.concurrency from config, else one per CPU).")
+	splitBy := fs.String("split-by", string(export.SplitByOperation), "File layout: operation for one file per operation (get-events-id.md), or method for a directory per path with a file per method (events__{id}/GET.md).")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s export [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Writes one markdown file per operation plus an %s manifest describing every file and a %s for client-side search.\n", export.IndexFile, export.SearchIndexFile)
+		fmt.Fprintf(a.stderr, "Files unchanged since the previous export are left untouched.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	split, err := export.ParseSplitBy(*splitBy)
+	if err != nil {
+		return err
+	}
+	siteLayout, err := export.ParseSite(*site)
+	if err != nil {
+		return err
+	}
+	if siteLayout != "" && split != export.SplitByOperation {
+		return fmt.Errorf("-split-by cannot be combined with -site, which lays out files by tag")
+	}
+	if siteLayout != "" && *name != "" {
+		return fmt.Errorf("-name cannot be combined with -site, which lays out files by tag")
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	specPath, err := resolveSpecPath(rest[0])
+	if err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	opts := export.Options{OutDir: *outDir, SpecPath: specPath, Force: *force, SplitBy: split, Site: siteLayout, DocIDPrefix: *docIDPrefix}
+	if siteLayout == "" {
+		opts.NameTemplate = cmp.Or(*name, cfg.Export.Name)
+	}
+	opts.Concurrency = cmp.Or(*concurrency, cfg.Export.Concurrency)
+	return a.exportDocs(cfg, doc, opts)
+}
+
+// exportDocs renders every operation of doc to its own file as opts says,
+// with the rendering flags given, and reports what changed.
+func (a *app) exportDocs(cfg *config.Config, doc *openapi3.T, opts export.Options) error {
+	genOpts, err := a.generateOptions(cfg, doc, "")
+	if err != nil {
+		return err
+	}
+	// Exported files are always markdown
+	opts.Generate = append(genOpts, generator.WithFormat(generator.FormatMarkdown))
+
+	ownerships, err := a.ownerships(doc)
+	if err != nil {
+		return err
+	}
+	opts.Owners = owners.Map(ownerships)
+
+	index, summary, err := export.Export(doc, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Exported %d operations to %s: %d updated, %d skipped, %d removed\n",
+		len(index.Files), filepath.Join(opts.OutDir, export.IndexFile), len(summary.Updated), len(summary.Skipped), len(summary.Removed))
+	return nil
+}
+
+// exportAll implements -all, exporting every operation of doc, loaded from
+// openapiFile, to -out-dir with the file names configured for export.
+func (a *app) exportAll(cfg *config.Config, doc *openapi3.T, openapiFile string) error {
+	specPath, err := resolveSpecPath(openapiFile)
+	if err != nil {
+		return err
+	}
+	return a.exportDocs(cfg, doc, export.Options{
+		OutDir:       *a.outDirFlag,
+		SpecPath:     specPath,
+		NameTemplate: cfg.Export.Name,
+		Concurrency:  cfg.Export.Concurrency,
+	})
+}
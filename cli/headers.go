@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/headers"
+)
+
+// runHeaders implements "docfinder headers <openapi-file>".
+func (a *app) runHeaders(args []string) error {
+	fs := a.newFlagSet("headers")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s headers <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Prints a matrix of custom request headers across operations and flags headers declared inconsistently.\n")
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(a.stdout, headers.Format(headers.Analyze(doc)))
+	return nil
+}
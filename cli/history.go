@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/history"
+)
+
+// recordLookup adds a successful lookup to the history. History is best
+// effort: failing to record it only warns.
+func (a *app) recordLookup(args []string) {
+	path := history.DefaultPath()
+	if path == "" {
+		return
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+
+	h, err := history.Load(path)
+	if err == nil {
+		h.Add(history.Entry{Args: withoutCredentials(args), Dir: wd, Time: time.Now()})
+		err = h.Save()
+	}
+	if err != nil {
+		fmt.Fprintf(a.stderr, "Warning: %v\n", err)
+	}
+}
+
+// credentialFlags are the flags whose values may carry credentials, such as
+// an Authorization header. Lookups are recorded without them; specs needing
+// them are repeated with the headers of the configuration file.
+var credentialFlags = []string{"spec-header"}
+
+// withoutCredentials returns args without the credentialFlags and their
+// values.
+func withoutCredentials(args []string) []string {
+	var kept []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(kept, args[i:]...)
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if strings.HasPrefix(arg, "-") && slices.Contains(credentialFlags, name) {
+			if !hasValue {
+				i++
+			}
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept
+}
+
+// loadHistory loads the history file, failing if history is disabled.
+func loadHistory() (*history.History, error) {
+	path := history.DefaultPath()
+	if path == "" {
+		return nil, fmt.Errorf("lookup history is disabled (%s=off or no user config directory)", history.EnvPath)
+	}
+	return history.Load(path)
+}
+
+// runHistory implements "docfinder history".
+func (a *app) runHistory(args []string) error {
+	fs := a.newFlagSet("history")
+	limit := fs.Int("n", 20, "Number of lookups to list (0 lists all).")
+	jsonOutput := fs.Bool("json", false, "Print the lookups as JSON.")
+	clearFlag := fs.Bool("clear", false, "Forget the recent lookups, keeping favorites.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s history [flags]\n\n", programName)
+		fmt.Fprintf(a.stderr, "Lists favorite lookups, then recent ones, numbered for '%s again <number>' and '%s star <number>'. The history is kept in %s, or the file %s names; %s=off disables it.\n\nFlags:\n", programName, programName, history.DefaultPath(), history.EnvPath, history.EnvPath)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	h, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	if *clearFlag {
+		h.Clear()
+		return h.Save()
+	}
+
+	picks := h.Picks()
+	if *limit > 0 && len(picks) > *limit {
+		picks = picks[:*limit]
+	}
+
+	if *jsonOutput {
+		type pick struct {
+			Number int `json:"number"`
+			history.Entry
+			Favorite bool `json:"favorite"`
+		}
+		out := make([]pick, len(picks))
+		for i, e := range picks {
+			out[i] = pick{Number: i + 1, Entry: e, Favorite: h.IsFavorite(e)}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	for i, e := range picks {
+		star := ""
+		if h.IsFavorite(e) {
+			star = "*"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, star, e.Time.Local().Format("2006-01-02 15:04"), e, e.Dir)
+	}
+	return w.Flush()
+}
+
+// runAgain implements "docfinder again [number] [flags]".
+func (a *app) runAgain(args []string) error {
+	fs := a.newFlagSet("again")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s again [number] [flags]\n\n", programName)
+		fmt.Fprintf(a.stderr, "Repeats the lookup numbered by '%s history' (default 1, the first favorite or else the most recent lookup) in the directory it was run in. Flags given are added to the lookup's, e.g. '%s again 3 -format term'.\n", programName, programName)
+	}
+	if len(args) > 0 && (args[0] == "-h" || args[0] == "-help" || args[0] == "--help") {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	n := 1
+	if len(args) > 0 {
+		if v, err := strconv.Atoi(args[0]); err == nil {
+			n, args = v, args[1:]
+		}
+	}
+
+	h, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	entry, err := h.Get(n)
+	if err != nil {
+		return err
+	}
+
+	if entry.Dir != "" {
+		if err := os.Chdir(entry.Dir); err != nil {
+			return fmt.Errorf("failed to enter the lookup's directory: %w", err)
+		}
+	}
+	entry.Args = append(entry.Args[:len(entry.Args):len(entry.Args)], args...)
+	fmt.Fprintf(a.stderr, "%s %s\n", programName, entry)
+	return a.runLookup(entry.Args)
+}
+
+// runStar implements "docfinder star [-remove] <number>".
+func (a *app) runStar(args []string) error {
+	fs := a.newFlagSet("star")
+	remove := fs.Bool("remove", false, "Remove the lookup from the favorites instead.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s star [flags] <number>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Stars the lookup numbered by '%s history' as a favorite. Favorites are listed first by history, so the lookups used daily keep low numbers.\n\nFlags:\n", programName)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	n, err := strconv.Atoi(rest[0])
+	if err != nil {
+		return fmt.Errorf("invalid lookup number: %s", rest[0])
+	}
+
+	h, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	entry, err := h.Get(n)
+	if err != nil {
+		return err
+	}
+
+	if *remove {
+		if !h.Unstar(entry) {
+			return fmt.Errorf("lookup #%d is not a favorite", n)
+		}
+	} else if !h.Star(entry) {
+		fmt.Fprintf(a.stderr, "Already a favorite: %s\n", entry)
+		return nil
+	}
+	return h.Save()
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/insomnia"
+)
+
+// runInsomnia implements "docfinder insomnia [endpoint-path] <openapi-file>".
+func (a *app) runInsomnia(args []string) error {
+	fs := a.newFlagSet("insomnia")
+	tag := fs.String("tag", "", "Only export operations with this tag.")
+	method := fs.String("method", "", "Only export operations with this HTTP method.")
+	env := fs.String("env", "", "Only export servers tagged with this x-environment.")
+	output := fs.String("o", "", "Write the export to this file instead of stdout.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s insomnia [flags] [endpoint-path] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Exports operations as an Insomnia v4 collection, with environments for each server and auth templates for security schemes.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	opts := insomnia.Options{
+		Method:      strings.ToUpper(strings.TrimSpace(*method)),
+		Tag:         *tag,
+		Environment: *env,
+		Date:        time.Now(),
+	}
+
+	var specFile string
+	switch len(rest) {
+	case 1:
+		specFile = rest[0]
+	case 2:
+		opts.Path = normalizeEndpointPath(rest[0])
+		specFile = rest[1]
+	default:
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(specFile)
+	if err != nil {
+		return err
+	}
+
+	if opts.Path != "" {
+		if _, err := findPathItem(doc, opts.Path); err != nil {
+			return err
+		}
+	}
+
+	export, err := insomnia.Build(doc, opts)
+	if err != nil {
+		return err
+	}
+
+	data, err := export.Marshal()
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		_, err = a.stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	fmt.Fprintf(a.stderr, "Wrote %s\n", *output)
+	return nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/examplelint"
+	"github.com/arthur-s/docfinder/internal/plugin"
+)
+
+// runLintExamples implements "docfinder lint-examples <openapi-file>".
+func (a *app) runLintExamples(args []string) error {
+	fs := a.newFlagSet("lint-examples")
+	jsonOutput := fs.Bool("json", false, "Print problems as JSON.")
+	plugins := fs.String("plugin", "", "Comma-separated "+plugin.Prefix+"<name> plugins contributing extra lint rules.")
+	warnOnly := fs.Bool("warn-only", false, "Report problems without exiting with an error.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s lint-examples [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Flags defaults and examples that contradict their schema: values outside minimum/maximum or length limits, values missing from the enum, and values the description rules out.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	problems := examplelint.Run(doc)
+	if *plugins != "" {
+		for _, name := range strings.Split(*plugins, ",") {
+			p, err := a.findPlugin(strings.TrimSpace(name))
+			if err != nil {
+				return err
+			}
+			found, err := p.Lint(context.Background(), doc)
+			if err != nil {
+				return err
+			}
+			for _, f := range found {
+				problems = append(problems, examplelint.Problem{Rule: f.Rule, Location: f.Location, Message: f.Message})
+			}
+		}
+	}
+
+	if *jsonOutput {
+		if problems == nil {
+			problems = []examplelint.Problem{}
+		}
+		data, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+	} else if len(problems) == 0 {
+		fmt.Fprintln(a.stdout, "No problems.")
+	} else {
+		for _, p := range problems {
+			fmt.Fprintln(a.stdout, p)
+		}
+	}
+
+	if len(problems) > 0 && !*warnOnly {
+		return fmt.Errorf("%d example problem(s)", len(problems))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/arthur-s/docfinder/internal/manifest"
+	"github.com/arthur-s/docfinder/internal/model"
+)
+
+// runList implements "docfinder list <openapi-file>" and
+// "docfinder list -all-services".
+func (a *app) runList(args []string) error {
+	fs := a.newFlagSet("list")
+	tag := fs.String("tag", "", "Only list operations with this tag.")
+	jsonOutput := fs.Bool("json", false, "Print the operations as JSON.")
+	allServices := fs.Bool("all-services", false, "List the operations of every service registered in "+manifest.FileName+".")
+	concurrency := fs.Int("concurrency", 0, fmt.Sprintf("Maximum specs loaded at once with -all-services (default %d).", manifest.DefaultConcurrency))
+	timeout := fs.Duration("timeout", 0, fmt.Sprintf("Time limit for loading each spec with -all-services (default %s).", manifest.DefaultTimeout))
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s list [flags] <openapi-file>\n  %s list [flags] -all-services\n\n", programName, programName)
+		fmt.Fprintf(a.stderr, "Lists every operation of the spec with its operationId, tags, and summary, to find the path to look up. With -all-services, the specs registered in %s are loaded concurrently and services that fail to load are reported and skipped.\n\nFlags:\n", manifest.FileName)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 && !*allServices || len(rest) != 0 && *allServices {
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	var sources []manifest.Loaded
+	if *allServices {
+		m, err := loadManifest()
+		if err != nil {
+			return err
+		}
+		registry := cfg.Registry
+		if *concurrency > 0 {
+			registry.Concurrency = *concurrency
+		}
+		if *timeout > 0 {
+			registry.Timeout = *timeout
+		}
+		sources = a.loadServices(m, registry)
+		if len(sources) == 0 && len(m.Services) > 0 {
+			return fmt.Errorf("no service in %s could be loaded", m.Path)
+		}
+	} else {
+		doc, err := a.loadSpec(rest[0])
+		if err != nil {
+			return err
+		}
+		sources = []manifest.Loaded{{Doc: doc}}
+	}
+
+	type operation struct {
+		Service     string   `json:"service,omitempty"`
+		Method      string   `json:"method"`
+		Path        string   `json:"path"`
+		OperationID string   `json:"operationId,omitempty"`
+		Tags        []string `json:"tags,omitempty"`
+		Summary     string   `json:"summary,omitempty"`
+		Deprecated  bool     `json:"deprecated,omitempty"`
+	}
+	operations := []operation{}
+	for _, source := range sources {
+		for _, op := range model.FromOpenAPI(source.Doc).Operations {
+			if *tag != "" && !slices.ContainsFunc(op.Tags, func(t string) bool { return strings.EqualFold(t, *tag) }) {
+				continue
+			}
+			operations = append(operations, operation{
+				Service:     source.Service,
+				Method:      op.Method,
+				Path:        op.Path,
+				OperationID: op.OperationID,
+				Tags:        op.Tags,
+				Summary:     op.Summary,
+				Deprecated:  op.Deprecated,
+			})
+		}
+	}
+	if len(operations) == 0 && *tag != "" {
+		return fmt.Errorf("no operations tagged %s", *tag)
+	}
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(operations, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	header := "METHOD\tPATH\tOPERATION ID\tTAGS\tSUMMARY"
+	if *allServices {
+		header = "SERVICE\t" + header
+	}
+	fmt.Fprintln(w, header)
+	for _, op := range operations {
+		// Keep each operation on one line
+		summary, _, _ := strings.Cut(op.Summary, "\n")
+		if op.Deprecated {
+			summary = strings.TrimSpace("(deprecated) " + summary)
+		}
+		if *allServices {
+			fmt.Fprintf(w, "%s\t", op.Service)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", op.Method, op.Path, op.OperationID, strings.Join(op.Tags, ","), summary)
+	}
+	return w.Flush()
+}
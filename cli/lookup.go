@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/manifest"
+	"github.com/arthur-s/docfinder/internal/spec"
+)
+
+// specMatch is a registered spec containing a looked-up endpoint.
+type specMatch struct {
+	service string
+	// location is the spec location as written in the manifest; path is
+	// where it resolves to.
+	location string
+	path     string
+	title    string
+	version  string
+}
+
+// label describes the spec, e.g. "Notify API 1.2.0 (specs/notify.yaml)".
+func (m specMatch) label() string {
+	name := strings.TrimSpace(m.title + " " + m.version)
+	if name == "" {
+		return m.location
+	}
+	return name + " (" + m.location + ")"
+}
+
+// registryLookup is the result of looking an endpoint up across every spec
+// registered in the manifest.
+type registryLookup struct {
+	// used is the match the endpoint is rendered from.
+	used specMatch
+	// others are the other registered specs containing the endpoint.
+	others []specMatch
+}
+
+// lookupArgs reports whether args look up an endpoint in the registry
+// rather than name a spec: "<endpoint-path>" or "METHOD <endpoint-path>".
+func lookupArgs(args []string) (method, endpointPath string, ok bool) {
+	switch {
+	case len(args) == 1:
+		return "", args[0], true
+	case len(args) == 2 && isHTTPMethod(args[0]):
+		return args[0], args[1], true
+	}
+	return "", "", false
+}
+
+// lookupRegistry searches every spec in the nearest manifest for the
+// endpoint, in service name order. Specs are opened concurrently and only
+// those whose paths include the endpoint are loaded; those that fail to load
+// are skipped with a warning, so one broken spec doesn't block lookups in
+// the others. When only is set, that service is used and the others are
+// listed.
+func (a *app) lookupRegistry(method, endpointPath, only string) (*registryLookup, error) {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := loadManifest()
+	if err != nil {
+		return nil, err
+	}
+	if only != "" {
+		if _, err := m.SpecPath(only); err != nil {
+			return nil, err
+		}
+	}
+
+	endpointPath = normalizeEndpointPath(endpointPath)
+	method = strings.ToUpper(strings.TrimSpace(method))
+
+	var matches []specMatch
+	for _, opened := range a.openServices(m, cfg.Registry, method, endpointPath) {
+		if match, ok := matchService(m, opened, method, endpointPath); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	endpoint := strings.TrimSpace(method + " " + endpointPath)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s not found in any spec registered in %s", endpoint, m.Path)
+	}
+
+	lookup := &registryLookup{used: matches[0], others: matches[1:]}
+	if only != "" {
+		lookup.others = nil
+		found := false
+		for _, match := range matches {
+			if match.service == only {
+				lookup.used, found = match, true
+			} else {
+				lookup.others = append(lookup.others, match)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s not found in service '%s'; it is in: %s", endpoint, only, serviceList(matches))
+		}
+	}
+	return lookup, nil
+}
+
+// loadServices loads the spec of every service registered in m, warning
+// about those that fail to load, and returns the ones that loaded in service
+// name order.
+func (a *app) loadServices(m *manifest.Manifest, cfg manifest.LoadConfig) []manifest.Loaded {
+	results := m.LoadAll(m.ServiceNames(), cfg, a.loadSpec)
+
+	var loaded []manifest.Loaded
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(a.stderr, "Warning: skipping service '%s': %v\n", r.Service, r.Err)
+			continue
+		}
+		loaded = append(loaded, r)
+	}
+	if failed := len(results) - len(loaded); failed > 0 {
+		fmt.Fprintf(a.stderr, "Warning: %d of %d services failed to load\n", failed, len(results))
+	}
+	return loaded
+}
+
+// openServices opens the spec of every service registered in m, loading
+// those whose paths include the endpoint (and method, if set). It warns
+// about the specs that fail to open or load, and returns the others in
+// service name order.
+func (a *app) openServices(m *manifest.Manifest, cfg manifest.LoadConfig, method, endpointPath string) []manifest.Opened {
+	results := m.OpenAll(m.ServiceNames(), cfg, func(path string) (*spec.Lazy, error) {
+		lazy, err := a.openSpec(path)
+		if err != nil {
+			return nil, err
+		}
+		if indexed(lazy, method, endpointPath) {
+			if _, err := lazy.Document(); err != nil {
+				return nil, err
+			}
+		}
+		return lazy, nil
+	})
+
+	var opened []manifest.Opened
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(a.stderr, "Warning: skipping service '%s': %v\n", r.Service, r.Err)
+			continue
+		}
+		opened = append(opened, r)
+	}
+	if failed := len(results) - len(opened); failed > 0 {
+		fmt.Fprintf(a.stderr, "Warning: %d of %d services failed to load\n", failed, len(results))
+	}
+	return opened
+}
+
+// indexed reports whether the index of lazy includes the endpoint (and
+// method, if set).
+func indexed(lazy *spec.Lazy, method, endpointPath string) bool {
+	if method == "" {
+		_, ok := lazy.Methods(endpointPath)
+		return ok
+	}
+	return lazy.Has(endpointPath, method)
+}
+
+// matchService reports whether the opened spec of a service contains the
+// endpoint (and method, if set). Only specs loaded by openServices can.
+func matchService(m *manifest.Manifest, opened manifest.Opened, method, endpointPath string) (specMatch, bool) {
+	if !opened.Spec.Loaded() || !indexed(opened.Spec, method, endpointPath) {
+		return specMatch{}, false
+	}
+	doc, err := opened.Spec.Document()
+	if err != nil {
+		return specMatch{}, false
+	}
+
+	pathItem, err := findPathItem(doc, endpointPath)
+	if err != nil {
+		return specMatch{}, false
+	}
+	if method != "" && pathItem.GetOperation(method) == nil {
+		return specMatch{}, false
+	}
+
+	match := specMatch{service: opened.Service, location: m.Services[opened.Service].Spec, path: opened.Path}
+	if doc.Info != nil {
+		match.title, match.version = doc.Info.Title, doc.Info.Version
+	}
+	return match, true
+}
+
+// serviceList formats the services of matches, e.g. "`billing`, `notify`".
+func serviceList(matches []specMatch) string {
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = "`" + match.service + "`"
+	}
+	return strings.Join(names, ", ")
+}
+
+// provenanceNote renders a markdown note naming the spec the endpoint was
+// rendered from and the other registered specs containing it.
+func (l *registryLookup) provenanceNote() string {
+	var note strings.Builder
+	fmt.Fprintf(&note, "> **Source:** %s, service `%s`\n", l.used.label(), l.used.service)
+	if len(l.others) > 0 {
+		note.WriteString(">\n> **Also in:**\n")
+		for _, other := range l.others {
+			fmt.Fprintf(&note, "> - `%s`: %s\n", other.service, other.label())
+		}
+		note.WriteString(">\n> Pass `--spec <service>` to choose.\n")
+	}
+	note.WriteString("\n")
+	return note.String()
+}
+
+// provenanceSummary is the plain-text form of provenanceNote, written to
+// stderr when the output is not markdown.
+func (l *registryLookup) provenanceSummary() string {
+	summary := fmt.Sprintf("Source: %s, service '%s'", l.used.label(), l.used.service)
+	if len(l.others) > 0 {
+		names := make([]string, len(l.others))
+		for i, other := range l.others {
+			names[i] = other.service
+		}
+		summary += fmt.Sprintf(" (also in: %s; pass -spec <service> to choose)", strings.Join(names, ", "))
+	}
+	return summary
+}
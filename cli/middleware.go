@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/middleware"
+)
+
+// runMiddleware implements "docfinder middleware [-router chi] <METHOD> <endpoint-path> <openapi-file>".
+func (a *app) runMiddleware(args []string) error {
+	fs := a.newFlagSet("middleware")
+	routerFlag := fs.String("router", "chi", "Router to generate middleware for: chi (also plain net/http), echo, or gin.")
+	packageFlag := fs.String("package", "middleware", "Package name of the generated Go file.")
+	outFlag := fs.String("o", "", "Write the generated code to this file instead of stdout.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s middleware [flags] <METHOD> <endpoint-path> <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Generates Go middleware validating request parameters and body against the operation with kin-openapi.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 3 || !isHTTPMethod(rest[0]) {
+		fs.Usage()
+		return errUsage
+	}
+
+	router, err := middleware.ParseRouter(*routerFlag)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[2])
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(rest[0])
+	endpointPath := normalizeEndpointPath(rest[1])
+	pathItem, err := findPathItem(doc, endpointPath)
+	if err != nil {
+		return err
+	}
+	if err := validateMethod(pathItem, method); err != nil {
+		return err
+	}
+
+	src, err := middleware.Generate(middleware.Options{
+		Router:      router,
+		Package:     *packageFlag,
+		Method:      method,
+		Path:        endpointPath,
+		OperationID: pathItem.GetOperation(method).OperationID,
+		SpecFile:    rest[2],
+	})
+	if err != nil {
+		return err
+	}
+
+	if *outFlag == "" {
+		_, err = a.stdout.Write(src)
+		return err
+	}
+	if err := os.WriteFile(*outFlag, src, 0o644); err != nil {
+		return fmt.Errorf("failed to write middleware: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/compare"
+	"github.com/arthur-s/docfinder/internal/generator"
+)
+
+// runMigrate implements "docfinder migrate <METHOD> <endpoint-path> <openapi-file>".
+func (a *app) runMigrate(args []string) error {
+	fs := a.newFlagSet("migrate")
+	to := fs.String("to", "", "Replacement operation (\"METHOD /path\", \"/path\", or an operationId) when the old one has no "+generator.ExtensionReplacedBy+" extension.")
+	maxDepth := fs.Int("max-depth", generator.MaxRecursionDepth, "Maximum nesting depth of body fields to map.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s migrate [flags] <METHOD> <endpoint-path> <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Renders a migration guide from a deprecated operation to the one named by its %s extension: parameters and body fields mapped old to new, with renamed, removed, and new ones noted.\n\nFlags:\n", generator.ExtensionReplacedBy)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 3 || !isHTTPMethod(rest[0]) {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[2])
+	if err != nil {
+		return err
+	}
+
+	endpointPath := normalizeEndpointPath(rest[1])
+	pathItem, err := findPathItem(doc, endpointPath)
+	if err != nil {
+		return err
+	}
+	old := compare.Endpoint{Method: strings.ToUpper(rest[0]), Path: endpointPath, PathItem: pathItem}
+
+	var replacement compare.Endpoint
+	if *to != "" {
+		replacement, err = compare.FindEndpoint(doc, *to, old.Method)
+	} else {
+		replacement, err = compare.ReplacedBy(doc, old)
+	}
+	if err != nil {
+		return err
+	}
+
+	markdown, err := compare.Migration(old, replacement, *maxDepth)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(a.stdout, markdown)
+	return nil
+}
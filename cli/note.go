@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/notes"
+)
+
+// runNote implements "docfinder note add|list|remove".
+func (a *app) runNote(args []string) error {
+	fs := a.newFlagSet("note")
+	file := fs.String("file", "", "Notes file (default: nearest "+notes.FileName+", else one in the working directory).")
+	author := fs.String("author", os.Getenv("USER"), "Author recorded with added notes.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n")
+		fmt.Fprintf(a.stderr, "  %s note add [flags] METHOD <endpoint-path> <text>\n", programName)
+		fmt.Fprintf(a.stderr, "  %s note list [flags] [METHOD <endpoint-path>]\n", programName)
+		fmt.Fprintf(a.stderr, "  %s note remove [flags] METHOD <endpoint-path> <number>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Keeps team notes about operations in %s; they are rendered in a Team Notes section of the docs.\n\nFlags:\n", notes.FileName)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	action, rest := rest[0], rest[1:]
+
+	path, err := notesPath(*file, action == "add")
+	if err != nil {
+		return err
+	}
+	f, err := notes.Load(path)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case action == "add" && len(rest) == 3 && isHTTPMethod(rest[0]):
+		method, endpoint := strings.ToUpper(rest[0]), normalizeEndpointPath(rest[1])
+		if err := f.Add(method, endpoint, rest[2], *author, time.Now()); err != nil {
+			return err
+		}
+		if err := f.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stderr, "Added note to %s %s in %s\n", method, endpoint, f.Path)
+		return nil
+
+	case action == "remove" && len(rest) == 3 && isHTTPMethod(rest[0]):
+		n, err := strconv.Atoi(rest[2])
+		if err != nil {
+			return fmt.Errorf("invalid note number: %s", rest[2])
+		}
+		if err := f.Remove(rest[0], normalizeEndpointPath(rest[1]), n); err != nil {
+			return err
+		}
+		return f.Save()
+
+	case action == "list" && (len(rest) == 0 || len(rest) == 2 && isHTTPMethod(rest[0])):
+		keys := f.Keys()
+		if len(rest) == 2 {
+			keys = []string{strings.ToUpper(rest[0]) + " " + normalizeEndpointPath(rest[1])}
+		}
+		for _, key := range keys {
+			fmt.Fprintln(a.stdout, key)
+			for i, note := range f.Operations[key] {
+				fmt.Fprintf(a.stdout, "  %d. %s", i+1, note.Text)
+				if note.Author != "" || note.Date != "" {
+					fmt.Fprintf(a.stdout, " (%s)", strings.TrimSpace(note.Author+" "+note.Date))
+				}
+				fmt.Fprintln(a.stdout)
+			}
+		}
+		return nil
+	}
+
+	fs.Usage()
+	return errUsage
+}
+
+// notesPath returns the notes file to use: explicit if set, else the nearest
+// existing one. When create is set and none exists, a new file in the
+// working directory is used; otherwise empty string is returned.
+func notesPath(explicit string, create bool) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	path, err := notes.Find(wd)
+	if errors.Is(err, notes.ErrNotFound) {
+		if create {
+			return filepath.Join(wd, notes.FileName), nil
+		}
+		return "", nil
+	}
+	return path, err
+}
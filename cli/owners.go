@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/arthur-s/docfinder/internal/owners"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// runOwnedBy implements "docfinder owned-by <team> <openapi-file>...".
+func (a *app) runOwnedBy(args []string) error {
+	fs := a.newFlagSet("owned-by")
+	jsonOutput := fs.Bool("json", false, "Print the endpoints as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s owned-by [flags] <team> <openapi-file>...\n\n", programName)
+		fmt.Fprintf(a.stderr, "Lists the endpoints a team owns, by the %s extension or else the nearest %s file (see -owners).\n\nFlags:\n", owners.ExtensionOwner, owners.FileName)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) < 2 {
+		fs.Usage()
+		return errUsage
+	}
+	team := rest[0]
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	type endpoint struct {
+		Spec   string   `json:"spec"`
+		Method string   `json:"method"`
+		Path   string   `json:"path"`
+		Teams  []string `json:"teams"`
+		Source string   `json:"source"`
+	}
+	endpoints := []endpoint{}
+	var known []owners.Ownership
+	for _, specFile := range rest[1:] {
+		doc, err := a.loadSpec(specFile)
+		if err != nil {
+			return err
+		}
+		ownerships, err := a.ownerships(doc)
+		if err != nil {
+			return err
+		}
+		known = append(known, ownerships...)
+		for _, o := range owners.OwnedBy(ownerships, team) {
+			endpoints = append(endpoints, endpoint{Spec: specFile, Method: o.Method, Path: o.Path, Teams: o.Teams, Source: o.Source})
+		}
+	}
+
+	if len(endpoints) == 0 {
+		teams := owners.Teams(known)
+		if len(teams) == 0 {
+			return fmt.Errorf("no endpoints owned by %s: no endpoint has an owner", team)
+		}
+		return fmt.Errorf("no endpoints owned by %s (owners: %s)", team, strings.Join(teams, ", "))
+	}
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(endpoints, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	for _, e := range endpoints {
+		prefix := ""
+		if len(rest) > 2 {
+			prefix = e.Spec + "\t"
+		}
+		fmt.Fprintf(w, "%s%s\t%s\t%s\n", prefix, e.Method, e.Path, e.Source)
+	}
+	return w.Flush()
+}
+
+// ownerships resolves the owners of every operation in doc from x-owner
+// extensions and the owners file given with -owners, or else the nearest
+// one, if any.
+func (a *app) ownerships(doc *openapi3.T) ([]owners.Ownership, error) {
+	path := *a.ownersFlag
+	if path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		if path, err = owners.Find(wd); errors.Is(err, owners.ErrNotFound) {
+			return owners.Resolve(doc, nil), nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := owners.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return owners.Resolve(doc, f), nil
+}
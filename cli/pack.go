@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/owners"
+	"github.com/arthur-s/docfinder/internal/pack"
+)
+
+// runPack implements "docfinder pack <openapi-file>".
+func (a *app) runPack(args []string) error {
+	fs := a.newFlagSet("pack")
+	output := fs.String("o", "docs.tar.gz", "Archive to write.")
+	fs.StringVar(a.snippetsFlag, "snippets", "", "Comma-separated languages to show each operation's request in: curl, go, python, js.")
+	concurrency := fs.Int("concurrency", 0, "Number of operations rendered at once (default: export.concurrency from config, else one per CPU).")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s pack [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Writes a self-contained .tar.gz archive of the documentation, for air-gapped environments: a markdown file per operation, a %s contents page, the %s manifest, a %s for client-side search, and the spec and example attachments in %s/.\n\nFlags:\n",
+			pack.ContentsFile, export.IndexFile, export.SearchIndexFile, pack.AssetsDir)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	specPath, err := resolveSpecPath(rest[0])
+	if err != nil {
+		return err
+	}
+	doc, err := a.loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	genOpts, err := a.generateOptions(cfg, doc, "")
+	if err != nil {
+		return err
+	}
+	ownerships, err := a.ownerships(doc)
+	if err != nil {
+		return err
+	}
+
+	index, err := pack.Write(doc, pack.Options{
+		Output: *output,
+		Export: export.Options{
+			SpecPath:    specPath,
+			Generate:    append(genOpts, generator.WithFormat(generator.FormatMarkdown)),
+			Owners:      owners.Map(ownerships),
+			Concurrency: cmp.Or(*concurrency, cfg.Export.Concurrency),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Packed %d operations into %s\n", len(index.Files), *output)
+	return nil
+}
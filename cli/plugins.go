@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/plugin"
+)
+
+// runPlugins implements "docfinder plugins".
+func (a *app) runPlugins(args []string) error {
+	fs := a.newFlagSet("plugins")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s plugins\n\n", programName)
+		fmt.Fprintf(a.stderr, "Lists the %s<name> executables found on PATH. Use them as a renderer (-renderer NAME), a spec source (plugin:NAME:LOCATION in place of a spec file), or extra lint rules (lint-examples -plugin NAME).\n", plugin.Prefix)
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	plugins := plugin.List()
+	if len(plugins) == 0 {
+		fmt.Fprintf(a.stdout, "No plugins found. Install %s<name> executables on your PATH.\n", plugin.Prefix)
+		return nil
+	}
+	for _, p := range plugins {
+		fmt.Fprintf(a.stdout, "%-20s %s\n", p.Name, p.Path)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/probe"
+)
+
+// headerFlags collects repeated -header "Name: value" flags.
+type headerFlags http.Header
+
+func (h headerFlags) String() string {
+	var lines []string
+	for name, values := range h {
+		for _, value := range values {
+			lines = append(lines, name+": "+value)
+		}
+	}
+	return strings.Join(lines, ", ")
+}
+
+func (h headerFlags) Set(s string) error {
+	name, value, ok := strings.Cut(s, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("invalid header %q (expected \"Name: value\")", s)
+	}
+	http.Header(h).Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	return nil
+}
+
+// runProbe implements "docfinder probe [METHOD] <endpoint-path> <openapi-file>".
+func (a *app) runProbe(args []string) error {
+	fs := a.newFlagSet("probe")
+	server := fs.String("server", "", "Server to probe: a URL, or the "+generator.ExtensionEnvironment+" of a documented server (e.g. staging). Default: the first documented server.")
+	header := headerFlags{}
+	fs.Var(header, "header", "Request header as \"Name: value\", e.g. for credentials. Repeatable.")
+	allowUnsafe := fs.Bool("allow-unsafe", false, "Allow methods other than GET and HEAD, which may change data on the server.")
+	data := fs.String("data", "", "Request body to send with -allow-unsafe.")
+	timeout := fs.Duration("timeout", 10*time.Second, "Timeout for the request.")
+	jsonOutput := fs.Bool("json", false, "Print the result as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s probe [flags] [METHOD] <endpoint-path> <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Sends a request to a live server and compares the response status, content type, and top-level JSON fields with the documented contract, reporting drift. The endpoint path is a documented path, with parameters filled from their examples, or a concrete path such as /users/42. Only GET and HEAD are sent unless -allow-unsafe is given.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	method := http.MethodGet
+	if len(rest) == 3 && isHTTPMethod(rest[0]) {
+		method, rest = strings.ToUpper(rest[0]), rest[1:]
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return errUsage
+	}
+	if !probe.IsSafe(method) && !*allowUnsafe {
+		return fmt.Errorf("probe only sends GET and HEAD requests; pass -allow-unsafe to send %s", method)
+	}
+	if *data != "" && !*allowUnsafe {
+		return fmt.Errorf("-data requires -allow-unsafe")
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[1])
+	if err != nil {
+		return err
+	}
+	req, err := probe.NewRequest(doc, method, normalizeEndpointPath(rest[0]), *server)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	if *data != "" {
+		body = []byte(*data)
+		if contentType := req.BodyContentType(); http.Header(header).Get("Content-Type") == "" && contentType != "" {
+			http.Header(header).Set("Content-Type", contentType)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	result, err := probe.Do(ctx, &http.Client{}, req, http.Header(header), body)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(out))
+	} else {
+		fmt.Fprintf(a.stdout, "%s %s -> %d %s", result.Method, result.URL, result.Status, http.StatusText(result.Status))
+		if result.ContentType != "" {
+			fmt.Fprintf(a.stdout, " (%s)", result.ContentType)
+		}
+		fmt.Fprintln(a.stdout)
+		for _, drift := range result.Drift {
+			fmt.Fprintf(a.stdout, "  drift %s\n", drift)
+		}
+		if len(result.Drift) == 0 {
+			fmt.Fprintf(a.stdout, "  matches the documented contract of %s %s\n", req.Method, req.Path)
+		}
+	}
+
+	if len(result.Drift) > 0 {
+		return fmt.Errorf("%d drift(s) from the documented contract", len(result.Drift))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/config"
+)
+
+// builtinProfiles are the render profiles available without configuration.
+// Profiles of the same name in the config file replace them.
+var builtinProfiles = map[string]config.Profile{
+	// A brief view for answering support questions: what to call and how
+	"support": {
+		"sections":        "metadata,parameters,examples",
+		"snippets":        "curl",
+		"quick-reference": "true",
+	},
+	// Everything needed to build a client against the operation
+	"integration": {
+		"snippets":   "curl,go,python,js",
+		"extensions": "true",
+		"diagram":    "sequence",
+	},
+}
+
+// applyProfile sets the top-level flags bundled in the profile selected
+// with -profile. Flags given on the command line take precedence.
+func (a *app) applyProfile(cfg *config.Config) error {
+	name := *a.profileFlag
+	if name == "" {
+		return nil
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		profile, ok = builtinProfiles[name]
+	}
+	if !ok {
+		names := slices.Collect(maps.Keys(builtinProfiles))
+		for n := range cfg.Profiles {
+			if _, ok := builtinProfiles[n]; !ok {
+				names = append(names, n)
+			}
+		}
+		slices.Sort(names)
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+
+	explicit := make(map[string]bool)
+	a.fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for _, flagName := range slices.Sorted(maps.Keys(profile)) {
+		if flagName == "profile" || a.fs.Lookup(flagName) == nil {
+			return fmt.Errorf("profile %s: unknown flag %q", name, flagName)
+		}
+		if explicit[flagName] {
+			continue
+		}
+		if err := a.fs.Set(flagName, profile[flagName]); err != nil {
+			return fmt.Errorf("profile %s: %w", name, err)
+		}
+	}
+	return nil
+}
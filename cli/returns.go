@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/arthur-s/docfinder/internal/fuzzy"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/arthur-s/docfinder/internal/returns"
+)
+
+// runReturns implements "docfinder returns <schema-name> <openapi-file>".
+func (a *app) runReturns(args []string) error {
+	fs := a.newFlagSet("returns")
+	jsonOutput := fs.Bool("json", false, "Print the operations as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s returns [flags] <schema-name> <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Lists the operations whose success responses return a component schema: the schema itself, an array of it, or an allOf, oneOf, or anyOf composition including it.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return errUsage
+	}
+	name := rest[0]
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[1])
+	if err != nil {
+		return err
+	}
+	api := model.FromOpenAPI(doc)
+	if api.Schemas[name] == nil {
+		var names []string
+		for schemaName := range api.Schemas {
+			names = append(names, schemaName)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("schema '%s' not found%s", name, fuzzy.DidYouMean(fuzzy.RankNames(names, name, fuzzy.MaxSuggestions)))
+	}
+	matches := returns.Find(api, name)
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+	fmt.Fprint(a.stdout, returns.Format(name, matches))
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/diff"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// runSchemaDiff implements "docfinder schema-diff <schema> <old-file> <new-file>".
+func (a *app) runSchemaDiff(args []string) error {
+	fs := a.newFlagSet("schema-diff")
+	breakingOnly := fs.Bool("breaking", false, "Only report breaking changes.")
+	failOnBreaking := fs.Bool("fail-on-breaking", false, "Exit with an error if any breaking change is found.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s schema-diff [flags] <schema-name> <old-openapi-file> <new-openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Compares a component schema across two spec versions and classifies breaking changes.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 3 {
+		fs.Usage()
+		return errUsage
+	}
+	name, oldFile, newFile := rest[0], rest[1], rest[2]
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	oldDoc, err := a.loadSpec(oldFile)
+	if err != nil {
+		return err
+	}
+	newDoc, err := a.loadSpec(newFile)
+	if err != nil {
+		return err
+	}
+
+	oldSchema := componentSchema(oldDoc, name)
+	newSchema := componentSchema(newDoc, name)
+	if oldSchema == nil && newSchema == nil {
+		return fmt.Errorf("schema '%s' not found in either spec", name)
+	}
+
+	changes := diff.Schemas(name, oldSchema, newSchema)
+	breaking := diff.Breaking(changes)
+	if *breakingOnly {
+		changes = breaking
+	}
+
+	fmt.Fprint(a.stdout, formatSchemaDiff(name, changes, len(breaking)))
+
+	if *failOnBreaking && len(breaking) > 0 {
+		return fmt.Errorf("%d breaking change(s) to schema '%s'", len(breaking), name)
+	}
+	return nil
+}
+
+// componentSchema returns the named component schema, or nil if absent.
+func componentSchema(doc *openapi3.T, name string) *openapi3.Schema {
+	if doc.Components == nil {
+		return nil
+	}
+	ref := doc.Components.Schemas[name]
+	if ref == nil {
+		return nil
+	}
+	return ref.Value
+}
+
+// formatSchemaDiff renders schema changes as a markdown list.
+func formatSchemaDiff(name string, changes []diff.Change, breaking int) string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "# Schema `%s`\n\n", name)
+	if len(changes) == 0 {
+		out.WriteString("No changes.\n")
+		return out.String()
+	}
+
+	fmt.Fprintf(&out, "%d change(s), %d breaking.\n\n", len(changes), breaking)
+	for _, change := range changes {
+		if change.Breaking {
+			fmt.Fprintf(&out, "- **Breaking:** %s\n", change)
+		} else {
+			fmt.Fprintf(&out, "- %s\n", change)
+		}
+	}
+
+	return out.String()
+}
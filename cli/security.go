@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/arthur-s/docfinder/internal/fuzzy"
+	"github.com/arthur-s/docfinder/internal/security"
+)
+
+// runSecurity implements "docfinder security <openapi-file>".
+func (a *app) runSecurity(args []string) error {
+	fs := a.newFlagSet("security")
+	scheme := fs.String("scheme", "", "Document only this security scheme.")
+	jsonOutput := fs.Bool("json", false, "Print the schemes as JSON.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s security [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Documents the security schemes of the spec: their type and where credentials go, OAuth2 flows with their authorization and token URLs and scopes, and the operations requiring each scheme.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+	schemes := security.Analyze(doc)
+	if *scheme != "" {
+		i := slices.IndexFunc(schemes, func(s security.Scheme) bool { return s.Name == *scheme })
+		if i < 0 {
+			var names []string
+			for _, s := range schemes {
+				names = append(names, s.Name)
+			}
+			return fmt.Errorf("unknown security scheme %s%s", *scheme, fuzzy.DidYouMean(fuzzy.RankNames(names, *scheme, fuzzy.MaxSuggestions)))
+		}
+		schemes = schemes[i : i+1]
+	}
+
+	if *jsonOutput {
+		data, err := json.MarshalIndent(schemes, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+		return nil
+	}
+	fmt.Fprint(a.stdout, security.Format(schemes))
+	return nil
+}
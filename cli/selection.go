@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/config"
+	"github.com/arthur-s/docfinder/internal/fuzzy"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/pager"
+	"github.com/arthur-s/docfinder/internal/tags"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// selecting reports whether operations are selected across paths, with -tag
+// or -path-regex, instead of by endpoint path or operationId.
+func (a *app) selecting() bool {
+	return *a.tagFlag != "" || *a.pathRegexFlag != ""
+}
+
+// renderSelection renders every operation of doc selected with -tag and
+// -path-regex as one document.
+func (a *app) renderSelection(cfg *config.Config, doc *openapi3.T, method string) error {
+	if *a.rendererFlag != "" {
+		return errors.New("-tag and -path-regex cannot be combined with -renderer")
+	}
+
+	sel := generator.Selection{Tag: *a.tagFlag}
+	if *a.pathRegexFlag != "" {
+		var err error
+		if sel.PathRegex, err = regexp.Compile(*a.pathRegexFlag); err != nil {
+			return fmt.Errorf("invalid -path-regex: %w", err)
+		}
+	}
+	if sel.Tag != "" {
+		if err := checkTag(doc, sel.Tag); err != nil {
+			return err
+		}
+	}
+
+	opts, err := a.generateOptions(cfg, doc, strings.ToUpper(strings.TrimSpace(method)))
+	if err != nil {
+		return err
+	}
+	markdown, err := generator.New(doc, opts...).GenerateSelection(sel)
+	if err != nil {
+		return err
+	}
+	return pager.Page(a.stdout, a.stderr, markdown, *a.noPagerFlag)
+}
+
+// renderAPI implements -full, rendering every operation of doc as one
+// document grouped by tag.
+func (a *app) renderAPI(cfg *config.Config, doc *openapi3.T, method string) error {
+	if *a.rendererFlag != "" {
+		return errors.New("-full cannot be combined with -renderer")
+	}
+
+	opts, err := a.generateOptions(cfg, doc, strings.ToUpper(strings.TrimSpace(method)))
+	if err != nil {
+		return err
+	}
+	markdown, err := generator.New(doc, opts...).GenerateAPI()
+	if err != nil {
+		return err
+	}
+	return pager.Page(a.stdout, a.stderr, markdown, *a.noPagerFlag)
+}
+
+// checkTag returns an error suggesting similar tags if no operation of doc
+// carries tag.
+func checkTag(doc *openapi3.T, tag string) error {
+	var names []string
+	for _, t := range tags.List(doc) {
+		if t.Operations == 0 {
+			continue
+		}
+		if strings.EqualFold(t.Name, tag) {
+			return nil
+		}
+		names = append(names, t.Name)
+	}
+	return fmt.Errorf("no operations tagged %s%s", tag, fuzzy.DidYouMean(fuzzy.RankNames(names, tag, fuzzy.MaxSuggestions)))
+}
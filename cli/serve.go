@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/serve"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// runServe implements "docfinder serve <openapi-file>".
+func (a *app) runServe(args []string) error {
+	fs := a.newFlagSet("serve")
+	addr := fs.String("addr", "localhost:8080", "Address to listen on.")
+	idle := fs.Duration("idle", 10*time.Minute, "Release the loaded spec after no request has read it for this long; it is loaded again on the next request. 0 keeps it loaded.")
+	auditPath := fs.String("audit-log", "", "Append a JSON line for every request to this file.")
+	auditMaxMB := fs.Int("audit-log-max-mb", 100, "Move the audit log aside to <file>.1 once it would grow past this many megabytes. 0 never rotates it.")
+	auditUser := fs.String("audit-user-header", "", "Request header naming the requester in the audit log, e.g. X-Forwarded-User set by an authenticating proxy.")
+	fs.StringVar(a.snippetsFlag, "snippets", "", "Comma-separated languages to show each operation's request in: curl, go, python, js.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s serve [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Serves the rendered docs over HTTP: markdown at %s{path}, the operations as JSON at %s, and an endpoint's JSON document at %s?path=...&method=....\n\nFlags:\n",
+			serve.DocsPrefix, serve.EndpointsRoute, serve.DocRoute)
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	lazy, err := a.openSpec(rest[0])
+	if err != nil {
+		return err
+	}
+	server := serve.NewLazy(lazy, func(doc *openapi3.T) ([]generator.Option, error) {
+		return a.generateOptions(cfg, doc, "")
+	})
+	if *auditPath != "" {
+		file, err := serve.OpenRotatingFile(*auditPath, int64(*auditMaxMB)<<20)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer file.Close()
+		log := serve.NewAuditLog(file)
+		log.UserHeader = *auditUser
+		log.OnError = func(err error) { fmt.Fprintln(a.stderr, err) }
+		server.SetAuditLog(log)
+	}
+	// Load the spec once up front, so a broken spec or flag fails here
+	// rather than on the first request
+	endpoints, err := server.Endpoints()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+	if *idle > 0 {
+		go func() {
+			ticker := time.NewTicker(min(*idle, time.Minute))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					server.ReleaseIdle(*idle)
+				}
+			}
+		}()
+	}
+
+	fmt.Fprintf(a.stderr, "Serving %d operations at http://%s%s (Ctrl-C to stop)\n", len(endpoints), listener.Addr(), serve.DocsPrefix)
+	if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/stats"
+)
+
+// runStats implements "docfinder stats".
+func (a *app) runStats(args []string) error {
+	fs := a.newFlagSet("stats")
+	record := fs.String("record", "", "Append a timestamped snapshot of the statistics to this JSON Lines file.")
+	trend := fs.String("trend", "", "Render the growth recorded in this JSON Lines file instead of measuring a spec.")
+	period := fs.String("period", stats.PeriodAll, "With -trend, show the last snapshot of each: all, month, quarter, or year.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s stats [-record FILE] <openapi-file>\n  %s stats -trend FILE [-period PERIOD]\n\n", programName, programName)
+		fmt.Fprintf(a.stderr, "Counts the paths, operations, component schemas, and deprecated operations of a spec. Snapshots recorded with -record, e.g. from CI on every release, show the API's growth with -trend.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *trend != "" {
+		if len(rest) != 0 || *record != "" {
+			fs.Usage()
+			return errUsage
+		}
+		snapshots, err := stats.Read(*trend)
+		if err != nil {
+			return err
+		}
+		if snapshots, err = stats.ByPeriod(snapshots, *period); err != nil {
+			return err
+		}
+		fmt.Fprint(a.stdout, stats.FormatTrend(snapshots))
+		return nil
+	}
+
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	snapshot := stats.Collect(doc)
+	snapshot.Time = time.Now().UTC()
+	if *record != "" {
+		if err := stats.Append(*record, snapshot); err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(a.stdout, stats.FormatSnapshot(snapshot))
+	return nil
+}
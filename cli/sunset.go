@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/arthur-s/docfinder/internal/har"
+	"github.com/arthur-s/docfinder/internal/sunset"
+)
+
+// runSunset implements "docfinder sunset <openapi-file> <traffic.har>...".
+func (a *app) runSunset(args []string) error {
+	fs := a.newFlagSet("sunset")
+	consumerFlag := fs.String("consumer", "header:User-Agent", "How consumers are identified: header:NAME (e.g. header:X-API-Key) or query:NAME.")
+	outFlag := fs.String("o", "", "Write the CSV report to this file instead of stdout.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s sunset [flags] <openapi-file> <traffic.har>...\n\n", programName)
+		fmt.Fprintf(a.stderr, "Reports, as CSV, which deprecated operations, parameters, and request body properties each consumer still uses in recorded HAR traffic.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) < 2 {
+		fs.Usage()
+		return errUsage
+	}
+
+	consumer, err := sunset.ParseConsumer(*consumerFlag)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+
+	var entries []har.Entry
+	for _, path := range rest[1:] {
+		loaded, err := har.Load(path)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, loaded...)
+	}
+
+	var w io.Writer = a.stdout
+	if *outFlag != "" {
+		f, err := os.Create(*outFlag)
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return sunset.WriteCSV(w, sunset.Report(doc, entries, consumer))
+}
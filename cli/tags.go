@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/arthur-s/docfinder/internal/tags"
+)
+
+// runTags implements "docfinder tags <openapi-file>".
+func (a *app) runTags(args []string) error {
+	fs := a.newFlagSet("tags")
+	jsonOutput := fs.Bool("json", false, "Print the tags and problems as JSON.")
+	warnOnly := fs.Bool("warn-only", false, "Report problems without exiting with an error.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s tags [flags] <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Lists the tags of the spec with the number of operations using each, after renaming them to the canonical names in the config file's tags.canonical map, and flags tags missing from the top-level tags list and tags that differ only in case.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[0])
+	if err != nil {
+		return err
+	}
+	list := tags.List(doc)
+	problems := tags.Check(doc)
+
+	if *jsonOutput {
+		out := struct {
+			Tags     []tags.Tag     `json:"tags"`
+			Problems []tags.Problem `json:"problems"`
+		}{list, problems}
+		if out.Tags == nil {
+			out.Tags = []tags.Tag{}
+		}
+		if out.Problems == nil {
+			out.Problems = []tags.Problem{}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout, string(data))
+	} else {
+		w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		for _, t := range list {
+			declared := ""
+			if !t.Declared {
+				declared = "undeclared"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\n", t.Name, t.Operations, declared)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			fmt.Fprintln(a.stdout)
+		}
+		for _, p := range problems {
+			fmt.Fprintln(a.stdout, p)
+		}
+	}
+
+	if len(problems) > 0 && !*warnOnly {
+		return fmt.Errorf("%d tag problem(s)", len(problems))
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/arthur-s/docfinder/internal/testplan"
+)
+
+// runTestPlan implements "docfinder testplan [METHOD] <endpoint-path> <openapi-file>".
+func (a *app) runTestPlan(args []string) error {
+	fs := a.newFlagSet("testplan")
+	format := fs.String("format", "markdown", "Output format: markdown, a checklist per operation, or csv.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s testplan [flags] [METHOD] <endpoint-path> <openapi-file>\n\n", programName)
+		fmt.Fprintf(a.stderr, "Derives test cases for each operation of an endpoint from its contract: credentials left out, each required parameter or body field left out, each enum value and range bound, and each documented response status, with the status to expect.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	method := ""
+	if len(rest) == 3 && isHTTPMethod(rest[0]) {
+		method, rest = strings.ToUpper(rest[0]), rest[1:]
+	}
+	if len(rest) != 2 {
+		fs.Usage()
+		return errUsage
+	}
+	if *format != "markdown" && *format != "csv" {
+		return fmt.Errorf("unsupported format: %s (expected markdown or csv)", *format)
+	}
+
+	if _, err := a.loadConfig(); err != nil {
+		return err
+	}
+
+	doc, err := a.loadSpec(rest[1])
+	if err != nil {
+		return err
+	}
+	endpointPath := normalizeEndpointPath(rest[0])
+	if _, err := findPathItem(doc, endpointPath); err != nil {
+		return err
+	}
+	cases, err := testplan.Plan(model.FromOpenAPI(doc), method, endpointPath)
+	if err != nil {
+		return err
+	}
+
+	if *format == "csv" {
+		return testplan.WriteCSV(a.stdout, cases)
+	}
+	fmt.Fprint(a.stdout, testplan.Format(endpointPath, cases))
+	return nil
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/diff"
+	"github.com/arthur-s/docfinder/internal/manifest"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// resolveAPIVersion returns the spec snapshot for a historical API version of
+// a service, along with the snapshots that came after it.
+func resolveAPIVersion(service, apiVersion string) (string, []manifest.Snapshot, error) {
+	if service == "" {
+		return "", nil, fmt.Errorf("-api-version requires -service")
+	}
+
+	m, err := loadManifest()
+	if err != nil {
+		return "", nil, err
+	}
+
+	snapshot, err := m.Snapshot(service, apiVersion)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return snapshot, m.LaterSnapshots(service, apiVersion), nil
+}
+
+// laterChangesNote renders a markdown note listing how the endpoint changed
+// in each snapshot after apiVersion. Snapshots that fail to load are reported
+// inline rather than aborting the render.
+func (a *app) laterChangesNote(apiVersion, endpointPath, method string, pathItem *openapi3.PathItem, later []manifest.Snapshot) string {
+	var note strings.Builder
+
+	fmt.Fprintf(&note, "> **Note:** This documentation reflects API version `%s`.\n", apiVersion)
+
+	previous := pathItem
+	var sections []string
+
+	for _, snapshot := range later {
+		doc, err := a.loadOpenAPISpec(snapshot.Spec)
+		if err != nil {
+			sections = append(sections, fmt.Sprintf("> **%s**\n> - ⚠️ could not load snapshot: %v\n", snapshot.Version, err))
+			continue
+		}
+
+		var current *openapi3.PathItem
+		if doc.Paths != nil {
+			current = doc.Paths.Find(endpointPath)
+		}
+
+		changes := diff.PathItems(previous, current, method)
+		previous = current
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		var section strings.Builder
+		fmt.Fprintf(&section, "> **%s**\n", snapshot.Version)
+		if current == nil {
+			section.WriteString("> - Endpoint removed\n")
+		} else {
+			for _, change := range changes {
+				fmt.Fprintf(&section, "> - %s\n", change)
+			}
+		}
+		sections = append(sections, section.String())
+	}
+
+	if len(sections) == 0 {
+		note.WriteString(">\n> No later changes to this endpoint.\n\n")
+		return note.String()
+	}
+
+	note.WriteString(">\n> Later changes to this endpoint:\n")
+	for _, section := range sections {
+		note.WriteString(">\n")
+		note.WriteString(section)
+	}
+	note.WriteString("\n")
+
+	return note.String()
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/watch"
+)
+
+// runWatch implements "docfinder watch <openapi-file>...".
+func (a *app) runWatch(args []string) error {
+	fs := a.newFlagSet("watch")
+	interval := fs.Duration("interval", 0, "How often to check the specs for changes (default: watch.interval from config, else 5s).")
+	webhook := fs.String("webhook", "", "Comma-separated URLs to POST a summary of each change to, in addition to watch.webhooks from config.")
+	webhookFormat := fs.String("webhook-format", "json", "Payload format for -webhook URLs: json or slack.")
+	breakingOnly := fs.Bool("breaking-only", false, "Only notify webhooks of changes that include breaking changes.")
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage:\n  %s watch [flags] <openapi-file>...\n\n", programName)
+		fmt.Fprintf(a.stderr, "Runs until interrupted, printing the changed endpoints of each spec whenever it changes and posting a summary with breaking changes flagged to the configured webhooks.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	format, err := watch.ParseFormat(*webhookFormat)
+	if err != nil {
+		return err
+	}
+	webhooks := cfg.Watch.Webhooks
+	if *webhook != "" {
+		for _, url := range strings.Split(*webhook, ",") {
+			webhooks = append(webhooks, watch.Webhook{URL: strings.TrimSpace(url), Format: format})
+		}
+	}
+	for _, w := range webhooks {
+		if _, err := watch.ParseFormat(string(w.Format)); err != nil {
+			return err
+		}
+	}
+
+	w := &watch.Watcher{
+		Specs:    rest,
+		Interval: cfg.Watch.Interval,
+		Load:     a.loadSpec,
+		OnError: func(path string, err error) {
+			fmt.Fprintf(a.stderr, "Warning: %s: %v\n", path, err)
+		},
+	}
+	if *interval > 0 {
+		w.Interval = *interval
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := &http.Client{}
+	w.OnChange = func(event watch.Event) {
+		fmt.Fprintf(a.stdout, "%s %s\n", event.Time.Format(time.RFC3339), event.Summary())
+		for _, endpoint := range event.Endpoints {
+			for _, change := range endpoint.Changes {
+				marker := ""
+				if change.Breaking {
+					marker = " [breaking]"
+				}
+				fmt.Fprintf(a.stdout, "  %s: %s%s\n", endpoint.Path, change.Description, marker)
+			}
+		}
+
+		if (*breakingOnly || cfg.Watch.BreakingOnly) && event.Breaking() == 0 {
+			return
+		}
+		for _, hook := range webhooks {
+			if err := hook.Notify(ctx, client, event); err != nil {
+				fmt.Fprintf(a.stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+
+	fmt.Fprintf(a.stderr, "Watching %s (Ctrl-C to stop)\n", strings.Join(rest, ", "))
+	return w.Run(ctx)
+}
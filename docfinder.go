@@ -0,0 +1,192 @@
+// Package docfinder builds OpenAPI documents in memory and renders their
+// endpoint documentation, so programs that construct or modify specs can
+// render docs without writing them to files first:
+//
+//	markdown, err := docfinder.NewSpecBuilder().
+//		Info("Events API", "1.0.0").
+//		Path("/events/{id}").
+//		Get(getEvent).
+//		Delete(deleteEvent).
+//		Render("/events/{id}", docfinder.WithMethod("GET"))
+package docfinder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultOpenAPIVersion is the openapi version of documents started with
+// NewSpecBuilder.
+const DefaultOpenAPIVersion = "3.0.3"
+
+// SpecBuilder builds an OpenAPI document. Methods return the builder for
+// chaining; operations are added to the path last selected with Path.
+// Mistakes such as adding an operation before selecting a path are
+// reported by Build.
+type SpecBuilder struct {
+	doc  *openapi3.T
+	path string
+	errs []error
+}
+
+// NewSpecBuilder starts an empty document.
+func NewSpecBuilder() *SpecBuilder {
+	return EditSpec(&openapi3.T{OpenAPI: DefaultOpenAPIVersion})
+}
+
+// EditSpec returns a builder that modifies doc in place, e.g. one loaded
+// from a file.
+func EditSpec(doc *openapi3.T) *SpecBuilder {
+	if doc.Paths == nil {
+		doc.Paths = openapi3.NewPaths()
+	}
+	return &SpecBuilder{doc: doc}
+}
+
+// Info sets the API title and version.
+func (b *SpecBuilder) Info(title, version string) *SpecBuilder {
+	if b.doc.Info == nil {
+		b.doc.Info = &openapi3.Info{}
+	}
+	b.doc.Info.Title = title
+	b.doc.Info.Version = version
+	return b
+}
+
+// Server adds a base URL.
+func (b *SpecBuilder) Server(url, description string) *SpecBuilder {
+	b.doc.AddServer(&openapi3.Server{URL: url, Description: description})
+	return b
+}
+
+// Schema adds a component schema, which operations can reference as
+// "#/components/schemas/<name>".
+func (b *SpecBuilder) Schema(name string, schema *openapi3.Schema) *SpecBuilder {
+	if b.doc.Components == nil {
+		b.doc.Components = &openapi3.Components{}
+	}
+	if b.doc.Components.Schemas == nil {
+		b.doc.Components.Schemas = make(openapi3.Schemas)
+	}
+	b.doc.Components.Schemas[name] = openapi3.NewSchemaRef("", schema)
+	return b
+}
+
+// Path selects the path following operations are added to, creating it if
+// needed.
+func (b *SpecBuilder) Path(path string) *SpecBuilder {
+	if !strings.HasPrefix(path, "/") {
+		b.errs = append(b.errs, fmt.Errorf("path %q must start with /", path))
+		return b
+	}
+	if b.doc.Paths.Value(path) == nil {
+		b.doc.Paths.Set(path, &openapi3.PathItem{})
+	}
+	b.path = path
+	return b
+}
+
+// Operation adds op to the selected path under method, replacing any
+// operation already there.
+func (b *SpecBuilder) Operation(method string, op *openapi3.Operation) *SpecBuilder {
+	method = strings.ToUpper(method)
+	switch {
+	case b.path == "":
+		b.errs = append(b.errs, fmt.Errorf("%s operation added before selecting a path", method))
+	case op == nil:
+		b.errs = append(b.errs, fmt.Errorf("nil %s operation for %s", method, b.path))
+	default:
+		b.doc.Paths.Value(b.path).SetOperation(method, op)
+	}
+	return b
+}
+
+// Get adds op as the GET operation of the selected path.
+func (b *SpecBuilder) Get(op *openapi3.Operation) *SpecBuilder {
+	return b.Operation(http.MethodGet, op)
+}
+
+// Put adds op as the PUT operation of the selected path.
+func (b *SpecBuilder) Put(op *openapi3.Operation) *SpecBuilder {
+	return b.Operation(http.MethodPut, op)
+}
+
+// Post adds op as the POST operation of the selected path.
+func (b *SpecBuilder) Post(op *openapi3.Operation) *SpecBuilder {
+	return b.Operation(http.MethodPost, op)
+}
+
+// Delete adds op as the DELETE operation of the selected path.
+func (b *SpecBuilder) Delete(op *openapi3.Operation) *SpecBuilder {
+	return b.Operation(http.MethodDelete, op)
+}
+
+// Patch adds op as the PATCH operation of the selected path.
+func (b *SpecBuilder) Patch(op *openapi3.Operation) *SpecBuilder {
+	return b.Operation(http.MethodPatch, op)
+}
+
+// Build returns the document with its local $refs resolved, or the first
+// mistake made while building it. The document is not validated against
+// the OpenAPI specification; call its Validate method for that.
+func (b *SpecBuilder) Build() (*openapi3.T, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	if err := openapi3.NewLoader().ResolveRefsIn(b.doc, nil); err != nil {
+		return nil, fmt.Errorf("failed to resolve references: %w", err)
+	}
+	return b.doc, nil
+}
+
+// Render builds the document and renders the documentation of path.
+func (b *SpecBuilder) Render(path string, opts ...Option) (string, error) {
+	doc, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+	return Render(doc, path, opts...)
+}
+
+// Render renders the documentation of path in doc, e.g. one built with
+// SpecBuilder or loaded and then modified in memory.
+func Render(doc *openapi3.T, path string, opts ...Option) (string, error) {
+	if doc == nil || doc.Paths == nil {
+		return "", fmt.Errorf("document has no paths")
+	}
+	pathItem := doc.Paths.Value(path)
+	if pathItem == nil {
+		return "", fmt.Errorf("path not found: %s", path)
+	}
+	return generator.New(doc, opts...).Generate(path, pathItem)
+}
+
+// RenderParameters writes the parameters section of an operation's
+// documentation for params to w. Like the other Render functions below, it
+// renders one building block of Render's output, so programs can assemble
+// custom documents, e.g. a page showing only a request body.
+func RenderParameters(w io.Writer, params openapi3.Parameters, opts ...Option) error {
+	return generator.New(nil, opts...).RenderParameters(w, params)
+}
+
+// RenderRequestBody writes the request body section for body to w.
+func RenderRequestBody(w io.Writer, body *openapi3.RequestBodyRef, opts ...Option) error {
+	return generator.New(nil, opts...).RenderRequestBody(w, body, "request")
+}
+
+// RenderResponses writes the responses section for responses to w.
+func RenderResponses(w io.Writer, responses *openapi3.Responses, opts ...Option) error {
+	return generator.New(nil, opts...).RenderResponses(w, responses, "response")
+}
+
+// RenderSchemaRef writes the schema block for schema to w, as shown under
+// a request or response content type.
+func RenderSchemaRef(w io.Writer, schema *openapi3.SchemaRef, opts ...Option) error {
+	return generator.New(nil, opts...).RenderSchemaRef(w, schema)
+}
@@ -0,0 +1,198 @@
+package docfinder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestSpecBuilder(t *testing.T) {
+	getEvent := &openapi3.Operation{
+		Summary: "Get an event",
+		Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().WithDescription("OK").
+				WithJSONSchemaRef(openapi3.NewSchemaRef("#/components/schemas/Event", nil)),
+		})),
+	}
+	event := openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())
+
+	doc, err := NewSpecBuilder().
+		Info("Events API", "1.0.0").
+		Server("https://api.example.com", "Production").
+		Schema("Event", event).
+		Path("/events/{id}").
+		Get(getEvent).
+		Delete(&openapi3.Operation{Summary: "Delete an event"}).
+		Path("/health").
+		Get(&openapi3.Operation{Summary: "Health check"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if got := doc.Paths.Len(); got != 2 {
+		t.Errorf("Expected 2 paths, got %d", got)
+	}
+	ref := getEvent.Responses.Status(200).Value.Content.Get("application/json").Schema
+	if ref.Value != event {
+		t.Errorf("Expected $ref to resolve to the Event schema, got %v", ref.Value)
+	}
+
+	markdown, err := Render(doc, "/events/{id}", WithMethod("GET"))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	for _, expected := range []string{
+		"**API:** Events API 1.0.0",
+		"`https://api.example.com` - Production",
+		"## GET /events/{id}",
+		"**name**",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, markdown)
+		}
+	}
+	if strings.Contains(markdown, "DELETE") {
+		t.Errorf("Did not expect %q in output:\n%s", "DELETE", markdown)
+	}
+}
+
+func TestSpecBuilder_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() *SpecBuilder
+		expected string
+	}{
+		{
+			"operation before path",
+			func() *SpecBuilder { return NewSpecBuilder().Get(&openapi3.Operation{}) },
+			"GET operation added before selecting a path",
+		},
+		{
+			"relative path",
+			func() *SpecBuilder { return NewSpecBuilder().Path("events") },
+			`path "events" must start with /`,
+		},
+		{
+			"nil operation",
+			func() *SpecBuilder { return NewSpecBuilder().Path("/events").Post(nil) },
+			"nil POST operation for /events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build().Build()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Build() error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEditSpec(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /events:
+    get:
+      summary: List events
+      responses:
+        "200":
+          description: OK
+`))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	markdown, err := EditSpec(doc).
+		Path("/events").
+		Post(&openapi3.Operation{Summary: "Create an event"}).
+		Render("/events")
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	for _, expected := range []string{"## GET /events", "## POST /events", "Create an event"} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, markdown)
+		}
+	}
+
+	if _, err := Render(doc, "/missing"); err == nil {
+		t.Error("Expected error for missing path")
+	}
+}
+
+func TestRenderBlocks(t *testing.T) {
+	event := openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())
+	params := openapi3.Parameters{{Value: openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema())}}
+	body := &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchema(event)}
+	responses := openapi3.NewResponses(openapi3.WithStatus(201, &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Created").WithJSONSchema(event),
+	}))
+
+	tests := []struct {
+		name     string
+		render   func(*strings.Builder) error
+		expected []string
+	}{
+		{"parameters", func(b *strings.Builder) error { return RenderParameters(b, params) }, []string{"### Parameters", "**limit** (query)"}},
+		{"request body", func(b *strings.Builder) error { return RenderRequestBody(b, body) }, []string{"### Request Body", "(required)", "**name**"}},
+		{"responses", func(b *strings.Builder) error { return RenderResponses(b, responses) }, []string{"### Responses", "#### 201 Created", "**name**"}},
+		{"schema", func(b *strings.Builder) error { return RenderSchemaRef(b, openapi3.NewSchemaRef("", event)) }, []string{"**Schema:**", "**name**"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := tt.render(&b); err != nil {
+				t.Fatalf("render error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(b.String(), expected) {
+					t.Errorf("Expected %q in output:\n%s", expected, b.String())
+				}
+			}
+		})
+	}
+}
+
+// httpieSnippet is a snippet language outside the built-in ones.
+type httpieSnippet struct{}
+
+func (httpieSnippet) Name() string  { return "httpie" }
+func (httpieSnippet) Label() string { return "HTTPie" }
+func (httpieSnippet) Fence() string { return "bash" }
+func (httpieSnippet) Render(req SnippetRequest) string {
+	return "http " + req.Method + " " + req.URL
+}
+
+func TestWithSnippets(t *testing.T) {
+	doc, err := NewSpecBuilder().
+		Info("Events API", "1.0.0").
+		Server("https://api.example.com", "").
+		Path("/events").
+		Get(&openapi3.Operation{Summary: "List events"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	languages, err := ParseSnippets("curl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	markdown, err := Render(doc, "/events", WithSnippets(append(languages, httpieSnippet{})...))
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	for _, expected := range []string{"curl", "http GET https://api.example.com/events"} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, markdown)
+		}
+	}
+}
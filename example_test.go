@@ -0,0 +1,73 @@
+package docfinder_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/arthur-s/docfinder"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func ExampleSpecBuilder_Render() {
+	listEvents := &openapi3.Operation{
+		Summary:    "List events",
+		Parameters: openapi3.Parameters{{Value: openapi3.NewQueryParameter("limit").WithSchema(openapi3.NewIntegerSchema())}},
+		Responses:  openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("OK")})),
+	}
+
+	markdown, err := docfinder.NewSpecBuilder().
+		Info("Events API", "1.0.0").
+		Path("/events").
+		Get(listEvents).
+		Post(&openapi3.Operation{Summary: "Create an event"}).
+		Render("/events", docfinder.WithMethod("GET"), docfinder.WithSections(docfinder.SectionParameters))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(markdown)
+	// Output:
+	// # API Endpoint: /events
+	//
+	// **API:** Events API 1.0.0
+	//
+	// ## GET /events
+	//
+	// ### Parameters
+	//
+	// - **limit** (query)
+	//   - Type: `integer`
+	//
+	// ---
+}
+
+func ExampleRenderResponses() {
+	event := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewStringSchema()).
+		WithProperty("parent", openapi3.NewObjectSchema().WithProperty("id", openapi3.NewStringSchema()))
+	responses := openapi3.NewResponses(openapi3.WithStatus(201, &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Created").WithJSONSchema(event),
+	}))
+
+	if err := docfinder.RenderResponses(os.Stdout, responses, docfinder.WithMaxDepth(1)); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// ### Responses
+	//
+	// #### 201 Created `success`
+	//
+	// Created
+	//
+	// **Content-Type:** `application/json`
+	//
+	// **Schema:**
+	//
+	// - Type: `object`
+	// - Properties:
+	//   - **id**
+	//     - Type: `string`
+	//   - **parent**
+	//     - Type: `object`
+	//     - *(max depth reached)*
+}
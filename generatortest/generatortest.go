@@ -0,0 +1,119 @@
+// Package generatortest provides golden-file snapshot assertions for
+// generated documentation, so changes in rendering show up as test diffs.
+//
+// Snapshots are compared against files on disk, conventionally under
+// testdata/. Run the tests with DOCFINDER_UPDATE_GOLDEN=1 to write the
+// current output as the new snapshot:
+//
+//	DOCFINDER_UPDATE_GOLDEN=1 go test ./... -run TestDocs
+package generatortest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// UpdateEnv is the environment variable that, set to a non-empty value,
+// makes AssertSnapshot rewrite snapshots instead of comparing against them.
+// It is an environment variable rather than a flag so that importing the
+// package doesn't clash with an -update flag of the test.
+const UpdateEnv = "DOCFINDER_UPDATE_GOLDEN"
+
+// Operation identifies the operation rendered for a snapshot.
+type Operation struct {
+	Doc *openapi3.T
+	// Path is the endpoint path, e.g. "/events/{id}".
+	Path string
+	// Method restricts output to one method. Empty renders all of them.
+	Method string
+	// Options configure rendering.
+	Options []docfinder.Option
+}
+
+// Render generates the markdown for op.
+func Render(op Operation) (string, error) {
+	if op.Doc == nil {
+		return "", fmt.Errorf("operation has no document")
+	}
+	opts := append(append([]docfinder.Option{}, op.Options...), docfinder.WithMethod(op.Method))
+	return docfinder.Render(op.Doc, op.Path, opts...)
+}
+
+// AssertMarkdownSnapshot renders op and compares the markdown with the
+// golden file, failing t with the first difference if they don't match.
+func AssertMarkdownSnapshot(t testing.TB, op Operation, golden string) {
+	t.Helper()
+
+	markdown, err := Render(op)
+	if err != nil {
+		t.Fatalf("failed to render %s %s: %v", op.Method, op.Path, err)
+	}
+	AssertSnapshot(t, markdown, golden)
+}
+
+// AssertSnapshot compares got with the golden file, or writes got to it when
+// UpdateEnv is set.
+func AssertSnapshot(t testing.TB, got, golden string) {
+	t.Helper()
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatalf("failed to create snapshot directory: %v", err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to update snapshot: %v", err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read snapshot (set %s=1 to create it): %v", UpdateEnv, err)
+	}
+
+	want := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if got != want {
+		t.Errorf("output does not match snapshot %s (set %s=1 to accept it):\n%s", golden, UpdateEnv, Diff(want, got))
+	}
+}
+
+// diffContext is the number of matching lines shown before a difference.
+const diffContext = 3
+
+// Diff describes the first difference between want and got, line by line,
+// with a few lines of preceding context.
+func Diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	i := 0
+	for i < len(wantLines) && i < len(gotLines) && wantLines[i] == gotLines[i] {
+		i++
+	}
+	if i == len(wantLines) && i == len(gotLines) {
+		return ""
+	}
+
+	var b strings.Builder
+	for j := max(0, i-diffContext); j < i; j++ {
+		fmt.Fprintf(&b, "  %4d   %s\n", j+1, wantLines[j])
+	}
+	if i < len(wantLines) {
+		fmt.Fprintf(&b, "  %4d - %s\n", i+1, wantLines[i])
+	} else {
+		fmt.Fprintf(&b, "  %4d - (end of snapshot)\n", i+1)
+	}
+	if i < len(gotLines) {
+		fmt.Fprintf(&b, "  %4d + %s\n", i+1, gotLines[i])
+	} else {
+		fmt.Fprintf(&b, "  %4d + (end of output)\n", i+1)
+	}
+	fmt.Fprintf(&b, "  (snapshot has %d lines, output has %d)", len(wantLines), len(gotLines))
+	return b.String()
+}
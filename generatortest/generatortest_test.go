@@ -0,0 +1,100 @@
+package generatortest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// recorder captures failures instead of failing the enclosing test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Helper() {}
+
+func TestAssertSnapshot(t *testing.T) {
+	t.Setenv(UpdateEnv, "")
+	golden := filepath.Join(t.TempDir(), "items.golden.md")
+	if err := os.WriteFile(golden, []byte("# Items\r\n\r\nList items\r\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		r := &recorder{TB: t}
+		AssertSnapshot(r, "# Items\n\nList items\n", golden)
+		if len(r.errors) != 0 {
+			t.Errorf("Did not expect failures, got %v", r.errors)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		r := &recorder{TB: t}
+		AssertSnapshot(r, "# Items\n\nList all items\n", golden)
+		if len(r.errors) != 1 || !strings.Contains(r.errors[0], "     3 - List items\n     3 + List all items") {
+			t.Errorf("Expected a diff of line 3, got %v", r.errors)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		t.Setenv(UpdateEnv, "1")
+
+		created := filepath.Join(t.TempDir(), "new", "items.golden.md")
+		AssertSnapshot(t, "# Items\n", created)
+		if data, err := os.ReadFile(created); err != nil || string(data) != "# Items\n" {
+			t.Errorf("Expected snapshot to be written, got %q, %v", data, err)
+		}
+	})
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		expected  string
+	}{
+		{"equal", "a\nb", "a\nb", ""},
+		{"changed line", "a\nb\nc", "a\nx\nc", "     1   a\n     2 - b\n     2 + x\n"},
+		{"missing lines", "a\nb", "a", "     1   a\n     2 - b\n     2 + (end of output)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := Diff(tt.want, tt.got)
+			if !strings.HasPrefix(diff, tt.expected) || (tt.expected == "") != (diff == "") {
+				t.Errorf("Diff() = %q, want prefix %q", diff, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	doc := &openapi3.T{
+		Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"},
+		Paths: openapi3.NewPaths(openapi3.WithPath("/items", &openapi3.PathItem{
+			Get:    &openapi3.Operation{Summary: "List items"},
+			Delete: &openapi3.Operation{Summary: "Delete items"},
+		})),
+	}
+
+	markdown, err := Render(Operation{Doc: doc, Path: "/items", Method: "get"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(markdown, "List items") || strings.Contains(markdown, "Delete items") {
+		t.Errorf("Expected only the GET operation, got:\n%s", markdown)
+	}
+
+	if _, err := Render(Operation{Doc: doc, Path: "/missing"}); err == nil {
+		t.Error("Expected error for unknown path")
+	}
+}
@@ -0,0 +1,160 @@
+// Package alias resolves renamed operations and paths so lookups by an old
+// operationId or path keep working after spec refactors.
+package alias
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/fuzzy"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Extensions recording former names.
+const (
+	// ExtensionPreviousOperationIDs lists former operationIds of an operation.
+	ExtensionPreviousOperationIDs = "x-previous-operation-ids"
+	// ExtensionPreviousPaths lists former path templates of a path item.
+	ExtensionPreviousPaths = "x-previous-paths"
+)
+
+// Config maps former names to current ones, for renames not recorded in the
+// spec itself.
+type Config struct {
+	OperationIDs map[string]string `yaml:"operationIds"`
+	Paths        map[string]string `yaml:"paths"`
+}
+
+// Match is an operation found by operationId.
+type Match struct {
+	Path        string
+	Method      string
+	OperationID string
+	// Renamed reports whether the lookup went through an alias.
+	Renamed bool
+}
+
+// Operation finds the operation with the given operationId. If none matches
+// directly, the config mapping and then x-previous-operation-ids are consulted.
+func Operation(doc *openapi3.T, id string, cfg Config) (Match, error) {
+	if m, ok := findOperation(doc, func(op *openapi3.Operation) bool { return op.OperationID == id }); ok {
+		return m, nil
+	}
+
+	if current, ok := cfg.OperationIDs[id]; ok {
+		if m, ok := findOperation(doc, func(op *openapi3.Operation) bool { return op.OperationID == current }); ok {
+			m.Renamed = true
+			return m, nil
+		}
+	}
+
+	if m, ok := findOperation(doc, func(op *openapi3.Operation) bool {
+		return slices.Contains(extensionStrings(op.Extensions, ExtensionPreviousOperationIDs), id)
+	}); ok {
+		m.Renamed = true
+		return m, nil
+	}
+
+	return Match{}, fmt.Errorf("operation not found: %s%s", id, fuzzy.DidYouMean(fuzzy.RankNames(operationIDs(doc), id, fuzzy.MaxSuggestions)))
+}
+
+// operationIDs returns the operationIds of doc.
+func operationIDs(doc *openapi3.T) []string {
+	var ids []string
+	findOperation(doc, func(op *openapi3.Operation) bool {
+		if op.OperationID != "" {
+			ids = append(ids, op.OperationID)
+		}
+		return false
+	})
+	return ids
+}
+
+// Path returns the current path template for a former path, consulting the
+// config mapping and then x-previous-paths. Path parameter names are ignored
+// when comparing templates, so "/users/{id}" matches "/users/{user_id}".
+func Path(doc *openapi3.T, path string, cfg Config) (string, bool) {
+	if doc == nil || doc.Paths == nil {
+		return "", false
+	}
+
+	if current, ok := cfg.Paths[path]; ok && doc.Paths.Value(current) != nil {
+		return current, true
+	}
+
+	want := normalizeTemplate(path)
+	for _, current := range sortedPaths(doc) {
+		for _, previous := range extensionStrings(doc.Paths.Value(current).Extensions, ExtensionPreviousPaths) {
+			if normalizeTemplate(previous) == want {
+				return current, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// findOperation returns the first operation satisfying match, searching paths
+// in sorted order so results are deterministic.
+func findOperation(doc *openapi3.T, match func(*openapi3.Operation) bool) (Match, bool) {
+	if doc == nil || doc.Paths == nil {
+		return Match{}, false
+	}
+
+	for _, path := range sortedPaths(doc) {
+		pathItem := doc.Paths.Value(path)
+		for _, method := range model.MethodOrder {
+			op := pathItem.GetOperation(method)
+			if op != nil && match(op) {
+				return Match{Path: path, Method: method, OperationID: op.OperationID}, true
+			}
+		}
+	}
+
+	return Match{}, false
+}
+
+func sortedPaths(doc *openapi3.T) []string {
+	var paths []string
+	for path, pathItem := range doc.Paths.Map() {
+		if pathItem != nil {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// normalizeTemplate replaces path parameter names with "{}" and ensures a
+// leading slash.
+func normalizeTemplate(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return pathParam.ReplaceAllString(path, "{}")
+}
+
+// extensionStrings reads an extension holding a string or a list of strings.
+func extensionStrings(extensions map[string]any, name string) []string {
+	switch v := extensions[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case []string:
+		return v
+	}
+	return nil
+}
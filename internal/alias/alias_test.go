@@ -0,0 +1,86 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func aliasDoc() *openapi3.T {
+	users := &openapi3.PathItem{
+		Extensions: map[string]any{ExtensionPreviousPaths: []any{"/accounts/{id}", "/members/{id}"}},
+		Get: &openapi3.Operation{
+			OperationID: "getUser",
+			Extensions:  map[string]any{ExtensionPreviousOperationIDs: []any{"getAccount", "fetchAccount"}},
+		},
+		Delete: &openapi3.Operation{
+			OperationID: "deleteUser",
+			Extensions:  map[string]any{ExtensionPreviousOperationIDs: "removeAccount"},
+		},
+	}
+	return &openapi3.T{Paths: openapi3.NewPaths(
+		openapi3.WithPath("/users/{user_id}", users),
+		openapi3.WithPath("/health", &openapi3.PathItem{Get: &openapi3.Operation{OperationID: "health"}}),
+	)}
+}
+
+func TestOperation(t *testing.T) {
+	cfg := Config{OperationIDs: map[string]string{"ping": "health"}}
+
+	tests := []struct {
+		id      string
+		path    string
+		method  string
+		renamed bool
+	}{
+		{"getUser", "/users/{user_id}", "GET", false},
+		{"fetchAccount", "/users/{user_id}", "GET", true},
+		{"removeAccount", "/users/{user_id}", "DELETE", true},
+		{"ping", "/health", "GET", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			m, err := Operation(aliasDoc(), tt.id, cfg)
+			if err != nil {
+				t.Fatalf("Operation() error: %v", err)
+			}
+			if m.Path != tt.path || m.Method != tt.method || m.Renamed != tt.renamed {
+				t.Errorf("Operation(%q) = %+v, want %s %s renamed=%v", tt.id, m, tt.method, tt.path, tt.renamed)
+			}
+		})
+	}
+
+	if _, err := Operation(aliasDoc(), "unknown", cfg); err == nil {
+		t.Error("Expected error for unknown operationId")
+	}
+
+	_, err := Operation(aliasDoc(), "getUserById", cfg)
+	if want := "operation not found: getUserById (did you mean getUser?)"; err == nil || err.Error() != want {
+		t.Errorf("Operation() error = %v, want %q", err, want)
+	}
+}
+
+func TestPath(t *testing.T) {
+	cfg := Config{Paths: map[string]string{"/status": "/health"}}
+
+	tests := []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		{"/accounts/{id}", "/users/{user_id}", true},
+		{"members/{member_id}", "/users/{user_id}", true},
+		{"/status", "/health", true},
+		{"/unknown", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, ok := Path(aliasDoc(), tt.path, cfg)
+			if result != tt.expected || ok != tt.ok {
+				t.Errorf("Path(%q) = %q, %v, want %q, %v", tt.path, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+// Package audit scans OpenAPI documents for risky patterns such as leaked
+// credentials, insecure servers, and weak security schemes.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Severity ranks how serious a finding is.
+type Severity int
+
+// Severity levels, lowest first.
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+)
+
+var severityNames = map[Severity]string{
+	SeverityLow:    "low",
+	SeverityMedium: "medium",
+	SeverityHigh:   "high",
+}
+
+// String returns the lowercase severity name.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// MarshalJSON encodes the severity as its name.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(s string) (Severity, error) {
+	for severity, name := range severityNames {
+		if strings.EqualFold(strings.TrimSpace(s), name) {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity: %s (expected low, medium, or high)", s)
+}
+
+// Rule identifiers reported in findings.
+const (
+	RuleServerCredentials = "server-credentials"
+	RuleInsecureServer    = "insecure-server"
+	RuleAPIKeyInQuery     = "apikey-in-query"
+	RuleBroadOAuthScope   = "broad-oauth-scope"
+	RuleExampleSecret     = "example-secret"
+)
+
+// Finding is a single risky pattern found in the document.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Location string   `json:"location"`
+	Message  string   `json:"message"`
+}
+
+// String formats the finding as a single line.
+func (f Finding) String() string {
+	return fmt.Sprintf("[%s] %s: %s (%s)", f.Severity, f.Location, f.Message, f.Rule)
+}
+
+// secretPatterns match values that look like real credentials.
+var secretPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Stripe live key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{16,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+}
+
+// broadScopes are OAuth scope names granting unrestricted access.
+var broadScopes = map[string]bool{
+	"*":           true,
+	"all":         true,
+	"admin":       true,
+	"root":        true,
+	"full_access": true,
+	"full-access": true,
+}
+
+// Run scans doc and returns its findings, most severe first.
+func Run(doc *openapi3.T) []Finding {
+	a := &auditor{seen: make(map[*openapi3.Schema]bool)}
+	if doc == nil {
+		return a.findings
+	}
+
+	a.checkServers("servers", doc.Servers)
+	a.checkComponents(doc.Components)
+
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.InMatchingOrder() {
+			pathItem := doc.Paths.Value(path)
+			if pathItem == nil {
+				continue
+			}
+			a.checkServers(path+" servers", pathItem.Servers)
+			a.checkParameters(path, pathItem.Parameters)
+
+			for method, op := range pathItem.Operations() {
+				if op != nil {
+					a.checkOperation(method+" "+path, op)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(a.findings, func(i, j int) bool {
+		fi, fj := a.findings[i], a.findings[j]
+		if fi.Severity != fj.Severity {
+			return fi.Severity > fj.Severity
+		}
+		if fi.Location != fj.Location {
+			return fi.Location < fj.Location
+		}
+		return fi.Rule < fj.Rule
+	})
+
+	return a.findings
+}
+
+// AtLeast returns the findings with severity at or above min.
+func AtLeast(findings []Finding, min Severity) []Finding {
+	var filtered []Finding
+	for _, f := range findings {
+		if f.Severity >= min {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// auditor accumulates findings while walking a document.
+type auditor struct {
+	findings []Finding
+	seen     map[*openapi3.Schema]bool
+}
+
+func (a *auditor) report(severity Severity, rule, location, format string, args ...any) {
+	a.findings = append(a.findings, Finding{
+		Severity: severity,
+		Rule:     rule,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// checkServers flags credentials embedded in server URLs and plain HTTP servers.
+func (a *auditor) checkServers(location string, servers openapi3.Servers) {
+	for i, server := range servers {
+		if server == nil {
+			continue
+		}
+		loc := fmt.Sprintf("%s[%d]", location, i)
+
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			continue
+		}
+
+		if u.User != nil {
+			a.report(SeverityHigh, RuleServerCredentials, loc, "server URL embeds credentials for user %q", u.User.Username())
+		}
+
+		if strings.EqualFold(u.Scheme, "http") && !isLocalHost(u.Hostname()) {
+			a.report(SeverityMedium, RuleInsecureServer, loc, "server %s does not use TLS", redact(u))
+		}
+	}
+}
+
+// checkComponents scans security schemes and reusable components.
+func (a *auditor) checkComponents(components *openapi3.Components) {
+	if components == nil {
+		return
+	}
+
+	for _, name := range sortedKeys(components.SecuritySchemes) {
+		ref := components.SecuritySchemes[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		a.checkSecurityScheme("components.securitySchemes."+name, ref.Value)
+	}
+
+	for _, name := range sortedKeys(components.Schemas) {
+		a.checkSchema("components.schemas."+name, components.Schemas[name])
+	}
+
+	for _, name := range sortedKeys(components.Examples) {
+		a.checkExample("components.examples."+name, components.Examples[name])
+	}
+
+	for _, name := range sortedKeys(components.Parameters) {
+		if ref := components.Parameters[name]; ref != nil && ref.Value != nil {
+			a.checkParameter("components.parameters."+name, ref.Value)
+		}
+	}
+}
+
+// checkSecurityScheme flags API keys sent in query strings and overly broad OAuth scopes.
+func (a *auditor) checkSecurityScheme(location string, scheme *openapi3.SecurityScheme) {
+	if scheme.Type == "apiKey" && scheme.In == "query" {
+		a.report(SeverityMedium, RuleAPIKeyInQuery, location,
+			"API key %q is sent in the query string, where it is logged by proxies and servers", scheme.Name)
+	}
+
+	if scheme.Type != "oauth2" || scheme.Flows == nil {
+		return
+	}
+
+	flows := map[string]*openapi3.OAuthFlow{
+		"implicit":          scheme.Flows.Implicit,
+		"password":          scheme.Flows.Password,
+		"clientCredentials": scheme.Flows.ClientCredentials,
+		"authorizationCode": scheme.Flows.AuthorizationCode,
+	}
+	for _, flowName := range sortedKeys(flows) {
+		flow := flows[flowName]
+		if flow == nil {
+			continue
+		}
+		for _, scope := range sortedKeys(flow.Scopes) {
+			if isBroadScope(scope) {
+				a.report(SeverityLow, RuleBroadOAuthScope, location+".flows."+flowName,
+					"scope %q grants unrestricted access; prefer narrowly scoped permissions", scope)
+			}
+		}
+	}
+}
+
+// checkOperation scans an operation's servers, parameters, and payload examples.
+func (a *auditor) checkOperation(location string, op *openapi3.Operation) {
+	if op.Servers != nil {
+		a.checkServers(location+" servers", *op.Servers)
+	}
+
+	a.checkParameters(location, op.Parameters)
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		a.checkContent(location+" requestBody", op.RequestBody.Value.Content)
+	}
+
+	if op.Responses != nil {
+		for _, status := range sortedKeys(op.Responses.Map()) {
+			ref := op.Responses.Value(status)
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			a.checkContent(location+" responses."+status, ref.Value.Content)
+		}
+	}
+}
+
+func (a *auditor) checkParameters(location string, params openapi3.Parameters) {
+	for _, ref := range params {
+		if ref != nil && ref.Value != nil {
+			a.checkParameter(location+" parameters."+ref.Value.Name, ref.Value)
+		}
+	}
+}
+
+func (a *auditor) checkParameter(location string, param *openapi3.Parameter) {
+	a.checkValue(location+".example", param.Example)
+	for _, name := range sortedKeys(param.Examples) {
+		a.checkExample(location+".examples."+name, param.Examples[name])
+	}
+	a.checkSchema(location+".schema", param.Schema)
+	a.checkContent(location, param.Content)
+}
+
+func (a *auditor) checkContent(location string, content openapi3.Content) {
+	for _, contentType := range sortedKeys(content) {
+		mediaType := content[contentType]
+		if mediaType == nil {
+			continue
+		}
+		loc := location + " " + contentType
+		a.checkValue(loc+".example", mediaType.Example)
+		for _, name := range sortedKeys(mediaType.Examples) {
+			a.checkExample(loc+".examples."+name, mediaType.Examples[name])
+		}
+		a.checkSchema(loc+".schema", mediaType.Schema)
+	}
+}
+
+func (a *auditor) checkExample(location string, ref *openapi3.ExampleRef) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	a.checkValue(location, ref.Value.Value)
+}
+
+// checkSchema scans schema examples and defaults, visiting each schema once.
+func (a *auditor) checkSchema(location string, ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil || a.seen[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	a.seen[schema] = true
+
+	a.checkValue(location+".example", schema.Example)
+	a.checkValue(location+".default", schema.Default)
+
+	for _, name := range sortedKeys(schema.Properties) {
+		a.checkSchema(location+".properties."+name, schema.Properties[name])
+	}
+	a.checkSchema(location+".items", schema.Items)
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i, sub := range group {
+			a.checkSchema(fmt.Sprintf("%s[%d]", location, i), sub)
+		}
+	}
+}
+
+// checkValue reports strings anywhere inside value that look like real secrets.
+func (a *auditor) checkValue(location string, value any) {
+	switch v := value.(type) {
+	case string:
+		for _, p := range secretPatterns {
+			if p.pattern.MatchString(v) {
+				a.report(SeverityHigh, RuleExampleSecret, location, "example contains what looks like a real %s", p.name)
+				return
+			}
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			a.checkValue(location+"."+key, v[key])
+		}
+	case []any:
+		for i, item := range v {
+			a.checkValue(fmt.Sprintf("%s[%d]", location, i), item)
+		}
+	}
+}
+
+// isLocalHost reports whether host refers to the local machine, where plain HTTP is acceptable.
+func isLocalHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isBroadScope reports whether an OAuth scope name grants unrestricted access.
+func isBroadScope(scope string) bool {
+	lower := strings.ToLower(scope)
+	return broadScopes[lower] || strings.HasSuffix(lower, ":*") || strings.HasSuffix(lower, ".*") ||
+		strings.HasSuffix(lower, ":admin") || strings.HasSuffix(lower, ".admin")
+}
+
+// redact returns the URL without user information.
+func redact(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	return clean.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
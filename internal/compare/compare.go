@@ -0,0 +1,322 @@
+// Package compare renders two operations side by side: two of the same
+// endpoint, e.g. GET and PUT, to show which fields can be written and which
+// are read-only, or a deprecated operation and its replacement, as a
+// migration guide.
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// side is one of the two operations being compared, with path-level
+// parameters merged into its parameters.
+type side struct {
+	method     string
+	operation  *openapi3.Operation
+	parameters map[string]*openapi3.Parameter
+}
+
+// field is a flattened schema property with its dotted path.
+type field struct {
+	schema   *openapi3.Schema
+	required bool
+}
+
+// fieldUse records how one operation uses a field.
+type fieldUse struct {
+	request, response *field
+}
+
+// Render compares two operations of the endpoint at path as markdown tables
+// of parameters and body fields. maxDepth limits how deeply nested properties
+// are flattened.
+func Render(path string, pathItem *openapi3.PathItem, methodA, methodB string, maxDepth int) (string, error) {
+	a, err := newSide(pathItem, methodA)
+	if err != nil {
+		return "", err
+	}
+	b, err := newSide(pathItem, methodB)
+	if err != nil {
+		return "", err
+	}
+
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# %s vs %s %s\n\n", a.method, b.method, path)
+
+	writeParameters(&md, a, b)
+	writeFields(&md, a, b, maxDepth)
+
+	return md.String(), nil
+}
+
+// newSide looks up method in pathItem.
+func newSide(pathItem *openapi3.PathItem, method string) (side, error) {
+	method = strings.ToUpper(method)
+	op := pathItem.GetOperation(method)
+	if op == nil {
+		return side{}, fmt.Errorf("method '%s' not found for this endpoint", method)
+	}
+
+	params := make(map[string]*openapi3.Parameter)
+	for _, list := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+		for _, ref := range list {
+			if ref != nil && ref.Value != nil {
+				params[ref.Value.In+":"+ref.Value.Name] = ref.Value
+			}
+		}
+	}
+
+	return side{method: method, operation: op, parameters: params}, nil
+}
+
+// writeParameters renders a table of parameters keyed by location and name.
+func writeParameters(md *strings.Builder, a, b side) {
+	paramsA := a.parameters
+	paramsB := b.parameters
+	keys := unionKeys(paramsA, paramsB)
+	if len(keys) == 0 {
+		return
+	}
+
+	md.WriteString("## Parameters\n\n")
+	fmt.Fprintf(md, "| Parameter | %s | %s |\n", a.method, b.method)
+	md.WriteString("|---|---|---|\n")
+
+	for _, key := range keys {
+		param := paramsA[key]
+		if param == nil {
+			param = paramsB[key]
+		}
+		fmt.Fprintf(md, "| `%s` (%s) | %s | %s |\n", param.Name, param.In, describeParameter(paramsA[key]), describeParameter(paramsB[key]))
+	}
+
+	md.WriteString("\n")
+}
+
+// writeFields renders a table of request and response body fields with
+// their access mode.
+func writeFields(md *strings.Builder, a, b side, maxDepth int) {
+	usesA := bodyFields(a.operation, maxDepth)
+	usesB := bodyFields(b.operation, maxDepth)
+	names := unionKeys(usesA, usesB)
+	if len(names) == 0 {
+		return
+	}
+
+	md.WriteString("## Body Fields\n\n")
+	fmt.Fprintf(md, "| Field | %s | %s | Access |\n", a.method, b.method)
+	md.WriteString("|---|---|---|---|\n")
+
+	for _, name := range names {
+		fmt.Fprintf(md, "| `%s` | %s | %s | %s |\n", name, describeUse(usesA[name]), describeUse(usesB[name]), access(usesA[name], usesB[name]))
+	}
+
+	md.WriteString("\n")
+	md.WriteString("*Access: **writable** fields can be sent in a request body; **read-only** fields are only returned.*\n")
+}
+
+// describeParameter summarizes a parameter for a table cell.
+func describeParameter(param *openapi3.Parameter) string {
+	if param == nil {
+		return "—"
+	}
+
+	parts := []string{"`" + generator.FormatParameterType(param) + "`"}
+	if param.Required {
+		parts = append(parts, "required")
+	}
+	if param.Deprecated {
+		parts = append(parts, "deprecated")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeUse summarizes how an operation uses a body field.
+func describeUse(use *fieldUse) string {
+	if use == nil {
+		return "—"
+	}
+
+	var parts []string
+	if use.request != nil {
+		part := "request `" + generator.FormatType(use.request.schema) + "`"
+		if use.request.required {
+			part += " (required)"
+		}
+		parts = append(parts, part)
+	}
+	if use.response != nil {
+		parts = append(parts, "response `"+generator.FormatType(use.response.schema)+"`")
+	}
+	return strings.Join(parts, "<br>")
+}
+
+// access classifies a field across both operations.
+func access(uses ...*fieldUse) string {
+	var inRequest, readOnly, writeOnly bool
+	for _, use := range uses {
+		if use == nil {
+			continue
+		}
+		for _, f := range []*field{use.request, use.response} {
+			if f == nil {
+				continue
+			}
+			readOnly = readOnly || f.schema.ReadOnly
+			writeOnly = writeOnly || f.schema.WriteOnly
+		}
+		if use.request != nil {
+			inRequest = true
+		}
+	}
+
+	switch {
+	case readOnly:
+		return "read-only"
+	case writeOnly:
+		return "**write-only**"
+	case inRequest:
+		return "**writable**"
+	default:
+		return "read-only"
+	}
+}
+
+// bodyFields flattens the request body and success response schemas of op.
+func bodyFields(op *openapi3.Operation, maxDepth int) map[string]*fieldUse {
+	uses := make(map[string]*fieldUse)
+	use := func(name string) *fieldUse {
+		if uses[name] == nil {
+			uses[name] = &fieldUse{}
+		}
+		return uses[name]
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if schema := bodySchema(op.RequestBody.Value.Content); schema != nil {
+			for name, f := range flatten(schema, maxDepth) {
+				use(name).request = f
+			}
+		}
+	}
+
+	if _, resp := successResponse(op.Responses); resp != nil {
+		if schema := bodySchema(resp.Content); schema != nil {
+			for name, f := range flatten(schema, maxDepth) {
+				use(name).response = f
+			}
+		}
+	}
+
+	return uses
+}
+
+// bodySchema returns the schema of the first JSON media type, or of the
+// first media type if none is JSON.
+func bodySchema(content openapi3.Content) *openapi3.Schema {
+	contentTypes := make([]string, 0, len(content))
+	for ct := range content {
+		contentTypes = append(contentTypes, ct)
+	}
+	sort.Strings(contentTypes)
+
+	sort.SliceStable(contentTypes, func(i, j int) bool {
+		return strings.Contains(contentTypes[i], "json") && !strings.Contains(contentTypes[j], "json")
+	})
+
+	for _, ct := range contentTypes {
+		if mt := content[ct]; mt != nil && mt.Schema != nil && mt.Schema.Value != nil {
+			return mt.Schema.Value
+		}
+	}
+	return nil
+}
+
+// successResponse returns the lowest 2xx response of op with its status
+// code.
+func successResponse(responses *openapi3.Responses) (string, *openapi3.Response) {
+	if responses == nil {
+		return "", nil
+	}
+
+	var codes []string
+	for code := range responses.Map() {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if ref := responses.Value(code); ref != nil && ref.Value != nil {
+			return code, ref.Value
+		}
+	}
+	return "", nil
+}
+
+// flatten returns the properties of schema keyed by dotted path, merging
+// allOf members. Array items are addressed as "name[]".
+func flatten(schema *openapi3.Schema, maxDepth int) map[string]*field {
+	fields := make(map[string]*field)
+	flattenInto(fields, "", schema, maxDepth, make(map[*openapi3.Schema]bool))
+	return fields
+}
+
+func flattenInto(fields map[string]*field, prefix string, schema *openapi3.Schema, depth int, visiting map[*openapi3.Schema]bool) {
+	if schema == nil || depth <= 0 || visiting[schema] {
+		return
+	}
+	visiting[schema] = true
+	defer delete(visiting, schema)
+
+	for _, member := range schema.AllOf {
+		if member != nil {
+			flattenInto(fields, prefix, member.Value, depth, visiting)
+		}
+	}
+
+	if schema.Type.Is("array") && schema.Items != nil {
+		flattenInto(fields, prefix+"[]", schema.Items.Value, depth-1, visiting)
+		return
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	for name, ref := range schema.Properties {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		fields[path] = &field{schema: ref.Value, required: required[name]}
+		flattenInto(fields, path, ref.Value, depth-1, visiting)
+	}
+}
+
+// unionKeys returns the sorted union of keys of two maps.
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func comparePathItem() *openapi3.PathItem {
+	event := openapi3.NewObjectSchema().
+		WithProperty("id", &openapi3.Schema{Type: &openapi3.Types{"string"}, ReadOnly: true}).
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("created_at", openapi3.NewDateTimeSchema()).
+		WithProperty("tags", openapi3.NewArraySchema().WithItems(
+			openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())))
+
+	update := openapi3.NewObjectSchema().
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("tags", openapi3.NewArraySchema().WithItems(
+			openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())))
+	update.Required = []string{"title"}
+
+	ok := func(schema *openapi3.Schema) *openapi3.Responses {
+		return openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+			WithDescription("OK").WithContent(openapi3.NewContentWithJSONSchema(schema))}))
+	}
+
+	return &openapi3.PathItem{
+		Parameters: openapi3.Parameters{
+			{Value: openapi3.NewPathParameter("id").WithSchema(openapi3.NewStringSchema())},
+		},
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewQueryParameter("expand").WithSchema(openapi3.NewStringSchema())},
+			},
+			Responses: ok(event),
+		},
+		Put: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(update)},
+			Responses:   ok(event),
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	markdown, err := Render("/events/{id}", comparePathItem(), "get", "PUT", 10)
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# GET vs PUT /events/{id}",
+		"| `id` (path) | `string`, required | `string`, required |",
+		"| `expand` (query) | `string` | — |",
+		"| `created_at` | response `string` | response `string` | read-only |",
+		"| `id` | response `string` | response `string` | read-only |",
+		"| `title` | response `string` | request `string` (required)<br>response `string` | **writable** |",
+		"| `tags[].name` | response `string` | request `string`<br>response `string` | **writable** |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestRender_MissingMethod(t *testing.T) {
+	if _, err := Render("/events/{id}", comparePathItem(), "GET", "DELETE", 10); err == nil {
+		t.Error("Expected error for missing method")
+	}
+}
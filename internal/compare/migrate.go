@@ -0,0 +1,370 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/alias"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Endpoint is an operation of a spec, addressed by method and path template.
+type Endpoint struct {
+	Method   string
+	Path     string
+	PathItem *openapi3.PathItem
+}
+
+// String returns e.g. "GET /v1/events".
+func (e Endpoint) String() string {
+	return e.Method + " " + e.Path
+}
+
+// ReplacedBy returns the operation named by the x-replaced-by extension of
+// old.
+func ReplacedBy(doc *openapi3.T, old Endpoint) (Endpoint, error) {
+	op := old.PathItem.GetOperation(strings.ToUpper(old.Method))
+	if op == nil {
+		return Endpoint{}, fmt.Errorf("method '%s' not found for this endpoint", old.Method)
+	}
+	target, ok := op.Extensions[generator.ExtensionReplacedBy].(string)
+	if !ok || strings.TrimSpace(target) == "" {
+		return Endpoint{}, fmt.Errorf("%s has no %s extension naming its replacement", old, generator.ExtensionReplacedBy)
+	}
+	replacement, err := FindEndpoint(doc, target, old.Method)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("%s of %s: %w", generator.ExtensionReplacedBy, old, err)
+	}
+	return replacement, nil
+}
+
+// FindEndpoint looks up an operation given as "METHOD /path", as "/path"
+// with the given method, or as an operationId.
+func FindEndpoint(doc *openapi3.T, target, method string) (Endpoint, error) {
+	target = strings.TrimSpace(target)
+	path := target
+	if fields := strings.Fields(target); len(fields) == 2 && strings.HasPrefix(fields[1], "/") {
+		method, path = fields[0], fields[1]
+	} else if !strings.HasPrefix(target, "/") {
+		match, err := alias.Operation(doc, target, alias.Config{})
+		if err != nil {
+			return Endpoint{}, err
+		}
+		method, path = match.Method, match.Path
+	}
+	method = strings.ToUpper(method)
+
+	if doc.Paths == nil {
+		return Endpoint{}, fmt.Errorf("endpoint not found: %s", path)
+	}
+	pathItem := doc.Paths.Value(path)
+	if pathItem == nil {
+		if pathItem = doc.Paths.Find(path); pathItem == nil {
+			return Endpoint{}, fmt.Errorf("endpoint not found: %s", path)
+		}
+	}
+	if pathItem.GetOperation(method) == nil {
+		return Endpoint{}, fmt.Errorf("method '%s' not found for %s", method, path)
+	}
+	return Endpoint{Method: method, Path: path, PathItem: pathItem}, nil
+}
+
+// element is a parameter or body field being matched across two operations.
+type element struct {
+	name     string
+	typ      string
+	required bool
+	// in is the location of a parameter.
+	in string
+	// replacedBy is the name of the replacement given with x-replaced-by; for
+	// a property, that of a sibling property.
+	replacedBy string
+}
+
+// mapping pairs an element of the old operation with its counterpart in the
+// new one. Either side is nil for removed and new elements.
+type mapping struct {
+	old, new *element
+	// guessed is set when the pair was matched by similar name only.
+	guessed bool
+}
+
+// Migration renders a guide for moving from the old operation to the new
+// one: its parameters and request and response body fields side by side,
+// with renamed, removed, new, and changed ones noted. maxDepth limits how
+// deeply nested properties are flattened.
+func Migration(old, new Endpoint, maxDepth int) (string, error) {
+	a, err := newSide(old.PathItem, old.Method)
+	if err != nil {
+		return "", err
+	}
+	b, err := newSide(new.PathItem, new.Method)
+	if err != nil {
+		return "", err
+	}
+	old.Method, new.Method = a.method, b.method
+
+	var md strings.Builder
+
+	fmt.Fprintf(&md, "# Migrating from %s to %s\n\n", old, new)
+	if a.operation.Deprecated {
+		fmt.Fprintf(&md, "`%s` is deprecated; use `%s` instead.\n\n", old, new)
+	}
+
+	params := matchElements(parameterElements(a.parameters), parameterElements(b.parameters))
+	var requestA, requestB, responseA, responseB map[string]*field
+	if body := a.operation.RequestBody; body != nil && body.Value != nil {
+		requestA = flatten(bodySchema(body.Value.Content), maxDepth)
+	}
+	if body := b.operation.RequestBody; body != nil && body.Value != nil {
+		requestB = flatten(bodySchema(body.Value.Content), maxDepth)
+	}
+	codeA, respA := successResponse(a.operation.Responses)
+	codeB, respB := successResponse(b.operation.Responses)
+	if respA != nil {
+		responseA = flatten(bodySchema(respA.Content), maxDepth)
+	}
+	if respB != nil {
+		responseB = flatten(bodySchema(respB.Content), maxDepth)
+	}
+	request := matchElements(fieldElements(requestA), fieldElements(requestB))
+	response := matchElements(fieldElements(responseA), fieldElements(responseB))
+
+	writeMigrationSummary(&md, params, request, response)
+
+	writeMappings(&md, "Parameters", old, new, params, true)
+	writeMappings(&md, "Request Body", old, new, request, true)
+	if codeA != "" && codeB != "" && codeA != codeB {
+		fmt.Fprintf(&md, "The success status changes from `%s` to `%s`.\n\n", codeA, codeB)
+	}
+	writeMappings(&md, "Response Body", old, new, response, false)
+
+	if hasGuesses(params, request, response) {
+		fmt.Fprintf(&md, "*Likely renames are matched by name and type only; confirm them, or record them with `%s` on the old parameter or property.*\n", generator.ExtensionReplacedBy)
+	}
+
+	return md.String(), nil
+}
+
+// hasGuesses reports whether any mapping was matched by similar name only.
+func hasGuesses(lists ...[]mapping) bool {
+	for _, list := range lists {
+		for _, m := range list {
+			if m.guessed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parameterElements indexes parameters by name.
+func parameterElements(params map[string]*openapi3.Parameter) map[string]*element {
+	elements := make(map[string]*element, len(params))
+	for _, param := range params {
+		replacedBy, _ := param.Extensions[generator.ExtensionReplacedBy].(string)
+		elements[param.Name] = &element{
+			name:       param.Name,
+			typ:        generator.FormatParameterType(param),
+			required:   param.Required,
+			in:         param.In,
+			replacedBy: replacedBy,
+		}
+	}
+	return elements
+}
+
+// fieldElements converts flattened body fields to elements.
+func fieldElements(fields map[string]*field) map[string]*element {
+	elements := make(map[string]*element, len(fields))
+	for path, f := range fields {
+		e := &element{name: path, typ: generator.FormatType(f.schema), required: f.required}
+		e.replacedBy, _ = f.schema.Extensions[generator.ExtensionReplacedBy].(string)
+		elements[path] = e
+	}
+	return elements
+}
+
+// matchElements pairs old elements with new ones, in name order so objects
+// come before their properties. An element pairs with the one named by its
+// x-replaced-by, else the one with the same name, else one whose name differs
+// only in case and separators and whose type agrees. Properties of a renamed
+// object follow it, so "events[].id" pairs with "data[].id" once "events"
+// pairs with "data".
+func matchElements(old, new map[string]*element) []mapping {
+	var mappings []mapping
+	matchedNew := make(map[string]bool)
+	renamed := make(map[string]string)
+
+	for _, key := range sortedKeys(old) {
+		e := old[key]
+		counterpart := renamedPath(key, renamed)
+		m := mapping{old: e}
+		switch {
+		case e.replacedBy != "" && new[siblingPath(counterpart, e.replacedBy)] != nil && !matchedNew[siblingPath(counterpart, e.replacedBy)]:
+			m.new = new[siblingPath(counterpart, e.replacedBy)]
+		case new[counterpart] != nil && !matchedNew[counterpart]:
+			m.new = new[counterpart]
+		default:
+			for _, newKey := range sortedKeys(new) {
+				if !matchedNew[newKey] && old[newKey] == nil && new[newKey].typ == e.typ && looseName(newKey) == looseName(counterpart) {
+					m.new, m.guessed = new[newKey], true
+					break
+				}
+			}
+		}
+		if m.new != nil {
+			matchedNew[m.new.name] = true
+			if m.new.name != key {
+				renamed[key] = m.new.name
+			}
+		}
+		mappings = append(mappings, m)
+	}
+
+	for _, key := range sortedKeys(new) {
+		if !matchedNew[key] {
+			mappings = append(mappings, mapping{new: new[key]})
+		}
+	}
+
+	sort.SliceStable(mappings, func(i, j int) bool {
+		return mappingKey(mappings[i]) < mappingKey(mappings[j])
+	})
+	return mappings
+}
+
+// renamedPath rewrites the longest renamed ancestor of path, e.g.
+// "events[].id" to "data[].id" when "events" was renamed to "data".
+func renamedPath(path string, renamed map[string]string) string {
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i] != '.' && path[i] != '[' {
+			continue
+		}
+		if parent, ok := renamed[path[:i]]; ok {
+			return parent + path[i:]
+		}
+	}
+	return path
+}
+
+// siblingPath replaces the last name of path, e.g. "data[].name" with
+// "title" gives "data[].title".
+func siblingPath(path, name string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i+1] + name
+	}
+	return name
+}
+
+// mappingKey orders mappings by their old name, else their new one.
+func mappingKey(m mapping) string {
+	if m.old != nil {
+		return m.old.name
+	}
+	return m.new.name
+}
+
+// looseName folds case and "_" and "-" separators, so "event_id" and
+// "eventId" compare equal.
+func looseName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// writeMigrationSummary counts the renamed, removed, and new elements.
+func writeMigrationSummary(md *strings.Builder, lists ...[]mapping) {
+	var renamed, removed, added int
+	for _, list := range lists {
+		for _, m := range list {
+			switch {
+			case m.new == nil:
+				removed++
+			case m.old == nil:
+				added++
+			case m.old.name != m.new.name:
+				renamed++
+			}
+		}
+	}
+	fmt.Fprintf(md, "**Summary:** %d renamed, %d removed, %d new\n\n", renamed, removed, added)
+}
+
+// writeMappings renders a table of mappings under heading. inRequest marks
+// elements the client sends, whose requiredness matters.
+func writeMappings(md *strings.Builder, heading string, old, new Endpoint, mappings []mapping, inRequest bool) {
+	if len(mappings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(md, "## %s\n\n", heading)
+	fmt.Fprintf(md, "| %s | %s | Notes |\n", old, new)
+	md.WriteString("|---|---|---|\n")
+	for _, m := range mappings {
+		fmt.Fprintf(md, "| %s | %s | %s |\n", describeElement(m.old, inRequest), describeElement(m.new, inRequest), migrationNotes(m, inRequest))
+	}
+	md.WriteString("\n")
+}
+
+// describeElement summarizes an element for a table cell.
+func describeElement(e *element, inRequest bool) string {
+	if e == nil {
+		return "—"
+	}
+	s := "`" + e.name + "`"
+	if e.in != "" {
+		s += " (" + e.in + ")"
+	}
+	s += " `" + e.typ + "`"
+	if inRequest && e.required {
+		s += ", required"
+	}
+	return s
+}
+
+// migrationNotes describes what changed between the two sides of m.
+func migrationNotes(m mapping, inRequest bool) string {
+	switch {
+	case m.new == nil:
+		return "**removed**"
+	case m.old == nil && inRequest && m.new.required:
+		return "**new, required**"
+	case m.old == nil:
+		return "new"
+	}
+
+	var notes []string
+	if m.guessed {
+		notes = append(notes, "likely renamed")
+	} else if m.old.name != m.new.name {
+		notes = append(notes, "renamed")
+	}
+	if m.old.in != m.new.in {
+		notes = append(notes, fmt.Sprintf("moved from %s to %s", m.old.in, m.new.in))
+	}
+	if m.old.typ != m.new.typ {
+		notes = append(notes, fmt.Sprintf("type `%s` → `%s`", m.old.typ, m.new.typ))
+	}
+	if inRequest && m.old.required != m.new.required {
+		if m.new.required {
+			notes = append(notes, "**now required**")
+		} else {
+			notes = append(notes, "no longer required")
+		}
+	}
+	if len(notes) == 0 {
+		return "unchanged"
+	}
+	return strings.Join(notes, "; ")
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,175 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const migrateSpec = `
+openapi: 3.0.3
+info: {title: Events, version: 1.0.0}
+paths:
+  /v1/events:
+    get:
+      operationId: listEventsV1
+      deprecated: true
+      x-replaced-by: listEvents
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}, x-replaced-by: page_size}
+        - {name: cursor, in: query, schema: {type: string}}
+        - {name: event_type, in: query, schema: {type: string}}
+        - {name: tenant, in: query, schema: {type: string}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  events:
+                    type: array
+                    x-replaced-by: data
+                    items:
+                      type: object
+                      properties:
+                        id: {type: integer}
+                        name: {type: string, x-replaced-by: title}
+    post:
+      x-replaced-by: /v2/events
+      responses:
+        "201": {description: Created}
+  /v2/events:
+    get:
+      operationId: listEvents
+      parameters:
+        - {name: page_size, in: query, schema: {type: integer}}
+        - {name: eventType, in: query, schema: {type: string}}
+        - {name: tenant, in: header, required: true, schema: {type: string}}
+        - {name: region, in: query, required: true, schema: {type: string}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: array
+                    items:
+                      type: object
+                      properties:
+                        id: {type: string}
+                        title: {type: string}
+                        created_at: {type: string}
+    post:
+      responses:
+        "202": {description: Accepted}
+`
+
+func loadMigrateSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(migrateSpec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return doc
+}
+
+func TestReplacedBy(t *testing.T) {
+	doc := loadMigrateSpec(t)
+	old := doc.Paths.Value("/v1/events")
+
+	tests := []struct {
+		method string
+		want   string
+		err    string
+	}{
+		{"GET", "GET /v2/events", ""},
+		{"post", "POST /v2/events", ""},
+		{"DELETE", "", "method 'DELETE' not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			got, err := ReplacedBy(doc, Endpoint{Method: tt.method, Path: "/v1/events", PathItem: old})
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ReplacedBy() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReplacedBy() error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("ReplacedBy() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ReplacedBy(doc, Endpoint{Method: "GET", Path: "/v2/events", PathItem: doc.Paths.Value("/v2/events")}); err == nil || !strings.Contains(err.Error(), "no x-replaced-by") {
+		t.Errorf("Expected an error for an operation without x-replaced-by, got %v", err)
+	}
+	if _, err := FindEndpoint(doc, "GET /v3/events", ""); err == nil {
+		t.Error("Expected an error for a missing replacement")
+	}
+}
+
+func TestMigration(t *testing.T) {
+	doc := loadMigrateSpec(t)
+	old := Endpoint{Method: "GET", Path: "/v1/events", PathItem: doc.Paths.Value("/v1/events")}
+	replacement, err := ReplacedBy(doc, old)
+	if err != nil {
+		t.Fatalf("ReplacedBy() error: %v", err)
+	}
+
+	markdown, err := Migration(old, replacement, 10)
+	if err != nil {
+		t.Fatalf("Migration() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Migrating from GET /v1/events to GET /v2/events",
+		"`GET /v1/events` is deprecated; use `GET /v2/events` instead.",
+		"**Summary:** 5 renamed, 1 removed, 2 new",
+		"| `limit` (query) `integer` | `page_size` (query) `integer` | renamed |",
+		"| `cursor` (query) `string` | — | **removed** |",
+		"| `event_type` (query) `string` | `eventType` (query) `string` | likely renamed |",
+		"| `tenant` (query) `string` | `tenant` (header) `string`, required | moved from query to header; **now required** |",
+		"| — | `region` (query) `string`, required | **new, required** |",
+		"| `events` `array` | `data` `array` | renamed |",
+		"| `events[].id` `integer` | `data[].id` `string` | renamed; type `integer` → `string` |",
+		"| `events[].name` `string` | `data[].title` `string` | renamed |",
+		"| — | `data[].created_at` `string` | new |",
+		"*Likely renames are matched by name and type only",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+
+	if strings.Contains(markdown, "## Request Body") {
+		t.Errorf("Did not expect %q in output:\n%s", "## Request Body", markdown)
+	}
+}
+
+func TestMigration_StatusChange(t *testing.T) {
+	doc := loadMigrateSpec(t)
+	old := Endpoint{Method: "POST", Path: "/v1/events", PathItem: doc.Paths.Value("/v1/events")}
+	replacement, err := ReplacedBy(doc, old)
+	if err != nil {
+		t.Fatalf("ReplacedBy() error: %v", err)
+	}
+
+	markdown, err := Migration(old, replacement, 10)
+	if err != nil {
+		t.Fatalf("Migration() error: %v", err)
+	}
+	if want := "The success status changes from `201` to `202`."; !strings.Contains(markdown, want) {
+		t.Errorf("Expected %q in output:\n%s", want, markdown)
+	}
+}
@@ -0,0 +1,235 @@
+// Package complexity scores OpenAPI operations by structural complexity to
+// highlight candidates for refactoring.
+package complexity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Score weights applied to each metric.
+const (
+	WeightParameter = 1
+	WeightProperty  = 1
+	WeightDepth     = 3
+	WeightBranch    = 2
+)
+
+// Score holds the complexity metrics of a single operation.
+type Score struct {
+	Method      string
+	Path        string
+	OperationID string
+
+	// Parameters is the number of parameters (path, query, header, cookie).
+	Parameters int
+	// Properties is the total number of schema properties across request and
+	// response bodies, counting nested properties.
+	Properties int
+	// Depth is the deepest schema nesting level found.
+	Depth int
+	// Branches is the number of oneOf/anyOf alternatives encountered.
+	Branches int
+
+	// Total is the weighted complexity score.
+	Total int
+}
+
+// Analyze scores every operation in doc, most complex first.
+func Analyze(doc *openapi3.T) []Score {
+	var scores []Score
+	if doc == nil || doc.Paths == nil {
+		return scores
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		for method, op := range pathItem.Operations() {
+			if op == nil {
+				continue
+			}
+			scores = append(scores, scoreOperation(method, path, pathItem, op))
+		}
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Total != scores[j].Total {
+			return scores[i].Total > scores[j].Total
+		}
+		if scores[i].Path != scores[j].Path {
+			return scores[i].Path < scores[j].Path
+		}
+		return scores[i].Method < scores[j].Method
+	})
+
+	return scores
+}
+
+// scoreOperation computes the metrics for one operation.
+func scoreOperation(method, path string, pathItem *openapi3.PathItem, op *openapi3.Operation) Score {
+	score := Score{
+		Method:      method,
+		Path:        path,
+		OperationID: op.OperationID,
+		Parameters:  len(pathItem.Parameters) + len(op.Parameters),
+	}
+
+	var w walker
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		for _, mt := range op.RequestBody.Value.Content {
+			if mt != nil {
+				w.walk(mt.Schema, 1)
+			}
+		}
+	}
+	if op.Responses != nil {
+		for _, resp := range op.Responses.Map() {
+			if resp == nil || resp.Value == nil {
+				continue
+			}
+			for _, mt := range resp.Value.Content {
+				if mt != nil {
+					w.walk(mt.Schema, 1)
+				}
+			}
+		}
+	}
+
+	score.Properties = w.properties
+	score.Depth = w.maxDepth
+	score.Branches = w.branches
+	score.Total = score.Parameters*WeightParameter +
+		score.Properties*WeightProperty +
+		score.Depth*WeightDepth +
+		score.Branches*WeightBranch
+
+	return score
+}
+
+// walker accumulates schema metrics, guarding against circular references.
+type walker struct {
+	properties int
+	maxDepth   int
+	branches   int
+	stack      []*openapi3.Schema
+}
+
+func (w *walker) walk(ref *openapi3.SchemaRef, depth int) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	schema := ref.Value
+
+	for _, s := range w.stack {
+		if s == schema {
+			return
+		}
+	}
+	w.stack = append(w.stack, schema)
+	defer func() { w.stack = w.stack[:len(w.stack)-1] }()
+
+	if depth > w.maxDepth {
+		w.maxDepth = depth
+	}
+
+	w.branches += len(schema.OneOf) + len(schema.AnyOf)
+	for _, refs := range []openapi3.SchemaRefs{schema.OneOf, schema.AnyOf} {
+		for _, branch := range refs {
+			w.walk(branch, depth)
+		}
+	}
+	for _, part := range schema.AllOf {
+		w.walk(part, depth)
+	}
+
+	w.properties += len(schema.Properties)
+	for _, prop := range schema.Properties {
+		w.walk(prop, depth+1)
+	}
+
+	if schema.Items != nil {
+		w.walk(schema.Items, depth+1)
+	}
+	if schema.AdditionalProperties.Schema != nil {
+		w.walk(schema.AdditionalProperties.Schema, depth+1)
+	}
+}
+
+// FormatTable renders scores as a ranked markdown table. top limits the number
+// of rows; zero means all.
+func FormatTable(scores []Score, top int) string {
+	var md strings.Builder
+
+	md.WriteString("# Operation Complexity\n\n")
+	md.WriteString("| Rank | Operation | Operation ID | Score | Params | Properties | Depth | Branches |\n")
+	md.WriteString("|-----:|-----------|--------------|------:|-------:|-----------:|------:|---------:|\n")
+
+	for i, s := range limit(scores, top) {
+		opID := ""
+		if s.OperationID != "" {
+			opID = "`" + s.OperationID + "`"
+		}
+		fmt.Fprintf(&md, "| %d | `%s %s` | %s | %d | %d | %d | %d | %d |\n",
+			i+1, s.Method, s.Path, opID, s.Total, s.Parameters, s.Properties, s.Depth, s.Branches)
+	}
+
+	fmt.Fprintf(&md, "\nScore = params×%d + properties×%d + depth×%d + branches×%d\n",
+		WeightParameter, WeightProperty, WeightDepth, WeightBranch)
+
+	return md.String()
+}
+
+// FormatMermaid renders scores as a mermaid treemap grouped by the first
+// path segment, wrapped in a markdown code fence.
+func FormatMermaid(scores []Score, top int) string {
+	groups := make(map[string][]Score)
+	var names []string
+	for _, s := range limit(scores, top) {
+		group := pathGroup(s.Path)
+		if _, ok := groups[group]; !ok {
+			names = append(names, group)
+		}
+		groups[group] = append(groups[group], s)
+	}
+	sort.Strings(names)
+
+	var md strings.Builder
+	md.WriteString("```mermaid\ntreemap-beta\n")
+	for _, name := range names {
+		fmt.Fprintf(&md, "%q\n", name)
+		for _, s := range groups[name] {
+			// Mermaid treemaps require positive values
+			value := s.Total
+			if value < 1 {
+				value = 1
+			}
+			fmt.Fprintf(&md, "    %q: %d\n", s.Method+" "+s.Path, value)
+		}
+	}
+	md.WriteString("```\n")
+
+	return md.String()
+}
+
+// pathGroup returns the first segment of a path, e.g. "/events" for "/events/{id}".
+func pathGroup(path string) string {
+	trimmed := strings.TrimPrefix(path, "/")
+	if i := strings.Index(trimmed, "/"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+	return "/" + trimmed
+}
+
+func limit(scores []Score, top int) []Score {
+	if top > 0 && top < len(scores) {
+		return scores[:top]
+	}
+	return scores
+}
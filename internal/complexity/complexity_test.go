@@ -0,0 +1,89 @@
+package complexity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func jsonContent(schema *openapi3.Schema) openapi3.Content {
+	return openapi3.NewContentWithJSONSchema(schema)
+}
+
+func TestAnalyze(t *testing.T) {
+	nested := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewStringSchema()).
+		WithProperty("tags", openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()))
+
+	body := openapi3.NewObjectSchema().
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("owner", nested)
+	body.Properties["kind"] = &openapi3.SchemaRef{Value: &openapi3.Schema{
+		OneOf: openapi3.SchemaRefs{
+			openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+			openapi3.NewSchemaRef("", openapi3.NewIntegerSchema()),
+		},
+	}}
+
+	// Self-reference must not loop forever
+	nested.Properties["parent"] = &openapi3.SchemaRef{Value: nested}
+
+	doc := &openapi3.T{Paths: openapi3.NewPaths(
+		openapi3.WithPath("/items", &openapi3.PathItem{
+			Post: &openapi3.Operation{
+				OperationID: "createItem",
+				Parameters: openapi3.Parameters{
+					{Value: openapi3.NewQueryParameter("dryRun")},
+				},
+				RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{Content: jsonContent(body)}},
+			},
+			Get: &openapi3.Operation{OperationID: "listItems"},
+		}),
+	)}
+
+	scores := Analyze(doc)
+	if len(scores) != 2 {
+		t.Fatalf("Analyze() returned %d scores, want 2", len(scores))
+	}
+
+	top := scores[0]
+	if top.OperationID != "createItem" {
+		t.Fatalf("Expected createItem to rank first, got %s", top.OperationID)
+	}
+
+	// body: name, owner, kind; owner: id, tags, parent
+	// Deepest path: body > owner > tags > items
+	if top.Properties != 6 {
+		t.Errorf("Properties = %d, want 6", top.Properties)
+	}
+	if top.Depth != 4 {
+		t.Errorf("Depth = %d, want 4", top.Depth)
+	}
+	if top.Branches != 2 {
+		t.Errorf("Branches = %d, want 2", top.Branches)
+	}
+	if top.Parameters != 1 {
+		t.Errorf("Parameters = %d, want 1", top.Parameters)
+	}
+
+	wantTotal := 1*WeightParameter + 6*WeightProperty + 4*WeightDepth + 2*WeightBranch
+	if top.Total != wantTotal {
+		t.Errorf("Total = %d, want %d", top.Total, wantTotal)
+	}
+}
+
+func TestFormatTableTop(t *testing.T) {
+	scores := []Score{
+		{Method: "POST", Path: "/a", Total: 9},
+		{Method: "GET", Path: "/b", Total: 3},
+	}
+
+	table := FormatTable(scores, 1)
+	if !strings.Contains(table, "`POST /a`") {
+		t.Error("Expected top operation in table")
+	}
+	if strings.Contains(table, "`GET /b`") {
+		t.Error("Did not expect operations beyond -top")
+	}
+}
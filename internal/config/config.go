@@ -0,0 +1,93 @@
+// Package config loads docfinder's optional YAML configuration file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/arthur-s/docfinder/internal/alias"
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/har"
+	"github.com/arthur-s/docfinder/internal/links"
+	"github.com/arthur-s/docfinder/internal/manifest"
+	"github.com/arthur-s/docfinder/internal/policy"
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/arthur-s/docfinder/internal/tags"
+	"github.com/arthur-s/docfinder/internal/watch"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the configuration file looked up in the working directory
+// when no explicit path is given.
+const DefaultFile = ".docfinder.yaml"
+
+// Config holds user-configurable settings.
+type Config struct {
+	// Badges configures the shields.io badges emitted with -badges.
+	Badges generator.BadgeConfig `yaml:"badges"`
+	// Vocabulary overrides section headings and labels.
+	Vocabulary generator.Vocabulary `yaml:"vocabulary"`
+	// PlatformHeaders documents response headers injected by the platform.
+	PlatformHeaders map[string]generator.PlatformHeader `yaml:"platform_headers"`
+	// Refs controls resolution of external $refs.
+	Refs spec.RefPolicy `yaml:"refs"`
+	// Fetch configures fetching specs given as http(s) URLs.
+	Fetch spec.FetchOptions `yaml:"fetch"`
+	// Registry configures loading every spec registered in the manifest,
+	// for registry lookups and list -all-services.
+	Registry manifest.LoadConfig `yaml:"registry"`
+	// Aliases maps former operationIds and paths to their current names.
+	Aliases alias.Config `yaml:"aliases"`
+	// Policy lists the documentation required by -fail-on-missing.
+	Policy policy.Config `yaml:"policy"`
+	// Tags maps tag names to canonical ones.
+	Tags tags.Config `yaml:"tags"`
+	// TrimExamples lists the fields removed from examples by
+	// -trim-examples, as dotted paths (default generator.DefaultTrimKeys).
+	TrimExamples []string `yaml:"trim_examples"`
+	// Watch configures the watch command's polling and webhooks.
+	Watch watch.Config `yaml:"watch"`
+	// Links configures which hosts check-links checks, and how.
+	Links links.Config `yaml:"links"`
+	// Masking configures how secrets and personal data in HAR traffic are
+	// masked.
+	Masking har.MaskConfig `yaml:"masking"`
+	// Export configures the files written by export and -all.
+	Export export.Config `yaml:"export"`
+	// Profiles are named sets of top-level flags selected with -profile.
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile sets top-level flags by name, e.g. sections: metadata,examples.
+type Profile map[string]string
+
+// Load reads the configuration from path.
+// If path is empty, DefaultFile is used when it exists; otherwise an empty
+// configuration is returned.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes a YAML configuration document.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,145 @@
+// Package deprecations reports the deprecated operations of a spec with the
+// dates they are removed, as documented by their Sunset headers.
+package deprecations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Entry is a deprecated operation.
+type Entry struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	// ReplacedBy is the replacement named by x-replaced-by.
+	ReplacedBy string           `json:"replacedBy,omitempty"`
+	Sunset     generator.Sunset `json:"sunset"`
+}
+
+// Report returns the deprecated operations of doc: those with a sunset date
+// first, soonest first, then the others ordered by path and method.
+func Report(doc *openapi3.T) []Entry {
+	entries := []Entry{}
+	for _, op := range export.Operations(doc) {
+		sunset := generator.OperationSunset(op.Operation)
+		if !sunset.Deprecated {
+			continue
+		}
+		replacedBy, _ := op.Operation.Extensions[generator.ExtensionReplacedBy].(string)
+		entries = append(entries, Entry{
+			Method:      op.Method,
+			Path:        op.Path,
+			OperationID: op.Operation.OperationID,
+			Summary:     op.Operation.Summary,
+			ReplacedBy:  replacedBy,
+			Sunset:      sunset,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Sunset.Date, entries[j].Sunset.Date
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+	return entries
+}
+
+// Format renders entries as markdown: upcoming sunsets as a table sorted by
+// date, then operations whose sunset date has passed as of now, then those
+// without a sunset date.
+func Format(entries []Entry, now time.Time) string {
+	var md strings.Builder
+	md.WriteString("# Deprecations\n\n")
+	if len(entries) == 0 {
+		md.WriteString("No deprecated operations.\n")
+		return md.String()
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	var upcoming, passed, undated []Entry
+	for _, e := range entries {
+		switch {
+		case e.Sunset.Date.IsZero():
+			undated = append(undated, e)
+		case e.Sunset.Date.Before(today):
+			passed = append(passed, e)
+		default:
+			upcoming = append(upcoming, e)
+		}
+	}
+	fmt.Fprintf(&md, "%d deprecated operation(s), %d with an upcoming sunset.\n", len(entries), len(upcoming))
+
+	if len(upcoming) > 0 {
+		md.WriteString("\n## Upcoming Sunsets\n\n")
+		md.WriteString("| Sunset | Days Left | Operation | Deprecated Since | Replacement | Details |\n")
+		md.WriteString("|---|---|---|---|---|---|\n")
+		for _, e := range upcoming {
+			days := int(e.Sunset.Date.Truncate(24*time.Hour).Sub(today).Hours() / 24)
+			fmt.Fprintf(&md, "| %s | %d | `%s %s` | %s | %s | %s |\n",
+				e.Sunset.Date.Format(generator.DateFormat), days, e.Method, e.Path,
+				formatDate(e.Sunset.Since), code(e.ReplacedBy), orDash(e.Sunset.Link))
+		}
+	}
+
+	if len(passed) > 0 {
+		md.WriteString("\n## Past Sunsets\n\n")
+		md.WriteString("These operations are still documented after their sunset date.\n\n")
+		for _, e := range passed {
+			fmt.Fprintf(&md, "- `%s %s`: sunset on %s%s\n", e.Method, e.Path, e.Sunset.Date.Format(generator.DateFormat), notes(e))
+		}
+	}
+
+	if len(undated) > 0 {
+		md.WriteString("\n## No Sunset Date\n\n")
+		for _, e := range undated {
+			fmt.Fprintf(&md, "- `%s %s`%s\n", e.Method, e.Path, notes(e))
+		}
+	}
+	return md.String()
+}
+
+// notes describes the replacement and link of e, e.g. "; use `GET /v2/items`".
+func notes(e Entry) string {
+	var parts []string
+	if e.ReplacedBy != "" {
+		parts = append(parts, fmt.Sprintf("use `%s`", e.ReplacedBy))
+	}
+	if e.Sunset.Link != "" {
+		parts = append(parts, "see "+e.Sunset.Link)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "; " + strings.Join(parts, "; ")
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(generator.DateFormat)
+}
+
+func code(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return "`" + s + "`"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
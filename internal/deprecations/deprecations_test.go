@@ -0,0 +1,74 @@
+package deprecations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const deprecationsSpec = `
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+paths:
+  /items:
+    get:
+      deprecated: true
+      x-replaced-by: GET /v2/items
+      responses: {'200': {description: OK}}
+    post:
+      x-sunset: '2026-12-01'
+      responses: {'201': {description: Created}}
+  /legacy:
+    get:
+      responses:
+        '200':
+          description: OK
+          headers:
+            Sunset: {schema: {type: string, example: 'Wed, 21 Oct 2026 07:28:00 GMT'}}
+            Deprecation: {schema: {type: string, example: '@1735689600'}}
+  /old:
+    get:
+      x-sunset: {date: '2025-01-01', link: https://example.com/old}
+      responses: {'200': {description: OK}}
+  /v2/items:
+    get:
+      responses: {'200': {description: OK}}
+`
+
+func TestReport(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(deprecationsSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := Report(doc)
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Method+" "+e.Path)
+	}
+	want := "GET /old, GET /legacy, POST /items, GET /items"
+	if strings.Join(got, ", ") != want {
+		t.Fatalf("Report() = %v, want %s", got, want)
+	}
+	if entries[3].ReplacedBy != "GET /v2/items" {
+		t.Errorf("Expected the replacement of GET /items, got %+v", entries[3])
+	}
+
+	md := Format(entries, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	for _, s := range []string{
+		"# Deprecations\n\n4 deprecated operation(s), 2 with an upcoming sunset.\n",
+		"| 2026-10-21 | 3 | `GET /legacy` | 2025-01-01 | - | - |\n| 2026-12-01 | 44 | `POST /items` | - | - | - |\n",
+		"## Past Sunsets\n\nThese operations are still documented after their sunset date.\n\n- `GET /old`: sunset on 2025-01-01; see https://example.com/old\n",
+		"## No Sunset Date\n\n- `GET /items`; use `GET /v2/items`\n",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("Expected %q in output:\n%s", s, md)
+		}
+	}
+
+	if md := Format(nil, time.Now()); !strings.Contains(md, "No deprecated operations.") {
+		t.Errorf("Format(nil) = %q", md)
+	}
+}
@@ -0,0 +1,295 @@
+// Package diff compares OpenAPI endpoint and component schema definitions
+// across spec versions.
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Kind classifies a change.
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Change describes a single difference between two versions of an endpoint.
+type Change struct {
+	Kind Kind
+	// Location identifies what changed, e.g. "GET query parameter `limit`".
+	Location string
+	// Detail optionally describes how it changed, e.g. "type `string` → `integer`".
+	Detail string
+	// Breaking reports whether existing clients may break.
+	Breaking bool
+}
+
+// String returns a human-readable one-line description of the change.
+func (c Change) String() string {
+	var verb string
+	switch c.Kind {
+	case Added:
+		verb = "Added"
+	case Removed:
+		verb = "Removed"
+	default:
+		verb = "Changed"
+	}
+
+	if c.Detail != "" {
+		return fmt.Sprintf("%s %s: %s", verb, c.Location, c.Detail)
+	}
+	return fmt.Sprintf("%s %s", verb, c.Location)
+}
+
+// PathItems compares two versions of a path item. A nil path item means the
+// endpoint does not exist in that version. method optionally restricts the
+// comparison to a single uppercase HTTP method.
+func PathItems(old, new *openapi3.PathItem, method string) []Change {
+	var changes []Change
+
+	for _, m := range model.MethodOrder {
+		if method != "" && m != method {
+			continue
+		}
+
+		oldOp := operation(old, m)
+		newOp := operation(new, m)
+
+		switch {
+		case oldOp == nil && newOp == nil:
+			continue
+		case oldOp == nil:
+			changes = append(changes, Change{Kind: Added, Location: fmt.Sprintf("operation `%s`", m)})
+		case newOp == nil:
+			changes = append(changes, Change{Kind: Removed, Location: fmt.Sprintf("operation `%s`", m), Breaking: true})
+		default:
+			changes = append(changes, Operations(m, oldOp, newOp)...)
+		}
+	}
+
+	return changes
+}
+
+// EndpointChanges lists the changes to one path.
+type EndpointChanges struct {
+	Path    string
+	Changes []Change
+}
+
+// Documents compares every path of two versions of a document. Paths are
+// matched by their exact template and returned in sorted order; unchanged
+// paths are omitted. Operations whose contract changed in ways PathItems
+// doesn't itemize, such as body schemas or path-level parameters, are
+// reported by comparing their generator.OperationFingerprint.
+func Documents(old, new *openapi3.T) []EndpointChanges {
+	oldPaths, newPaths := pathMap(old), pathMap(new)
+	oldGen, newGen := generator.New(old), generator.New(new)
+
+	var endpoints []EndpointChanges
+	for _, path := range unionKeys(oldPaths, newPaths) {
+		var changes []Change
+		for _, method := range model.MethodOrder {
+			methodChanges := PathItems(oldPaths[path], newPaths[path], method)
+			if len(methodChanges) == 0 && operation(oldPaths[path], method) != nil && operation(newPaths[path], method) != nil {
+				oldSum, _ := oldGen.OperationFingerprint(path, method)
+				newSum, _ := newGen.OperationFingerprint(path, method)
+				if oldSum != newSum {
+					methodChanges = []Change{{Kind: Changed, Location: fmt.Sprintf("operation `%s`", method), Detail: "contract changed (schemas, headers, or security)"}}
+				}
+			}
+			changes = append(changes, methodChanges...)
+		}
+		if len(changes) > 0 {
+			endpoints = append(endpoints, EndpointChanges{Path: path, Changes: changes})
+		}
+	}
+	return endpoints
+}
+
+func pathMap(doc *openapi3.T) map[string]*openapi3.PathItem {
+	if doc == nil || doc.Paths == nil {
+		return nil
+	}
+	return doc.Paths.Map()
+}
+
+// operation returns the operation for method, tolerating a nil path item.
+func operation(pathItem *openapi3.PathItem, method string) *openapi3.Operation {
+	if pathItem == nil {
+		return nil
+	}
+	return pathItem.GetOperation(method)
+}
+
+// Operations compares two versions of the same operation.
+func Operations(method string, old, new *openapi3.Operation) []Change {
+	var changes []Change
+
+	if old.Deprecated != new.Deprecated {
+		detail := "no longer deprecated"
+		if new.Deprecated {
+			detail = "now deprecated"
+		}
+		changes = append(changes, Change{Kind: Changed, Location: fmt.Sprintf("operation `%s`", method), Detail: detail})
+	}
+
+	changes = append(changes, compareParameters(method, old.Parameters, new.Parameters)...)
+	changes = append(changes, compareRequestBodies(method, old.RequestBody, new.RequestBody)...)
+	changes = append(changes, compareResponses(method, old.Responses, new.Responses)...)
+
+	return changes
+}
+
+// compareParameters compares parameter lists keyed by location and name.
+func compareParameters(method string, old, new openapi3.Parameters) []Change {
+	oldParams := indexParameters(old)
+	newParams := indexParameters(new)
+
+	var changes []Change
+	for _, key := range unionKeys(oldParams, newParams) {
+		oldParam, inOld := oldParams[key]
+		newParam, inNew := newParams[key]
+
+		param := newParam
+		if !inNew {
+			param = oldParam
+		}
+		location := fmt.Sprintf("%s %s parameter `%s`", method, param.In, param.Name)
+
+		switch {
+		case !inOld:
+			detail := ""
+			if newParam.Required {
+				detail = "required"
+			}
+			changes = append(changes, Change{Kind: Added, Location: location, Detail: detail, Breaking: newParam.Required})
+		case !inNew:
+			changes = append(changes, Change{Kind: Removed, Location: location, Breaking: true})
+		default:
+			if oldParam.Required != newParam.Required {
+				changes = append(changes, Change{Kind: Changed, Location: location, Detail: requiredDetail(newParam.Required), Breaking: newParam.Required})
+			}
+			if oldType, newType := generator.FormatParameterType(oldParam), generator.FormatParameterType(newParam); oldType != newType {
+				changes = append(changes, Change{Kind: Changed, Location: location, Detail: fmt.Sprintf("type `%s` → `%s`", oldType, newType), Breaking: true})
+			}
+			if oldParam.Deprecated != newParam.Deprecated && newParam.Deprecated {
+				changes = append(changes, Change{Kind: Changed, Location: location, Detail: "now deprecated"})
+			}
+		}
+	}
+
+	return changes
+}
+
+// compareRequestBodies compares request body presence and requiredness.
+func compareRequestBodies(method string, old, new *openapi3.RequestBodyRef) []Change {
+	oldBody := requestBody(old)
+	newBody := requestBody(new)
+	location := fmt.Sprintf("%s request body", method)
+
+	switch {
+	case oldBody == nil && newBody == nil:
+		return nil
+	case oldBody == nil:
+		return []Change{{Kind: Added, Location: location, Breaking: newBody.Required}}
+	case newBody == nil:
+		return []Change{{Kind: Removed, Location: location, Breaking: true}}
+	}
+
+	var changes []Change
+	if oldBody.Required != newBody.Required {
+		changes = append(changes, Change{Kind: Changed, Location: location, Detail: requiredDetail(newBody.Required), Breaking: newBody.Required})
+	}
+
+	for _, ct := range unionKeys(oldBody.Content, newBody.Content) {
+		_, inOld := oldBody.Content[ct]
+		_, inNew := newBody.Content[ct]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: Added, Location: fmt.Sprintf("%s content type `%s`", location, ct)})
+		case !inNew:
+			changes = append(changes, Change{Kind: Removed, Location: fmt.Sprintf("%s content type `%s`", location, ct), Breaking: true})
+		}
+	}
+
+	return changes
+}
+
+// compareResponses compares documented response status codes.
+func compareResponses(method string, old, new *openapi3.Responses) []Change {
+	oldMap := responseMap(old)
+	newMap := responseMap(new)
+
+	var changes []Change
+	for _, status := range unionKeys(oldMap, newMap) {
+		_, inOld := oldMap[status]
+		_, inNew := newMap[status]
+		location := fmt.Sprintf("%s response `%s`", method, status)
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: Added, Location: location})
+		case !inNew:
+			changes = append(changes, Change{Kind: Removed, Location: location})
+		}
+	}
+
+	return changes
+}
+
+// indexParameters keys parameters by "in:name", the identity of a parameter
+// within an operation.
+func indexParameters(params openapi3.Parameters) map[string]*openapi3.Parameter {
+	index := make(map[string]*openapi3.Parameter, len(params))
+	for _, ref := range params {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		index[ref.Value.In+":"+ref.Value.Name] = ref.Value
+	}
+	return index
+}
+
+func requestBody(ref *openapi3.RequestBodyRef) *openapi3.RequestBody {
+	if ref == nil {
+		return nil
+	}
+	return ref.Value
+}
+
+func responseMap(responses *openapi3.Responses) map[string]*openapi3.ResponseRef {
+	if responses == nil {
+		return nil
+	}
+	return responses.Map()
+}
+
+func requiredDetail(required bool) string {
+	if required {
+		return "now required"
+	}
+	return "now optional"
+}
+
+// unionKeys returns the sorted union of keys of two maps.
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,153 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func param(in, name string, required bool, typ string) *openapi3.ParameterRef {
+	return &openapi3.ParameterRef{Value: &openapi3.Parameter{
+		In:       in,
+		Name:     name,
+		Required: required,
+		Schema:   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{typ}}},
+	}}
+}
+
+func responses(codes ...string) *openapi3.Responses {
+	r := openapi3.NewResponses()
+	r.Delete("default")
+	for _, code := range codes {
+		r.Set(code, &openapi3.ResponseRef{Value: openapi3.NewResponse()})
+	}
+	return r
+}
+
+func TestPathItems(t *testing.T) {
+	old := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				param("path", "id", true, "string"),
+				param("query", "expand", false, "string"),
+				param("query", "limit", false, "string"),
+			},
+			Responses: responses("200", "404"),
+		},
+		Delete: &openapi3.Operation{Responses: responses("204")},
+	}
+
+	new := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Deprecated: true,
+			Parameters: openapi3.Parameters{
+				param("path", "id", true, "string"),
+				param("query", "limit", true, "integer"),
+				param("header", "X-Tenant", true, "string"),
+			},
+			Responses: responses("200", "410"),
+		},
+		Put: &openapi3.Operation{Responses: responses("200")},
+	}
+
+	changes := PathItems(old, new, "")
+
+	var lines []string
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+	got := strings.Join(lines, "\n")
+
+	expected := []string{
+		"Changed operation `GET`: now deprecated",
+		"Added GET header parameter `X-Tenant`: required",
+		"Removed GET query parameter `expand`",
+		"Changed GET query parameter `limit`: now required",
+		"Changed GET query parameter `limit`: type `string` → `integer`",
+		"Removed GET response `404`",
+		"Added GET response `410`",
+		"Added operation `PUT`",
+		"Removed operation `DELETE`",
+	}
+	want := strings.Join(expected, "\n")
+
+	if got != want {
+		t.Errorf("PathItems() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPathItems_MethodFilter(t *testing.T) {
+	old := &openapi3.PathItem{Get: &openapi3.Operation{}, Delete: &openapi3.Operation{}}
+	new := &openapi3.PathItem{Get: &openapi3.Operation{}}
+
+	if changes := PathItems(old, new, "GET"); len(changes) != 0 {
+		t.Errorf("Expected no GET changes, got %v", changes)
+	}
+	if changes := PathItems(old, nil, "DELETE"); len(changes) != 1 || changes[0].Kind != Removed {
+		t.Errorf("Expected DELETE removal, got %v", changes)
+	}
+}
+
+func TestDocuments(t *testing.T) {
+	old := &openapi3.T{Paths: openapi3.NewPaths(
+		openapi3.WithPath("/events", &openapi3.PathItem{Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{param("query", "limit", false, "integer")},
+		}}),
+		openapi3.WithPath("/health", &openapi3.PathItem{Get: &openapi3.Operation{}}),
+		openapi3.WithPath("/legacy", &openapi3.PathItem{Get: &openapi3.Operation{}}),
+	)}
+	new := &openapi3.T{Paths: openapi3.NewPaths(
+		openapi3.WithPath("/events", &openapi3.PathItem{Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				param("query", "limit", false, "integer"),
+				param("query", "cursor", false, "string"),
+				param("header", "X-Tenant", true, "string"),
+			},
+		}}),
+		openapi3.WithPath("/health", &openapi3.PathItem{Get: &openapi3.Operation{}}),
+	)}
+
+	endpoints := Documents(old, new)
+	if len(endpoints) != 2 || endpoints[0].Path != "/events" || endpoints[1].Path != "/legacy" {
+		t.Fatalf("Documents() = %+v", endpoints)
+	}
+
+	var breaking []string
+	for _, e := range endpoints {
+		for _, c := range Breaking(e.Changes) {
+			breaking = append(breaking, e.Path+" "+c.String())
+		}
+	}
+	want := "/events Added GET header parameter `X-Tenant`: required\n/legacy Removed operation `GET`"
+	if got := strings.Join(breaking, "\n"); got != want {
+		t.Errorf("Breaking changes =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDocuments_ContractChange(t *testing.T) {
+	response := func(schemaType string) *openapi3.Responses {
+		return openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{
+			Value: openapi3.NewResponse().WithDescription("OK").
+				WithJSONSchema(openapi3.NewObjectSchema().WithProperty("id", &openapi3.Schema{Type: &openapi3.Types{schemaType}})),
+		}))
+	}
+	doc := func(schemaType, summary string) *openapi3.T {
+		return &openapi3.T{Paths: openapi3.NewPaths(
+			openapi3.WithPath("/events", &openapi3.PathItem{Get: &openapi3.Operation{Summary: summary, Responses: response(schemaType)}}),
+		)}
+	}
+
+	if endpoints := Documents(doc("string", "List"), doc("string", "List events")); len(endpoints) != 0 {
+		t.Errorf("Expected no changes for a new summary, got %+v", endpoints)
+	}
+
+	endpoints := Documents(doc("string", "List"), doc("integer", "List"))
+	if len(endpoints) != 1 || len(endpoints[0].Changes) != 1 {
+		t.Fatalf("Documents() = %+v", endpoints)
+	}
+	want := "Changed operation `GET`: contract changed (schemas, headers, or security)"
+	if got := endpoints[0].Changes[0].String(); got != want {
+		t.Errorf("Change = %q, want %q", got, want)
+	}
+}
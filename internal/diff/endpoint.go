@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Endpoint compares two versions of a path item like PathItems, and also
+// compares the request body and response schemas of operations present in
+// both at the field level. method optionally restricts the comparison to a
+// single uppercase HTTP method.
+func Endpoint(old, new *openapi3.PathItem, method string) []Change {
+	var changes []Change
+	for _, m := range model.MethodOrder {
+		if method != "" && m != method {
+			continue
+		}
+		changes = append(changes, PathItems(old, new, m)...)
+		if oldOp, newOp := operation(old, m), operation(new, m); oldOp != nil && newOp != nil {
+			changes = append(changes, Fields(m, oldOp, newOp)...)
+		}
+	}
+	return changes
+}
+
+// Fields compares the schemas of the request body and of each response
+// documented by both versions of an operation, per content type. Changes are
+// classified as conservatively as Schemas classifies them.
+func Fields(method string, old, new *openapi3.Operation) []Change {
+	var changes []Change
+
+	if oldBody, newBody := requestBody(old.RequestBody), requestBody(new.RequestBody); oldBody != nil && newBody != nil {
+		changes = append(changes, compareContent(fmt.Sprintf("%s request body", method), oldBody.Content, newBody.Content)...)
+	}
+
+	oldMap, newMap := responseMap(old.Responses), responseMap(new.Responses)
+	for _, status := range unionKeys(oldMap, newMap) {
+		oldResp, newResp := response(oldMap[status]), response(newMap[status])
+		if oldResp == nil || newResp == nil {
+			continue
+		}
+		changes = append(changes, compareContent(fmt.Sprintf("%s response `%s`", method, status), oldResp.Content, newResp.Content)...)
+	}
+
+	return changes
+}
+
+// compareContent compares the schemas of content types present in both
+// versions.
+func compareContent(location string, old, new openapi3.Content) []Change {
+	var changes []Change
+	for _, ct := range unionKeys(old, new) {
+		oldMedia, newMedia := old[ct], new[ct]
+		if oldMedia == nil || newMedia == nil {
+			continue
+		}
+		oldSchema, newSchema := schemaValue(oldMedia.Schema), schemaValue(newMedia.Schema)
+		if oldSchema == nil || newSchema == nil {
+			continue
+		}
+
+		c := &schemaComparer{
+			prefix:  fmt.Sprintf("%s `%s` ", location, ct),
+			visited: make(map[[2]*openapi3.Schema]bool),
+		}
+		c.compare("", oldSchema, newSchema)
+		changes = append(changes, c.changes...)
+	}
+	return changes
+}
+
+func response(ref *openapi3.ResponseRef) *openapi3.Response {
+	if ref == nil {
+		return nil
+	}
+	return ref.Value
+}
@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestEndpoint(t *testing.T) {
+	eventSchema := func(required []string, props map[string]string) *openapi3.Schema {
+		s := openapi3.NewObjectSchema()
+		for name, typ := range props {
+			s.WithProperty(name, &openapi3.Schema{Type: &openapi3.Types{typ}})
+		}
+		s.Required = required
+		return s
+	}
+	pathItem := func(body, resp *openapi3.Schema) *openapi3.PathItem {
+		return &openapi3.PathItem{Put: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(body)},
+			Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{
+				Value: openapi3.NewResponse().WithDescription("OK").WithJSONSchema(resp),
+			})),
+		}}
+	}
+
+	old := pathItem(
+		eventSchema(nil, map[string]string{"name": "string", "notes": "string"}),
+		eventSchema(nil, map[string]string{"id": "string", "name": "string"}),
+	)
+	new := pathItem(
+		eventSchema([]string{"name"}, map[string]string{"name": "string", "venue": "string"}),
+		eventSchema(nil, map[string]string{"id": "integer", "name": "string"}),
+	)
+
+	var lines []string
+	for _, c := range Endpoint(old, new, "") {
+		lines = append(lines, c.String())
+	}
+	got := strings.Join(lines, "\n")
+
+	want := strings.Join([]string{
+		"Changed PUT request body `application/json` property `name`: now required",
+		"Removed PUT request body `application/json` property `notes`",
+		"Added PUT request body `application/json` property `venue`",
+		"Changed PUT response `200` `application/json` property `id`: type `string` → `integer`",
+	}, "\n")
+	if got != want {
+		t.Errorf("Endpoint() =\n%s\nwant\n%s", got, want)
+	}
+
+	if changes := Endpoint(old, new, "GET"); len(changes) != 0 {
+		t.Errorf("Expected no GET changes, got %v", changes)
+	}
+}
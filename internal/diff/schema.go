@@ -0,0 +1,267 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Schemas compares two versions of a component schema at the property level.
+// A nil schema means it does not exist in that version.
+//
+// Changes are classified conservatively, assuming the schema may appear in
+// both requests and responses: removing or retyping a property, making it
+// required, removing enum values, or tightening constraints is breaking;
+// adding optional properties or enum values and relaxing constraints is not.
+func Schemas(name string, old, new *openapi3.Schema) []Change {
+	location := fmt.Sprintf("schema `%s`", name)
+
+	switch {
+	case old == nil && new == nil:
+		return nil
+	case old == nil:
+		return []Change{{Kind: Added, Location: location}}
+	case new == nil:
+		return []Change{{Kind: Removed, Location: location, Breaking: true}}
+	}
+
+	c := &schemaComparer{visited: make(map[[2]*openapi3.Schema]bool)}
+	c.compare("", old, new)
+	return c.changes
+}
+
+// Breaking returns the breaking changes.
+func Breaking(changes []Change) []Change {
+	var breaking []Change
+	for _, change := range changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// schemaComparer accumulates changes while walking two schemas in parallel.
+type schemaComparer struct {
+	changes []Change
+	// prefix qualifies locations, e.g. "GET request body `application/json` ".
+	prefix string
+	// visited guards against recursive schemas.
+	visited map[[2]*openapi3.Schema]bool
+}
+
+func (c *schemaComparer) add(kind Kind, path, detail string, breaking bool) {
+	c.changes = append(c.changes, Change{Kind: kind, Location: c.prefix + propertyLocation(path), Detail: detail, Breaking: breaking})
+}
+
+// compare compares two versions of the schema at path ("" for the root).
+func (c *schemaComparer) compare(path string, old, new *openapi3.Schema) {
+	key := [2]*openapi3.Schema{old, new}
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+
+	if oldType, newType := generator.FormatType(old), generator.FormatType(new); oldType != newType {
+		c.add(Changed, path, fmt.Sprintf("type `%s` → `%s`", oldType, newType), true)
+		return
+	}
+
+	if old.Format != new.Format {
+		c.add(Changed, path, fmt.Sprintf("format `%s` → `%s`", orNone(old.Format), orNone(new.Format)), true)
+	}
+
+	if old.Nullable != new.Nullable {
+		if new.Nullable {
+			c.add(Changed, path, "now nullable", true)
+		} else {
+			c.add(Changed, path, "no longer nullable", true)
+		}
+	}
+
+	c.compareEnums(path, old.Enum, new.Enum)
+	c.compareConstraints(path, old, new)
+	c.compareProperties(path, old, new)
+
+	if old.Items != nil && new.Items != nil && old.Items.Value != nil && new.Items.Value != nil {
+		c.compare(path+"[]", old.Items.Value, new.Items.Value)
+	}
+}
+
+// compareProperties compares object properties and their requiredness.
+func (c *schemaComparer) compareProperties(path string, old, new *openapi3.Schema) {
+	oldRequired := requiredSet(old)
+	newRequired := requiredSet(new)
+
+	for _, name := range unionKeys(old.Properties, new.Properties) {
+		oldProp := schemaValue(old.Properties[name])
+		newProp := schemaValue(new.Properties[name])
+		propPath := joinPath(path, name)
+
+		switch {
+		case oldProp == nil && newProp == nil:
+			continue
+		case oldProp == nil:
+			if newRequired[name] {
+				c.add(Added, propPath, "required", true)
+			} else {
+				c.add(Added, propPath, "", false)
+			}
+			continue
+		case newProp == nil:
+			c.add(Removed, propPath, "", true)
+			continue
+		}
+
+		if oldRequired[name] != newRequired[name] {
+			c.add(Changed, propPath, requiredDetail(newRequired[name]), newRequired[name])
+		}
+
+		c.compare(propPath, oldProp, newProp)
+	}
+}
+
+// compareEnums reports enum values added or removed.
+func (c *schemaComparer) compareEnums(path string, old, new []any) {
+	oldValues := enumSet(old)
+	newValues := enumSet(new)
+
+	// Introducing an enum restricts previously free-form values.
+	if len(old) == 0 && len(new) > 0 {
+		c.add(Changed, path, fmt.Sprintf("now restricted to %s", strings.Join(sortedSet(newValues), ", ")), true)
+		return
+	}
+	if len(old) > 0 && len(new) == 0 {
+		c.add(Changed, path, "no longer restricted to enum values", false)
+		return
+	}
+
+	var added, removed []string
+	for value := range newValues {
+		if !oldValues[value] {
+			added = append(added, value)
+		}
+	}
+	for value := range oldValues {
+		if !newValues[value] {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	if len(removed) > 0 {
+		c.add(Changed, path, "enum values removed: "+strings.Join(removed, ", "), true)
+	}
+	if len(added) > 0 {
+		c.add(Changed, path, "enum values added: "+strings.Join(added, ", "), false)
+	}
+}
+
+// compareConstraints reports tightened or relaxed length and range limits.
+func (c *schemaComparer) compareConstraints(path string, old, new *openapi3.Schema) {
+	compareLimit(c, path, "minLength", uintPtr(old.MinLength), uintPtr(new.MinLength), false)
+	compareLimit(c, path, "maxLength", old.MaxLength, new.MaxLength, true)
+	compareLimit(c, path, "minItems", uintPtr(old.MinItems), uintPtr(new.MinItems), false)
+	compareLimit(c, path, "maxItems", old.MaxItems, new.MaxItems, true)
+	compareLimit(c, path, "minimum", old.Min, new.Min, false)
+	compareLimit(c, path, "maximum", old.Max, new.Max, true)
+
+	if old.Pattern != new.Pattern {
+		c.add(Changed, path, fmt.Sprintf("pattern `%s` → `%s`", orNone(old.Pattern), orNone(new.Pattern)), new.Pattern != "")
+	}
+}
+
+// compareLimit compares a bound. For upper bounds a lower or newly added
+// value tightens the constraint; for lower bounds a higher or newly added
+// value does.
+func compareLimit[T uint64 | float64](c *schemaComparer, path, name string, old, new *T, upper bool) {
+	var tightened bool
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		tightened = true
+	case new == nil:
+		tightened = false
+	case *old == *new:
+		return
+	case upper:
+		tightened = *new < *old
+	default:
+		tightened = *new > *old
+	}
+
+	c.add(Changed, path, fmt.Sprintf("%s %s → %s", name, formatLimit(old), formatLimit(new)), tightened)
+}
+
+func formatLimit[T uint64 | float64](v *T) string {
+	if v == nil {
+		return "none"
+	}
+	return fmt.Sprintf("`%v`", *v)
+}
+
+// uintPtr converts a zero-means-unset bound to a pointer.
+func uintPtr(v uint64) *uint64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+func propertyLocation(path string) string {
+	if path == "" {
+		return "schema root"
+	}
+	return fmt.Sprintf("property `%s`", path)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func schemaValue(ref *openapi3.SchemaRef) *openapi3.Schema {
+	if ref == nil {
+		return nil
+	}
+	return ref.Value
+}
+
+func requiredSet(schema *openapi3.Schema) map[string]bool {
+	set := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		set[name] = true
+	}
+	return set
+}
+
+func enumSet(values []any) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[fmt.Sprintf("`%v`", v)] = true
+	}
+	return set
+}
+
+func sortedSet(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func moneySchema() *openapi3.Schema {
+	return &openapi3.Schema{
+		Type:     &openapi3.Types{"object"},
+		Required: []string{"amount"},
+		Properties: openapi3.Schemas{
+			"amount":   openapi3.NewFloat64Schema().WithMin(0).NewRef(),
+			"currency": openapi3.NewStringSchema().WithEnum("USD", "EUR", "GBP").NewRef(),
+			"note":     openapi3.NewStringSchema().WithMaxLength(200).NewRef(),
+			"rounding": openapi3.NewStringSchema().NewRef(),
+		},
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	old := moneySchema()
+
+	new := moneySchema()
+	new.Required = []string{"amount", "currency"}
+	new.Properties["amount"] = openapi3.NewStringSchema().NewRef()
+	new.Properties["currency"] = openapi3.NewStringSchema().WithEnum("USD", "EUR", "JPY").NewRef()
+	new.Properties["note"] = openapi3.NewStringSchema().WithMaxLength(500).NewRef()
+	delete(new.Properties, "rounding")
+	new.Properties["scale"] = openapi3.NewIntegerSchema().NewRef()
+
+	expected := []struct {
+		change   string
+		breaking bool
+	}{
+		{"Changed property `amount`: type `number` → `string`", true},
+		{"Changed property `currency`: now required", true},
+		{"Changed property `currency`: enum values removed: `GBP`", true},
+		{"Changed property `currency`: enum values added: `JPY`", false},
+		{"Changed property `note`: maxLength `200` → `500`", false},
+		{"Removed property `rounding`", true},
+		{"Added property `scale`", false},
+	}
+
+	changes := Schemas("Money", old, new)
+	if len(changes) != len(expected) {
+		t.Fatalf("Schemas() returned %d changes, want %d: %v", len(changes), len(expected), changes)
+	}
+	for i, want := range expected {
+		if got := changes[i].String(); got != want.change {
+			t.Errorf("change %d = %q, want %q", i, got, want.change)
+		}
+		if changes[i].Breaking != want.breaking {
+			t.Errorf("change %q breaking = %v, want %v", changes[i], changes[i].Breaking, want.breaking)
+		}
+	}
+
+	if got := len(Breaking(changes)); got != 4 {
+		t.Errorf("Breaking() returned %d changes, want 4", got)
+	}
+}
+
+func TestSchemas_Nested(t *testing.T) {
+	item := func(required ...string) *openapi3.Schema {
+		s := openapi3.NewObjectSchema().WithProperty("sku", openapi3.NewStringSchema())
+		s.Required = required
+		return s
+	}
+
+	old := openapi3.NewObjectSchema().WithProperty("items", openapi3.NewArraySchema().WithItems(item()))
+	new := openapi3.NewObjectSchema().WithProperty("items", openapi3.NewArraySchema().WithItems(item("sku")))
+
+	changes := Schemas("Order", old, new)
+	if len(changes) != 1 || changes[0].String() != "Changed property `items[].sku`: now required" {
+		t.Errorf("Schemas() = %v, want nested requiredness change", changes)
+	}
+}
+
+func TestSchemas_Recursive(t *testing.T) {
+	node := openapi3.NewObjectSchema()
+	node.WithPropertyRef("children", openapi3.NewArraySchema().WithItems(node).NewRef())
+
+	if changes := Schemas("Node", node, node); len(changes) != 0 {
+		t.Errorf("Did not expect changes comparing a recursive schema with itself, got %v", changes)
+	}
+}
+
+func TestSchemas_Presence(t *testing.T) {
+	if changes := Schemas("Money", moneySchema(), nil); len(changes) != 1 || !changes[0].Breaking {
+		t.Errorf("Expected removed schema to be a breaking change, got %v", changes)
+	}
+	if changes := Schemas("Money", nil, moneySchema()); len(changes) != 1 || changes[0].Kind != Added || changes[0].Breaking {
+		t.Errorf("Expected added schema to be non-breaking, got %v", changes)
+	}
+}
@@ -0,0 +1,307 @@
+// Package doctor diagnoses the environment docfinder runs in: the
+// configuration file, the ref cache, the specs registered in the manifest,
+// and whether they load, suggesting a fix for each problem found.
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/config"
+	"github.com/arthur-s/docfinder/internal/manifest"
+	"github.com/arthur-s/docfinder/internal/plugin"
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/arthur-s/docfinder/internal/watch"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+// Check outcomes.
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warning"
+	StatusFailed  Status = "failed"
+)
+
+// Check is the result of one diagnostic.
+type Check struct {
+	// Name identifies what was checked, e.g. "config" or "spec notify".
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Fix suggests how to resolve a warning or failure.
+	Fix string `json:"fix,omitempty"`
+}
+
+// String formats the check as a line, followed by its fix if any.
+func (c Check) String() string {
+	line := fmt.Sprintf("[%s] %s: %s", c.Status, c.Name, c.Message)
+	if c.Fix != "" {
+		line += "\n    fix: " + c.Fix
+	}
+	return line
+}
+
+// Failed returns the number of failed checks.
+func Failed(checks []Check) int {
+	n := 0
+	for _, c := range checks {
+		if c.Status == StatusFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// Config checks the configuration file at path, or config.DefaultFile when
+// path is empty. It returns the configuration to use for the remaining
+// checks, which is empty if the file cannot be read.
+func Config(path string) (*config.Config, []Check) {
+	name := path
+	if name == "" {
+		name = config.DefaultFile
+		if _, err := os.Stat(name); errors.Is(err, os.ErrNotExist) {
+			return &config.Config{}, []Check{{Name: "config", Status: StatusOK, Message: "no " + config.DefaultFile + ", using defaults"}}
+		}
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return &config.Config{}, []Check{{Name: "config", Status: StatusFailed, Message: err.Error(),
+			Fix: "check the -config path and the file's permissions"}}
+	}
+	cfg, err := config.Parse(data)
+	if err != nil {
+		return &config.Config{}, []Check{{Name: "config", Status: StatusFailed, Message: fmt.Sprintf("%s: %v", name, err),
+			Fix: "fix the YAML syntax at the reported line"}}
+	}
+
+	var checks []Check
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var strict config.Config
+	if err := decoder.Decode(&strict); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			for _, msg := range typeErr.Errors {
+				checks = append(checks, Check{Name: "config", Status: StatusWarning, Message: fmt.Sprintf("%s: %s", name, msg),
+					Fix: "check the spelling and nesting of the setting; unknown settings are ignored"})
+			}
+		}
+	}
+
+	if _, err := cfg.Policy.Requirements(); err != nil {
+		checks = append(checks, Check{Name: "config", Status: StatusFailed, Message: fmt.Sprintf("policy.require: %v", err),
+			Fix: "remove or correct the requirement"})
+	}
+	for _, w := range cfg.Watch.Webhooks {
+		if _, err := watch.ParseFormat(string(w.Format)); err != nil {
+			checks = append(checks, Check{Name: "config", Status: StatusFailed, Message: fmt.Sprintf("watch.webhooks: %v", err),
+				Fix: "set format to json or slack"})
+		}
+		if w.URL == "" {
+			checks = append(checks, Check{Name: "config", Status: StatusFailed, Message: "watch.webhooks: webhook without a url",
+				Fix: "add the url or remove the webhook"})
+		}
+	}
+
+	if len(checks) == 0 {
+		checks = append(checks, Check{Name: "config", Status: StatusOK, Message: name + " is valid"})
+	}
+	return cfg, checks
+}
+
+// Cache checks the directory fetched remote refs are cached in.
+func Cache(policy spec.RefPolicy) Check {
+	dir := policy.CacheLocation()
+	if dir == "" {
+		status := StatusWarning
+		if policy.Offline {
+			status = StatusFailed
+		}
+		return Check{Name: "ref cache", Status: status, Message: "no user cache directory; remote refs are fetched every time",
+			Fix: "set refs.cacheDir in " + config.DefaultFile}
+	}
+
+	info, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		if policy.Offline {
+			return Check{Name: "ref cache", Status: StatusWarning, Message: dir + " does not exist, so offline mode cannot resolve remote refs",
+				Fix: "run once without -offline to fill the cache"}
+		}
+		return Check{Name: "ref cache", Status: StatusOK, Message: dir + " is empty; it is created on the first remote ref"}
+	}
+	if err != nil {
+		return Check{Name: "ref cache", Status: StatusFailed, Message: err.Error(), Fix: "check the permissions of " + dir}
+	}
+	if !info.IsDir() {
+		return Check{Name: "ref cache", Status: StatusFailed, Message: dir + " is not a directory",
+			Fix: "remove the file or point refs.cacheDir elsewhere"}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Check{Name: "ref cache", Status: StatusFailed, Message: err.Error(), Fix: "check the permissions of " + dir}
+	}
+	var size int64
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() {
+			size += info.Size()
+		}
+	}
+
+	probe, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return Check{Name: "ref cache", Status: StatusWarning, Message: dir + " is not writable, so fetched refs are not cached",
+			Fix: "fix the permissions of " + dir + " or set refs.cacheDir"}
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return Check{Name: "ref cache", Status: StatusOK, Message: fmt.Sprintf("%s holds %d cached refs (%d KiB)", dir, len(entries), (size+1023)/1024)}
+}
+
+// Source is a spec registered in the manifest.
+type Source struct {
+	// Name is the service, with the version for historical snapshots,
+	// e.g. "billing@2023-10".
+	Name string
+	// Location is the spec file, directory, or plugin source.
+	Location string
+}
+
+// Manifest checks the manifest found from dir upward and that every spec
+// it registers can be reached. It returns the reachable specs.
+func Manifest(dir string) ([]Source, []Check) {
+	path, err := manifest.Find(dir)
+	if errors.Is(err, manifest.ErrNotFound) {
+		return nil, []Check{{Name: "manifest", Status: StatusOK, Message: "no " + manifest.FileName + "; specs are passed on the command line"}}
+	}
+	if err != nil {
+		return nil, []Check{{Name: "manifest", Status: StatusFailed, Message: err.Error()}}
+	}
+	m, err := manifest.Load(path)
+	if err != nil {
+		return nil, []Check{{Name: "manifest", Status: StatusFailed, Message: err.Error(), Fix: "fix the YAML syntax of " + path}}
+	}
+
+	checks := []Check{{Name: "manifest", Status: StatusOK, Message: fmt.Sprintf("%s registers %d services", path, len(m.Services))}}
+	var sources []Source
+	for _, service := range m.ServiceNames() {
+		var candidates []Source
+		if location, err := m.SpecPath(service); err != nil {
+			checks = append(checks, Check{Name: "spec " + service, Status: StatusFailed, Message: err.Error(),
+				Fix: "set the service's spec in " + path})
+		} else {
+			candidates = append(candidates, Source{Name: service, Location: location})
+		}
+		// Every version sorts after the empty one
+		for _, snapshot := range m.LaterSnapshots(service, "") {
+			if snapshot.Version != manifest.CurrentVersion {
+				candidates = append(candidates, Source{Name: service + "@" + snapshot.Version, Location: snapshot.Spec})
+			}
+		}
+
+		for _, source := range candidates {
+			if check, ok := reachable(source, path); !ok {
+				checks = append(checks, check)
+				continue
+			}
+			sources = append(sources, source)
+		}
+	}
+	return sources, checks
+}
+
+// reachable checks that the spec of source exists, or that its source
+// plugin is installed.
+func reachable(source Source, manifestPath string) (Check, bool) {
+	if name, _, ok := plugin.ParseSource(source.Location); ok {
+		if _, err := plugin.Find(name); err != nil {
+			return Check{Name: "spec " + source.Name, Status: StatusFailed, Message: err.Error(),
+				Fix: "install the plugin, or check the name in " + manifestPath}, false
+		}
+		return Check{}, true
+	}
+
+	info, err := os.Stat(source.Location)
+	if err != nil {
+		return Check{Name: "spec " + source.Name, Status: StatusFailed, Message: fmt.Sprintf("%s does not exist", source.Location),
+			Fix: "correct the location in " + manifestPath + "; relative locations are resolved from " + filepath.Dir(manifestPath)}, false
+	}
+	if info.IsDir() {
+		if _, err := spec.FindRoot(source.Location); err != nil {
+			return Check{Name: "spec " + source.Name, Status: StatusFailed, Message: err.Error(),
+				Fix: "point the location at the root spec file"}, false
+		}
+	}
+	return Check{}, true
+}
+
+// Spec checks that the spec of source loads with load and validates.
+func Spec(source Source, load func(string) (*openapi3.T, error)) Check {
+	name := "spec " + source.Name
+	doc, err := load(source.Location)
+	if err != nil {
+		var limitErr *spec.LimitError
+		fix := "fix the reported error; -offline and refs.allow can also block external refs"
+		if errors.As(err, &limitErr) {
+			fix = "raise refs." + limitErr.Limit + " in " + config.DefaultFile + " if the spec is trusted"
+		}
+		return Check{Name: name, Status: StatusFailed, Message: err.Error(), Fix: fix}
+	}
+
+	operations := 0
+	if doc.Paths != nil {
+		for _, pathItem := range doc.Paths.Map() {
+			operations += len(pathItem.Operations())
+		}
+	}
+	message := fmt.Sprintf("%s loads: %d operations", source.Location, operations)
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return Check{Name: name, Status: StatusWarning, Message: fmt.Sprintf("%s, but is not valid OpenAPI: %s", message, firstLine(err.Error())),
+			Fix: "docfinder renders it anyway; fix it so stricter tools accept it"}
+	}
+	return Check{Name: name, Status: StatusOK, Message: message}
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// Version reports the docfinder build and the plugin protocol it speaks,
+// which is what to compare when a plugin rejects its requests.
+func Version() Check {
+	version := "(unknown)"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		version = info.Main.Version
+	}
+	return Check{Name: "version", Status: StatusOK, Message: fmt.Sprintf("docfinder %s, %s, plugin protocol %d", version, runtime.Version(), plugin.ProtocolVersion)}
+}
+
+// Plugins lists the installed plugins.
+func Plugins() Check {
+	plugins := plugin.List()
+	if len(plugins) == 0 {
+		return Check{Name: "plugins", Status: StatusOK, Message: "none installed"}
+	}
+	names := make([]string, len(plugins))
+	for i, p := range plugins {
+		names[i] = p.Name
+	}
+	return Check{Name: "plugins", Status: StatusOK, Message: strings.Join(names, ", ")}
+}
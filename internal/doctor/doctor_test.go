@@ -0,0 +1,159 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		statuses []Status
+		contains string
+	}{
+		{"Valid", "refs:\n  offline: true\n", []Status{StatusOK}, "is valid"},
+		{"Empty", "", []Status{StatusOK}, "is valid"},
+		{"UnknownSetting", "vocabluary:\n  parameters: Params\n", []Status{StatusWarning}, "vocabluary"},
+		{"UnknownRequirement", "policy:\n  require: [summary, bogus]\n", []Status{StatusFailed}, "bogus"},
+		{"WebhookFormat", "watch:\n  webhooks:\n    - url: https://example.com\n      format: teams\n", []Status{StatusFailed}, "teams"},
+		{"Syntax", "refs: [\n", []Status{StatusFailed}, "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeFile(t, path, tt.content)
+
+			_, checks := Config(path)
+			if len(checks) != len(tt.statuses) {
+				t.Fatalf("Expected %d checks, got %v", len(tt.statuses), checks)
+			}
+			for i, check := range checks {
+				if check.Status != tt.statuses[i] {
+					t.Errorf("checks[%d].Status = %s, want %s", i, check.Status, tt.statuses[i])
+				}
+			}
+			if !strings.Contains(checks[0].Message, tt.contains) {
+				t.Errorf("Expected %q in message: %s", tt.contains, checks[0].Message)
+			}
+		})
+	}
+
+	t.Run("NoDefaultFile", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		if _, checks := Config(""); len(checks) != 1 || checks[0].Status != StatusOK {
+			t.Errorf("Expected the defaults to be ok, got %v", checks)
+		}
+	})
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "ref"), strings.Repeat("x", 2048))
+	if check := Cache(spec.RefPolicy{CacheDir: dir}); check.Status != StatusOK || !strings.Contains(check.Message, "1 cached refs (2 KiB)") {
+		t.Errorf("Unexpected check: %v", check)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	if check := Cache(spec.RefPolicy{CacheDir: missing}); check.Status != StatusOK {
+		t.Errorf("Expected a missing cache to be ok, got %v", check)
+	}
+	if check := Cache(spec.RefPolicy{CacheDir: missing, Offline: true}); check.Status != StatusWarning || check.Fix == "" {
+		t.Errorf("Expected a warning with a fix offline, got %v", check)
+	}
+
+	if check := Cache(spec.RefPolicy{CacheDir: filepath.Join(dir, "ref")}); check.Status != StatusFailed {
+		t.Errorf("Expected a file in place of the cache to fail, got %v", check)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "notify.yaml"), "openapi: 3.0.0\n")
+	writeFile(t, filepath.Join(dir, "specs.yaml"), `services:
+  notify: notify.yaml
+  billing:
+    spec: billing.yaml
+    versions:
+      "2023-10": notify.yaml
+  events: plugin:nonexistent-registry:events
+`)
+
+	sources, checks := Manifest(dir)
+
+	var names []string
+	for _, source := range sources {
+		names = append(names, source.Name)
+	}
+	if got := strings.Join(names, ","); got != "billing@2023-10,notify" {
+		t.Errorf("Reachable sources = %s, want billing@2023-10,notify", got)
+	}
+
+	failed := make(map[string]bool)
+	for _, check := range checks {
+		if check.Status == StatusFailed {
+			failed[check.Name] = true
+			if check.Fix == "" {
+				t.Errorf("Expected a fix for %v", check)
+			}
+		}
+	}
+	if !failed["spec billing"] || !failed["spec events"] || len(failed) != 2 {
+		t.Errorf("Expected billing and events to fail, got %v", checks)
+	}
+}
+
+func TestSpec(t *testing.T) {
+	source := Source{Name: "api", Location: "api.yaml"}
+
+	valid := func(string) (*openapi3.T, error) {
+		return openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          description: OK
+`))
+	}
+	if check := Spec(source, valid); check.Status != StatusOK || !strings.Contains(check.Message, "1 operations") {
+		t.Errorf("Unexpected check: %v", check)
+	}
+
+	invalid := func(string) (*openapi3.T, error) {
+		return &openapi3.T{OpenAPI: "3.0.0"}, nil
+	}
+	if check := Spec(source, invalid); check.Status != StatusWarning {
+		t.Errorf("Expected an invalid spec to warn, got %v", check)
+	}
+
+	tooLarge := func(string) (*openapi3.T, error) {
+		return nil, &spec.LimitError{Limit: "maxBytes", Max: 10, Location: "api.yaml"}
+	}
+	if check := Spec(source, tooLarge); check.Status != StatusFailed || !strings.Contains(check.Fix, "refs.maxBytes") {
+		t.Errorf("Expected the limit in the fix, got %v", check)
+	}
+
+	broken := func(string) (*openapi3.T, error) { return nil, errors.New("boom") }
+	if check := Spec(source, broken); check.Status != StatusFailed {
+		t.Errorf("Expected a load error to fail, got %v", check)
+	}
+}
@@ -0,0 +1,190 @@
+// Package endpoint describes a single operation of a spec for tools that
+// check infrastructure against it, such as the Terraform provider in
+// terraform-provider-docfinder: whether the endpoint exists, how it is
+// authenticated, and how long the upstream may take to answer.
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExtensionTimeout is the time the upstream may take to answer an operation,
+// a duration such as "30s" or a number of seconds. It may be set on an
+// operation, its path item, or the document, the most specific winning.
+const ExtensionTimeout = "x-timeout"
+
+// ErrNotFound is returned by Lookup when the spec has no such endpoint.
+var ErrNotFound = errors.New("endpoint not found")
+
+// Info is the metadata of an operation.
+type Info struct {
+	Method string
+	// Path is the path template of the operation, which may differ from
+	// the looked up path in its parameter names.
+	Path        string
+	OperationID string
+	Summary     string
+	Deprecated  bool
+	Tags        []string
+	// AuthRequired reports whether every security requirement of the
+	// operation names a scheme. It is false for public operations and for
+	// those where authentication is optional.
+	AuthRequired bool
+	// AuthSchemes are the security schemes any requirement names, sorted.
+	AuthSchemes []string
+	// Scopes are the scopes any requirement asks for, sorted.
+	Scopes []string
+	// Timeout is the x-timeout of the operation, zero if unset.
+	Timeout time.Duration
+	// Servers are the base URLs the operation is served from.
+	Servers []string
+}
+
+// Lookup returns the operation for method on path. path is a path template
+// of the spec, whose parameter names may differ from the spec's.
+func Lookup(doc *openapi3.T, method, path string) (*Info, error) {
+	method = strings.ToUpper(method)
+
+	template, item := findPath(doc.Paths, path)
+	if item == nil {
+		return nil, fmt.Errorf("%w: no path %s", ErrNotFound, path)
+	}
+	op := item.GetOperation(method)
+	if op == nil {
+		return nil, fmt.Errorf("%w: no %s operation for %s", ErrNotFound, method, template)
+	}
+
+	info := &Info{
+		Method:      method,
+		Path:        template,
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Deprecated:  op.Deprecated,
+		Tags:        op.Tags,
+	}
+
+	security := doc.Security
+	if op.Security != nil {
+		security = *op.Security
+	}
+	setAuth(info, security)
+
+	timeout, err := findTimeout(op.Extensions, item.Extensions, doc.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, template, err)
+	}
+	info.Timeout = timeout
+
+	servers := doc.Servers
+	if len(item.Servers) > 0 {
+		servers = item.Servers
+	}
+	if op.Servers != nil && len(*op.Servers) > 0 {
+		servers = *op.Servers
+	}
+	for _, server := range servers {
+		info.Servers = append(info.Servers, server.URL)
+	}
+	return info, nil
+}
+
+// findPath returns the path template matching path and its path item.
+func findPath(paths *openapi3.Paths, path string) (string, *openapi3.PathItem) {
+	if paths == nil {
+		return "", nil
+	}
+	if item := paths.Value(path); item != nil {
+		return path, item
+	}
+	item := paths.Find(path)
+	if item == nil {
+		return "", nil
+	}
+	for _, template := range paths.InMatchingOrder() {
+		if paths.Value(template) == item {
+			return template, item
+		}
+	}
+	return path, item
+}
+
+// setAuth records the schemes and scopes of the security requirements.
+func setAuth(info *Info, security openapi3.SecurityRequirements) {
+	schemes := make(map[string]bool)
+	scopes := make(map[string]bool)
+	info.AuthRequired = len(security) > 0
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			// An empty requirement allows anonymous calls
+			info.AuthRequired = false
+		}
+		for scheme, requirementScopes := range requirement {
+			schemes[scheme] = true
+			for _, scope := range requirementScopes {
+				scopes[scope] = true
+			}
+		}
+	}
+	info.AuthSchemes = sortedKeys(schemes)
+	info.Scopes = sortedKeys(scopes)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// findTimeout returns the first x-timeout of the extension maps, most
+// specific first.
+func findTimeout(extensions ...map[string]any) (time.Duration, error) {
+	for _, ext := range extensions {
+		value, ok := ext[ExtensionTimeout]
+		if !ok {
+			continue
+		}
+		return parseTimeout(value)
+	}
+	return 0, nil
+}
+
+// parseTimeout parses an x-timeout value: a duration string or a number of
+// seconds.
+func parseTimeout(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case float64:
+		return secondsTimeout(v)
+	case int:
+		return secondsTimeout(float64(v))
+	case string:
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return secondsTimeout(seconds)
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid %s %q: want a duration such as \"30s\"", ExtensionTimeout, v)
+		}
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid %s %v: want a duration such as \"30s\"", ExtensionTimeout, value)
+}
+
+func secondsTimeout(seconds float64) (time.Duration, error) {
+	if seconds < 0 {
+		return 0, fmt.Errorf("invalid %s %v: must not be negative", ExtensionTimeout, seconds)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
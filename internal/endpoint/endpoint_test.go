@@ -0,0 +1,112 @@
+package endpoint
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func endpointDoc() *openapi3.T {
+	adminOnly := openapi3.SecurityRequirements{{"oauth2": {"orders:admin"}}}
+	public := openapi3.SecurityRequirements{}
+	optional := openapi3.SecurityRequirements{{"api_key": {}}, {}}
+	return &openapi3.T{
+		Servers:    openapi3.Servers{{URL: "https://api.example.com"}},
+		Security:   openapi3.SecurityRequirements{{"api_key": {}}, {"oauth2": {"orders:read", "orders:write"}}},
+		Extensions: map[string]any{ExtensionTimeout: "10s"},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/orders/{order_id}", &openapi3.PathItem{
+				Extensions: map[string]any{ExtensionTimeout: 30.0},
+				Get:        &openapi3.Operation{OperationID: "getOrder", Summary: "Get an order", Tags: []string{"orders"}},
+				Delete: &openapi3.Operation{
+					OperationID: "deleteOrder",
+					Deprecated:  true,
+					Security:    &adminOnly,
+					Extensions:  map[string]any{ExtensionTimeout: "1m30s"},
+					Servers:     &openapi3.Servers{{URL: "https://admin.example.com"}},
+				},
+			}),
+			openapi3.WithPath("/health", &openapi3.PathItem{Get: &openapi3.Operation{OperationID: "health", Security: &public}}),
+			openapi3.WithPath("/catalog", &openapi3.PathItem{Get: &openapi3.Operation{OperationID: "catalog", Security: &optional}}),
+		),
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         Info
+	}{
+		{"get", "/orders/{id}", Info{
+			Method: "GET", Path: "/orders/{order_id}", OperationID: "getOrder", Summary: "Get an order", Tags: []string{"orders"},
+			AuthRequired: true, AuthSchemes: []string{"api_key", "oauth2"}, Scopes: []string{"orders:read", "orders:write"},
+			Timeout: 30 * time.Second, Servers: []string{"https://api.example.com"},
+		}},
+		{"DELETE", "/orders/{order_id}", Info{
+			Method: "DELETE", Path: "/orders/{order_id}", OperationID: "deleteOrder", Deprecated: true,
+			AuthRequired: true, AuthSchemes: []string{"oauth2"}, Scopes: []string{"orders:admin"},
+			Timeout: 90 * time.Second, Servers: []string{"https://admin.example.com"},
+		}},
+		{"GET", "/health", Info{
+			Method: "GET", Path: "/health", OperationID: "health",
+			Timeout: 10 * time.Second, Servers: []string{"https://api.example.com"},
+		}},
+		{"GET", "/catalog", Info{
+			Method: "GET", Path: "/catalog", OperationID: "catalog", AuthSchemes: []string{"api_key"},
+			Timeout: 10 * time.Second, Servers: []string{"https://api.example.com"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			got, err := Lookup(endpointDoc(), tt.method, tt.path)
+			if err != nil {
+				t.Fatalf("Lookup() error: %v", err)
+			}
+			if got.Method != tt.want.Method || got.Path != tt.want.Path || got.OperationID != tt.want.OperationID ||
+				got.Summary != tt.want.Summary || got.Deprecated != tt.want.Deprecated || got.AuthRequired != tt.want.AuthRequired ||
+				got.Timeout != tt.want.Timeout || !slices.Equal(got.Tags, tt.want.Tags) || !slices.Equal(got.AuthSchemes, tt.want.AuthSchemes) ||
+				!slices.Equal(got.Scopes, tt.want.Scopes) || !slices.Equal(got.Servers, tt.want.Servers) {
+				t.Errorf("Lookup() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup_NotFound(t *testing.T) {
+	for _, tt := range []struct{ method, path string }{
+		{"GET", "/invoices"},
+		{"POST", "/orders/{id}"},
+	} {
+		if _, err := Lookup(endpointDoc(), tt.method, tt.path); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Lookup(%s %s) error = %v, want ErrNotFound", tt.method, tt.path, err)
+		}
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value   any
+		want    time.Duration
+		wantErr bool
+	}{
+		{"45s", 45 * time.Second, false},
+		{"2.5", 2500 * time.Millisecond, false},
+		{5, 5 * time.Second, false},
+		{0.25, 250 * time.Millisecond, false},
+		{"soon", 0, true},
+		{"-1s", 0, true},
+		{-3.0, 0, true},
+		{true, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTimeout(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseTimeout(%v) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+// Package examplelint checks declared defaults and example payloads against
+// the schemas and descriptions they belong to, flagging values that
+// contradict them.
+package examplelint
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Rule identifiers reported in problems.
+const (
+	// RuleDefaultRange flags defaults outside minimum/maximum or length limits.
+	RuleDefaultRange = "default-range"
+	// RuleDefaultEnum flags defaults that are not one of the enum values.
+	RuleDefaultEnum = "default-enum"
+	// RuleDefaultDescription flags defaults the description contradicts,
+	// e.g. "Defaults to 20." with default: 50.
+	RuleDefaultDescription = "default-description"
+	// RuleExampleRange flags example values outside the schema's limits.
+	RuleExampleRange = "example-range"
+	// RuleExampleEnum flags example values that are not one of the enum values.
+	RuleExampleEnum = "example-enum"
+	// RuleExampleDescription flags example values the description says are
+	// impossible, e.g. 0 for "Must be positive."
+	RuleExampleDescription = "example-description"
+)
+
+// Problem is a default or example value contradicting its schema.
+type Problem struct {
+	Rule string `json:"rule"`
+	// Location is where the value is declared, e.g.
+	// "POST /orders requestBody application/json.examples.bulk".
+	Location string `json:"location"`
+	// Field is the offending field inside an example payload, e.g.
+	// "lines[0].qty". Empty for top-level values and defaults.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the problem as a single line.
+func (p Problem) String() string {
+	location := p.Location
+	if p.Field != "" {
+		location += " " + p.Field
+	}
+	return fmt.Sprintf("%s: %s (%s)", location, p.Message, p.Rule)
+}
+
+// Run checks every schema default and example in doc.
+func Run(doc *openapi3.T) []Problem {
+	l := &linter{seen: make(map[*openapi3.Schema]bool)}
+	if doc == nil {
+		return nil
+	}
+
+	if doc.Components != nil {
+		for _, name := range sortedKeys(doc.Components.Schemas) {
+			l.checkSchema("components.schemas."+name, doc.Components.Schemas[name])
+		}
+		for _, name := range sortedKeys(doc.Components.Parameters) {
+			if ref := doc.Components.Parameters[name]; ref != nil && ref.Value != nil {
+				l.checkParameter("components.parameters."+name, ref.Value)
+			}
+		}
+	}
+
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.InMatchingOrder() {
+			pathItem := doc.Paths.Value(path)
+			if pathItem == nil {
+				continue
+			}
+			l.checkParameters(path, pathItem.Parameters)
+			for _, method := range model.MethodOrder {
+				if op := pathItem.GetOperation(method); op != nil {
+					l.checkOperation(method+" "+path, op)
+				}
+			}
+		}
+	}
+
+	return l.problems
+}
+
+// linter accumulates problems while walking a document.
+type linter struct {
+	problems []Problem
+	seen     map[*openapi3.Schema]bool
+}
+
+func (l *linter) report(rule, location, field, format string, args ...any) {
+	l.problems = append(l.problems, Problem{
+		Rule:     rule,
+		Location: location,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) checkOperation(location string, op *openapi3.Operation) {
+	l.checkParameters(location, op.Parameters)
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		l.checkContent(location+" requestBody", op.RequestBody.Value.Content)
+	}
+
+	if op.Responses != nil {
+		for _, status := range sortedKeys(op.Responses.Map()) {
+			if ref := op.Responses.Value(status); ref != nil && ref.Value != nil {
+				l.checkContent(location+" responses."+status, ref.Value.Content)
+			}
+		}
+	}
+}
+
+func (l *linter) checkParameters(location string, params openapi3.Parameters) {
+	for _, ref := range params {
+		if ref != nil && ref.Value != nil {
+			l.checkParameter(location+" parameters."+ref.Value.Name, ref.Value)
+		}
+	}
+}
+
+func (l *linter) checkParameter(location string, param *openapi3.Parameter) {
+	if param.Schema != nil && param.Schema.Value != nil {
+		schema := param.Schema.Value
+		if param.Description != "" && schema.Description == "" {
+			// The parameter's description documents its schema.
+			described := *schema
+			described.Description = param.Description
+			schema = &described
+			if schema.Default != nil {
+				l.checkStatedDefault(location+".schema.default", schema.Default, param.Description)
+			}
+		}
+		if param.Example != nil {
+			l.checkValue(location+".example", "", param.Example, schema)
+		}
+		for _, name := range sortedKeys(param.Examples) {
+			if ex := param.Examples[name]; ex != nil && ex.Value != nil {
+				l.checkValue(location+".examples."+name, "", ex.Value.Value, schema)
+			}
+		}
+	}
+	l.checkSchema(location+".schema", param.Schema)
+	l.checkContent(location, param.Content)
+}
+
+func (l *linter) checkContent(location string, content openapi3.Content) {
+	for _, contentType := range sortedKeys(content) {
+		mediaType := content[contentType]
+		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+			continue
+		}
+		loc := location + " " + contentType
+		schema := mediaType.Schema.Value
+		if mediaType.Example != nil {
+			l.checkValue(loc+".example", "", mediaType.Example, schema)
+		}
+		for _, name := range sortedKeys(mediaType.Examples) {
+			if ex := mediaType.Examples[name]; ex != nil && ex.Value != nil {
+				l.checkValue(loc+".examples."+name, "", ex.Value.Value, schema)
+			}
+		}
+		l.checkSchema(loc+".schema", mediaType.Schema)
+	}
+}
+
+// checkSchema checks the default and example declared on each schema,
+// visiting each schema once.
+func (l *linter) checkSchema(location string, ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil || l.seen[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	l.seen[schema] = true
+
+	if schema.Default != nil {
+		l.checkDefault(location+".default", schema)
+	}
+	if schema.Example != nil {
+		l.checkValue(location+".example", "", schema.Example, schema)
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		l.checkSchema(location+".properties."+name, schema.Properties[name])
+	}
+	l.checkSchema(location+".items", schema.Items)
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i, sub := range group {
+			l.checkSchema(fmt.Sprintf("%s[%d]", location, i), sub)
+		}
+	}
+}
+
+// checkDefault reports a default outside the schema's limits or enum, or
+// one the description states differently.
+func (l *linter) checkDefault(location string, schema *openapi3.Schema) {
+	value := schema.Default
+	if msg := rangeViolation(value, schema); msg != "" {
+		l.report(RuleDefaultRange, location, "", "default %s %s", formatValue(value), msg)
+	}
+	if !inEnum(value, schema.Enum) {
+		l.report(RuleDefaultEnum, location, "", "default %s is not one of the enum values", formatValue(value))
+	}
+	l.checkStatedDefault(location, value, schema.Description)
+}
+
+// checkStatedDefault reports a default that differs from the one description
+// states in prose.
+func (l *linter) checkStatedDefault(location string, value any, description string) {
+	if stated, ok := statedDefault(description); ok && !sameValue(value, stated) {
+		l.report(RuleDefaultDescription, location, "", "default %s contradicts the description, which says it defaults to %s",
+			formatValue(value), stated)
+	}
+}
+
+// checkValue walks an example payload alongside its schema and reports
+// values outside limits, outside the enum, or contradicting the description.
+func (l *linter) checkValue(location, field string, value any, schema *openapi3.Schema) {
+	if schema == nil {
+		return
+	}
+
+	for _, sub := range schema.AllOf {
+		if sub != nil && sub.Value != nil {
+			l.checkValue(location, field, value, sub.Value)
+		}
+	}
+
+	if value == nil {
+		if msg := descriptionViolation(nil, schema.Description); msg != "" && !schema.Nullable {
+			l.report(RuleExampleDescription, location, field, "null contradicts the description: %s", msg)
+		}
+		return
+	}
+
+	if msg := rangeViolation(value, schema); msg != "" {
+		l.report(RuleExampleRange, location, field, "%s %s", formatValue(value), msg)
+	}
+	if !inEnum(value, schema.Enum) {
+		l.report(RuleExampleEnum, location, field, "%s is not one of the enum values", formatValue(value))
+	}
+	if msg := descriptionViolation(value, schema.Description); msg != "" {
+		l.report(RuleExampleDescription, location, field, "%s contradicts the description: %s", formatValue(value), msg)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			if prop := schema.Properties[key]; prop != nil && prop.Value != nil {
+				l.checkValue(location, joinField(field, key), v[key], prop.Value)
+			}
+		}
+	case []any:
+		if schema.Items != nil && schema.Items.Value != nil {
+			for i, item := range v {
+				l.checkValue(location, fmt.Sprintf("%s[%d]", field, i), item, schema.Items.Value)
+			}
+		}
+	}
+}
+
+// rangeViolation describes how value breaks the schema's numeric or length
+// limits, or returns empty string.
+func rangeViolation(value any, schema *openapi3.Schema) string {
+	if n, ok := number(value); ok {
+		if schema.Min != nil && (n < *schema.Min || schema.ExclusiveMin && n == *schema.Min) {
+			return "is below the minimum " + bound(*schema.Min, schema.ExclusiveMin)
+		}
+		if schema.Max != nil && (n > *schema.Max || schema.ExclusiveMax && n == *schema.Max) {
+			return "is above the maximum " + bound(*schema.Max, schema.ExclusiveMax)
+		}
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := uint64(len([]rune(v)))
+		if length < schema.MinLength {
+			return fmt.Sprintf("is shorter than minLength %d", schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return fmt.Sprintf("is longer than maxLength %d", *schema.MaxLength)
+		}
+	case []any:
+		length := uint64(len(v))
+		if length < schema.MinItems {
+			return fmt.Sprintf("has %d items, fewer than minItems %d", length, schema.MinItems)
+		}
+		if schema.MaxItems != nil && length > *schema.MaxItems {
+			return fmt.Sprintf("has %d items, more than maxItems %d", length, *schema.MaxItems)
+		}
+	}
+	return ""
+}
+
+func bound(n float64, exclusive bool) string {
+	s := strconv.FormatFloat(n, 'f', -1, 64)
+	if exclusive {
+		s += " (exclusive)"
+	}
+	return s
+}
+
+// inEnum reports whether value is one of enum. An empty enum allows anything.
+func inEnum(value any, enum []any) bool {
+	if len(enum) == 0 {
+		return true
+	}
+	for _, e := range enum {
+		if equalValues(value, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares decoded values, treating numbers of different Go
+// types as equal when their values are.
+func equalValues(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+var (
+	statedDefaultPattern = regexp.MustCompile(`(?i)\bdefaults?(?:\s+value)?(?:\s+(?:is|to)\s+|:\s*)` +
+		"(?:`([^`]+)`|'([^']+)'|\"([^\"]+)\"|(-?\\d+(?:\\.\\d+)?|true|false)\\b)")
+	atLeastPattern     = regexp.MustCompile(`(?i)\bmust be (?:at least|greater than or equal to|>=)\s*(-?\d+(?:\.\d+)?)`)
+	greaterThanPattern = regexp.MustCompile(`(?i)\bmust be (?:greater|more|larger) than\s*(-?\d+(?:\.\d+)?)`)
+	atMostPattern      = regexp.MustCompile(`(?i)\b(?:must be (?:at most|less than or equal to|<=)|must not exceed|cannot exceed|can't exceed)\s*(-?\d+(?:\.\d+)?)`)
+	lessThanPattern    = regexp.MustCompile(`(?i)\bmust be (?:less|smaller|fewer) than\s*(-?\d+(?:\.\d+)?)`)
+	positivePattern    = regexp.MustCompile(`(?i)\b(?:must be|always) positive\b|\bgreater than zero\b`)
+	nonNegativePattern = regexp.MustCompile(`(?i)\b(?:non-negative|never negative|cannot be negative|can't be negative|must not be negative)\b`)
+	nonEmptyPattern    = regexp.MustCompile(`(?i)\b(?:never empty|cannot be empty|can't be empty|must not be empty|non-empty)\b`)
+	nonNullPattern     = regexp.MustCompile(`(?i)\b(?:never null|cannot be null|can't be null|must not be null|non-null)\b`)
+)
+
+// statedDefault extracts the default a description states in prose, such as
+// "Defaults to `20`." Only quoted values, numbers, and booleans count, so
+// "Defaults to the caller's locale" is not mistaken for a literal.
+func statedDefault(description string) (string, bool) {
+	m := statedDefaultPattern.FindStringSubmatch(description)
+	if m == nil {
+		return "", false
+	}
+	for _, group := range m[1:] {
+		if group != "" {
+			return group, true
+		}
+	}
+	return "", false
+}
+
+// descriptionViolation returns the sentence fragment of description that
+// value contradicts, or empty string.
+func descriptionViolation(value any, description string) string {
+	if description == "" {
+		return ""
+	}
+
+	if value == nil {
+		if m := nonNullPattern.FindString(description); m != "" {
+			return m
+		}
+		return ""
+	}
+
+	if n, ok := number(value); ok {
+		if m := positivePattern.FindString(description); m != "" && n <= 0 {
+			return m
+		}
+		if m := nonNegativePattern.FindString(description); m != "" && n < 0 {
+			return m
+		}
+		if m := atLeastPattern.FindStringSubmatch(description); m != nil && n < parseFloat(m[1]) {
+			return m[0]
+		}
+		if m := greaterThanPattern.FindStringSubmatch(description); m != nil && n <= parseFloat(m[1]) {
+			return m[0]
+		}
+		if m := atMostPattern.FindStringSubmatch(description); m != nil && n > parseFloat(m[1]) {
+			return m[0]
+		}
+		if m := lessThanPattern.FindStringSubmatch(description); m != nil && n >= parseFloat(m[1]) {
+			return m[0]
+		}
+		return ""
+	}
+
+	empty := false
+	switch v := value.(type) {
+	case string:
+		empty = v == ""
+	case []any:
+		empty = len(v) == 0
+	case map[string]any:
+		empty = len(v) == 0
+	}
+	if m := nonEmptyPattern.FindString(description); m != "" && empty {
+		return m
+	}
+	return ""
+}
+
+// number converts numeric values decoded from JSON or YAML to float64.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// sameValue reports whether value equals the textual form s, comparing
+// numbers numerically.
+func sameValue(value any, s string) bool {
+	if n, ok := number(value); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return err == nil && f == n
+	}
+	return strings.EqualFold(fmt.Sprint(value), s)
+}
+
+// formatValue renders a value as compact JSON.
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func joinField(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+package examplelint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const lintSpec = `
+openapi: 3.0.3
+info: {title: Orders, version: 1.0.0}
+paths:
+  /orders:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          description: Page size. Defaults to 20.
+          schema: {type: integer, minimum: 1, maximum: 100, default: 50}
+        - name: sort
+          in: query
+          schema: {type: string, enum: [asc, desc], default: newest}
+      responses:
+        '200': {description: OK}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Order'}
+            examples:
+              bulk:
+                value:
+                  currency: usd
+                  note: ''
+                  lines:
+                    - {sku: A-1, qty: 0}
+                    - {sku: B-2, qty: 3}
+      responses:
+        '201': {description: Created}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        currency:
+          type: string
+          enum: [EUR, USD]
+        note:
+          type: string
+          description: Free-form note, never empty when present.
+        retries:
+          type: integer
+          maximum: 5
+          default: 10
+        locale:
+          type: string
+          description: Defaults to the caller's locale.
+          default: en
+        lines:
+          type: array
+          maxItems: 1
+          items:
+            type: object
+            properties:
+              sku: {type: string}
+              qty:
+                type: integer
+                description: Quantity ordered. Must be positive.
+`
+
+func TestRun(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(lintSpec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	var lines []string
+	for _, p := range Run(doc) {
+		lines = append(lines, p.String())
+	}
+	output := strings.Join(lines, "\n")
+
+	expected := []string{
+		"components.schemas.Order.properties.retries.default: default 10 is above the maximum 5 (default-range)",
+		`GET /orders parameters.limit.schema.default: default 50 contradicts the description, which says it defaults to 20 (default-description)`,
+		`GET /orders parameters.sort.schema.default: default "newest" is not one of the enum values (default-enum)`,
+		`POST /orders requestBody application/json.examples.bulk currency: "usd" is not one of the enum values (example-enum)`,
+		`POST /orders requestBody application/json.examples.bulk note: "" contradicts the description: never empty (example-description)`,
+		`POST /orders requestBody application/json.examples.bulk lines: [{"qty":0,"sku":"A-1"},{"qty":3,"sku":"B-2"}] has 2 items, more than maxItems 1 (example-range)`,
+		`POST /orders requestBody application/json.examples.bulk lines[0].qty: 0 contradicts the description: Must be positive (example-description)`,
+	}
+	for _, s := range expected {
+		if !strings.Contains(output, s) {
+			t.Errorf("Expected %q in output:\n%s", s, output)
+		}
+	}
+
+	unexpected := []string{"locale", "lines[1].qty", "limit.example"}
+	for _, s := range unexpected {
+		if strings.Contains(output, s) {
+			t.Errorf("Did not expect %q in output:\n%s", s, output)
+		}
+	}
+}
+
+func TestStatedDefault(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+		ok          bool
+	}{
+		{"Page size. Defaults to 20.", "20", true},
+		{"Sort order (default: `asc`).", "asc", true},
+		{"The default is 'EUR'.", "EUR", true},
+		{"Whether to expand. Default value is true", "true", true},
+		{"Defaults to the caller's locale.", "", false},
+		{"No default.", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			got, ok := statedDefault(tt.description)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("statedDefault(%q) = %q, %v, want %q, %v", tt.description, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Site is a documentation site the export is laid out for.
+type Site string
+
+// Documentation sites.
+const (
+	// SiteDocusaurus writes MDX files in a directory per tag, each with a
+	// _category_.json, and a SidebarFile listing them.
+	SiteDocusaurus Site = "docusaurus"
+)
+
+// ParseSite parses a documentation site name. The empty string means none.
+func ParseSite(s string) (Site, error) {
+	switch site := Site(strings.ToLower(strings.TrimSpace(s))); site {
+	case "", SiteDocusaurus:
+		return site, nil
+	}
+	return "", fmt.Errorf("unknown site: %s (expected docusaurus)", s)
+}
+
+// Docusaurus files.
+const (
+	// SidebarFile is the sidebar fragment written for SiteDocusaurus.
+	SidebarFile = "sidebars.js"
+	// CategoryFile holds the metadata of a tag's directory.
+	CategoryFile = "_category_.json"
+	// untaggedCategory collects operations without tags.
+	untaggedCategory = "Other"
+)
+
+// docusaurusSite lays out operations by their first tag.
+type docusaurusSite struct {
+	// categories are the tags in sidebar order: declared tags first, then
+	// undeclared ones by name, then untaggedCategory.
+	categories   []string
+	descriptions map[string]string
+}
+
+func newDocusaurusSite(doc *openapi3.T) *docusaurusSite {
+	s := &docusaurusSite{descriptions: make(map[string]string)}
+	seen := make(map[string]bool)
+	for _, t := range doc.Tags {
+		if t != nil && !seen[t.Name] {
+			seen[t.Name] = true
+			s.categories = append(s.categories, t.Name)
+			s.descriptions[t.Name] = t.Description
+		}
+	}
+
+	var undeclared []string
+	untagged := false
+	for _, op := range Operations(doc) {
+		switch tag := category(op); {
+		case tag == untaggedCategory:
+			untagged = true
+		case !seen[tag]:
+			seen[tag] = true
+			undeclared = append(undeclared, tag)
+		}
+	}
+	sort.Strings(undeclared)
+	s.categories = append(s.categories, undeclared...)
+	if untagged && !seen[untaggedCategory] {
+		s.categories = append(s.categories, untaggedCategory)
+	}
+	return s
+}
+
+// category returns the tag op is listed under: its first.
+func category(op Operation) string {
+	if len(op.Operation.Tags) == 0 || op.Operation.Tags[0] == "" {
+		return untaggedCategory
+	}
+	return op.Operation.Tags[0]
+}
+
+// categoryDir returns the directory of a tag's operations.
+func categoryDir(tag string) string {
+	if dir := generator.Slugify(tag); dir != "" {
+		return dir
+	}
+	return generator.Slugify(untaggedCategory)
+}
+
+// docID returns the id of an operation's doc, its file name without the
+// extension.
+func docID(op Operation) string {
+	return generator.Slugify(op.Method + "-" + op.Path)
+}
+
+// fileName returns the path of the MDX file op is exported to.
+func (s *docusaurusSite) fileName(op Operation) string {
+	return categoryDir(category(op)) + "/" + docID(op) + ".mdx"
+}
+
+// frontMatter returns the Docusaurus front matter of op's doc.
+func frontMatter(op Operation) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %s\n", docID(op))
+	fmt.Fprintf(&b, "title: %s\n", yamlString(title(op)))
+	fmt.Fprintf(&b, "sidebar_label: %s\n", yamlString(title(op)))
+	if len(op.Operation.Tags) > 0 {
+		tags := make([]string, len(op.Operation.Tags))
+		for i, tag := range op.Operation.Tags {
+			tags[i] = yamlString(tag)
+		}
+		fmt.Fprintf(&b, "tags: [%s]\n", strings.Join(tags, ", "))
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+// yamlString quotes s as a JSON string, which YAML reads as written.
+func yamlString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// sidebarCategory is a category in a Docusaurus sidebar.
+type sidebarCategory struct {
+	Type  string   `json:"type"`
+	Label string   `json:"label"`
+	Items []string `json:"items"`
+}
+
+// categoryMetadata is the content of a CategoryFile.
+type categoryMetadata struct {
+	Label    string        `json:"label"`
+	Position int           `json:"position"`
+	Link     *categoryLink `json:"link,omitempty"`
+}
+
+type categoryLink struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// assets returns the SidebarFile and the CategoryFile of every category
+// with operations, keyed by path.
+func (s *docusaurusSite) assets(files []FileEntry, idPrefix string) (map[string]string, error) {
+	items := make(map[string][]string)
+	for _, f := range files {
+		dir := path.Dir(f.Path)
+		items[dir] = append(items[dir], path.Join(idPrefix, strings.TrimSuffix(f.Path, path.Ext(f.Path))))
+	}
+
+	assets := make(map[string]string)
+	sidebar := []sidebarCategory{}
+	for _, tag := range s.categories {
+		dir := categoryDir(tag)
+		if len(items[dir]) == 0 {
+			continue
+		}
+		sidebar = append(sidebar, sidebarCategory{Type: "category", Label: tag, Items: items[dir]})
+		// Only the first tag slugged to a directory claims it
+		delete(items, dir)
+
+		metadata := categoryMetadata{
+			Label:    tag,
+			Position: len(sidebar),
+			Link:     &categoryLink{Type: "generated-index", Description: s.descriptions[tag]},
+		}
+		data, err := json.MarshalIndent(metadata, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		assets[dir+"/"+CategoryFile] = string(data) + "\n"
+	}
+
+	data, err := json.MarshalIndent(sidebar, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	assets[SidebarFile] = "// Generated by docfinder export. Spread into a sidebar, e.g.\n" +
+		"// api: require('./" + path.Join("docs", idPrefix, SidebarFile) + "'),\n" +
+		"module.exports = " + string(data) + ";\n"
+	return assets, nil
+}
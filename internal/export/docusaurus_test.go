@@ -0,0 +1,88 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestExport_Docusaurus(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	outDir := filepath.Join(dir, "out")
+	if err := os.WriteFile(specPath, []byte(exportSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(exportSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{
+		OutDir:      outDir,
+		SpecPath:    specPath,
+		Site:        SiteDocusaurus,
+		DocIDPrefix: "api",
+		Generate:    []generator.Option{generator.WithSnippets(generator.SnippetLanguages[0])},
+	}
+	index, _, err := Export(doc, opts)
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	for i, want := range []string{"events/get-events-id.mdx", "other/delete-events-id.mdx"} {
+		if index.Files[i].Path != want {
+			t.Errorf("Files[%d].Path = %q, want %q", i, index.Files[i].Path, want)
+		}
+	}
+	var assets []string
+	for _, a := range index.Assets {
+		assets = append(assets, a.Path)
+	}
+	if strings.Join(assets, ",") != "events/_category_.json,other/_category_.json,sidebars.js" {
+		t.Errorf("Unexpected assets: %v", assets)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	get := read("events/get-events-id.mdx")
+	for _, want := range []string{
+		"---\nid: get-events-id\ntitle: \"Get an event\"\nsidebar_label: \"Get an event\"\ntags: [\"events\"]\n---\n\n" + generator.MDXImports,
+		"## GET /events/\\{id\\}",
+		"<Tabs groupId=\"language\">\n<TabItem value=\"curl\" label=\"curl\">",
+	} {
+		if !strings.Contains(get, want) {
+			t.Errorf("Expected %q in output:\n%s", want, get)
+		}
+	}
+
+	sidebar := read(SidebarFile)
+	if !strings.Contains(sidebar, `"label": "events",`) || !strings.Contains(sidebar, `"api/other/delete-events-id"`) {
+		t.Errorf("Unexpected sidebar:\n%s", sidebar)
+	}
+	if category := read("events/" + CategoryFile); !strings.Contains(category, `"position": 1`) {
+		t.Errorf("Unexpected category metadata:\n%s", category)
+	}
+
+	// Removing the untagged operation removes its category
+	doc.Paths.Value("/events/{id}").Delete = nil
+	_, summary, err := Export(doc, opts)
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	if strings.Join(summary.Removed, ",") != "other/_category_.json,other/delete-events-id.mdx" {
+		t.Errorf("Unexpected removed files: %+v", summary)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "other")); !os.IsNotExist(err) {
+		t.Errorf("Expected the empty category directory to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,498 @@
+// Package export writes per-operation markdown documentation for a whole
+// spec to a directory, together with a machine-readable index.
+package export
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// IndexFile is the name of the index written alongside exported docs.
+const IndexFile = "index.json"
+
+// unsafeFileChars matches characters not kept in directory names, e.g. ":"
+// which Windows rejects.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._{}-]+`)
+
+// Options configures Export.
+type Options struct {
+	// OutDir is the directory files are written to. It is created if needed.
+	OutDir string
+	// SpecPath is the source spec, recorded in the index and hashed.
+	SpecPath string
+	// Generate configures rendering of each operation.
+	Generate []generator.Option
+	// Force rewrites every file, even those whose content hash matches the
+	// previous index.
+	Force bool
+	// SplitBy is the file layout. Empty means SplitByOperation.
+	SplitBy SplitBy
+	// Owners holds the teams owning each operation, keyed by
+	// generator.NoteKey, recorded in the index.
+	Owners map[string][]string
+	// Site lays the files out for a documentation site. Empty means plain
+	// markdown in the SplitBy layout.
+	Site Site
+	// DocIDPrefix is prepended to the doc ids in SiteDocusaurus sidebars:
+	// the path of OutDir inside the Docusaurus docs directory, e.g. "api".
+	DocIDPrefix string
+	// NameTemplate names the file of each operation instead of SplitBy,
+	// e.g. "{tag}/{method}-{path}.md". See TemplateName for placeholders.
+	NameTemplate string
+	// Concurrency is how many operations are rendered at once. Zero means
+	// one per CPU.
+	Concurrency int
+}
+
+// Config holds the export settings from the configuration file.
+type Config struct {
+	// Name is the default NameTemplate.
+	Name string `yaml:"name"`
+	// Concurrency is the default Concurrency.
+	Concurrency int `yaml:"concurrency"`
+}
+
+// SplitBy is a layout of exported files.
+type SplitBy string
+
+// File layouts.
+const (
+	// SplitByOperation writes every operation to a file named after its
+	// method and path, e.g. "get-events-event_id.md".
+	SplitByOperation SplitBy = "operation"
+	// SplitByMethod writes a directory per path with a file per method,
+	// e.g. "events__{event_id}/GET.md".
+	SplitByMethod SplitBy = "method"
+)
+
+// ParseSplitBy parses a file layout name.
+func ParseSplitBy(s string) (SplitBy, error) {
+	switch split := SplitBy(strings.ToLower(strings.TrimSpace(s))); split {
+	case SplitByOperation, SplitByMethod:
+		return split, nil
+	}
+	return "", fmt.Errorf("unknown split: %s (expected operation or method)", s)
+}
+
+// Summary counts what an export did to the output directory.
+type Summary struct {
+	// Updated are the files written because they are new or changed.
+	Updated []string
+	// Skipped are the files left untouched because they did not change.
+	Skipped []string
+	// Removed are the files of operations no longer in the spec.
+	Removed []string
+}
+
+// Index describes every file produced by an export.
+type Index struct {
+	Spec  SpecInfo    `json:"spec"`
+	Files []FileEntry `json:"files"`
+	// Assets are the files generated for a Site besides the operations,
+	// such as sidebars and category metadata.
+	Assets []AssetEntry `json:"assets,omitempty"`
+}
+
+// AssetEntry describes one generated file that is not an operation.
+type AssetEntry struct {
+	// Path is relative to the output directory, with forward slashes.
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// SpecInfo identifies the spec an export was generated from.
+type SpecInfo struct {
+	Path    string `json:"path"`
+	SHA256  string `json:"sha256"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// FileEntry describes one generated markdown file.
+type FileEntry struct {
+	// Path is relative to the output directory, with forward slashes.
+	Path        string   `json:"path"`
+	Method      string   `json:"method"`
+	Endpoint    string   `json:"endpoint"`
+	OperationID string   `json:"operationId,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Owners      []string `json:"owners,omitempty"`
+	Title       string   `json:"title"`
+	// Anchors are the GitHub-style heading anchors in the file, in order.
+	Anchors []string `json:"anchors"`
+	// SHA256 hashes the rendered file, so later exports can skip files
+	// whose operation did not change.
+	SHA256 string `json:"sha256,omitempty"`
+	// Fingerprint is the generator.OperationFingerprint of the operation,
+	// which changes only with its contract, not its descriptions.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// Export renders every operation in doc to its own markdown file under
+// opts.OutDir and writes an IndexFile describing them and a SearchIndexFile
+// for client-side search. Files whose content
+// hash matches the IndexFile of a previous export are not rewritten, and
+// files of operations removed since then are deleted.
+func Export(doc *openapi3.T, opts Options) (*Index, Summary, error) {
+	var summary Summary
+
+	specData, err := os.ReadFile(opts.SpecPath)
+	if err != nil {
+		return nil, summary, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	index := &Index{
+		Spec:  SpecInfo{Path: opts.SpecPath, SHA256: hash(specData)},
+		Files: []FileEntry{},
+	}
+	if doc.Info != nil {
+		index.Spec.Title = doc.Info.Title
+		index.Spec.Version = doc.Info.Version
+	}
+
+	if err := os.MkdirAll(opts.OutDir, 0o755); err != nil {
+		return nil, summary, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	previous, err := readPreviousHashes(opts.OutDir)
+	if err != nil {
+		return nil, summary, err
+	}
+
+	gen := generator.New(doc, opts.Generate...)
+
+	// write writes a file unless it is unchanged since the previous export,
+	// and returns its content hash.
+	write := func(name string, content []byte) (string, error) {
+		file := filepath.Join(opts.OutDir, filepath.FromSlash(name))
+		sum := hash(content)
+		unchanged := previous[name] == sum
+		delete(previous, name)
+
+		if !opts.Force && unchanged && fileExists(file) {
+			summary.Skipped = append(summary.Skipped, name)
+			return sum, nil
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(file, content, 0o644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		summary.Updated = append(summary.Updated, name)
+		return sum, nil
+	}
+
+	var site *docusaurusSite
+	if opts.Site == SiteDocusaurus {
+		site = newDocusaurusSite(doc)
+	}
+
+	ops := Operations(doc)
+	names := make([]string, len(ops))
+	owner := make(map[string]Operation)
+	for i, op := range ops {
+		switch {
+		case site != nil:
+			names[i] = site.fileName(op)
+		case opts.NameTemplate != "":
+			if names[i], err = TemplateName(opts.NameTemplate, op); err != nil {
+				return nil, summary, err
+			}
+		default:
+			names[i] = fileName(op, opts.SplitBy)
+		}
+		if other, ok := owner[names[i]]; ok {
+			return nil, summary, fmt.Errorf("%s %s and %s %s would both be written to %s", other.Method, other.Path, op.Method, op.Path, names[i])
+		}
+		owner[names[i]] = op
+	}
+
+	rendered, err := render(gen, ops, site != nil, opts.Concurrency)
+	if err != nil {
+		return nil, summary, err
+	}
+
+	search := make([]SearchDocument, 0, len(ops))
+	for i, op := range ops {
+		name, markdown := names[i], rendered[i].markdown
+		content := markdown
+		if site != nil {
+			content = frontMatter(op) + markdown
+		}
+		sum, err := write(name, []byte(content))
+		if err != nil {
+			return nil, summary, err
+		}
+
+		index.Files = append(index.Files, FileEntry{
+			Path:        name,
+			Method:      op.Method,
+			Endpoint:    op.Path,
+			OperationID: op.Operation.OperationID,
+			Tags:        op.Operation.Tags,
+			Owners:      opts.Owners[generator.NoteKey(op.Method, op.Path)],
+			Title:       title(op),
+			Anchors:     Anchors(markdown),
+			SHA256:      sum,
+			Fingerprint: rendered[i].fingerprint,
+		})
+		search = append(search, searchDocument(name, op))
+	}
+
+	if site != nil {
+		assets, err := site.assets(index.Files, opts.DocIDPrefix)
+		if err != nil {
+			return nil, summary, err
+		}
+		for _, name := range sortedKeys(assets) {
+			sum, err := write(name, []byte(assets[name]))
+			if err != nil {
+				return nil, summary, err
+			}
+			index.Assets = append(index.Assets, AssetEntry{Path: name, SHA256: sum})
+		}
+	}
+
+	// Only files the previous index listed are removed, never other files
+	// in the directory.
+	for _, name := range sortedKeys(previous) {
+		file := filepath.Join(opts.OutDir, filepath.FromSlash(name))
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, summary, fmt.Errorf("failed to remove %s: %w", name, err)
+		}
+		// Remove the path directory with its last file; fails if not empty
+		if dir := filepath.Dir(file); dir != filepath.Clean(opts.OutDir) {
+			_ = os.Remove(dir)
+		}
+		summary.Removed = append(summary.Removed, name)
+	}
+
+	if err := writeJSON(opts.OutDir, SearchIndexFile, search, opts.Force); err != nil {
+		return nil, summary, err
+	}
+	if err := writeJSON(opts.OutDir, IndexFile, index, opts.Force); err != nil {
+		return nil, summary, err
+	}
+	return index, summary, nil
+}
+
+// writeJSON writes v as indented JSON to the file name in dir, unless the
+// file already holds it and force is false.
+func writeJSON(dir, name string, v any, force bool) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	file := filepath.Join(dir, name)
+	if existing, err := os.ReadFile(file); err != nil || !bytes.Equal(existing, data) || force {
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// renderedOperation is an operation's documentation and fingerprint.
+type renderedOperation struct {
+	markdown    string
+	fingerprint string
+}
+
+// render renders ops, concurrency at a time, in MDX for a site. Results are
+// in the order of ops; the first error in that order is returned.
+func render(gen *generator.Generator, ops []Operation, mdx bool, concurrency int) ([]renderedOperation, error) {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	results := make([]renderedOperation, len(ops))
+	errs := make([]error, len(ops))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, op := range ops {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			opts := []generator.Option{generator.WithMethod(op.Method)}
+			if mdx {
+				opts = append(opts, generator.WithFormat(generator.FormatMDX))
+			}
+			markdown, err := gen.Generate(op.Path, op.PathItem, opts...)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to render %s %s: %w", op.Method, op.Path, err)
+				return
+			}
+			fingerprint, err := gen.OperationFingerprint(op.Path, op.Method)
+			results[i], errs[i] = renderedOperation{markdown, fingerprint}, err
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// readPreviousHashes returns the content hash of each file in the IndexFile
+// of a previous export to dir, keyed by file path. A missing index, or one
+// written before hashes were recorded, yields no hashes.
+func readPreviousHashes(dir string) (map[string]string, error) {
+	hashes := make(map[string]string)
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return hashes, nil
+		}
+		return nil, fmt.Errorf("failed to read previous %s: %w", IndexFile, err)
+	}
+
+	var previous Index
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("failed to parse previous %s: %w", IndexFile, err)
+	}
+	for _, f := range previous.Files {
+		// Paths come from a file on disk; never follow one out of dir.
+		if f.Path == "" || !filepath.IsLocal(filepath.FromSlash(f.Path)) {
+			continue
+		}
+		hashes[f.Path] = f.SHA256
+	}
+	for _, a := range previous.Assets {
+		if a.Path == "" || !filepath.IsLocal(filepath.FromSlash(a.Path)) {
+			continue
+		}
+		hashes[a.Path] = a.SHA256
+	}
+	return hashes, nil
+}
+
+// hash returns the hex-encoded SHA-256 of data.
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fileName returns the path of the file op is exported to, relative to the
+// output directory and with forward slashes.
+func fileName(op Operation, split SplitBy) string {
+	if split != SplitByMethod {
+		return generator.Slugify(op.Method+"-"+op.Path) + ".md"
+	}
+
+	dir := strings.Join(strings.Split(strings.Trim(op.Path, "/"), "/"), "__")
+	dir = unsafeFileChars.ReplaceAllString(dir, "-")
+	if dir == "" || strings.Trim(dir, ".") == "" {
+		dir = "_root" + dir
+	}
+	return dir + "/" + op.Method + ".md"
+}
+
+// namePlaceholder matches the placeholders of a NameTemplate.
+var namePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// TemplateName returns the path of the file op is exported to with the
+// name template tmpl, relative to the output directory. Placeholders are
+// replaced with the operation's:
+//
+//   - {method}: method in lowercase, e.g. "get"; {METHOD} in uppercase
+//   - {path}: path as a slug, e.g. "events-event_id"
+//   - {operationId}: operationId, or {method}-{path} if it has none
+//   - {tag}: first tag as a slug, or "other" if it has none
+//
+// ".md" is appended if the name has no extension.
+func TemplateName(tmpl string, op Operation) (string, error) {
+	var unknown string
+	name := namePlaceholder.ReplaceAllStringFunc(tmpl, func(placeholder string) string {
+		switch placeholder {
+		case "{method}":
+			return strings.ToLower(op.Method)
+		case "{METHOD}":
+			return strings.ToUpper(op.Method)
+		case "{path}":
+			if slug := generator.Slugify(op.Path); slug != "" {
+				return slug
+			}
+			return "root"
+		case "{operationId}":
+			if id := unsafeFileChars.ReplaceAllString(op.Operation.OperationID, "-"); strings.Trim(id, ".-") != "" {
+				return id
+			}
+			return generator.Slugify(op.Method + "-" + op.Path)
+		case "{tag}":
+			return generator.Slugify(category(op))
+		}
+		unknown = placeholder
+		return ""
+	})
+	if unknown != "" {
+		return "", fmt.Errorf("unknown placeholder %s in file name template %q (expected {method}, {METHOD}, {path}, {operationId}, or {tag})", unknown, tmpl)
+	}
+
+	if path.Ext(name) == "" {
+		name += ".md"
+	}
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", fmt.Errorf("file name template %q names %s, outside the output directory", tmpl, name)
+	}
+	if name == IndexFile {
+		return "", fmt.Errorf("file name template %q names the %s file", tmpl, IndexFile)
+	}
+	return name, nil
+}
+
+// title returns the operation summary, or "METHOD path" if it has none.
+func title(op Operation) string {
+	if op.Operation.Summary != "" {
+		return op.Operation.Summary
+	}
+	return op.Method + " " + op.Path
+}
+
+// Anchors returns the GitHub-style anchors of the markdown headings in md.
+// Repeated headings get "-1", "-2", ... suffixes as on GitHub. Headings inside
+// fenced code blocks are ignored.
+func Anchors(md string) []string {
+	anchors := []string{}
+	for _, h := range generator.Headings(md) {
+		anchors = append(anchors, h.Anchor)
+	}
+	return anchors
+}
@@ -0,0 +1,349 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const exportSpec = `openapi: 3.0.0
+info:
+  title: Events API
+  version: 2.1.0
+paths:
+  /events/{id}:
+    get:
+      operationId: getEvent
+      summary: Get an event
+      tags: [events]
+      responses:
+        '200':
+          description: OK
+    delete:
+      responses:
+        '204':
+          description: Deleted
+`
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(exportSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outDir := filepath.Join(dir, "out")
+	index, _, err := Export(doc, Options{OutDir: outDir, SpecPath: specPath})
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+
+	if index.Spec.Title != "Events API" || index.Spec.Version != "2.1.0" || len(index.Spec.SHA256) != 64 {
+		t.Errorf("Unexpected spec info: %+v", index.Spec)
+	}
+
+	if len(index.Files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(index.Files))
+	}
+
+	get := index.Files[0]
+	if get.Path != "get-events-id.md" || get.Method != "GET" || get.OperationID != "getEvent" || get.Title != "Get an event" {
+		t.Errorf("Unexpected GET entry: %+v", get)
+	}
+	if !reflect.DeepEqual(get.Tags, []string{"events"}) {
+		t.Errorf("Tags = %v, want [events]", get.Tags)
+	}
+	if len(get.Anchors) == 0 || get.Anchors[1] != "get-eventsid" {
+		t.Errorf("Unexpected anchors: %v", get.Anchors)
+	}
+	if len(get.Fingerprint) != 64 || get.Fingerprint == index.Files[1].Fingerprint {
+		t.Errorf("Unexpected fingerprints: %q, %q", get.Fingerprint, index.Files[1].Fingerprint)
+	}
+
+	if del := index.Files[1]; del.Path != "delete-events-id.md" || del.Title != "DELETE /events/{id}" {
+		t.Errorf("Unexpected DELETE entry: %+v", del)
+	}
+
+	for _, f := range index.Files {
+		if _, err := os.Stat(filepath.Join(outDir, f.Path)); err != nil {
+			t.Errorf("Expected exported file %s: %v", f.Path, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, IndexFile))
+	if err != nil {
+		t.Fatalf("Expected %s: %v", IndexFile, err)
+	}
+	var written Index
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Invalid %s: %v", IndexFile, err)
+	}
+	if !reflect.DeepEqual(&written, index) {
+		t.Errorf("Written index differs from returned index")
+	}
+}
+
+func TestExport_Incremental(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	outDir := filepath.Join(dir, "out")
+
+	export := func(spec string, force bool) Summary {
+		t.Helper()
+		if err := os.WriteFile(specPath, []byte(spec), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, summary, err := Export(doc, Options{OutDir: outDir, SpecPath: specPath, Force: force})
+		if err != nil {
+			t.Fatalf("Export() error: %v", err)
+		}
+		return summary
+	}
+
+	if summary := export(exportSpec, false); len(summary.Updated) != 2 || len(summary.Skipped) != 0 {
+		t.Fatalf("Expected every file written on first export, got %+v", summary)
+	}
+
+	if summary := export(exportSpec, false); len(summary.Updated) != 0 || len(summary.Skipped) != 2 {
+		t.Errorf("Expected every file skipped on unchanged export, got %+v", summary)
+	}
+
+	if summary := export(exportSpec, true); len(summary.Updated) != 2 {
+		t.Errorf("Expected -force to rewrite every file, got %+v", summary)
+	}
+
+	changed := strings.Replace(exportSpec, "summary: Get an event", "summary: Fetch an event", 1)
+	changed = strings.Replace(changed, "    delete:\n      responses:\n        '204':\n          description: Deleted\n", "", 1)
+	summary := export(changed, false)
+	if !reflect.DeepEqual(summary.Updated, []string{"get-events-id.md"}) || len(summary.Skipped) != 0 ||
+		!reflect.DeepEqual(summary.Removed, []string{"delete-events-id.md"}) {
+		t.Errorf("Expected GET updated and DELETE removed, got %+v", summary)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "delete-events-id.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected removed operation's file to be deleted, got %v", err)
+	}
+}
+
+func TestExport_SplitByMethod(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	outDir := filepath.Join(dir, "out")
+	if err := os.WriteFile(specPath, []byte(exportSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(exportSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, _, err := Export(doc, Options{OutDir: outDir, SpecPath: specPath, SplitBy: SplitByMethod})
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	for i, want := range []string{"events__{id}/GET.md", "events__{id}/DELETE.md"} {
+		if index.Files[i].Path != want {
+			t.Errorf("Files[%d].Path = %q, want %q", i, index.Files[i].Path, want)
+		}
+		if _, err := os.Stat(filepath.Join(outDir, filepath.FromSlash(want))); err != nil {
+			t.Errorf("Expected exported file %s: %v", want, err)
+		}
+	}
+
+	// Removing the path's operations removes its directory
+	doc.Paths.Delete("/events/{id}")
+	_, summary, err := Export(doc, Options{OutDir: outDir, SpecPath: specPath, SplitBy: SplitByMethod})
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	if len(summary.Removed) != 2 {
+		t.Errorf("Expected 2 files removed, got %+v", summary)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "events__{id}")); !os.IsNotExist(err) {
+		t.Errorf("Expected the empty path directory to be removed, got %v", err)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		method, path string
+		split        SplitBy
+		expected     string
+	}{
+		{"GET", "/events/{event_id}", SplitByOperation, "get-events-event_id.md"},
+		{"GET", "/events/{event_id}", SplitByMethod, "events__{event_id}/GET.md"},
+		{"PUT", "/v1/users:batch", SplitByMethod, "v1__users-batch/PUT.md"},
+		{"GET", "/", SplitByMethod, "_root/GET.md"},
+		{"GET", "/..", SplitByMethod, "_root../GET.md"},
+	}
+
+	for _, tt := range tests {
+		if got := fileName(Operation{Method: tt.method, Path: tt.path}, tt.split); got != tt.expected {
+			t.Errorf("fileName(%s %s, %s) = %q, want %q", tt.method, tt.path, tt.split, got, tt.expected)
+		}
+	}
+}
+
+func TestExport_NameTemplate(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	outDir := filepath.Join(dir, "out")
+	if err := os.WriteFile(specPath, []byte(exportSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(exportSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, _, err := Export(doc, Options{OutDir: outDir, SpecPath: specPath, NameTemplate: "{tag}/{METHOD}-{path}", Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	for i, want := range []string{"events/GET-events-id.md", "other/DELETE-events-id.md"} {
+		if index.Files[i].Path != want {
+			t.Errorf("Files[%d].Path = %q, want %q", i, index.Files[i].Path, want)
+		}
+		if _, err := os.Stat(filepath.Join(outDir, filepath.FromSlash(want))); err != nil {
+			t.Errorf("Expected exported file %s: %v", want, err)
+		}
+	}
+
+	_, _, err = Export(doc, Options{OutDir: outDir, SpecPath: specPath, NameTemplate: "{path}.md"})
+	if err == nil || !strings.Contains(err.Error(), "would both be written to events-id.md") {
+		t.Errorf("Expected error for colliding file names, got %v", err)
+	}
+}
+
+const searchSpec = `openapi: 3.0.0
+info: {title: Events API, version: 1.0.0}
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        label: {type: string}
+        children: {type: array, items: {$ref: '#/components/schemas/Node'}}
+paths:
+  /events/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+    put:
+      summary: Replace an event
+      parameters:
+        - {name: If-Match, in: header, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - type: object
+                  properties:
+                    kind: {type: string}
+                - type: object
+                  properties:
+                    payload: {$ref: '#/components/schemas/Node'}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  updated_at: {type: string}
+`
+
+func TestExport_SearchIndex(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	outDir := filepath.Join(dir, "out")
+	if err := os.WriteFile(specPath, []byte(searchSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Export(doc, Options{OutDir: outDir, SpecPath: specPath}); err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, SearchIndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var search []SearchDocument
+	if err := json.Unmarshal(data, &search); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []SearchDocument{{
+		ID:         "put-events-id.md",
+		Title:      "Replace an event",
+		Summary:    "Replace an event",
+		Method:     "PUT",
+		Endpoint:   "/events/{id}",
+		Parameters: []string{"id", "If-Match"},
+		Fields:     []string{"children", "kind", "label", "payload", "updated_at"},
+	}}
+	if !reflect.DeepEqual(search, expected) {
+		t.Errorf("Search index = %+v, want %+v", search, expected)
+	}
+}
+
+func TestTemplateName(t *testing.T) {
+	get := Operation{Method: "GET", Path: "/events/{event_id}", Operation: &openapi3.Operation{OperationID: "getEvent", Tags: []string{"Event Stream"}}}
+	root := Operation{Method: "GET", Path: "/", Operation: &openapi3.Operation{}}
+
+	tests := []struct {
+		tmpl     string
+		op       Operation
+		expected string
+		err      string
+	}{
+		{"{method}-{path}.md", get, "get-events-event_id.md", ""},
+		{"{tag}/{operationId}", get, "event-stream/getEvent.md", ""},
+		{"{METHOD}/{path}.mdx", get, "GET/events-event_id.mdx", ""},
+		{"{tag}/{operationId}", root, "other/get.md", ""},
+		{"{path}", root, "root.md", ""},
+		{"{verb}-{path}", get, "", "unknown placeholder {verb}"},
+		{"../{path}", get, "", "outside the output directory"},
+		{"index.json", get, "", "names the index.json file"},
+	}
+
+	for _, tt := range tests {
+		got, err := TemplateName(tt.tmpl, tt.op)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("TemplateName(%q) error = %v, want %q", tt.tmpl, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("TemplateName(%q, %s %s) = %q, %v, want %q", tt.tmpl, tt.op.Method, tt.op.Path, got, err, tt.expected)
+		}
+	}
+}
+
+func TestAnchors(t *testing.T) {
+	md := "# API: Events (v2)\n\n## GET /events/{id}\n\n```\n# not a heading\n```\n\n#### 200\n\n#### 200\n#nospace\n"
+	expected := []string{"api-events-v2", "get-eventsid", "200", "200-1"}
+
+	if result := Anchors(md); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Anchors() = %v, want %v", result, expected)
+	}
+}
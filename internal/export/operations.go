@@ -0,0 +1,41 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Operation is a single operation located in a spec.
+type Operation struct {
+	Method    string
+	Path      string
+	PathItem  *openapi3.PathItem
+	Operation *openapi3.Operation
+}
+
+// Operations returns every operation in doc, ordered by path and then method.
+func Operations(doc *openapi3.T) []Operation {
+	var ops []Operation
+	if doc == nil || doc.Paths == nil {
+		return ops
+	}
+
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, method := range model.MethodOrder {
+			if op := pathItem.GetOperation(method); op != nil {
+				ops = append(ops, Operation{Method: method, Path: path, PathItem: pathItem, Operation: op})
+			}
+		}
+	}
+
+	return ops
+}
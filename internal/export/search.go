@@ -0,0 +1,112 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SearchIndexFile is the name of the client-side search index written with
+// exported docs. It is an array of SearchDocument that lunr and MiniSearch
+// index as is, with "id" as the reference field.
+const SearchIndexFile = "search-index.json"
+
+// maxFieldDepth bounds how deep body schemas are searched for field names.
+const maxFieldDepth = 8
+
+// SearchDocument is the entry of one exported page in a search index.
+type SearchDocument struct {
+	// ID is the path of the page, relative to the output directory.
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Summary  string   `json:"summary,omitempty"`
+	Method   string   `json:"method"`
+	Endpoint string   `json:"endpoint"`
+	Tags     []string `json:"tags,omitempty"`
+	// Parameters are the names of the operation's parameters, including
+	// those of its path.
+	Parameters []string `json:"parameters,omitempty"`
+	// Fields are the property names of the operation's request and
+	// response bodies at any depth, sorted.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// searchDocument returns the search document of the page name documenting
+// op.
+func searchDocument(name string, op Operation) SearchDocument {
+	doc := SearchDocument{
+		ID:       name,
+		Title:    title(op),
+		Summary:  op.Operation.Summary,
+		Method:   op.Method,
+		Endpoint: op.Path,
+		Tags:     op.Operation.Tags,
+	}
+
+	seen := make(map[string]bool)
+	for _, params := range []openapi3.Parameters{op.PathItem.Parameters, op.Operation.Parameters} {
+		for _, ref := range params {
+			if ref != nil && ref.Value != nil && !seen[ref.Value.Name] {
+				seen[ref.Value.Name] = true
+				doc.Parameters = append(doc.Parameters, ref.Value.Name)
+			}
+		}
+	}
+
+	fields := make(map[string]bool)
+	visited := make(map[*openapi3.Schema]bool)
+	if body := op.Operation.RequestBody; body != nil && body.Value != nil {
+		collectContentFields(body.Value.Content, fields, visited)
+	}
+	if op.Operation.Responses != nil {
+		for _, resp := range op.Operation.Responses.Map() {
+			if resp != nil && resp.Value != nil {
+				collectContentFields(resp.Value.Content, fields, visited)
+			}
+		}
+	}
+	for field := range fields {
+		doc.Fields = append(doc.Fields, field)
+	}
+	sort.Strings(doc.Fields)
+	return doc
+}
+
+// collectContentFields adds the property names of the schemas of content
+// to fields.
+func collectContentFields(content openapi3.Content, fields map[string]bool, visited map[*openapi3.Schema]bool) {
+	for _, mediaType := range content {
+		if mediaType != nil && mediaType.Schema != nil {
+			collectFields(mediaType.Schema.Value, fields, visited, 0)
+		}
+	}
+}
+
+// collectFields adds the property names of schema, and of the schemas it
+// nests, to fields. Each schema is visited once, so recursive schemas end.
+func collectFields(schema *openapi3.Schema, fields map[string]bool, visited map[*openapi3.Schema]bool, depth int) {
+	if schema == nil || visited[schema] || depth > maxFieldDepth {
+		return
+	}
+	visited[schema] = true
+
+	for name, prop := range schema.Properties {
+		fields[name] = true
+		if prop != nil {
+			collectFields(prop.Value, fields, visited, depth+1)
+		}
+	}
+	if schema.Items != nil {
+		collectFields(schema.Items.Value, fields, visited, depth+1)
+	}
+	if schema.AdditionalProperties.Schema != nil {
+		collectFields(schema.AdditionalProperties.Schema.Value, fields, visited, depth+1)
+	}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref != nil {
+				collectFields(ref.Value, fields, visited, depth)
+			}
+		}
+	}
+}
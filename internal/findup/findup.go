@@ -0,0 +1,36 @@
+// Package findup finds configuration files such as manifests in a directory
+// or its parents, the way git finds its repository.
+package findup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned by Find when no file of the name exists in the
+// directory tree.
+var ErrNotFound = errors.New("file not found")
+
+// Find searches dir and its parents for a file called name and returns the
+// first match.
+func Find(dir, name string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+		dir = parent
+	}
+}
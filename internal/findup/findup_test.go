@@ -0,0 +1,28 @@
+package findup
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(filepath.Join(nested, "specs.yaml"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(root, "specs.yaml")
+	if err := os.WriteFile(want, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The directory of the same name in nested is skipped
+	if got, err := Find(nested, "specs.yaml"); err != nil || got != want {
+		t.Errorf("Find() = %q, %v, want %q", got, err, want)
+	}
+	if _, err := Find(nested, "missing.yaml"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package fuzzy ranks the paths or operationIds of a spec by their
+// similarity to one that matched none of them, for "did you mean"
+// suggestions.
+package fuzzy
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Close bounds the distance of close matches: paths differing by typos and
+// parameter spellings, but not by a whole segment.
+const Close = 1.0
+
+// MaxSuggestions is the number of suggestions an error lists.
+const MaxSuggestions = 5
+
+// Match is a path and its distance from the path looked up.
+type Match struct {
+	Path     string
+	Distance float64
+}
+
+// Distance returns the edit distance between two paths, counted in
+// segments. Adding or removing a segment costs 1, and replacing one costs
+// the share of its characters that differ, ignoring case, or 1 if most do.
+// Template parameters match each other whatever their names, and a literal
+// in place of a parameter costs 0.5, so "/events/42" is close to
+// "/events/{event_id}".
+func Distance(a, b string) float64 {
+	as, bs := segments(a), segments(b)
+
+	// prev and cur are rows of the edit distance matrix
+	prev := make([]float64, len(bs)+1)
+	cur := make([]float64, len(bs)+1)
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+	for i := 1; i <= len(as); i++ {
+		cur[0] = float64(i)
+		for j := 1; j <= len(bs); j++ {
+			cur[j] = min(
+				prev[j]+1,
+				cur[j-1]+1,
+				prev[j-1]+segmentCost(as[i-1], bs[j-1]),
+			)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(bs)]
+}
+
+// Rank returns up to n paths nearest to target, nearest first. Paths that
+// share nothing with target, costing as much as rewriting all of its
+// segments, are left out.
+func Rank(paths []string, target string, n int) []Match {
+	limit := float64(len(segments(target)))
+
+	var matches []Match
+	for _, p := range paths {
+		if d := Distance(p, target); d < limit {
+			matches = append(matches, Match{Path: p, Distance: d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Path < matches[j].Path
+	})
+	if len(matches) > n {
+		matches = matches[:n]
+	}
+	return matches
+}
+
+// Unique returns the only path closer than Close to target, reporting false
+// if none or several are.
+func Unique(paths []string, target string) (string, bool) {
+	var found string
+	count := 0
+	for _, p := range paths {
+		if Distance(p, target) < Close {
+			found = p
+			count++
+		}
+	}
+	return found, count == 1
+}
+
+// RankNames returns up to n names similar to target, such as operationIds,
+// nearest first. A name is similar if at most half of its characters
+// differ from target, ignoring case, or if one contains the other.
+func RankNames(names []string, target string, n int) []string {
+	type scored struct {
+		name  string
+		ratio float64
+	}
+	lower := strings.ToLower(target)
+
+	var matches []scored
+	for _, name := range names {
+		candidate := strings.ToLower(name)
+		ratio := float64(levenshtein(candidate, lower)) / float64(max(len([]rune(candidate)), len([]rune(lower)), 1))
+		if ratio <= 0.5 || strings.Contains(candidate, lower) || strings.Contains(lower, candidate) {
+			matches = append(matches, scored{name, ratio})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].ratio != matches[j].ratio {
+			return matches[i].ratio < matches[j].ratio
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var ranked []string
+	for _, m := range matches {
+		if len(ranked) == n {
+			break
+		}
+		if !slices.Contains(ranked, m.name) {
+			ranked = append(ranked, m.name)
+		}
+	}
+	return ranked
+}
+
+// DidYouMean formats suggestions for an error message: empty for none,
+// " (did you mean x?)" for one, and an indented list for more.
+func DidYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(" (did you mean %s?)", suggestions[0])
+	}
+	var b strings.Builder
+	b.WriteString("\nDid you mean one of these?")
+	for _, s := range suggestions {
+		b.WriteString("\n  " + s)
+	}
+	return b.String()
+}
+
+func segments(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// segmentCost returns the cost of replacing segment a with b.
+func segmentCost(a, b string) float64 {
+	switch pa, pb := isParam(a), isParam(b); {
+	case pa && pb:
+		return 0
+	case pa || pb:
+		return 0.5
+	}
+
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if a == b {
+		return 0
+	}
+	ratio := float64(levenshtein(a, b)) / float64(max(len([]rune(a)), len([]rune(b))))
+	if ratio > 0.5 {
+		return 1
+	}
+	return ratio
+}
+
+// levenshtein returns the number of rune edits turning a into b.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
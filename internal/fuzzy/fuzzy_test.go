@@ -0,0 +1,122 @@
+package fuzzy
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"/v1/events/{event_id}", "/v1/events/{id}", 0},
+		{"/v1/Events", "/v1/events", 0},
+		{"/v1/events/{event_id}", "/v1/events/42", 0.5},
+		{"/v1/events", "/v1/event", 1.0 / 6},
+		{"/v1/events/{id}", "/events/{id}", 1},
+		{"/v1/events", "/v1/users", 1},
+		{"/events", "/", 1},
+		{"/", "/", 0},
+	}
+
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Distance(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+var paths = []string{
+	"/v1/events",
+	"/v1/events/{event_id}",
+	"/v1/events/{event_id}/attendees",
+	"/v1/users",
+	"/v1/users/{user_id}",
+	"/health",
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		target string
+		n      int
+		want   []string
+	}{
+		{"/v1/event/{id}", 2, []string{"/v1/events/{event_id}", "/v1/users/{user_id}"}},
+		{"/events/{id}", 1, []string{"/v1/events/{event_id}"}},
+		{"/helth", 5, []string{"/health"}},
+		{"/metrics", 5, nil},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, m := range Rank(paths, tt.target, tt.n) {
+			got = append(got, m.Path)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Rank(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+		ok     bool
+	}{
+		{"/v1/event/{id}", "/v1/events/{event_id}", true},
+		{"/v1/evnts/{id}/attendee", "/v1/events/{event_id}/attendees", true},
+		{"/helth", "/health", true},
+		// /v1/events and /v1/users are both one segment away
+		{"/v1/{kind}", "", false},
+		// A missing segment is suggested but not picked
+		{"/events/{id}", "", false},
+		{"/metrics", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Unique(paths, tt.target)
+		if ok != tt.ok || ok && got != tt.want {
+			t.Errorf("Unique(%q) = %q, %v; want %q, %v", tt.target, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRankNames(t *testing.T) {
+	ids := []string{"getEvent", "listEvents", "deleteEvent", "getUser", "createEvent"}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"getEventById", []string{"getEvent"}},
+		{"GetEvents", []string{"getEvent", "listEvents", "createEvent", "deleteEvent"}},
+		{"event", []string{"getEvent", "listEvents", "createEvent", "deleteEvent"}},
+		{"health", nil},
+	}
+
+	for _, tt := range tests {
+		if got := RankNames(ids, tt.target, MaxSuggestions); !slices.Equal(got, tt.want) {
+			t.Errorf("RankNames(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	tests := []struct {
+		suggestions []string
+		want        string
+	}{
+		{nil, ""},
+		{[]string{"getEvent"}, " (did you mean getEvent?)"},
+		{[]string{"/a", "/b"}, "\nDid you mean one of these?\n  /a\n  /b"},
+	}
+
+	for _, tt := range tests {
+		if got := DidYouMean(tt.suggestions); got != tt.want {
+			t.Errorf("DidYouMean(%v) = %q, want %q", tt.suggestions, got, tt.want)
+		}
+	}
+}
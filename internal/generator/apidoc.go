@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// HeaderUntagged titles the section of operations without tags in a
+// full-API document.
+const HeaderUntagged = "Other Operations"
+
+// GenerateAPI renders every operation of the spec as one document: the API's
+// title, description, and servers, a table of contents, and a section per
+// tag, in the order of the spec's tags section, then tags it doesn't declare,
+// then operations without tags. Operations with several tags are listed
+// under their first. The table of contents is added whatever the number of
+// operations unless TOCMinOperations is 0. opts override the Generator's
+// defaults for this call only.
+func (g *Generator) GenerateAPI(opts ...Option) (string, error) {
+	r, err := g.prepare(opts)
+	if err != nil {
+		return "", err
+	}
+	if r.opts.Format == FormatJSONDocument || r.opts.Format == FormatGitHubComment {
+		return "", fmt.Errorf("documents of several paths are not available in the %s format", r.opts.Format)
+	}
+	if r.doc == nil || r.doc.Paths == nil {
+		return "", fmt.Errorf("no operations in the spec")
+	}
+
+	servers, err := FilterServers(r.doc.Servers, r.opts.Environment)
+	if err != nil {
+		return "", err
+	}
+
+	groups, count := r.tagGroups()
+	if count == 0 {
+		return "", fmt.Errorf("no operations in the spec")
+	}
+
+	var header strings.Builder
+	header.WriteString("# API Reference\n\n")
+	if info := r.doc.Info; info != nil {
+		fmt.Fprintf(&header, "**API:** %s %s\n\n", info.Title, info.Version)
+		if info.Description != "" {
+			fmt.Fprintf(&header, "%s\n\n", r.blockDescription(info.Description))
+		}
+	}
+	r.writeServers(&header, servers)
+
+	var sections strings.Builder
+	for _, group := range groups {
+		if group.tag == "" {
+			fmt.Fprintf(&sections, "## %s\n\n", HeaderUntagged)
+		} else {
+			info := r.tagInfo(group.tag)
+			fmt.Fprintf(&sections, "## %s\n\n", group.tag)
+			r.writeTagDescription(&sections, info)
+		}
+
+		// Operations nest under their tag, one heading level down
+		var operations strings.Builder
+		for _, op := range group.operations {
+			r.writeOperationSafely(&operations, op.method, op.path, op.operation)
+		}
+		sections.WriteString(demoteHeadings(operations.String()))
+	}
+
+	return r.finish(header.String(), sections.String(), r.opts.TOCMinOperations > 0), nil
+}
+
+// tagGroup is the operations of a full-API document listed under a tag, or
+// under no tag if tag is empty.
+type tagGroup struct {
+	tag        string
+	operations []tagOperation
+}
+
+// tagOperation is an operation of a tagGroup.
+type tagOperation struct {
+	method, path string
+	operation    *openapi3.Operation
+}
+
+// tagGroups groups the rendered operations of the spec by their first tag,
+// ordered by path within each group, and returns the groups with the number
+// of operations.
+func (g *Generator) tagGroups() ([]*tagGroup, int) {
+	paths := g.doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+
+	byTag := make(map[string]*tagGroup)
+	var undeclared []string
+	count := 0
+	for _, path := range paths {
+		pathItem := g.doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+		for _, method := range model.MethodOrder {
+			operation := pathItem.GetOperation(method)
+			if operation == nil {
+				continue
+			}
+			if g.opts.Method != "" && method != g.opts.Method {
+				continue
+			}
+			if !availableIn(operation.Extensions, g.opts.MinVersion) {
+				continue
+			}
+
+			tag := ""
+			if len(operation.Tags) > 0 {
+				tag = operation.Tags[0]
+				if info := g.tagInfo(tag); info != nil {
+					tag = info.Name
+				}
+			}
+			group, ok := byTag[tag]
+			if !ok {
+				group = &tagGroup{tag: tag}
+				byTag[tag] = group
+				if tag != "" && g.tagInfo(tag) == nil {
+					undeclared = append(undeclared, tag)
+				}
+			}
+			group.operations = append(group.operations, tagOperation{method, path, operation})
+			count++
+		}
+	}
+
+	var groups []*tagGroup
+	for _, info := range g.doc.Tags {
+		if info == nil {
+			continue
+		}
+		if group, ok := byTag[info.Name]; ok && !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, tag := range undeclared {
+		groups = append(groups, byTag[tag])
+	}
+	if group, ok := byTag[""]; ok {
+		groups = append(groups, group)
+	}
+	return groups, count
+}
+
+// demoteHeadings moves every markdown heading of md outside fenced code
+// blocks one level down, e.g. "## GET /events" to "### GET /events".
+func demoteHeadings(md string) string {
+	lines := strings.Split(md, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		text := strings.TrimLeft(line, "#")
+		if text != line && strings.HasPrefix(text, " ") && len(line)-len(text) < 6 {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateAPI(t *testing.T) {
+	op := func(summary string, tags ...string) *openapi3.Operation {
+		return &openapi3.Operation{Summary: summary, Tags: tags, Responses: openapi3.NewResponses()}
+	}
+	doc := &openapi3.T{
+		Info: &openapi3.Info{Title: "Accounts API", Version: "2.0.0", Description: "Manage accounts."},
+		Tags: openapi3.Tags{
+			{Name: "Users", Description: "People who sign in."},
+			{Name: "Events", Description: "Events record what happened to an account."},
+		},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/events", &openapi3.PathItem{Get: op("List events", "events", "Users"), Post: op("Create an event", "Events")}),
+			openapi3.WithPath("/users", &openapi3.PathItem{Get: op("List users", "Users")}),
+			openapi3.WithPath("/health", &openapi3.PathItem{Get: op("Check health")}),
+			openapi3.WithPath("/billing", &openapi3.PathItem{Get: op("Get billing", "Billing")}),
+		),
+	}
+
+	markdown, err := New(doc).GenerateAPI()
+	if err != nil {
+		t.Fatalf("GenerateAPI() error: %v", err)
+	}
+	for _, s := range []string{
+		"# API Reference\n\n**API:** Accounts API 2.0.0\n\nManage accounts.\n",
+		HeaderContents,
+		"- [Users](#users)\n  - [GET /users](#get-users)\n",
+		"## Events\n\nEvents record what happened to an account.\n\n### GET /events\n",
+		"### POST /events\n",
+		"## " + HeaderUntagged + "\n\n### GET /health\n",
+	} {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Expected %q in output:\n%s", s, markdown)
+		}
+	}
+	if n := strings.Count(markdown, "### GET /events\n"); n != 1 {
+		t.Errorf("Expected GET /events once, under its first tag, got %d times:\n%s", n, markdown)
+	}
+
+	// Declared tags in order, then undeclared tags, then untagged operations
+	var order []int
+	for _, s := range []string{"## Users\n", "## Events\n", "## Billing\n", "## " + HeaderUntagged + "\n"} {
+		order = append(order, strings.Index(markdown, s))
+	}
+	for i := 1; i < len(order); i++ {
+		if order[i-1] < 0 || order[i-1] > order[i] {
+			t.Errorf("Expected tag sections in declaration order, got positions %v:\n%s", order, markdown)
+			break
+		}
+	}
+
+	// Even a single operation gets a table of contents, unless disabled
+	markdown, err = New(doc, WithMethod("POST")).GenerateAPI()
+	if err != nil || !strings.Contains(markdown, HeaderContents) || strings.Contains(markdown, "### GET") {
+		t.Errorf("Expected only POST /events with a table of contents, got %v:\n%s", err, markdown)
+	}
+	if markdown, _ := New(doc, WithTOCMinOperations(0)).GenerateAPI(); strings.Contains(markdown, HeaderContents) {
+		t.Errorf("Expected no table of contents with WithTOCMinOperations(0):\n%s", markdown)
+	}
+
+	if _, err := New(doc, WithFormat(FormatJSONDocument)).GenerateAPI(); err == nil {
+		t.Error("Expected error for the json format")
+	}
+}
+
+func TestDemoteHeadings(t *testing.T) {
+	md := "## GET /events\n\n### Responses\n\n```bash\n# a comment\n```\n#hashtag\n"
+	expected := "### GET /events\n\n#### Responses\n\n```bash\n# a comment\n```\n#hashtag\n"
+	if got := demoteHeadings(md); got != expected {
+		t.Errorf("demoteHeadings() = %q, want %q", got, expected)
+	}
+}
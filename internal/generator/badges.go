@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// BadgeStyle configures a single shields.io badge.
+type BadgeStyle struct {
+	// Label is the left-hand text of the badge.
+	Label string `yaml:"label"`
+	// Color is the default shields.io color for the badge value.
+	Color string `yaml:"color"`
+	// Extension is the vendor extension the badge value is read from
+	// (only used by extension-driven badges such as stability and since).
+	Extension string `yaml:"extension"`
+	// Disabled hides the badge entirely.
+	Disabled bool `yaml:"disabled"`
+}
+
+// BadgeConfig configures the badges rendered at the top of each operation.
+type BadgeConfig struct {
+	Stability  BadgeStyle `yaml:"stability"`
+	Auth       BadgeStyle `yaml:"auth"`
+	Deprecated BadgeStyle `yaml:"deprecated"`
+	Since      BadgeStyle `yaml:"since"`
+
+	// Colors maps badge values (e.g. "beta", "required") to shields.io colors,
+	// overriding the per-badge default color.
+	Colors map[string]string `yaml:"colors"`
+}
+
+// DefaultBadgeConfig returns the built-in badge configuration.
+func DefaultBadgeConfig() BadgeConfig {
+	return BadgeConfig{
+		Stability:  BadgeStyle{Label: "stability", Color: "blue", Extension: "x-stability"},
+		Auth:       BadgeStyle{Label: "auth", Color: "blue"},
+		Deprecated: BadgeStyle{Label: "deprecated", Color: "red"},
+		Since:      BadgeStyle{Label: "since", Color: "informational", Extension: "x-since"},
+		Colors: map[string]string{
+			"stable":       "brightgreen",
+			"beta":         "yellow",
+			"alpha":        "orange",
+			"experimental": "orange",
+			"required":     "orange",
+			"optional":     "yellowgreen",
+			"none":         "lightgrey",
+		},
+	}
+}
+
+// withDefaults fills unset fields of c from DefaultBadgeConfig.
+func (c BadgeConfig) withDefaults() BadgeConfig {
+	def := DefaultBadgeConfig()
+
+	c.Stability = c.Stability.withDefaults(def.Stability)
+	c.Auth = c.Auth.withDefaults(def.Auth)
+	c.Deprecated = c.Deprecated.withDefaults(def.Deprecated)
+	c.Since = c.Since.withDefaults(def.Since)
+
+	colors := make(map[string]string, len(def.Colors)+len(c.Colors))
+	for value, color := range def.Colors {
+		colors[value] = color
+	}
+	for value, color := range c.Colors {
+		colors[strings.ToLower(value)] = color
+	}
+	c.Colors = colors
+
+	return c
+}
+
+// withDefaults fills unset fields of s from def.
+func (s BadgeStyle) withDefaults(def BadgeStyle) BadgeStyle {
+	if s.Label == "" {
+		s.Label = def.Label
+	}
+	if s.Color == "" {
+		s.Color = def.Color
+	}
+	if s.Extension == "" {
+		s.Extension = def.Extension
+	}
+	return s
+}
+
+// writeBadges writes the shields.io badge line for an operation.
+func (g *Generator) writeBadges(md *strings.Builder, operation *openapi3.Operation) {
+	if g.opts.Badges == nil {
+		return
+	}
+
+	cfg := g.opts.Badges
+	var badges []string
+
+	if !cfg.Stability.Disabled {
+		if value := extensionString(operation.Extensions, cfg.Stability.Extension); value != "" {
+			badges = append(badges, FormatBadge(cfg.Stability.Label, value, cfg.color(cfg.Stability, value)))
+		}
+	}
+
+	if !cfg.Auth.Disabled {
+		value := authRequirement(operation.Security, g.doc.Security)
+		badges = append(badges, FormatBadge(cfg.Auth.Label, value, cfg.color(cfg.Auth, value)))
+	}
+
+	if !cfg.Deprecated.Disabled && operation.Deprecated {
+		badges = append(badges, FormatBadge(cfg.Deprecated.Label, "yes", cfg.color(cfg.Deprecated, "yes")))
+	}
+
+	if !cfg.Since.Disabled {
+		if value := extensionString(operation.Extensions, cfg.Since.Extension); value != "" {
+			badges = append(badges, FormatBadge(cfg.Since.Label, value, cfg.color(cfg.Since, value)))
+		}
+	}
+
+	if len(badges) == 0 {
+		return
+	}
+
+	md.WriteString(strings.Join(badges, " "))
+	md.WriteString("\n\n")
+}
+
+// color returns the configured color for a badge value.
+func (c *BadgeConfig) color(style BadgeStyle, value string) string {
+	if color, ok := c.Colors[strings.ToLower(value)]; ok {
+		return color
+	}
+	return style.Color
+}
+
+// FormatBadge returns shields.io badge markdown for a label/value pair.
+func FormatBadge(label, value, color string) string {
+	return fmt.Sprintf("![%s: %s](https://img.shields.io/badge/%s-%s-%s)",
+		label, value, shieldsEscape(label), shieldsEscape(value), url.PathEscape(color))
+}
+
+// shieldsEscape escapes text for use in a shields.io static badge path,
+// where dashes and underscores are separators and must be doubled.
+func shieldsEscape(s string) string {
+	s = strings.ReplaceAll(s, "-", "--")
+	s = strings.ReplaceAll(s, "_", "__")
+	s = strings.ReplaceAll(s, " ", "_")
+	return url.PathEscape(s)
+}
+
+// authRequirement reports whether an operation requires authentication:
+// "required", "optional" (an empty requirement is allowed), or "none".
+// Operation-level security overrides the document-level default.
+func authRequirement(opSecurity *openapi3.SecurityRequirements, docSecurity openapi3.SecurityRequirements) string {
+	requirements := docSecurity
+	if opSecurity != nil {
+		requirements = *opSecurity
+	}
+
+	if len(requirements) == 0 {
+		return "none"
+	}
+
+	for _, req := range requirements {
+		if len(req) == 0 {
+			return "optional"
+		}
+	}
+	return "required"
+}
+
+// extensionString returns a vendor extension value as a string,
+// or empty string if the extension is absent.
+func extensionString(extensions map[string]any, name string) string {
+	if name == "" || extensions == nil {
+		return ""
+	}
+
+	value, ok := extensions[name]
+	if !ok || value == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(fmt.Sprint(value))
+}
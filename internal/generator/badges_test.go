@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFormatBadge(t *testing.T) {
+	tests := []struct {
+		label, value, color string
+		expected            string
+	}{
+		{"stability", "beta", "yellow", "![stability: beta](https://img.shields.io/badge/stability-beta-yellow)"},
+		{"since", "v2.3-rc", "blue", "![since: v2.3-rc](https://img.shields.io/badge/since-v2.3--rc-blue)"},
+		{"auth", "api key", "orange", "![auth: api key](https://img.shields.io/badge/auth-api_key-orange)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result := FormatBadge(tt.label, tt.value, tt.color)
+			if result != tt.expected {
+				t.Errorf("FormatBadge() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateMarkdown_Badges(t *testing.T) {
+	doc := &openapi3.T{
+		Info:     &openapi3.Info{Title: "Test API", Version: "1.0.0"},
+		Security: openapi3.SecurityRequirements{{"bearerAuth": []string{}}},
+	}
+
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary:    "Get item",
+			Deprecated: true,
+			Extensions: map[string]any{
+				"x-stability": "beta",
+				"x-since":     "2.3",
+			},
+		},
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/items", pathItem, "")
+		if strings.Contains(markdown, "img.shields.io") {
+			t.Error("Did not expect badges unless enabled")
+		}
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		gen := New(doc, WithBadges(BadgeConfig{}))
+		markdown := gen.GenerateMarkdown("/items", pathItem, "")
+
+		for _, want := range []string{
+			"badge/stability-beta-yellow",
+			"badge/auth-required-orange",
+			"badge/deprecated-yes-red",
+			"badge/since-2.3-informational",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected badge %q in output", want)
+			}
+		}
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		gen := New(doc, WithBadges(BadgeConfig{
+			Stability: BadgeStyle{Label: "maturity"},
+			Since:     BadgeStyle{Disabled: true},
+			Colors:    map[string]string{"Beta": "purple"},
+		}))
+		markdown := gen.GenerateMarkdown("/items", pathItem, "")
+
+		if !strings.Contains(markdown, "badge/maturity-beta-purple") {
+			t.Error("Expected relabelled stability badge with configured color")
+		}
+		if strings.Contains(markdown, "badge/since-") {
+			t.Error("Did not expect disabled since badge")
+		}
+	})
+}
+
+func TestAuthRequirement(t *testing.T) {
+	docSecurity := openapi3.SecurityRequirements{{"apiKey": []string{}}}
+	none := openapi3.SecurityRequirements{}
+	optional := openapi3.SecurityRequirements{{}, {"apiKey": []string{}}}
+
+	tests := []struct {
+		name        string
+		opSecurity  *openapi3.SecurityRequirements
+		docSecurity openapi3.SecurityRequirements
+		expected    string
+	}{
+		{"inherits document security", nil, docSecurity, "required"},
+		{"no security anywhere", nil, nil, "none"},
+		{"operation opts out", &none, docSecurity, "none"},
+		{"empty requirement allowed", &optional, nil, "optional"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := authRequirement(tt.opSecurity, tt.docSecurity)
+			if result != tt.expected {
+				t.Errorf("authRequirement() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
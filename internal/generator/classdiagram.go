@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentSchemaPrefix prefixes $refs to component schemas.
+const componentSchemaPrefix = "#/components/schemas/"
+
+// classDiagram collects the classes and edges of a Mermaid class diagram.
+type classDiagram struct {
+	classes map[string]*diagramClass
+	order   []string
+	edges   []string
+	seen    map[string]bool
+	// propertyOrder is the order fields are listed in.
+	propertyOrder PropertyOrder
+}
+
+// diagramClass is a schema drawn as a class with its properties as fields.
+type diagramClass struct {
+	name   string
+	fields []string
+}
+
+// writeSchemaDiagram writes a Mermaid class diagram of the schemas used by
+// the operation's request and response bodies. Component schemas become
+// classes; properties referencing other schemas become associations, allOf
+// references inheritance, and oneOf/anyOf references dependencies. Inline
+// bodies are drawn as classes named after where they appear.
+func (g *Generator) writeSchemaDiagram(md *strings.Builder, operation *openapi3.Operation) {
+	d := &classDiagram{classes: make(map[string]*diagramClass), seen: make(map[string]bool), propertyOrder: g.opts.PropertyOrder}
+
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		d.addContent("Request", operation.RequestBody.Value.Content, g.opts.includesContentType)
+	}
+	if operation.Responses != nil {
+		for _, status := range getSortedStatusCodes(operation.Responses.Map()) {
+			if ref := operation.Responses.Value(status); ref != nil && ref.Value != nil {
+				rendered := g.responseContentTypes(ref.Value.Content)
+				d.addContent("Response"+status, ref.Value.Content, func(contentType string) bool {
+					return slices.Contains(rendered, contentType)
+				})
+			}
+		}
+	}
+
+	if len(d.order) == 0 {
+		return
+	}
+
+	md.WriteString(heading(g.opts.Vocabulary.SchemaDiagram))
+	md.WriteString("```mermaid\nclassDiagram\n")
+	for _, name := range d.order {
+		class := d.classes[name]
+		if len(class.fields) == 0 {
+			fmt.Fprintf(md, "    class %s\n", name)
+			continue
+		}
+		fmt.Fprintf(md, "    class %s {\n", name)
+		for _, field := range class.fields {
+			fmt.Fprintf(md, "        %s\n", field)
+		}
+		md.WriteString("    }\n")
+	}
+	for _, edge := range d.edges {
+		fmt.Fprintf(md, "    %s\n", edge)
+	}
+	md.WriteString("```\n\n")
+}
+
+// addContent adds the body schema of each included media type.
+func (d *classDiagram) addContent(fallback string, content openapi3.Content, include func(string) bool) {
+	for _, contentType := range getSortedContentTypes(content) {
+		mediaType := content[contentType]
+		if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil || !include(contentType) {
+			continue
+		}
+		if isStructured(mediaType.Schema) {
+			d.class(fallback, mediaType.Schema)
+		}
+	}
+}
+
+// isStructured reports whether a body schema is worth drawing: a component
+// schema, an object, a composition, or an array of one of those.
+func isStructured(ref *openapi3.SchemaRef) bool {
+	if componentName(ref) != "" {
+		return true
+	}
+	s := ref.Value
+	if s.Items != nil && s.Items.Value != nil && s.Type.Is(openapi3.TypeArray) {
+		return isStructured(s.Items)
+	}
+	return len(s.Properties) > 0 || len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0
+}
+
+// class returns the class name for a schema, adding the class on first use.
+// Component schemas are named after the component, inline ones fallback.
+func (d *classDiagram) class(fallback string, ref *openapi3.SchemaRef) string {
+	name := componentName(ref)
+	if name == "" {
+		name = fallback
+	}
+	name = className(name)
+
+	if d.classes[name] != nil {
+		return name
+	}
+	class := &diagramClass{name: name}
+	d.classes[name] = class
+	d.order = append(d.order, name)
+
+	schema := ref.Value
+	if schema.Type.Is(openapi3.TypeArray) && schema.Items != nil && schema.Items.Value != nil && componentName(ref) == "" {
+		// An inline array body is drawn as its items
+		target := d.class(fallback+"Item", schema.Items)
+		d.edge(fmt.Sprintf(`%s --> "*" %s : items`, name, target))
+		return name
+	}
+	d.fill(class, schema, "", 0)
+	return name
+}
+
+// fill adds the fields and edges of schema to class. Inline nested objects
+// are flattened into the class with dotted field names.
+func (d *classDiagram) fill(class *diagramClass, schema *openapi3.Schema, prefix string, depth int) {
+	if depth > MaxRecursionDepth {
+		return
+	}
+
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+		if componentName(member) != "" {
+			d.edge(fmt.Sprintf("%s <|-- %s", d.class("", member), class.name))
+		} else {
+			d.fill(class, member.Value, prefix, depth+1)
+		}
+	}
+	for _, group := range []struct {
+		keyword string
+		members openapi3.SchemaRefs
+	}{{"oneOf", schema.OneOf}, {"anyOf", schema.AnyOf}} {
+		for _, member := range group.members {
+			if member != nil && member.Value != nil && componentName(member) != "" {
+				d.edge(fmt.Sprintf("%s ..> %s : %s", class.name, d.class("", member), group.keyword))
+			}
+		}
+	}
+
+	for _, name := range propertyNames(schema.Properties, d.propertyOrder) {
+		prop := schema.Properties[name]
+		if prop == nil || prop.Value == nil {
+			continue
+		}
+		field := prefix + name
+		typ, nested, nestedPrefix := d.fieldType(class, field, prop)
+		class.fields = append(class.fields, fmt.Sprintf("+%s %s", typ, field))
+		if nested != nil {
+			d.fill(class, nested, nestedPrefix, depth+1)
+		}
+	}
+}
+
+// fieldType returns the type shown for a property, adding edges for
+// properties referencing component schemas. Inline objects are returned as
+// nested with the prefix their fields are flattened under.
+func (d *classDiagram) fieldType(class *diagramClass, field string, prop *openapi3.SchemaRef) (typ string, nested *openapi3.Schema, nestedPrefix string) {
+	if componentName(prop) != "" {
+		target := d.class("", prop)
+		d.edge(fmt.Sprintf("%s --> %s : %s", class.name, target, field))
+		return target, nil, ""
+	}
+
+	schema := prop.Value
+	if schema.Type.Is(openapi3.TypeArray) && schema.Items != nil && schema.Items.Value != nil {
+		if componentName(schema.Items) != "" {
+			target := d.class("", schema.Items)
+			d.edge(fmt.Sprintf(`%s --> "*" %s : %s`, class.name, target, field))
+			return target + "[]", nil, ""
+		}
+		if len(schema.Items.Value.Properties) > 0 {
+			nested, nestedPrefix = schema.Items.Value, field+"[]."
+		}
+		return FormatType(schema.Items.Value) + "[]", nested, nestedPrefix
+	}
+
+	// Alternatives between component schemas
+	var alternatives []string
+	for _, member := range append(append(openapi3.SchemaRefs{}, schema.OneOf...), schema.AnyOf...) {
+		if member != nil && member.Value != nil && componentName(member) != "" {
+			target := d.class("", member)
+			d.edge(fmt.Sprintf("%s --> %s : %s", class.name, target, field))
+			alternatives = append(alternatives, target)
+		}
+	}
+	if len(alternatives) > 0 {
+		return strings.Join(alternatives, "|"), nil, ""
+	}
+
+	if len(schema.Properties) > 0 || len(schema.AllOf) > 0 {
+		nested, nestedPrefix = schema, field+"."
+	}
+	return strings.ReplaceAll(FormatType(schema), " | ", "|"), nested, nestedPrefix
+}
+
+// edge adds an edge once.
+func (d *classDiagram) edge(edge string) {
+	if !d.seen[edge] {
+		d.seen[edge] = true
+		d.edges = append(d.edges, edge)
+	}
+}
+
+// componentName returns the component schema name a ref points to, or empty
+// string for inline schemas.
+func componentName(ref *openapi3.SchemaRef) string {
+	if ref == nil {
+		return ""
+	}
+	if i := strings.Index(ref.Ref, componentSchemaPrefix); i >= 0 {
+		return ref.Ref[i+len(componentSchemaPrefix):]
+	}
+	return ""
+}
+
+var classNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// className converts a schema name into a valid Mermaid class name.
+func className(name string) string {
+	return classNameInvalid.ReplaceAllString(name, "_")
+}
@@ -1,20 +1,70 @@
 package generator
 
-// Markdown heading constants
+// Default words for section headings and block labels. See Vocabulary.
 const (
-	HeaderParameters  = "### Parameters\n\n"
-	HeaderRequestBody = "### Request Body\n\n"
-	HeaderResponses   = "### Responses\n\n"
-	HeaderSecurity    = "### Security\n\n"
-	HeaderExamples    = "\n**Examples:**\n\n"
-	HeaderHeaders     = "**Headers:**\n\n"
-	HeaderSchema      = "**Schema:**\n\n"
+	LabelParameters  = "Parameters"
+	LabelRequestBody = "Request Body"
+	LabelResponses   = "Responses"
+	LabelSecurity    = "Security"
+	LabelFlows       = "Request Flows"
+	LabelErrorFormat = "Error format"
+	LabelExample     = "Example"
+	LabelExamples    = "Examples"
+	LabelHeaders     = "Headers"
+	LabelSchema      = "Schema"
+	LabelExtensions  = "Extensions"
+	LabelContents    = "Contents"
+	LabelTeamNotes   = "Team Notes"
+	LabelEncoding    = "Encoding"
+	LabelEnumUsage   = "Observed Enum Usage"
+	LabelTypeCaveats = "Type Caveats"
+	LabelQuickRef    = "Quick Reference"
+	LabelSnippets    = "Code Samples"
 
-	SeparatorOperation = "---\n\n"
-	MarkerRequired     = " **(required)**"
-	MarkerDeprecated   = " ⚠️ *deprecated*"
+	LabelParamFilters    = "Filtering"
+	LabelParamSorting    = "Sorting"
+	LabelParamPagination = "Pagination"
+	LabelParamFields     = "Field Selection"
+	LabelParamOther      = "Other Query Parameters"
+
+	LabelSequenceDiagram = "Sequence Diagram"
+	LabelSchemaDiagram   = "Schema Diagram"
+)
+
+// Markdown heading constants, as rendered with the default vocabulary
+const (
+	HeaderParameters  = "### " + LabelParameters + "\n\n"
+	HeaderRequestBody = "### " + LabelRequestBody + "\n\n"
+	HeaderResponses   = "### " + LabelResponses + "\n\n"
+	HeaderSecurity    = "### " + LabelSecurity + "\n\n"
+	HeaderFlows       = "### " + LabelFlows + "\n\n"
+	HeaderExample     = "\n**" + LabelExample + ":**\n\n"
+	HeaderExamples    = "\n**" + LabelExamples + ":**\n\n"
+	HeaderHeaders     = "**" + LabelHeaders + ":**\n\n"
+	HeaderSchema      = "**" + LabelSchema + ":**\n\n"
+	HeaderExtensions  = "**" + LabelExtensions + ":**\n\n"
+	HeaderContents    = "**" + LabelContents + ":**\n\n"
+	HeaderTeamNotes   = "### " + LabelTeamNotes + "\n\n"
+	HeaderEncoding    = "**" + LabelEncoding + ":**\n\n"
+	HeaderEnumUsage   = "### " + LabelEnumUsage + "\n\n"
+	HeaderTypeCaveats = "### " + LabelTypeCaveats + "\n\n"
+	HeaderQuickRef    = "**" + LabelQuickRef + ":**\n\n"
+	HeaderSnippets    = "### " + LabelSnippets + "\n\n"
+
+	SeparatorOperation      = "---\n\n"
+	MarkerRequired          = " **(required)**"
+	MarkerDeprecated        = " ⚠️ *deprecated*"
+	MarkerRenderError       = "> ⚠ could not render: %v"
+	MarkerNotAcceptable     = "*No content type matches the preference. Available: `%s`.*"
+	MarkerNonStandardStatus = "⚠️ **Non-standard status code:** %s is not a registered HTTP status; clients may only understand it as %sxx."
+	MarkerFoldOpen          = "<details><summary>%s</summary>"
+	MarkerFoldClose         = "</details>"
 )
 
 // MaxRecursionDepth is the maximum depth for recursive schema formatting
 // to prevent stack overflow on circular references or deeply nested schemas.
 const MaxRecursionDepth = 20
+
+// DefaultMaxExampleLines is the number of lines after which rendered
+// examples are truncated.
+const DefaultMaxExampleLines = 50
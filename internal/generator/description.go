@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// dangerousElements are HTML elements removed together with their content.
+var dangerousElements = []string{"script", "style", "iframe", "object", "embed", "form"}
+
+var (
+	dangerousBlocks []*regexp.Regexp
+	dangerousTags   []*regexp.Regexp
+
+	htmlTag          = regexp.MustCompile(`<[a-zA-Z][^>]*>`)
+	eventHandlerAttr = regexp.MustCompile(`(?i)\s+on[a-z]+\s*=\s*("[^"]*"|'[^']*'|[^\s>]+)`)
+	scriptURLAttr    = regexp.MustCompile(`(?i)\b(href|src)\s*=\s*("\s*(?:javascript|vbscript|data):[^"]*"|'\s*(?:javascript|vbscript|data):[^']*'|(?:javascript|vbscript|data):[^\s>]*)`)
+	scriptURLLink    = regexp.MustCompile(`(?i)\]\(\s*(?:javascript|vbscript|data):(?:[^()]|\([^()]*\))*\)`)
+)
+
+func init() {
+	for _, name := range dangerousElements {
+		dangerousBlocks = append(dangerousBlocks, regexp.MustCompile(`(?is)<`+name+`\b[^>]*>.*?</`+name+`\s*>`))
+		dangerousTags = append(dangerousTags, regexp.MustCompile(`(?i)</?`+name+`\b[^>]*>`))
+	}
+}
+
+// SanitizeMarkdown removes HTML that could execute code or alter the page
+// from markdown text: script-like elements with their content, inline event
+// handlers, and javascript:, vbscript:, or data: link targets. Other markdown
+// and benign HTML are left intact.
+func SanitizeMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	for _, re := range dangerousBlocks {
+		s = re.ReplaceAllString(s, "")
+	}
+	for _, re := range dangerousTags {
+		s = re.ReplaceAllString(s, "")
+	}
+
+	s = htmlTag.ReplaceAllStringFunc(s, func(tag string) string {
+		tag = eventHandlerAttr.ReplaceAllString(tag, "")
+		return scriptURLAttr.ReplaceAllString(tag, `$1="#"`)
+	})
+	s = scriptURLLink.ReplaceAllString(s, "](#)")
+
+	return strings.TrimSpace(s)
+}
+
+// indentBlock prefixes every non-empty line of s with indent.
+func indentBlock(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = indent + line
+		} else {
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeListDescription writes a description following lead, the start of a
+// list item such as "  - Description:". By default the description is
+// inlined after lead. In markdown mode it is sanitized, and a multi-line
+// description is written as a block indented to nest under the list item so
+// its own lists, tables, and paragraphs survive.
+func writeListDescription(md *strings.Builder, lead, description, indent string, markdown bool) {
+	if !markdown {
+		fmt.Fprintf(md, "%s %s\n", lead, description)
+		return
+	}
+
+	description = SanitizeMarkdown(description)
+	if !strings.Contains(description, "\n") {
+		fmt.Fprintf(md, "%s %s\n", lead, description)
+		return
+	}
+
+	fmt.Fprintf(md, "%s\n\n%s\n\n", lead, indentBlock(description, indent))
+}
+
+// blockDescription returns a description written as its own paragraph,
+// sanitized when MarkdownDescriptions is enabled.
+func (g *Generator) blockDescription(description string) string {
+	if !g.opts.MarkdownDescriptions {
+		return description
+	}
+	return SanitizeMarkdown(description)
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain markdown untouched", "| a | b |\n|---|---|\n| 1 | 2 |", "| a | b |\n|---|---|\n| 1 | 2 |"},
+		{"script removed with content", "Before<script>alert(1)</script>After", "BeforeAfter"},
+		{"unclosed iframe tag removed", `See <iframe src="https://evil.example">`, "See"},
+		{"event handler stripped", `<img src="a.png" onerror="alert(1)">`, `<img src="a.png">`},
+		{"javascript href neutralized", `<a href="javascript:alert(1)">x</a>`, `<a href="#">x</a>`},
+		{"javascript link neutralized", "[click](javascript:alert(1))", "[click](#)"},
+		{"safe link kept", "[docs](https://example.com)", "[docs](https://example.com)"},
+		{"line endings normalized", "a\r\nb\r\n", "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := SanitizeMarkdown(tt.input); result != tt.expected {
+				t.Errorf("SanitizeMarkdown(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerate_MarkdownDescriptions(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	schema := openapi3.NewObjectSchema().WithProperty("status", &openapi3.Schema{
+		Type:        &openapi3.Types{"string"},
+		Description: "One of:\n\n- `active`\n- `paused`",
+	})
+
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Description: "Lists items.\n\n| Field | Meaning |\n|---|---|\n| id | Identifier |\n\n<script>steal()</script>",
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewQueryParameter("filter").
+					WithDescription("Filter expression:\n\n- `a:b` matches\n- `!a` negates").
+					WithSchema(openapi3.NewStringSchema())},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").
+					WithContent(openapi3.NewContentWithJSONSchema(schema))}),
+			),
+		},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/items", pathItem, "")
+		if !strings.Contains(markdown, "**Description:** Lists items.") {
+			t.Error("Expected inline description by default")
+		}
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		markdown := New(doc, WithMarkdownDescriptions(true)).GenerateMarkdown("/items", pathItem, "")
+
+		for _, want := range []string{
+			"**Description:**\n\nLists items.\n\n| Field | Meaning |\n",
+			"  - Description:\n\n    Filter expression:\n\n    - `a:b` matches\n    - `!a` negates\n\n",
+			"  - **status**:\n\n    One of:\n\n    - `active`\n",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+
+		if strings.Contains(markdown, "steal()") {
+			t.Error("Did not expect script content in output")
+		}
+	})
+}
@@ -0,0 +1,286 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Diagram identifies a generated Mermaid diagram.
+type Diagram string
+
+// Supported diagrams.
+const (
+	DiagramSequence Diagram = "sequence"
+	DiagramSchema   Diagram = "schema"
+)
+
+// AllDiagrams lists every supported diagram.
+var AllDiagrams = []Diagram{DiagramSequence, DiagramSchema}
+
+// ParseDiagrams parses a comma-separated list of diagram names.
+func ParseDiagrams(s string) ([]Diagram, error) {
+	var diagrams []Diagram
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !isKnownDiagram(Diagram(name)) {
+			names := make([]string, len(AllDiagrams))
+			for i, d := range AllDiagrams {
+				names[i] = string(d)
+			}
+			return nil, fmt.Errorf("unknown diagram: %s (expected one of %s)", name, strings.Join(names, ", "))
+		}
+		diagrams = append(diagrams, Diagram(name))
+	}
+	return diagrams, nil
+}
+
+func isKnownDiagram(d Diagram) bool {
+	for _, known := range AllDiagrams {
+		if d == known {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDiagrams writes the requested diagrams for an operation.
+func (g *Generator) writeDiagrams(md *strings.Builder, method, path string, operation *openapi3.Operation) {
+	if g.opts.hasDiagram(DiagramSequence) {
+		g.writeSequenceDiagram(md, method, path, operation)
+	}
+	if g.opts.hasDiagram(DiagramSchema) {
+		g.writeSchemaDiagram(md, operation)
+	}
+}
+
+// writeSequenceDiagram writes a Mermaid sequence diagram of the operation:
+// obtaining a token from the authorization server for OAuth 2.0 and OpenID
+// Connect security, the request with each documented response, and any
+// callbacks the API makes back to the client.
+func (g *Generator) writeSequenceDiagram(md *strings.Builder, method, path string, operation *openapi3.Operation) {
+	var lines []string
+	add := func(format string, args ...any) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	auth := g.authFlow(operation)
+	callbacks := sortedCallbacks(operation.Callbacks)
+
+	add("participant Client")
+	if auth != nil {
+		add("participant Auth as Authorization Server")
+	}
+	add("participant API as %s", mermaidText(g.apiTitle()))
+	if len(callbacks) > 0 {
+		add("participant Receiver as Callback Receiver")
+	}
+
+	if auth != nil {
+		lines = append(lines, auth...)
+	}
+
+	add("Client->>API: %s %s", strings.ToUpper(method), mermaidText(path))
+	writeResponseArrows(&lines, "API", "Client", operation.Responses)
+
+	for _, cb := range callbacks {
+		add("Note over API,Receiver: Callback %s", mermaidText(cb.name))
+		add("API-)Receiver: %s %s", cb.method, mermaidText(cb.expression))
+		writeResponseArrows(&lines, "Receiver", "API", cb.operation.Responses)
+	}
+
+	md.WriteString(heading(g.opts.Vocabulary.SequenceDiagram))
+	md.WriteString("```mermaid\nsequenceDiagram\n")
+	for _, line := range lines {
+		fmt.Fprintf(md, "    %s\n", line)
+	}
+	md.WriteString("```\n\n")
+}
+
+// apiTitle returns the document title, or "API".
+func (g *Generator) apiTitle() string {
+	if g.doc != nil && g.doc.Info != nil && g.doc.Info.Title != "" {
+		return g.doc.Info.Title
+	}
+	return "API"
+}
+
+// authFlow returns diagram lines for obtaining a token when the operation
+// requires OAuth 2.0 or OpenID Connect, or nil otherwise. The operation's
+// security applies, falling back to the document's.
+func (g *Generator) authFlow(operation *openapi3.Operation) []string {
+	security := operation.Security
+	if security == nil && g.doc != nil {
+		security = &g.doc.Security
+	}
+	if security == nil || g.doc == nil || g.doc.Components == nil {
+		return nil
+	}
+
+	for _, req := range *security {
+		names := make([]string, 0, len(req))
+		for name := range req {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			ref := g.doc.Components.SecuritySchemes[name]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			if lines := tokenArrows(ref.Value, req[name]); lines != nil {
+				return lines
+			}
+		}
+	}
+	return nil
+}
+
+// tokenArrows returns the arrows for obtaining a token with scheme, or nil if
+// it doesn't involve an authorization server.
+func tokenArrows(scheme *openapi3.SecurityScheme, scopes []string) []string {
+	detail := func(grant string) string {
+		if len(scopes) > 0 {
+			grant += ", scopes: " + strings.Join(scopes, " ")
+		}
+		return " (" + grant + ")"
+	}
+
+	switch scheme.Type {
+	case "openIdConnect":
+		return []string{
+			"Client->>Auth: Authenticate" + mermaidText(detail("OpenID Connect")),
+			"Auth-->>Client: ID and access tokens",
+		}
+	case "oauth2":
+		if scheme.Flows == nil {
+			return nil
+		}
+		switch flows := scheme.Flows; {
+		case flows.AuthorizationCode != nil:
+			return []string{
+				"Client->>Auth: Authorize at " + mermaidText(flows.AuthorizationCode.AuthorizationURL+detail("authorization code")),
+				"Auth-->>Client: Authorization code",
+				"Client->>Auth: POST " + mermaidText(flows.AuthorizationCode.TokenURL) + " (exchange code)",
+				"Auth-->>Client: Access token",
+			}
+		case flows.ClientCredentials != nil:
+			return []string{
+				"Client->>Auth: POST " + mermaidText(flows.ClientCredentials.TokenURL+detail("client credentials")),
+				"Auth-->>Client: Access token",
+			}
+		case flows.Password != nil:
+			return []string{
+				"Client->>Auth: POST " + mermaidText(flows.Password.TokenURL+detail("password")),
+				"Auth-->>Client: Access token",
+			}
+		case flows.Implicit != nil:
+			return []string{
+				"Client->>Auth: Authorize at " + mermaidText(flows.Implicit.AuthorizationURL+detail("implicit")),
+				"Auth-->>Client: Access token",
+			}
+		}
+	}
+	return nil
+}
+
+// writeResponseArrows appends one reply arrow per documented response, as
+// alternatives when there are several.
+func writeResponseArrows(lines *[]string, from, to string, responses *openapi3.Responses) {
+	if responses == nil || len(responses.Map()) == 0 {
+		return
+	}
+
+	codes := getSortedStatusCodes(responses.Map())
+	arrow := func(code string) string {
+		text := code
+		if ref := responses.Value(code); ref != nil && ref.Value != nil && ref.Value.Description != nil {
+			if desc := firstLine(*ref.Value.Description); desc != "" {
+				text += " " + desc
+			}
+		}
+		return fmt.Sprintf("%s-->>%s: %s", from, to, mermaidText(text))
+	}
+
+	if len(codes) == 1 {
+		*lines = append(*lines, arrow(codes[0]))
+		return
+	}
+
+	for i, code := range codes {
+		keyword := "else"
+		if i == 0 {
+			keyword = "alt"
+		}
+		*lines = append(*lines, keyword+" "+code, "    "+arrow(code))
+	}
+	*lines = append(*lines, "end")
+}
+
+// callback is one operation of an operation's callbacks.
+type callback struct {
+	name       string
+	expression string
+	method     string
+	operation  *openapi3.Operation
+}
+
+// sortedCallbacks flattens callbacks in name, expression, and method order.
+func sortedCallbacks(callbacks openapi3.Callbacks) []callback {
+	names := make([]string, 0, len(callbacks))
+	for name := range callbacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []callback
+	for _, name := range names {
+		ref := callbacks[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+
+		expressions := make([]string, 0, ref.Value.Len())
+		for expression := range ref.Value.Map() {
+			expressions = append(expressions, expression)
+		}
+		sort.Strings(expressions)
+
+		for _, expression := range expressions {
+			pathItem := ref.Value.Value(expression)
+			if pathItem == nil {
+				continue
+			}
+			for _, method := range model.MethodOrder {
+				if op := pathItem.GetOperation(method); op != nil {
+					result = append(result, callback{name: name, expression: expression, method: method, operation: op})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// firstLine returns the first non-empty line of s, trimmed.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+var mermaidReplacer = strings.NewReplacer(";", ",", "#", "#35;", "\n", " ")
+
+// mermaidText escapes characters with special meaning in Mermaid messages.
+func mermaidText(s string) string {
+	return mermaidReplacer.Replace(s)
+}
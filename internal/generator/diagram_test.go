@@ -0,0 +1,13 @@
+package generator
+
+import "testing"
+
+func TestParseDiagrams(t *testing.T) {
+	diagrams, err := ParseDiagrams(" Sequence ,")
+	if err != nil || len(diagrams) != 1 || diagrams[0] != DiagramSequence {
+		t.Errorf("ParseDiagrams() = %v, %v; want [sequence]", diagrams, err)
+	}
+	if _, err := ParseDiagrams("gantt"); err == nil {
+		t.Error("Expected error for unknown diagram")
+	}
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// writeEncoding writes how each property of a multipart or form body is
+// serialized: its content type, per-part headers, and style.
+func (g *Generator) writeEncoding(md *strings.Builder, encoding map[string]*openapi3.Encoding) {
+	names := make([]string, 0, len(encoding))
+	for name, enc := range encoding {
+		if enc != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	md.WriteString("\n" + blockLabel(g.opts.Vocabulary.Encoding))
+
+	for _, name := range names {
+		enc := encoding[name]
+		fmt.Fprintf(md, "- **%s**\n", name)
+
+		if enc.ContentType != "" {
+			types := strings.Split(enc.ContentType, ",")
+			for i, t := range types {
+				types[i] = "`" + strings.TrimSpace(t) + "`"
+			}
+			fmt.Fprintf(md, "  - Content-Type: %s\n", strings.Join(types, ", "))
+		}
+		if enc.Style != "" || enc.Explode != nil {
+			sm := enc.SerializationMethod()
+			fmt.Fprintf(md, "  - Style: `%s`, explode: `%t`\n", sm.Style, sm.Explode)
+		}
+		if enc.AllowReserved {
+			md.WriteString("  - Allow reserved: `true`\n")
+		}
+
+		g.writeEncodingHeaders(md, enc.Headers)
+	}
+}
+
+// writeEncodingHeaders writes the headers of a multipart part, such as a
+// Content-Disposition carrying a required filename.
+func (g *Generator) writeEncodingHeaders(md *strings.Builder, headers openapi3.Headers) {
+	names := getSortedKeys(headers)
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Fprintf(md, "  - %s:\n", g.opts.Vocabulary.Headers)
+	for _, name := range names {
+		ref := headers[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		header := ref.Value
+
+		lead := fmt.Sprintf("    - `%s`", name)
+		if header.Required {
+			lead += MarkerRequired
+		}
+		if header.Description != "" {
+			writeListDescription(md, lead+" -", header.Description, "      ", g.opts.MarkdownDescriptions)
+		} else {
+			md.WriteString(lead + "\n")
+		}
+
+		if header.Schema != nil && header.Schema.Value != nil {
+			fmt.Fprintf(md, "      - Type: `%s`\n", FormatNullableType(header.Schema.Value, g.opts.Nullable))
+		}
+	}
+}
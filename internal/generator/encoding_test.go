@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateMarkdown_Encoding(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+paths:
+  /uploads:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file: {type: string, format: binary}
+                tags: {type: array, items: {type: string}}
+            encoding:
+              file:
+                contentType: image/png, image/jpeg
+                headers:
+                  Content-Disposition:
+                    description: Must include a filename.
+                    required: true
+                    schema: {type: string}
+              tags:
+                style: form
+                explode: false
+          application/json:
+            schema: {type: object}
+      responses:
+        '201': {description: Created}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	markdown := New(doc).GenerateMarkdown("/uploads", doc.Paths.Value("/uploads"), "POST")
+
+	expected := []string{
+		HeaderEncoding + "- **file**\n  - Content-Type: `image/png`, `image/jpeg`\n",
+		"  - Headers:\n    - `Content-Disposition`" + MarkerRequired + " - Must include a filename.\n      - Type: `string`\n",
+		"- **tags**\n  - Style: `form`, explode: `false`\n",
+	}
+	for _, s := range expected {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Expected %q in output:\n%s", s, markdown)
+		}
+	}
+	if strings.Count(markdown, HeaderEncoding) != 1 {
+		t.Errorf("Expected encoding only for the multipart body:\n%s", markdown)
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Locations of enum values observed in request and response bodies, beside
+// the parameter locations ("query", "header", "path", "cookie").
+const (
+	EnumInRequestBody  = "request body"
+	EnumInResponseBody = "response body"
+)
+
+// EnumUsage is how often each value of an enum parameter or property was
+// observed in recorded traffic.
+type EnumUsage struct {
+	// In is the parameter location, EnumInRequestBody, or EnumInResponseBody.
+	In string `json:"in"`
+	// Name is the parameter name or the property path, e.g. "items[].kind".
+	Name string `json:"name"`
+	// Counts maps observed enum values to the number of times they were seen.
+	Counts map[string]int `json:"counts"`
+	// Unobserved lists the enum values never seen.
+	Unobserved []string `json:"unobserved,omitempty"`
+}
+
+// Total returns the number of observations.
+func (u EnumUsage) Total() int {
+	total := 0
+	for _, n := range u.Counts {
+		total += n
+	}
+	return total
+}
+
+// writeEnumUsage writes the observed frequency of enum values of an
+// operation, most frequent first.
+func (g *Generator) writeEnumUsage(md *strings.Builder, method, path string) {
+	usages := g.opts.EnumUsage[NoteKey(method, path)]
+	if len(usages) == 0 {
+		return
+	}
+
+	md.WriteString(heading(g.opts.Vocabulary.EnumUsage))
+	md.WriteString("*Optional: how often each enum value appeared in recorded traffic, to help pick realistic values. The spec, not this sample, defines what is valid.*\n\n")
+	for _, usage := range usages {
+		total := usage.Total()
+		fmt.Fprintf(md, "- **%s** (%s)\n", usage.Name, usage.In)
+
+		values := make([]string, 0, len(usage.Counts))
+		for value := range usage.Counts {
+			values = append(values, value)
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if usage.Counts[values[i]] != usage.Counts[values[j]] {
+				return usage.Counts[values[i]] > usage.Counts[values[j]]
+			}
+			return values[i] < values[j]
+		})
+
+		for _, value := range values {
+			fmt.Fprintf(md, "  - `%s=%s` (%s of traffic)\n", usage.Name, value, formatShare(usage.Counts[value], total))
+		}
+		for _, value := range usage.Unobserved {
+			fmt.Fprintf(md, "  - `%s=%s` (not observed)\n", usage.Name, value)
+		}
+	}
+	md.WriteString("\n")
+}
+
+// formatShare formats n out of total as a rounded percentage, e.g. "87%".
+func formatShare(n, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	share := 100 * float64(n) / float64(total)
+	if share > 0 && share < 1 {
+		return "<1%"
+	}
+	return fmt.Sprintf("%d%%", int(math.Round(share)))
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFormatShare(t *testing.T) {
+	tests := []struct {
+		n, total int
+		expected string
+	}{
+		{87, 100, "87%"},
+		{7, 8, "88%"},
+		{1, 1000, "<1%"},
+		{0, 10, "0%"},
+		{0, 0, "0%"},
+	}
+
+	for _, tt := range tests {
+		if got := formatShare(tt.n, tt.total); got != tt.expected {
+			t.Errorf("formatShare(%d, %d) = %q, want %q", tt.n, tt.total, got, tt.expected)
+		}
+	}
+}
+
+func TestGenerateMarkdown_EnumUsage(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+	pathItem := &openapi3.PathItem{Get: &openapi3.Operation{Summary: "List events"}}
+	usage := map[string][]EnumUsage{
+		"GET /events": {{
+			In:         openapi3.ParameterInQuery,
+			Name:       "status",
+			Counts:     map[string]int{"archived": 13, "active": 87},
+			Unobserved: []string{"deleted"},
+		}},
+	}
+
+	markdown, err := New(doc).Generate("/events", pathItem, WithEnumUsage(usage))
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	expected := HeaderEnumUsage + "*Optional:"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, markdown)
+	}
+	expected = "- **status** (query)\n" +
+		"  - `status=active` (87% of traffic)\n" +
+		"  - `status=archived` (13% of traffic)\n" +
+		"  - `status=deleted` (not observed)\n"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, markdown)
+	}
+
+	markdown, _ = New(doc).Generate("/events", pathItem)
+	if strings.Contains(markdown, LabelEnumUsage) {
+		t.Errorf("Did not expect %q in output:\n%s", LabelEnumUsage, markdown)
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExtensionEnvironment tags a server with the environment it belongs to,
+// e.g. "prod" or "sandbox". The value is a string or a list of strings.
+const ExtensionEnvironment = "x-environment"
+
+// ServerEnvironments returns the lowercase environments a server is tagged
+// with, or nil if it has no x-environment extension.
+func ServerEnvironments(server *openapi3.Server) []string {
+	if server == nil || server.Extensions == nil {
+		return nil
+	}
+
+	var envs []string
+	switch value := server.Extensions[ExtensionEnvironment].(type) {
+	case string:
+		envs = append(envs, value)
+	case []any:
+		for _, v := range value {
+			envs = append(envs, fmt.Sprint(v))
+		}
+	case []string:
+		envs = append(envs, value...)
+	}
+
+	out := envs[:0]
+	for _, env := range envs {
+		if env = strings.ToLower(strings.TrimSpace(env)); env != "" {
+			out = append(out, env)
+		}
+	}
+	return out
+}
+
+// FilterServers returns the servers tagged with env (case-insensitive).
+// An empty env returns servers unchanged. It returns an error naming the
+// known environments if no server matches.
+func FilterServers(servers openapi3.Servers, env string) (openapi3.Servers, error) {
+	env = strings.ToLower(strings.TrimSpace(env))
+	if env == "" {
+		return servers, nil
+	}
+
+	var filtered openapi3.Servers
+	known := map[string]bool{}
+	for _, server := range servers {
+		matched := false
+		for _, e := range ServerEnvironments(server) {
+			known[e] = true
+			matched = matched || e == env
+		}
+		if matched {
+			filtered = append(filtered, server)
+		}
+	}
+
+	if len(filtered) == 0 {
+		if len(known) == 0 {
+			return nil, fmt.Errorf("no servers for environment %q: no server has %s", env, ExtensionEnvironment)
+		}
+		names := make([]string, 0, len(known))
+		for name := range known {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("no servers for environment %q (available: %s)", env, strings.Join(names, ", "))
+	}
+	return filtered, nil
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_Environment(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+servers:
+  - url: https://api.example.com
+    x-environment: Prod
+  - url: https://sandbox.example.com
+    x-environment: [sandbox, staging]
+  - url: http://localhost:8080
+paths:
+  /items:
+    get:
+      responses:
+        '200': {description: OK}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	gen := New(doc)
+	pathItem := doc.Paths.Find("/items")
+
+	tests := []struct {
+		env        string
+		expected   []string
+		unexpected []string
+	}{
+		{"", []string{"https://api.example.com", "https://sandbox.example.com", "http://localhost:8080"}, nil},
+		{"prod", []string{"https://api.example.com"}, []string{"sandbox", "localhost"}},
+		{"STAGING", []string{"https://sandbox.example.com"}, []string{"api.example.com", "localhost"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			result, err := gen.Generate("/items", pathItem, WithEnvironment(tt.env))
+			if err != nil {
+				t.Fatalf("Generate() error: %v", err)
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(result, s) {
+					t.Errorf("Expected %q in output:\n%s", s, result)
+				}
+			}
+			for _, s := range tt.unexpected {
+				if strings.Contains(result, s) {
+					t.Errorf("Did not expect %q in output:\n%s", s, result)
+				}
+			}
+		})
+	}
+
+	_, err = gen.Generate("/items", pathItem, WithEnvironment("qa"))
+	if err == nil || !strings.Contains(err.Error(), "available: prod, sandbox, staging") {
+		t.Errorf("Expected error listing environments, got %v", err)
+	}
+}
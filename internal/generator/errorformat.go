@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// errorGroup is a set of error responses sharing the same body schemas.
+type errorGroup struct {
+	// statuses lists the member status codes in rendering order.
+	statuses []string
+	// content holds the shared media types, taken from the first member.
+	content openapi3.Content
+}
+
+// errorGroups finds 4xx/5xx responses whose rendered media types all use the
+// same schemas, and returns the group of every status belonging to one with
+// at least two members.
+func (g *Generator) errorGroups(responses map[string]*openapi3.ResponseRef, statusCodes []string) map[string]*errorGroup {
+	bySignature := make(map[string]*errorGroup)
+	var signatures []string
+
+	for _, status := range statusCodes {
+		if !strings.HasPrefix(status, "4") && !strings.HasPrefix(status, "5") {
+			continue
+		}
+		respRef := responses[status]
+		if respRef == nil || respRef.Value == nil {
+			continue
+		}
+
+		signature := g.contentSignature(respRef.Value.Content)
+		if signature == "" {
+			continue
+		}
+
+		group, ok := bySignature[signature]
+		if !ok {
+			group = &errorGroup{content: respRef.Value.Content}
+			bySignature[signature] = group
+			signatures = append(signatures, signature)
+		}
+		group.statuses = append(group.statuses, status)
+	}
+
+	groups := make(map[string]*errorGroup)
+	for _, signature := range signatures {
+		group := bySignature[signature]
+		if len(group.statuses) < 2 {
+			continue
+		}
+		for _, status := range group.statuses {
+			groups[status] = group
+		}
+	}
+	return groups
+}
+
+// contentSignature identifies the schemas of the rendered media types, or
+// returns empty string if none has a schema. Referenced schemas are
+// identified by their $ref, inline ones by identity.
+func (g *Generator) contentSignature(content openapi3.Content) string {
+	var parts []string
+	for _, contentType := range g.responseContentTypes(content) {
+		mediaType := content[contentType]
+		if mediaType.Schema == nil || mediaType.Schema.Value == nil {
+			return ""
+		}
+
+		key := mediaType.Schema.Ref
+		if key == "" {
+			key = fmt.Sprintf("%p", mediaType.Schema.Value)
+		}
+		parts = append(parts, contentType+"="+key)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+// writeErrorFormat writes the schemas shared by an error group once, ahead
+// of its member responses.
+func (g *Generator) writeErrorFormat(md *strings.Builder, group *errorGroup) {
+	fmt.Fprintf(md, "#### %s\n\n", g.opts.Vocabulary.ErrorFormat)
+
+	codes := make([]string, len(group.statuses))
+	for i, status := range group.statuses {
+		codes[i] = "`" + status + "`"
+	}
+	fmt.Fprintf(md, "Returned by %s.\n\n", strings.Join(codes, ", "))
+
+	for _, contentType := range g.responseContentTypes(group.content) {
+		mediaType := group.content[contentType]
+		writeContentType(md, contentType)
+		g.writeBodySchema(md, mediaType.Schema.Value)
+	}
+
+	md.WriteString("\n")
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_ErrorFormat(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	errorSchema := &openapi3.SchemaRef{
+		Ref: "#/components/schemas/Error",
+		Value: openapi3.NewObjectSchema().
+			WithProperty("code", openapi3.NewStringSchema()).
+			WithProperty("message", openapi3.NewStringSchema()),
+	}
+	errorResponse := func(description string) *openapi3.ResponseRef {
+		return &openapi3.ResponseRef{Value: openapi3.NewResponse().
+			WithDescription(description).
+			WithContent(openapi3.Content{"application/json": &openapi3.MediaType{Schema: errorSchema}})}
+	}
+	conflict := openapi3.NewObjectSchema().WithProperty("current_version", openapi3.NewIntegerSchema())
+
+	pathItem := &openapi3.PathItem{
+		Put: &openapi3.Operation{
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").
+					WithContent(openapi3.NewContentWithJSONSchemaRef(errorSchema))}),
+				openapi3.WithStatus(400, errorResponse("Malformed request")),
+				openapi3.WithStatus(404, errorResponse("Item not found")),
+				openapi3.WithStatus(409, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("Version conflict").
+					WithContent(openapi3.NewContentWithJSONSchema(conflict))}),
+				openapi3.WithStatus(500, errorResponse("Server error")),
+			),
+		},
+	}
+
+	markdown := New(doc).GenerateMarkdown("/items/{id}", pathItem, "")
+
+	if got := strings.Count(markdown, "**code**"); got != 2 {
+		t.Errorf("Expected the error schema once plus the 200 schema, got %d occurrences:\n%s", got, markdown)
+	}
+
+	for _, want := range []string{
+		"#### Error format\n\nReturned by `400`, `404`, `500`.\n\n**Content-Type:** `application/json`",
+		"#### 404 Not Found `client error`\n\nItem not found\n\n**Schema:** see *Error format* above.",
+		"#### 409 Conflict `client error`\n\nVersion conflict\n\n**Content-Type:** `application/json`",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+
+	if strings.Index(markdown, "#### Error format") > strings.Index(markdown, "#### 400 Bad Request") {
+		t.Error("Expected the error format before the first grouped response")
+	}
+
+	t.Run("Single error response", func(t *testing.T) {
+		single := &openapi3.PathItem{Get: &openapi3.Operation{
+			Responses: openapi3.NewResponses(openapi3.WithStatus(404, errorResponse("Item not found"))),
+		}}
+		if strings.Contains(New(doc).GenerateMarkdown("/items/{id}", single, ""), "Error format") {
+			t.Error("Did not expect an error format for a single error response")
+		}
+	})
+}
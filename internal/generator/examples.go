@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ExampleAttacher stores full example payloads outside the rendered document.
+// It is used when an example is truncated so readers can still reach the
+// complete payload.
+type ExampleAttacher interface {
+	// AttachExample stores data under a name derived from key and returns a
+	// link to it that is valid from the rendered document.
+	AttachExample(key string, data []byte) (link string, err error)
+}
+
+// DirAttacher writes example attachments as .json files into Dir.
+type DirAttacher struct {
+	// Dir is the directory attachments are written to.
+	Dir string
+	// LinkPrefix is prepended to file names in links (e.g. "examples/").
+	// If empty, Dir is used.
+	LinkPrefix string
+}
+
+// AttachExample writes data to Dir/<slug>.json.
+func (a *DirAttacher) AttachExample(key string, data []byte) (string, error) {
+	if err := os.MkdirAll(a.Dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+
+	name := Slugify(key) + ".json"
+	if err := os.WriteFile(filepath.Join(a.Dir, name), data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write example attachment: %w", err)
+	}
+
+	prefix := a.LinkPrefix
+	if prefix == "" {
+		prefix = filepath.ToSlash(a.Dir)
+	}
+	return path.Join(prefix, name), nil
+}
+
+// writeJSONBlock writes a JSON code block, truncating it when it exceeds the
+// configured line limit. full is the example before trimmed fields were
+// removed from jsonStr, attached along with truncated examples. key
+// identifies the example for attachments.
+func (g *Generator) writeJSONBlock(md *strings.Builder, jsonStr, full, key string, trimmed int) {
+	lines := strings.Split(jsonStr, "\n")
+	truncated := g.opts.MaxExampleLines > 0 && len(lines) > g.opts.MaxExampleLines
+	if !truncated && trimmed == 0 {
+		fmt.Fprintf(md, "```json\n%s\n```\n\n", jsonStr)
+		return
+	}
+
+	var notes []string
+	if truncated {
+		fmt.Fprintf(md, "```json\n%s\n...\n```\n\n", strings.Join(lines[:g.opts.MaxExampleLines], "\n"))
+		notes = append(notes, fmt.Sprintf("truncated, %d lines", len(lines)))
+	} else {
+		fmt.Fprintf(md, "```json\n%s\n```\n\n", jsonStr)
+	}
+	if trimmed == 1 {
+		notes = append(notes, "trimmed 1 field")
+	} else if trimmed > 1 {
+		notes = append(notes, fmt.Sprintf("trimmed %d fields", trimmed))
+	}
+	fmt.Fprintf(md, "*(%s)*", strings.Join(notes, ", "))
+
+	if g.opts.ExampleAttacher != nil {
+		link, err := g.opts.ExampleAttacher.AttachExample(key, []byte(full+"\n"))
+		if err != nil {
+			fmt.Fprintf(md, " ⚠️ could not attach full example: %v", err)
+		} else {
+			fmt.Fprintf(md, " [Full example](%s)", link)
+		}
+	}
+
+	md.WriteString("\n\n")
+}
+
+// writeParameterExample writes a parameter's example inline when it is a
+// scalar, or as a JSON block nested under the parameter otherwise.
+func writeParameterExample(md *strings.Builder, example any) {
+	switch example.(type) {
+	case map[string]any, []any:
+		jsonStr, err := FormatJSON(example)
+		if err == nil {
+			fmt.Fprintf(md, "  - Example:\n\n%s\n\n", indentBlock("```json\n"+jsonStr+"\n```", "    "))
+			return
+		}
+	}
+	fmt.Fprintf(md, "  - Example: `%v`\n", example)
+}
+
+// codeLanguage returns the code block language for a media type.
+func codeLanguage(contentType string) string {
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "xml"):
+		return "xml"
+	case strings.Contains(contentType, "yaml"):
+		return "yaml"
+	case strings.Contains(contentType, "csv"):
+		return "csv"
+	case strings.HasPrefix(contentType, "text/html"):
+		return "html"
+	default:
+		return ""
+	}
+}
+
+// exampleScope returns the attachment key prefix for an operation.
+func exampleScope(method, path string) string {
+	return strings.ToLower(method) + "-" + path
+}
+
+var slugInvalid = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Slugify converts text (paths, media types, names) into a file-name-safe slug.
+func Slugify(s string) string {
+	s = strings.ToLower(s)
+	s = slugInvalid.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
@@ -0,0 +1,209 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// bigExamplePathItem returns a POST operation whose request example renders
+// to many lines of JSON.
+func bigExamplePathItem() *openapi3.PathItem {
+	items := make([]interface{}, 30)
+	for i := range items {
+		items[i] = i
+	}
+
+	return &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Examples: openapi3.Examples{
+							"bulk": &openapi3.ExampleRef{Value: &openapi3.Example{
+								Value: map[string]interface{}{"items": items},
+							}},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestGenerateMarkdown_ExampleTruncation(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	t.Run("UnderLimit", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/items", bigExamplePathItem(), "")
+		if strings.Contains(markdown, "truncated") {
+			t.Error("Did not expect truncation under the default limit")
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		gen := New(doc, WithMaxExampleLines(10))
+		markdown := gen.GenerateMarkdown("/items", bigExamplePathItem(), "")
+
+		if !strings.Contains(markdown, "*(truncated, 34 lines)*") {
+			t.Errorf("Expected truncation note, got:\n%s", markdown)
+		}
+		if strings.Contains(markdown, "    29\n") {
+			t.Error("Did not expect lines past the limit in output")
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		gen := New(doc, WithMaxExampleLines(0))
+		markdown := gen.GenerateMarkdown("/items", bigExamplePathItem(), "")
+		if strings.Contains(markdown, "truncated") {
+			t.Error("Did not expect truncation when disabled")
+		}
+	})
+
+	t.Run("Attached", func(t *testing.T) {
+		dir := t.TempDir()
+		gen := New(doc, WithMaxExampleLines(10), WithExampleAttacher(&DirAttacher{Dir: dir, LinkPrefix: "examples"}))
+		markdown := gen.GenerateMarkdown("/items", bigExamplePathItem(), "")
+
+		name := "post-items-request-application-json-bulk.json"
+		if !strings.Contains(markdown, "[Full example](examples/"+name+")") {
+			t.Errorf("Expected link to attachment, got:\n%s", markdown)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Expected attachment file: %v", err)
+		}
+		if strings.Count(string(data), "\n") != 34 {
+			t.Errorf("Expected full example in attachment, got %d lines", strings.Count(string(data), "\n"))
+		}
+	})
+}
+
+func TestGenerateMarkdown_TrimExamples(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: &openapi3.Response{
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Example: map[string]any{
+							"id":       "u_1",
+							"nickname": nil,
+							"_links":   map[string]any{"self": "/users/u_1"},
+							"meta":     map[string]any{"debug": "trace", "page": 1},
+							"items":    []any{map[string]any{"_links": nil, "name": "a"}, nil},
+						},
+					},
+				},
+			}})),
+		},
+	}
+	doc := &openapi3.T{}
+
+	markdown := New(doc).GenerateMarkdown("/users", pathItem, "")
+	if !strings.Contains(markdown, `"nickname": null`) || strings.Contains(markdown, "trimmed") {
+		t.Errorf("Did not expect trimming without the option:\n%s", markdown)
+	}
+
+	dir := t.TempDir()
+	gen := New(doc, WithTrimExamples(), WithExampleAttacher(&DirAttacher{Dir: dir, LinkPrefix: "examples"}))
+	markdown = gen.GenerateMarkdown("/users", pathItem, "")
+
+	expected := "```json\n{\n  \"id\": \"u_1\",\n  \"items\": [\n    {\n      \"name\": \"a\"\n    },\n    null\n  ],\n  \"meta\": {\n    \"page\": 1\n  }\n}\n```\n\n" +
+		"*(trimmed 4 fields)* [Full example](examples/get-users-200-application-json-example.json)"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, markdown)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "get-users-200-application-json-example.json"))
+	if err != nil {
+		t.Fatalf("Expected attachment file: %v", err)
+	}
+	if !strings.Contains(string(data), `"debug": "trace"`) {
+		t.Errorf("Expected the untrimmed example in the attachment, got:\n%s", data)
+	}
+
+	markdown = New(doc, WithTrimExamples("id")).GenerateMarkdown("/users", pathItem, "")
+	if strings.Contains(markdown, `"id"`) || !strings.Contains(markdown, `"_links"`) {
+		t.Errorf("Expected only the configured keys to be trimmed:\n%s", markdown)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"get-/events/{event_id}", "get-events-event_id"},
+		{"application/vnd.api+json", "application-vnd-api-json"},
+		{"  Mixed Case  ", "mixed-case"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := Slugify(tt.input); result != tt.expected {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateMarkdown_SingularExamples(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	pathItem := &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{
+					Name: "limit", In: "query", Example: 25,
+					Schema: openapi3.NewIntegerSchema().WithDefault(10).NewRef(),
+				}},
+				{Value: &openapi3.Parameter{
+					Name: "filter", In: "query",
+					Example: map[string]any{"status": "active"},
+					Schema:  openapi3.NewObjectSchema().NewRef(),
+				}},
+			},
+			RequestBody: &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{Example: map[string]any{"name": "widget"}},
+					"application/xml":  &openapi3.MediaType{Example: "<item><name>widget</name></item>\n"},
+				},
+			}},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").
+					WithContent(openapi3.Content{
+						"application/problem+json": &openapi3.MediaType{Example: "quoted"},
+						"text/plain":               &openapi3.MediaType{Example: "ok"},
+					})}),
+			),
+		},
+	}
+
+	markdown := New(doc).GenerateMarkdown("/items", pathItem, "")
+
+	for _, want := range []string{
+		"  - Example: `25`\n",
+		"  - Example:\n\n    ```json\n    {\n      \"status\": \"active\"\n    }\n    ```\n\n",
+		HeaderExample + "```json\n{\n  \"name\": \"widget\"\n}\n```\n\n",
+		HeaderExample + "```xml\n<item><name>widget</name></item>\n```\n\n",
+		HeaderExample + "```json\n\"quoted\"\n```\n\n",
+		HeaderExample + "```\nok\n```\n\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+
+	t.Run("Examples section excluded", func(t *testing.T) {
+		markdown := New(doc, WithSections(SectionRequestBody, SectionResponses)).GenerateMarkdown("/items", pathItem, "")
+		if strings.Contains(markdown, HeaderExample) {
+			t.Error("Did not expect body examples when the examples section is excluded")
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The Fields option selects parts of the JSON output, much like a GraphQL
+// query, for scripts that need a few fields of each operation. A field is a
+// dotted path into a JSONOperation, optionally prefixed with "operation.":
+// "summary", "parameters.name", "responses.200.schema". Paths starting with
+// "api" or "servers" address the JSONDocument instead. Arrays are
+// transparent, and within an array a segment may also name an element by
+// its status, name, content type, or method, so "responses.200" is the 200
+// response. Request bodies and responses are transparent to their content,
+// so "responses.200.schema" selects the schemas of every content type.
+//
+// The output keeps the shape of the full document, pruned to the selected
+// fields. Array elements keep the fields that identify them, such as a
+// parameter's name and a response's status, and the document keeps its
+// path and every operation's method and path.
+
+// fieldTree is the set of selected fields, keyed by path segment. A nil
+// tree selects the whole value.
+type fieldTree map[string]fieldTree
+
+// documentFields are the JSONDocument fields a selection may start with;
+// all others address each operation.
+var documentFields = map[string]bool{"api": true, "servers": true}
+
+// identityFields are kept in array elements with selected fields, so the
+// elements can be told apart.
+var identityFields = []string{"method", "path", "status", "name", "in", "contentType", "property", "error"}
+
+// elementIdentity lists the fields an array element can be selected by.
+var elementIdentity = []string{"status", "name", "contentType", "method"}
+
+// parseFields parses the Fields option into a fieldTree rooted at the
+// JSONDocument.
+func parseFields(fields []string) (fieldTree, error) {
+	tree := fieldTree{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		segments := strings.Split(field, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field %q: empty segment", field)
+			}
+		}
+		switch {
+		case documentFields[segments[0]]:
+		case segments[0] == "operation" || segments[0] == "operations":
+			segments = append([]string{"operations"}, segments[1:]...)
+		default:
+			segments = append([]string{"operations"}, segments...)
+		}
+
+		node := tree
+		for i, segment := range segments {
+			child, ok := node[segment]
+			if ok && child == nil {
+				// Already selected whole
+				break
+			}
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+			if !ok {
+				child = fieldTree{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return tree, nil
+}
+
+// selectFields prunes the encoded JSONDocument data to the fields in tree.
+func selectFields(data []byte, tree fieldTree) ([]byte, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool)
+	// Every operation is listed, if only by its method and path
+	if operations, ok := doc["operations"].([]any); ok {
+		for i := range operations {
+			keep[appendPointer("#", "operations", fmt.Sprint(i), "method")] = true
+		}
+	}
+	markFields(doc, "#", tree, false, keep)
+	out, _ := pruneFields(doc, "#", false, keep)
+	selected, _ := out.(map[string]any)
+	if selected == nil {
+		selected = map[string]any{}
+	}
+	selected["path"] = doc["path"]
+	if selected["operations"] == nil {
+		selected["operations"] = []any{}
+	}
+	return json.MarshalIndent(selected, "", "  ")
+}
+
+// markFields records in keep the pointers of the values tree selects in
+// value, found at pointer. element is set for array elements, which can be
+// selected by their identity.
+func markFields(value any, pointer string, tree fieldTree, element bool, keep map[string]bool) {
+	if tree == nil {
+		keep[pointer] = true
+		return
+	}
+
+	switch v := value.(type) {
+	case []any:
+		for i, item := range v {
+			markFields(item, appendPointer(pointer, fmt.Sprint(i)), tree, true, keep)
+		}
+	case map[string]any:
+		for segment, sub := range tree {
+			if field, ok := v[segment]; ok {
+				markFields(field, appendPointer(pointer, segment), sub, false, keep)
+				continue
+			}
+			if element && identifiedBy(v, segment) {
+				markFields(v, pointer, sub, false, keep)
+				continue
+			}
+			if content, ok := v["content"]; ok {
+				markFields(content, appendPointer(pointer, "content"), fieldTree{segment: sub}, false, keep)
+			}
+		}
+	}
+}
+
+// identifiedBy reports whether an array element is named segment.
+func identifiedBy(element map[string]any, segment string) bool {
+	for _, field := range elementIdentity {
+		if id, ok := element[field].(string); ok && strings.EqualFold(id, segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneFields returns value without the parts not marked in keep, and
+// whether anything is left.
+func pruneFields(value any, pointer string, element bool, keep map[string]bool) (any, bool) {
+	if keep[pointer] {
+		return value, true
+	}
+
+	switch v := value.(type) {
+	case []any:
+		out := []any{}
+		for i, item := range v {
+			if pruned, ok := pruneFields(item, appendPointer(pointer, fmt.Sprint(i)), true, keep); ok {
+				out = append(out, pruned)
+			}
+		}
+		return out, len(out) > 0
+	case map[string]any:
+		out := make(map[string]any)
+		for name, field := range v {
+			if pruned, ok := pruneFields(field, appendPointer(pointer, name), false, keep); ok {
+				out[name] = pruned
+			}
+		}
+		if len(out) == 0 {
+			return nil, false
+		}
+		if element {
+			for _, name := range identityFields {
+				if id, ok := v[name]; ok {
+					out[name] = id
+				}
+			}
+		}
+		return out, true
+	}
+	return nil, false
+}
@@ -0,0 +1,237 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OperationFingerprint returns a hash of the contract of an operation: its
+// parameters, request body, responses with their headers, security
+// requirements, and the schemas of all of them. Descriptions, summaries,
+// examples, tags, and extensions are ignored, so are $ref names and the
+// order of maps, so the fingerprint changes only when a client might have
+// to. Path-level parameters and document-level security are included.
+func (g *Generator) OperationFingerprint(path, method string) (string, error) {
+	if g.doc == nil || g.doc.Paths == nil {
+		return "", fmt.Errorf("path not found: %s", path)
+	}
+	pathItem := g.doc.Paths.Value(path)
+	if pathItem == nil {
+		return "", fmt.Errorf("path not found: %s", path)
+	}
+	method = strings.ToUpper(method)
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return "", fmt.Errorf("method %s not found for %s", method, path)
+	}
+
+	security := g.doc.Security
+	if operation.Security != nil {
+		security = *operation.Security
+	}
+
+	f := fingerprinter{visiting: make(map[*openapi3.Schema]bool)}
+	contract := map[string]any{
+		"method":     method,
+		"path":       path,
+		"deprecated": operation.Deprecated,
+		"parameters": f.parameters(pathItem.Parameters, operation.Parameters),
+		"security":   canonicalSecurity(security),
+	}
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		body := operation.RequestBody.Value
+		contract["requestBody"] = map[string]any{"required": body.Required, "content": f.content(body.Content)}
+	}
+	if operation.Responses != nil {
+		responses := make(map[string]any)
+		for status, ref := range operation.Responses.Map() {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			headers := make(map[string]any)
+			for name, header := range ref.Value.Headers {
+				if header != nil && header.Value != nil {
+					headers[strings.ToLower(name)] = f.parameter(&header.Value.Parameter)
+				}
+			}
+			responses[status] = map[string]any{"headers": headers, "content": f.content(ref.Value.Content)}
+		}
+		contract["responses"] = responses
+	}
+
+	// encoding/json sorts map keys, making the encoding canonical
+	data, err := json.Marshal(contract)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint %s %s: %w", method, path, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// fingerprinter converts contract elements to canonical values.
+type fingerprinter struct {
+	visiting map[*openapi3.Schema]bool
+}
+
+// parameters returns the operation parameters merged with those of the path
+// item, keyed by location and name. Header names are case-insensitive.
+func (f *fingerprinter) parameters(pathParams, opParams openapi3.Parameters) map[string]any {
+	params := make(map[string]any)
+	for _, list := range []openapi3.Parameters{pathParams, opParams} {
+		for _, ref := range list {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			name := ref.Value.Name
+			if ref.Value.In == openapi3.ParameterInHeader {
+				name = strings.ToLower(name)
+			}
+			params[ref.Value.In+" "+name] = f.parameter(ref.Value)
+		}
+	}
+	return params
+}
+
+func (f *fingerprinter) parameter(param *openapi3.Parameter) map[string]any {
+	p := map[string]any{
+		"required":   param.Required,
+		"deprecated": param.Deprecated,
+		"style":      param.Style,
+		"content":    f.content(param.Content),
+	}
+	if param.Explode != nil {
+		p["explode"] = *param.Explode
+	}
+	if param.Schema != nil {
+		p["schema"] = f.schema(param.Schema.Value)
+	}
+	return p
+}
+
+func (f *fingerprinter) content(content openapi3.Content) map[string]any {
+	c := make(map[string]any, len(content))
+	for contentType, mediaType := range content {
+		if mediaType == nil {
+			continue
+		}
+		var schema any
+		if mediaType.Schema != nil {
+			schema = f.schema(mediaType.Schema.Value)
+		}
+		c[strings.ToLower(contentType)] = schema
+	}
+	return c
+}
+
+// schema returns the contract-relevant keywords of schema. A schema that
+// contains itself is cut off with a "cycle" marker.
+func (f *fingerprinter) schema(schema *openapi3.Schema) any {
+	if schema == nil {
+		return nil
+	}
+	if f.visiting[schema] {
+		return map[string]any{"cycle": true}
+	}
+	f.visiting[schema] = true
+	defer delete(f.visiting, schema)
+
+	s := make(map[string]any)
+	set := func(key string, value any, present bool) {
+		if present {
+			s[key] = value
+		}
+	}
+
+	if schema.Type != nil {
+		types := append([]string(nil), schema.Type.Slice()...)
+		sort.Strings(types)
+		set("type", types, len(types) > 0)
+	}
+	set("format", schema.Format, schema.Format != "")
+	set("enum", schema.Enum, len(schema.Enum) > 0)
+	set("nullable", true, schema.Nullable)
+	set("readOnly", true, schema.ReadOnly)
+	set("writeOnly", true, schema.WriteOnly)
+	set("pattern", schema.Pattern, schema.Pattern != "")
+	set("min", schema.Min, schema.Min != nil)
+	set("max", schema.Max, schema.Max != nil)
+	set("exclusiveMin", true, schema.ExclusiveMin)
+	set("exclusiveMax", true, schema.ExclusiveMax)
+	set("multipleOf", schema.MultipleOf, schema.MultipleOf != nil)
+	set("minLength", schema.MinLength, schema.MinLength != 0)
+	set("maxLength", schema.MaxLength, schema.MaxLength != nil)
+	set("minItems", schema.MinItems, schema.MinItems != 0)
+	set("maxItems", schema.MaxItems, schema.MaxItems != nil)
+	set("uniqueItems", true, schema.UniqueItems)
+	set("minProperties", schema.MinProps, schema.MinProps != 0)
+	set("maxProperties", schema.MaxProps, schema.MaxProps != nil)
+
+	if len(schema.Required) > 0 {
+		required := append([]string(nil), schema.Required...)
+		sort.Strings(required)
+		s["required"] = required
+	}
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]any, len(schema.Properties))
+		for name, ref := range schema.Properties {
+			if ref != nil {
+				properties[name] = f.schema(ref.Value)
+			}
+		}
+		s["properties"] = properties
+	}
+	if schema.Items != nil {
+		s["items"] = f.schema(schema.Items.Value)
+	}
+	if ap := schema.AdditionalProperties; ap.Has != nil || ap.Schema != nil {
+		if ap.Schema != nil {
+			s["additionalProperties"] = f.schema(ap.Schema.Value)
+		} else {
+			s["additionalProperties"] = *ap.Has
+		}
+	}
+	for key, refs := range map[string]openapi3.SchemaRefs{"allOf": schema.AllOf, "oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		if len(refs) == 0 {
+			continue
+		}
+		members := make([]any, len(refs))
+		for i, ref := range refs {
+			if ref != nil {
+				members[i] = f.schema(ref.Value)
+			}
+		}
+		s[key] = members
+	}
+	if schema.Not != nil {
+		s["not"] = f.schema(schema.Not.Value)
+	}
+	if schema.Discriminator != nil {
+		s["discriminator"] = schema.Discriminator.PropertyName
+	}
+
+	return s
+}
+
+// canonicalSecurity returns each alternative of the requirements as a
+// sorted "scheme:scope,scope" list, sorted.
+func canonicalSecurity(requirements openapi3.SecurityRequirements) []string {
+	alternatives := make([]string, 0, len(requirements))
+	for _, requirement := range requirements {
+		schemes := make([]string, 0, len(requirement))
+		for scheme, scopes := range requirement {
+			sorted := append([]string(nil), scopes...)
+			sort.Strings(sorted)
+			schemes = append(schemes, scheme+":"+strings.Join(sorted, ","))
+		}
+		sort.Strings(schemes)
+		alternatives = append(alternatives, strings.Join(schemes, " "))
+	}
+	sort.Strings(alternatives)
+	return alternatives
+}
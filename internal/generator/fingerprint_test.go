@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const fingerprintSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /events/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      summary: Get an event
+      description: Returns one event.
+      parameters:
+        - name: X-Trace
+          in: header
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Event'
+              example:
+                name: launch
+components:
+  schemas:
+    Event:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          description: Event name.
+        parent:
+          $ref: '#/components/schemas/Event'
+`
+
+func TestOperationFingerprint(t *testing.T) {
+	fingerprint := func(t *testing.T, spec string) string {
+		t.Helper()
+		doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		if err != nil {
+			t.Fatalf("Failed to load spec: %v", err)
+		}
+		sum, err := New(doc).OperationFingerprint("/events/{id}", "get")
+		if err != nil {
+			t.Fatalf("OperationFingerprint() error: %v", err)
+		}
+		return sum
+	}
+
+	base := fingerprint(t, fingerprintSpec)
+	if again := fingerprint(t, fingerprintSpec); again != base {
+		t.Errorf("Expected a stable fingerprint, got %s and %s", base, again)
+	}
+
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		changed bool
+	}{
+		{"description", "description: Returns one event.", "description: Returns a single event.", false},
+		{"summary", "summary: Get an event", "summary: Fetch an event", false},
+		{"property description", "description: Event name.", "description: The name.", false},
+		{"example", "name: launch", "name: landing", false},
+		{"header case", "name: X-Trace", "name: x-trace", false},
+		{"property type", "name:\n          type: string", "name:\n          type: integer", true},
+		{"required property", "required: [name]", "required: []", true},
+		{"status code", `"200":`, `"201":`, true},
+		{"path parameter", "required: true\n        schema:\n          type: string", "required: true\n        schema:\n          type: integer", true},
+		{"content type", "application/json:", "application/xml:", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(fingerprintSpec, tt.old) {
+				t.Fatalf("Spec does not contain %q", tt.old)
+			}
+			got := fingerprint(t, strings.Replace(fingerprintSpec, tt.old, tt.new, 1))
+			if changed := got != base; changed != tt.changed {
+				t.Errorf("Fingerprint changed = %v, want %v", changed, tt.changed)
+			}
+		})
+	}
+}
+
+func TestOperationFingerprint_NotFound(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(fingerprintSpec))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	gen := New(doc)
+
+	if _, err := gen.OperationFingerprint("/missing", "GET"); err == nil {
+		t.Error("Expected error for missing path")
+	}
+	if _, err := gen.OperationFingerprint("/events/{id}", "DELETE"); err == nil {
+		t.Error("Expected error for missing method")
+	}
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Example values used in generated request flows when the spec provides none.
+const (
+	flowETag         = `"33a64df5"`
+	flowLastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	flowRangeSize    = 4096
+	flowRangeChunk   = 1024
+)
+
+// writeRequestFlows writes two-request examples for operations accepting
+// Range or conditional request headers: fetching a resource in parts, and
+// updating it with optimistic concurrency control.
+func (g *Generator) writeRequestFlows(md *strings.Builder, method, path string, operation *openapi3.Operation) {
+	rangeParam := g.headerParameter(operation, "Range")
+	ifMatch := g.headerParameter(operation, "If-Match")
+	ifUnmodified := g.headerParameter(operation, "If-Unmodified-Since")
+	if rangeParam == nil && ifMatch == nil && ifUnmodified == nil {
+		return
+	}
+
+	method = strings.ToUpper(method)
+	md.WriteString(heading(g.opts.Vocabulary.Flows))
+
+	if rangeParam != nil {
+		writeRangeFlow(md, method, path)
+	}
+	if ifMatch != nil {
+		writeConditionalFlow(md, method, path, operation, "If-Match", "ETag", headerExample(ifMatch, flowETag))
+	}
+	if ifUnmodified != nil {
+		writeConditionalFlow(md, method, path, operation, "If-Unmodified-Since", "Last-Modified", headerExample(ifUnmodified, flowLastModified))
+	}
+}
+
+// headerParameter returns the operation's header parameter with the given
+// name, matched case-insensitively, if it is available in the pinned version.
+func (g *Generator) headerParameter(operation *openapi3.Operation, name string) *openapi3.Parameter {
+	for _, paramRef := range operation.Parameters {
+		if paramRef == nil || paramRef.Value == nil {
+			continue
+		}
+		param := paramRef.Value
+		if param.In == openapi3.ParameterInHeader && strings.EqualFold(param.Name, name) && availableIn(param.Extensions, g.opts.MinVersion) {
+			return param
+		}
+	}
+	return nil
+}
+
+// headerExample returns the string example of a header parameter, or fallback.
+func headerExample(param *openapi3.Parameter, fallback string) string {
+	if s, ok := param.Example.(string); ok && s != "" {
+		return s
+	}
+	if param.Schema != nil && param.Schema.Value != nil {
+		if s, ok := param.Schema.Value.Example.(string); ok && s != "" {
+			return s
+		}
+	}
+	return fallback
+}
+
+// writeRangeFlow writes a partial-content sequence: the first chunk of a
+// resource, then the remainder resumed from where the first ended.
+func writeRangeFlow(md *strings.Builder, method, path string) {
+	md.WriteString("**Partial content** — fetch the resource in parts, resuming where the previous response ended:\n\n")
+
+	md.WriteString("1. Request the first range:\n\n")
+	writeHTTPExchange(md,
+		[]string{method + " " + path, fmt.Sprintf("Range: bytes=0-%d", flowRangeChunk-1)},
+		http.StatusPartialContent,
+		fmt.Sprintf("Content-Range: bytes 0-%d/%d", flowRangeChunk-1, flowRangeSize),
+		fmt.Sprintf("Content-Length: %d", flowRangeChunk))
+
+	md.WriteString("2. Request the rest, starting after the last byte received:\n\n")
+	writeHTTPExchange(md,
+		[]string{method + " " + path, fmt.Sprintf("Range: bytes=%d-", flowRangeChunk)},
+		http.StatusPartialContent,
+		fmt.Sprintf("Content-Range: bytes %d-%d/%d", flowRangeChunk, flowRangeSize-1, flowRangeSize),
+		fmt.Sprintf("Content-Length: %d", flowRangeSize-flowRangeChunk))
+}
+
+// writeConditionalFlow writes an optimistic-concurrency sequence: read the
+// resource to learn its validator, then send the request conditioned on it.
+// condition is the request header and validator the response header it echoes.
+func writeConditionalFlow(md *strings.Builder, method, path string, operation *openapi3.Operation, condition, validator, value string) {
+	fmt.Fprintf(md, "**Optimistic concurrency** (`%s`) — apply the request only if the resource is unchanged since it was read:\n\n", condition)
+
+	fmt.Fprintf(md, "1. Read the resource and note its `%s`:\n\n", validator)
+	writeHTTPExchange(md,
+		[]string{"GET " + path},
+		http.StatusOK,
+		validator+": "+value)
+
+	fmt.Fprintf(md, "2. Send the request with `%s`:\n\n", condition)
+	writeHTTPExchange(md,
+		[]string{method + " " + path, condition + ": " + value},
+		successStatus(operation.Responses))
+
+	fmt.Fprintf(md, "If the resource changed in between, the server responds `%d %s` instead; read it again and retry.\n\n",
+		http.StatusPreconditionFailed, http.StatusText(http.StatusPreconditionFailed))
+}
+
+// writeHTTPExchange writes a request and its response as nested http code
+// blocks under a numbered list item.
+func writeHTTPExchange(md *strings.Builder, request []string, status int, responseHeaders ...string) {
+	request[0] += " HTTP/1.1"
+	fmt.Fprintf(md, "   ```http\n   %s\n   ```\n\n", strings.Join(request, "\n   "))
+
+	response := append([]string{fmt.Sprintf("HTTP/1.1 %d %s", status, http.StatusText(status))}, responseHeaders...)
+	fmt.Fprintf(md, "   ```http\n   %s\n   ```\n\n", strings.Join(response, "\n   "))
+}
+
+// successStatus returns the lowest documented 2xx status code, or 200.
+func successStatus(responses *openapi3.Responses) int {
+	if responses == nil {
+		return http.StatusOK
+	}
+	for _, code := range getSortedStatusCodes(responses.Map()) {
+		if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 300 {
+			return status
+		}
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_RequestFlows(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	header := func(name string) *openapi3.ParameterRef {
+		return &openapi3.ParameterRef{Value: openapi3.NewHeaderParameter(name).WithSchema(openapi3.NewStringSchema())}
+	}
+
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary:    "Download file",
+			Parameters: openapi3.Parameters{header("range")},
+		},
+		Put: &openapi3.Operation{
+			Summary: "Replace file",
+			Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "If-Match", In: "header", Example: `"v7"`}},
+				header("If-Unmodified-Since"),
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(204, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Replaced")}),
+				openapi3.WithStatus(412, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Changed")}),
+			),
+		},
+		Delete: &openapi3.Operation{Summary: "Delete file"},
+	}
+
+	t.Run("Range", func(t *testing.T) {
+		markdown := New(doc, WithMethod("GET")).GenerateMarkdown("/files/{id}", pathItem, "GET")
+
+		for _, want := range []string{
+			HeaderFlows,
+			"   ```http\n   GET /files/{id} HTTP/1.1\n   Range: bytes=0-1023\n   ```",
+			"   HTTP/1.1 206 Partial Content\n   Content-Range: bytes 0-1023/4096\n",
+			"   Range: bytes=1024-\n",
+			"   Content-Range: bytes 1024-4095/4096\n",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+	})
+
+	t.Run("Conditional", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/files/{id}", pathItem, "PUT")
+
+		for _, want := range []string{
+			"**Optimistic concurrency** (`If-Match`)",
+			"   HTTP/1.1 200 OK\n   ETag: \"v7\"\n",
+			"   PUT /files/{id} HTTP/1.1\n   If-Match: \"v7\"\n",
+			"   HTTP/1.1 204 No Content\n",
+			"**Optimistic concurrency** (`If-Unmodified-Since`)",
+			"   If-Unmodified-Since: Wed, 21 Oct 2015 07:28:00 GMT\n",
+			"`412 Precondition Failed`",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+	})
+
+	t.Run("No flow headers", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/files/{id}", pathItem, "DELETE")
+		if strings.Contains(markdown, HeaderFlows) {
+			t.Error("Did not expect request flows without Range or conditional headers")
+		}
+	})
+
+	t.Run("Examples section excluded", func(t *testing.T) {
+		markdown := New(doc, WithSections(SectionParameters)).GenerateMarkdown("/files/{id}", pathItem, "GET")
+		if strings.Contains(markdown, HeaderFlows) {
+			t.Error("Did not expect request flows when the examples section is excluded")
+		}
+	})
+}
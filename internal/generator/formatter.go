@@ -30,6 +30,64 @@ func FormatType(schema *openapi3.Schema) string {
 	return strings.Join(types, " | ")
 }
 
+// FormatParameterType returns FormatType of the schema of param.
+func FormatParameterType(param *openapi3.Parameter) string {
+	if param.Schema == nil {
+		return FormatType(nil)
+	}
+	return FormatType(param.Schema.Value)
+}
+
+// IsNullable reports whether schema accepts null, either by the 3.0
+// "nullable: true" or by listing "null" among its 3.1 types.
+func IsNullable(schema *openapi3.Schema) bool {
+	return schema != nil && (schema.Nullable || schema.Type.Includes(openapi3.TypeNull))
+}
+
+// FormatNonNullType is FormatType without the "null" type of 3.1 nullable
+// schemas. A schema whose only type is "null" is returned as "null".
+func FormatNonNullType(schema *openapi3.Schema) string {
+	if schema == nil {
+		return FormatType(nil)
+	}
+	var types []string
+	for _, t := range schema.Type.Slice() {
+		if t != openapi3.TypeNull {
+			types = append(types, t)
+		}
+	}
+	switch {
+	case len(types) == 0 && schema.Type.Includes(openapi3.TypeNull):
+		return openapi3.TypeNull
+	case len(types) == 0:
+		return "unknown"
+	}
+	return strings.Join(types, " | ")
+}
+
+// FormatNullableType returns the type of schema as displayed in style: the
+// same for 3.0 and 3.1 nullable schemas. With NullableField, the type is
+// returned without null, to be followed by a "Nullable" line.
+func FormatNullableType(schema *openapi3.Schema, style NullableStyle) string {
+	typ := FormatNonNullType(schema)
+	if !IsNullable(schema) || typ == openapi3.TypeNull {
+		return typ
+	}
+	switch style {
+	case NullableField:
+		return typ
+	case NullableUnion:
+		return typ + " | " + openapi3.TypeNull
+	}
+	return typ + " (nullable)"
+}
+
+// hasType reports whether the only non-null type of schema is typ, so a
+// 3.1 ["object", "null"] schema is rendered as an object.
+func hasType(schema *openapi3.Schema, typ string) bool {
+	return FormatNonNullType(schema) == typ
+}
+
 // FormatConstraints returns a comma-separated string of validation constraints
 // for a schema (minLength, maxLength, pattern, min, max, etc.).
 // Returns empty string if there are no constraints.
@@ -122,7 +180,7 @@ func buildRequiredMap(required []string) map[string]bool {
 }
 
 // getSortedKeys returns sorted keys from a map for deterministic iteration.
-func getSortedKeys(m map[string]interface{}) []string {
+func getSortedKeys[V any](m map[string]V) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
@@ -141,6 +199,50 @@ func getSortedPropertyNames(properties openapi3.Schemas) []string {
 	return names
 }
 
+// propertyNames returns the property names of a schema in the given order.
+// Spec order needs the declaration location of every property, recorded by
+// the spec loader or by kin-openapi's origin tracking; without it the names
+// are sorted.
+func propertyNames(properties openapi3.Schemas, order PropertyOrder) []string {
+	names := getSortedPropertyNames(properties)
+	if order != PropertyOrderSpec {
+		return names
+	}
+
+	locations := make(map[string]openapi3.Location, len(names))
+	for _, name := range names {
+		location := propertyLocation(properties[name])
+		if location == nil {
+			return names
+		}
+		locations[name] = *location
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		a, b := locations[names[i]], locations[names[j]]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return names
+}
+
+// propertyLocation returns where a property is declared. A $ref property's
+// own origin is its declaration; the referenced schema's is elsewhere.
+func propertyLocation(ref *openapi3.SchemaRef) *openapi3.Location {
+	if ref == nil {
+		return nil
+	}
+	origin := ref.Origin
+	if ref.Ref == "" && ref.Value != nil {
+		origin = ref.Value.Origin
+	}
+	if origin == nil {
+		return nil
+	}
+	return origin.Key
+}
+
 // getSortedHeaderNames returns sorted header names from response headers.
 func getSortedHeaderNames(headers openapi3.Headers) []string {
 	names := make([]string, 0, len(headers))
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -48,6 +49,73 @@ func TestFormatType(t *testing.T) {
 	}
 }
 
+func TestFormatParameterType(t *testing.T) {
+	if got := FormatParameterType(&openapi3.Parameter{Name: "id"}); got != "unknown" {
+		t.Errorf("FormatParameterType() without schema = %q, want unknown", got)
+	}
+	param := &openapi3.Parameter{Name: "id", Schema: openapi3.NewSchemaRef("", openapi3.NewIntegerSchema())}
+	if got := FormatParameterType(param); got != "integer" {
+		t.Errorf("FormatParameterType() = %q, want integer", got)
+	}
+}
+
+func TestFormatNullableType(t *testing.T) {
+	nullable30 := &openapi3.Schema{Type: &openapi3.Types{"string"}, Nullable: true}
+	nullable31 := &openapi3.Schema{Type: &openapi3.Types{"string", "null"}}
+	union := &openapi3.Schema{Type: &openapi3.Types{"string", "integer", "null"}}
+	onlyNull := &openapi3.Schema{Type: &openapi3.Types{"null"}}
+	plain := &openapi3.Schema{Type: &openapi3.Types{"string"}}
+
+	tests := []struct {
+		schema *openapi3.Schema
+		style  NullableStyle
+		want   string
+	}{
+		{nullable30, NullableSuffix, "string (nullable)"},
+		{nullable31, NullableSuffix, "string (nullable)"},
+		{union, NullableSuffix, "string | integer (nullable)"},
+		{nullable30, NullableField, "string"},
+		{nullable31, NullableField, "string"},
+		{nullable30, NullableUnion, "string | null"},
+		{nullable31, NullableUnion, "string | null"},
+		{onlyNull, NullableSuffix, "null"},
+		{plain, NullableSuffix, "string"},
+		{nullable31, "", "string (nullable)"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatNullableType(tt.schema, tt.style); got != tt.want {
+			t.Errorf("FormatNullableType(%v, %q) = %q, want %q", tt.schema.Type.Slice(), tt.style, got, tt.want)
+		}
+	}
+	if !IsNullable(nullable30) || !IsNullable(nullable31) || IsNullable(plain) || IsNullable(nil) {
+		t.Error("IsNullable() disagrees between 3.0 and 3.1 nullable schemas")
+	}
+}
+
+func TestFormatSchema_Nullable31Object(t *testing.T) {
+	schema := &openapi3.Schema{
+		Type: &openapi3.Types{"object", "null"},
+		Properties: openapi3.Schemas{
+			"name": {Value: &openapi3.Schema{Type: &openapi3.Types{"string"}, Nullable: true}},
+		},
+	}
+
+	tests := []struct {
+		style NullableStyle
+		want  string
+	}{
+		{NullableSuffix, "- Type: `object (nullable)`\n- Properties:\n  - **name**\n    - Type: `string (nullable)`\n"},
+		{NullableField, "- Type: `object`\n- Nullable: `true`\n- Properties:\n  - **name**\n    - Type: `string`\n    - Nullable: `true`\n"},
+		{NullableUnion, "- Type: `object | null`\n- Properties:\n  - **name**\n    - Type: `string | null`\n"},
+	}
+	for _, tt := range tests {
+		if got := formatSchema(schema, 0, MaxRecursionDepth, schemaStyle{nullable: tt.style}); got != tt.want {
+			t.Errorf("formatSchema() with %s =\n%s\nwant\n%s", tt.style, got, tt.want)
+		}
+	}
+}
+
 func TestFormatConstraints(t *testing.T) {
 	minLen := uint64(5)
 	maxLen := uint64(100)
@@ -210,6 +278,39 @@ func TestGetSortedPropertyNames(t *testing.T) {
 	}
 }
 
+func TestPropertyNames(t *testing.T) {
+	at := func(line int) *openapi3.Origin {
+		return &openapi3.Origin{Key: &openapi3.Location{Line: line, Column: 5}}
+	}
+	properties := openapi3.Schemas{
+		"zebra":  &openapi3.SchemaRef{Value: &openapi3.Schema{Origin: at(3)}},
+		"alpha":  &openapi3.SchemaRef{Ref: "#/components/schemas/Alpha", Origin: at(7), Value: &openapi3.Schema{Origin: at(1)}},
+		"middle": &openapi3.SchemaRef{Value: &openapi3.Schema{Origin: at(5)}},
+	}
+
+	tests := []struct {
+		name       string
+		properties openapi3.Schemas
+		order      PropertyOrder
+		expected   string
+	}{
+		{"Spec", properties, PropertyOrderSpec, "zebra,middle,alpha"},
+		{"Alpha", properties, PropertyOrderAlpha, "alpha,middle,zebra"},
+		{"UnknownLocation", openapi3.Schemas{
+			"zebra": properties["zebra"],
+			"alpha": &openapi3.SchemaRef{Value: &openapi3.Schema{}},
+		}, PropertyOrderSpec, "alpha,zebra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(propertyNames(tt.properties, tt.order), ","); got != tt.expected {
+				t.Errorf("propertyNames() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetSortedContentTypes(t *testing.T) {
 	content := openapi3.Content{
 		"text/plain":       &openapi3.MediaType{},
@@ -1,52 +1,161 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/arthur-s/docfinder/internal/model"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // Generator generates markdown documentation from OpenAPI specifications.
 type Generator struct {
-	doc *openapi3.T
+	doc  *openapi3.T
+	opts GenerateOptions
+	// schemaPath is the parsed SchemaPath option.
+	schemaPath SchemaPath
+	// fields is the parsed Fields option, nil to render every field.
+	fields fieldTree
 }
 
 // New creates a new Generator with the given OpenAPI document.
-func New(doc *openapi3.T) *Generator {
-	return &Generator{doc: doc}
+// opts set the defaults for every Generate call.
+func New(doc *openapi3.T, opts ...Option) *Generator {
+	g := &Generator{doc: doc, opts: DefaultOptions()}
+	for _, opt := range opts {
+		opt(&g.opts)
+	}
+	return g
 }
 
-// GenerateMarkdown generates markdown documentation for a specific endpoint.
+// Generate renders documentation for a specific endpoint.
 // path is the endpoint path (e.g., "/users/{id}").
 // pathItem contains the OpenAPI path item definition.
-// method is an optional HTTP method filter (e.g., "GET", "POST"). Empty string means all methods.
-// Returns a markdown-formatted string.
-func (g *Generator) GenerateMarkdown(path string, pathItem *openapi3.PathItem, method string) string {
+// opts override the Generator's defaults for this call only.
+func (g *Generator) Generate(path string, pathItem *openapi3.PathItem, opts ...Option) (string, error) {
+	r, err := g.prepare(opts)
+	if err != nil {
+		return "", err
+	}
+
 	if pathItem == nil {
-		return ""
+		return "", nil
 	}
 
-	var md strings.Builder
+	var servers openapi3.Servers
+	if r.doc != nil {
+		if servers, err = FilterServers(r.doc.Servers, r.opts.Environment); err != nil {
+			return "", err
+		}
+	}
+
+	if r.opts.Format == FormatJSONDocument {
+		return r.generateJSON(path, pathItem, servers)
+	}
+	if r.opts.Format == FormatGitHubComment {
+		return r.generateGitHubComment(path, pathItem, servers), nil
+	}
+
+	var header, operations strings.Builder
+
+	r.writeHeader(&header, path, servers)
+	count := r.writeOperations(&operations, path, pathItem, r.opts.Method)
+	return r.finish(header.String(), operations.String(), r.opts.wantsTOC(count)), nil
+}
+
+// prepare returns a copy of g with opts applied and validated.
+func (g *Generator) prepare(opts []Option) (*Generator, error) {
+	r := *g
+	for _, opt := range opts {
+		opt(&r.opts)
+	}
+
+	if r.opts.Format != FormatMarkdown && r.opts.Format != FormatJSONDocument && r.opts.Format != FormatGitHubComment && r.opts.Format != FormatMDX && r.opts.Format != FormatTerm {
+		return nil, fmt.Errorf("unsupported format: %s", r.opts.Format)
+	}
+	if r.opts.Outline && r.opts.Format != FormatMarkdown {
+		return nil, fmt.Errorf("outline is only available in the %s format", FormatMarkdown)
+	}
+
+	if len(r.opts.Fields) > 0 {
+		if r.opts.Format != FormatJSONDocument {
+			return nil, fmt.Errorf("field selection is only available in the %s format", FormatJSONDocument)
+		}
+		var err error
+		if r.fields, err = parseFields(r.opts.Fields); err != nil {
+			return nil, err
+		}
+		if len(r.fields) == 0 {
+			r.fields = nil
+		}
+	}
 
-	g.writeHeader(&md, path)
-	g.writeOperations(&md, path, pathItem, method)
+	if r.opts.SchemaPath != "" {
+		var err error
+		if r.schemaPath, err = ParseSchemaPath(r.opts.SchemaPath); err != nil {
+			return nil, err
+		}
+	}
 
+	return &r, nil
+}
+
+// finish assembles a markdown document from its header and rendered
+// operations, adding a table of contents if toc is set and converting it to
+// the output format.
+func (g *Generator) finish(header, operations string, toc bool) string {
+	if g.opts.Outline {
+		return g.outline(header + operations)
+	}
+
+	var md strings.Builder
+	md.WriteString(header)
+	// Anchors can't be followed in a terminal
+	if toc && g.opts.Format != FormatTerm {
+		g.writeTOC(&md, header+operations)
+	}
+	md.WriteString(operations)
+
+	switch g.opts.Format {
+	case FormatMDX:
+		return mdx(md.String())
+	case FormatTerm:
+		return g.term(md.String())
+	}
 	return md.String()
 }
 
-// writeHeader writes the API metadata and server information.
-func (g *Generator) writeHeader(md *strings.Builder, path string) {
+// GenerateMarkdown generates markdown documentation for a specific endpoint.
+// path is the endpoint path (e.g., "/users/{id}").
+// pathItem contains the OpenAPI path item definition.
+// method is an optional HTTP method filter (e.g., "GET", "POST"). Empty string means all methods.
+// Returns a markdown-formatted string.
+func (g *Generator) GenerateMarkdown(path string, pathItem *openapi3.PathItem, method string) string {
+	markdown, _ := g.Generate(path, pathItem, WithMethod(method), WithFormat(FormatMarkdown))
+	return markdown
+}
+
+// writeHeader writes the API metadata and the given servers.
+func (g *Generator) writeHeader(md *strings.Builder, path string, servers openapi3.Servers) {
 	fmt.Fprintf(md, "# API Endpoint: %s\n\n", path)
 
+	if g.doc == nil {
+		return
+	}
+
 	if g.doc.Info != nil {
 		fmt.Fprintf(md, "**API:** %s %s\n\n", g.doc.Info.Title, g.doc.Info.Version)
 	}
 
-	// Server information
-	if len(g.doc.Servers) > 0 {
+	g.writeServers(md, servers)
+}
+
+// writeServers lists the base URLs of the API.
+func (g *Generator) writeServers(md *strings.Builder, servers openapi3.Servers) {
+	if len(servers) > 0 {
 		md.WriteString("**Base URL(s):**\n")
-		for _, server := range g.doc.Servers {
+		for _, server := range servers {
 			if server.Description != "" {
 				fmt.Fprintf(md, "- `%s` - %s\n", server.URL, server.Description)
 			} else {
@@ -59,8 +168,11 @@ func (g *Generator) writeHeader(md *strings.Builder, path string) {
 
 // writeOperations writes all HTTP operations for the endpoint, optionally filtered by method.
 // methodFilter is an uppercase HTTP method (e.g., "GET", "POST") or empty string for all methods.
-func (g *Generator) writeOperations(md *strings.Builder, path string, pathItem *openapi3.PathItem, methodFilter string) {
-	for method, operation := range pathItem.Operations() {
+// It returns the number of operations written.
+func (g *Generator) writeOperations(md *strings.Builder, path string, pathItem *openapi3.PathItem, methodFilter string) int {
+	count := 0
+	for _, method := range model.MethodOrder {
+		operation := pathItem.GetOperation(method)
 		if operation == nil {
 			continue
 		}
@@ -70,28 +182,78 @@ func (g *Generator) writeOperations(md *strings.Builder, path string, pathItem *
 			continue
 		}
 
-		g.writeOperation(md, method, path, operation)
+		// Hide operations not available to the pinned client version
+		if !availableIn(operation.Extensions, g.opts.MinVersion) {
+			continue
+		}
+
+		g.writeOperationSafely(md, method, path, operation)
+		count++
 	}
+	return count
+}
+
+// writeOperationSafely writes an operation, replacing it with an inline
+// warning if rendering fails so one malformed operation doesn't abort the
+// whole document.
+func (g *Generator) writeOperationSafely(md *strings.Builder, method, path string, operation *openapi3.Operation) {
+	var op strings.Builder
+	if err := renderSafely(func() { g.writeOperation(&op, method, path, operation) }); err != nil {
+		fmt.Fprintf(md, "## %s %s\n\n", strings.ToUpper(method), path)
+		fmt.Fprintf(md, MarkerRenderError+"\n\n", err)
+		md.WriteString(SeparatorOperation)
+		return
+	}
+	md.WriteString(op.String())
 }
 
 // writeOperation writes a single HTTP operation.
 func (g *Generator) writeOperation(md *strings.Builder, method, path string, operation *openapi3.Operation) {
 	fmt.Fprintf(md, "## %s %s\n\n", strings.ToUpper(method), path)
 
-	g.writeOperationMetadata(md, operation)
-	g.writeParameters(md, operation.Parameters)
-	g.writeRequestBody(md, operation.RequestBody)
-	g.writeResponses(md, operation.Responses)
-	g.writeSecurity(md, operation.Security)
+	g.writeBadges(md, operation)
+	if g.opts.QuickReference {
+		g.writeQuickReference(md, operation)
+	}
+
+	if g.opts.hasSection(SectionMetadata) {
+		g.writeOperationMetadata(md, operation)
+		if owners := g.opts.Owners[NoteKey(method, path)]; len(owners) > 0 {
+			fmt.Fprintf(md, "**Owners:** %s\n\n", strings.Join(owners, ", "))
+		}
+		g.writeTeamNotes(md, method, path)
+	}
+	g.writeDiagrams(md, method, path, operation)
+	if g.opts.hasSection(SectionParameters) {
+		g.writeParameters(md, operation.Parameters)
+	}
+
+	scope := exampleScope(method, path)
+	if g.opts.hasSection(SectionRequestBody) {
+		g.writeRequestBody(md, operation.RequestBody, scope)
+	}
+	if g.opts.hasSection(SectionResponses) {
+		g.writeResponses(md, operation.Responses, scope)
+	}
+	if g.opts.hasSection(SectionSecurity) {
+		g.writeSecurity(md, operation.Security)
+	}
+	if g.opts.hasSection(SectionExamples) {
+		g.writeSnippets(md, method, path, operation)
+		g.writeRequestFlows(md, method, path, operation)
+	}
+	g.writeEnumUsage(md, method, path)
+	g.writeTypeCaveats(md, operation)
 
 	md.WriteString(SeparatorOperation)
 }
 
 // writeOperationMetadata writes operation summary, description, and tags.
 func (g *Generator) writeOperationMetadata(md *strings.Builder, operation *openapi3.Operation) {
-	// Deprecation warning
-	if operation.Deprecated {
-		md.WriteString("⚠️ **DEPRECATED** - This operation is deprecated and may be removed in a future version.\n\n")
+	writeDeprecation(md, operation)
+
+	if lifecycle := formatLifecycle(operation.Extensions); lifecycle != "" {
+		fmt.Fprintf(md, "**Availability:** %s\n\n", lifecycle)
 	}
 
 	if operation.Summary != "" {
@@ -99,7 +261,11 @@ func (g *Generator) writeOperationMetadata(md *strings.Builder, operation *opena
 	}
 
 	if operation.Description != "" {
-		fmt.Fprintf(md, "**Description:** %s\n\n", operation.Description)
+		if g.opts.MarkdownDescriptions {
+			fmt.Fprintf(md, "**Description:**\n\n%s\n\n", SanitizeMarkdown(operation.Description))
+		} else {
+			fmt.Fprintf(md, "**Description:** %s\n\n", operation.Description)
+		}
 	}
 
 	if operation.OperationID != "" {
@@ -109,76 +275,143 @@ func (g *Generator) writeOperationMetadata(md *strings.Builder, operation *opena
 	if len(operation.Tags) > 0 {
 		fmt.Fprintf(md, "**Tags:** %s\n\n", strings.Join(operation.Tags, ", "))
 	}
+
+	if g.opts.IncludeExtensions {
+		g.writeExtensions(md, operation.Extensions)
+	}
 }
 
-// writeParameters writes parameter documentation.
-func (g *Generator) writeParameters(md *strings.Builder, parameters openapi3.Parameters) {
-	if len(parameters) == 0 {
+// writeExtensions writes x- vendor extensions as a sorted list.
+func (g *Generator) writeExtensions(md *strings.Builder, extensions map[string]any) {
+	if len(extensions) == 0 {
 		return
 	}
 
-	md.WriteString(HeaderParameters)
+	md.WriteString(blockLabel(g.opts.Vocabulary.Extensions))
 
+	for _, name := range getSortedKeys(extensions) {
+		value, err := json.Marshal(extensions[name])
+		if err != nil {
+			fmt.Fprintf(md, "- `%s`: `%v`\n", name, extensions[name])
+			continue
+		}
+		fmt.Fprintf(md, "- `%s`: `%s`\n", name, value)
+	}
+
+	md.WriteString("\n")
+}
+
+// writeParameters writes parameter documentation. Long lists of query
+// parameters are split into groups such as filters and pagination.
+func (g *Generator) writeParameters(md *strings.Builder, parameters openapi3.Parameters) {
+	var params []*openapi3.Parameter
 	for _, paramRef := range parameters {
 		if paramRef == nil || paramRef.Value == nil {
 			continue
 		}
+		if !availableIn(paramRef.Value.Extensions, g.opts.MinVersion) {
+			continue
+		}
+		params = append(params, paramRef.Value)
+	}
+	if len(params) == 0 {
+		return
+	}
 
-		param := paramRef.Value
-		required := ""
-		if param.Required {
-			required = MarkerRequired
+	md.WriteString(heading(g.opts.Vocabulary.Parameters))
+
+	others, groups, grouped := g.groupQueryParameters(params)
+	if !grouped {
+		for _, param := range params {
+			g.writeParameter(md, param)
+		}
+		md.WriteString("\n")
+		return
+	}
+
+	for _, param := range others {
+		g.writeParameter(md, param)
+	}
+	if len(others) > 0 {
+		md.WriteString("\n")
+	}
+	for _, group := range paramGroupOrder {
+		if len(groups[group]) == 0 {
+			continue
 		}
-		deprecated := ""
-		if param.Deprecated {
-			deprecated = MarkerDeprecated
+		fmt.Fprintf(md, "#### %s\n\n", g.paramGroupLabel(group))
+		for _, param := range groups[group] {
+			g.writeParameter(md, param)
 		}
+		md.WriteString("\n")
+	}
+}
 
-		fmt.Fprintf(md, "- **%s** (%s)%s%s\n", param.Name, param.In, required, deprecated)
+// writeParameter writes a single parameter as a list item.
+func (g *Generator) writeParameter(md *strings.Builder, param *openapi3.Parameter) {
+	required := ""
+	if param.Required {
+		required = MarkerRequired
+	}
+	deprecated := ""
+	if param.Deprecated {
+		deprecated = MarkerDeprecated
+	}
 
-		if param.Description != "" {
-			fmt.Fprintf(md, "  - Description: %s\n", param.Description)
-		}
+	fmt.Fprintf(md, "- **%s** (%s)%s%s\n", param.Name, param.In, required, deprecated)
 
-		if param.Schema != nil && param.Schema.Value != nil {
-			schema := param.Schema.Value
-			fmt.Fprintf(md, "  - Type: `%s`\n", FormatType(schema))
+	if param.Description != "" {
+		writeListDescription(md, "  - Description:", param.Description, "    ", g.opts.MarkdownDescriptions)
+	}
 
-			if schema.Format != "" {
-				fmt.Fprintf(md, "  - Format: `%s`\n", schema.Format)
-			}
-			if schema.Default != nil {
-				fmt.Fprintf(md, "  - Default: `%v`\n", schema.Default)
-			}
-			if schema.Example != nil {
-				fmt.Fprintf(md, "  - Example: `%v`\n", schema.Example)
-			}
+	if lifecycle := formatLifecycle(param.Extensions); lifecycle != "" {
+		fmt.Fprintf(md, "  - Availability: %s\n", lifecycle)
+	}
 
-			constraints := FormatConstraints(schema)
-			if constraints != "" {
-				fmt.Fprintf(md, "  - Constraints: %s\n", constraints)
-			}
+	if param.Schema != nil && param.Schema.Value != nil {
+		schema := param.Schema.Value
+		fmt.Fprintf(md, "  - Type: `%s`\n", FormatNullableType(schema, g.opts.Nullable))
 
-			if len(schema.Enum) > 0 {
-				fmt.Fprintf(md, "  - Allowed values: %v\n", schema.Enum)
-			}
+		if schema.Format != "" {
+			fmt.Fprintf(md, "  - Format: `%s`\n", schema.Format)
+		}
+		if g.opts.Nullable == NullableField && IsNullable(schema) {
+			fmt.Fprintf(md, "  - Nullable: `true`\n")
+		}
+		if schema.Default != nil {
+			fmt.Fprintf(md, "  - Default: `%v`\n", schema.Default)
+		}
+		if schema.Example != nil && param.Example == nil {
+			fmt.Fprintf(md, "  - Example: `%v`\n", schema.Example)
+		}
+
+		constraints := FormatConstraints(schema)
+		if constraints != "" {
+			fmt.Fprintf(md, "  - Constraints: %s\n", constraints)
+		}
+
+		if len(schema.Enum) > 0 {
+			fmt.Fprintf(md, "  - Allowed values: %v\n", schema.Enum)
 		}
 	}
 
-	md.WriteString("\n")
+	if param.Example != nil {
+		writeParameterExample(md, param.Example)
+	}
 }
 
 // writeRequestBody writes request body documentation.
-func (g *Generator) writeRequestBody(md *strings.Builder, requestBodyRef *openapi3.RequestBodyRef) {
+// scope identifies the operation when naming example attachments.
+func (g *Generator) writeRequestBody(md *strings.Builder, requestBodyRef *openapi3.RequestBodyRef, scope string) {
 	if requestBodyRef == nil || requestBodyRef.Value == nil {
 		return
 	}
 
 	reqBody := requestBodyRef.Value
-	md.WriteString(HeaderRequestBody)
+	md.WriteString(heading(g.opts.Vocabulary.RequestBody))
 
 	if reqBody.Description != "" {
-		fmt.Fprintf(md, "%s\n\n", reqBody.Description)
+		fmt.Fprintf(md, "%s\n\n", g.blockDescription(reqBody.Description))
 	}
 
 	if reqBody.Required {
@@ -192,112 +425,130 @@ func (g *Generator) writeRequestBody(md *strings.Builder, requestBodyRef *openap
 
 	for _, contentType := range contentTypes {
 		mediaType := reqBody.Content[contentType]
-		if mediaType == nil {
+		if mediaType == nil || !g.opts.includesContentType(contentType) {
 			continue
 		}
 
-		fmt.Fprintf(md, "**Content-Type:** `%s`\n\n", contentType)
+		writeContentType(md, contentType)
 
 		if mediaType.Schema != nil && mediaType.Schema.Value != nil {
-			md.WriteString(HeaderSchema)
-			md.WriteString(FormatSchema(mediaType.Schema.Value, 0, MaxRecursionDepth))
+			g.writeBodySchema(md, mediaType.Schema.Value)
 		}
+		g.writeEncoding(md, mediaType.Encoding)
 
-		g.writeExamples(md, mediaType.Examples)
+		g.writeExample(md, mediaType.Example, contentType, scope+"-request-"+contentType)
+		g.writeExamples(md, mediaType.Examples, contentType, scope+"-request-"+contentType)
 	}
 
 	md.WriteString("\n")
 }
 
 // writeResponses writes response documentation.
-func (g *Generator) writeResponses(md *strings.Builder, responses *openapi3.Responses) {
+// scope identifies the operation when naming example attachments.
+func (g *Generator) writeResponses(md *strings.Builder, responses *openapi3.Responses, scope string) {
 	if responses == nil || responses.Map() == nil || len(responses.Map()) == 0 {
 		return
 	}
 
-	md.WriteString(HeaderResponses)
+	md.WriteString(heading(g.opts.Vocabulary.Responses))
 
 	// Sort status codes for deterministic output
 	statusCodes := getSortedStatusCodes(responses.Map())
 
+	// Error responses sharing a schema render it once, before the first of them
+	errorGroups := g.errorGroups(responses.Map(), statusCodes)
+	platform := g.platformHeaders()
+
 	for _, status := range statusCodes {
 		respRef := responses.Map()[status]
 		if respRef == nil || respRef.Value == nil {
 			continue
 		}
 
+		group := errorGroups[status]
+		if group != nil && group.statuses[0] == status {
+			g.writeErrorFormat(md, group)
+		}
+
 		resp := respRef.Value
-		fmt.Fprintf(md, "#### %s\n\n", status)
+		fmt.Fprintf(md, "#### %s\n\n", statusHeading(status))
+		if ParseStatus(status).NonStandard {
+			fmt.Fprintf(md, MarkerNonStandardStatus+"\n\n", status, status[:1])
+		}
 
 		if resp.Description != nil {
-			fmt.Fprintf(md, "%s\n\n", *resp.Description)
+			fmt.Fprintf(md, "%s\n\n", g.blockDescription(*resp.Description))
 		}
 
-		g.writeResponseHeaders(md, resp.Headers)
+		g.writeResponseHeaders(md, resp.Headers, platform)
 
-		// Sort content types for deterministic output
-		contentTypes := getSortedContentTypes(resp.Content)
+		if group != nil {
+			fmt.Fprintf(md, "**%s:** see *%s* above.\n\n", g.opts.Vocabulary.Schema, g.opts.Vocabulary.ErrorFormat)
+		}
+
+		contentTypes := g.responseContentTypes(resp.Content)
+		if len(contentTypes) == 0 && len(g.opts.Prefer) > 0 && len(resp.Content) > 0 {
+			fmt.Fprintf(md, MarkerNotAcceptable+"\n\n", strings.Join(getSortedContentTypes(resp.Content), "`, `"))
+		}
 
 		for _, contentType := range contentTypes {
 			mediaType := resp.Content[contentType]
-			if mediaType == nil {
-				continue
-			}
-
-			fmt.Fprintf(md, "**Content-Type:** `%s`\n\n", contentType)
+			if group == nil {
+				writeContentType(md, contentType)
 
-			if mediaType.Schema != nil && mediaType.Schema.Value != nil {
-				md.WriteString(HeaderSchema)
-				md.WriteString(FormatSchema(mediaType.Schema.Value, 0, MaxRecursionDepth))
+				if mediaType.Schema != nil && mediaType.Schema.Value != nil {
+					g.writeBodySchema(md, mediaType.Schema.Value)
+				}
+				g.writeEncoding(md, mediaType.Encoding)
 			}
 
-			g.writeExamples(md, mediaType.Examples)
+			g.writeExample(md, mediaType.Example, contentType, scope+"-"+status+"-"+contentType)
+			g.writeExamples(md, mediaType.Examples, contentType, scope+"-"+status+"-"+contentType)
 		}
 
 		md.WriteString("\n")
 	}
 }
 
-// writeResponseHeaders writes response header documentation.
-func (g *Generator) writeResponseHeaders(md *strings.Builder, headers openapi3.Headers) {
-	if len(headers) == 0 {
+// writeResponseHeaders writes response header documentation, including
+// platform-provided headers.
+func (g *Generator) writeResponseHeaders(md *strings.Builder, headers openapi3.Headers, platform map[string]*openapi3.Header) {
+	merged := mergeResponseHeaders(headers, platform)
+	if len(merged) == 0 {
 		return
 	}
 
-	md.WriteString(HeaderHeaders)
+	md.WriteString(blockLabel(g.opts.Vocabulary.Headers))
 
-	// Sort header names for deterministic output
-	headerNames := getSortedHeaderNames(headers)
-
-	for _, headerName := range headerNames {
-		headerRef := headers[headerName]
-		if headerRef == nil || headerRef.Value == nil {
-			continue
+	for _, h := range merged {
+		lead := fmt.Sprintf("- `%s`", h.name)
+		if h.platform {
+			lead += MarkerPlatform
 		}
 
-		header := headerRef.Value
-		desc := ""
+		header := h.header
 		if header.Description != "" {
-			desc = fmt.Sprintf(" - %s", header.Description)
+			writeListDescription(md, lead+" -", header.Description, "  ", g.opts.MarkdownDescriptions)
+		} else {
+			md.WriteString(lead + "\n")
 		}
 
-		fmt.Fprintf(md, "- `%s`%s\n", headerName, desc)
-
 		if header.Schema != nil && header.Schema.Value != nil {
-			fmt.Fprintf(md, "  - Type: `%s`\n", FormatType(header.Schema.Value))
+			fmt.Fprintf(md, "  - Type: `%s`\n", FormatNullableType(header.Schema.Value, g.opts.Nullable))
 		}
 	}
 
 	md.WriteString("\n")
 }
 
-// writeExamples writes example documentation.
-func (g *Generator) writeExamples(md *strings.Builder, examples map[string]*openapi3.ExampleRef) {
-	if len(examples) == 0 {
+// writeExamples writes example documentation for a media type.
+// scope identifies the media type when naming example attachments.
+func (g *Generator) writeExamples(md *strings.Builder, examples map[string]*openapi3.ExampleRef, contentType, scope string) {
+	if len(examples) == 0 || !g.opts.hasSection(SectionExamples) {
 		return
 	}
 
-	md.WriteString(HeaderExamples)
+	md.WriteString("\n" + blockLabel(g.opts.Vocabulary.Examples))
 
 	// Sort example names for deterministic output
 	exampleNames := getSortedExampleNames(examples)
@@ -316,14 +567,48 @@ func (g *Generator) writeExamples(md *strings.Builder, examples map[string]*open
 			fmt.Fprintf(md, "*Example: `%s`*:\n\n", exampleName)
 		}
 
-		jsonStr, err := FormatJSON(example.Value)
-		if err != nil {
-			// Fallback to %v formatting if JSON marshal fails
-			fmt.Fprintf(md, "```\n%v\n```\n\n", example.Value)
-		} else {
-			fmt.Fprintf(md, "```json\n%s\n```\n\n", jsonStr)
-		}
+		g.writeExampleValue(md, example.Value, contentType, scope+"-"+exampleName)
+	}
+}
+
+// writeExample writes the singular example of a media type.
+// scope identifies the media type when naming example attachments.
+func (g *Generator) writeExample(md *strings.Builder, example any, contentType, scope string) {
+	if example == nil || !g.opts.hasSection(SectionExamples) {
+		return
+	}
+
+	md.WriteString("\n" + blockLabel(g.opts.Vocabulary.Example))
+	g.writeExampleValue(md, example, contentType, scope+"-example")
+}
+
+// writeExampleValue writes an example as a code block. String examples of
+// non-JSON media types (XML, plain text, CSV) are written verbatim;
+// everything else is formatted as JSON.
+func (g *Generator) writeExampleValue(md *strings.Builder, value any, contentType, key string) {
+	if text, ok := value.(string); ok && !isJSONContentType(contentType) {
+		fmt.Fprintf(md, "```%s\n%s\n```\n\n", codeLanguage(contentType), strings.TrimRight(text, "\n"))
+		return
+	}
+
+	jsonStr, err := FormatJSON(value)
+	if err != nil {
+		// Fallback to %v formatting if JSON marshal fails
+		fmt.Fprintf(md, "```\n%v\n```\n\n", value)
+		return
+	}
+	if !g.opts.TrimExamples {
+		g.writeJSONBlock(md, jsonStr, jsonStr, key, 0)
+		return
+	}
+
+	trimmed, removed := trimExample(value, g.opts.TrimKeys)
+	trimmedStr, err := FormatJSON(trimmed)
+	if err != nil || removed == 0 {
+		g.writeJSONBlock(md, jsonStr, jsonStr, key, 0)
+		return
 	}
+	g.writeJSONBlock(md, trimmedStr, jsonStr, key, removed)
 }
 
 // writeSecurity writes security requirement documentation.
@@ -332,10 +617,11 @@ func (g *Generator) writeSecurity(md *strings.Builder, security *openapi3.Securi
 		return
 	}
 
-	md.WriteString(HeaderSecurity)
+	md.WriteString(heading(g.opts.Vocabulary.Security))
 
 	for _, secReq := range *security {
-		for name, scopes := range secReq {
+		for _, name := range getSortedKeys(secReq) {
+			scopes := secReq[name]
 			if len(scopes) > 0 {
 				fmt.Fprintf(md, "- **%s**: %s\n", name, strings.Join(scopes, ", "))
 			} else {
@@ -7,157 +7,30 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
-func TestGenerateMarkdown_MultipleMethodsNoFilter(t *testing.T) {
-	// Create a minimal OpenAPI document with an endpoint that has GET, PUT, DELETE
+func TestGenerateMarkdown_NilPathItem(t *testing.T) {
 	doc := &openapi3.T{
 		Info: &openapi3.Info{
 			Title:   "Test API",
 			Version: "1.0.0",
 		},
-		Servers: []*openapi3.Server{
-			{
-				URL:         "https://api.example.com",
-				Description: "Test Server",
-			},
-		},
-	}
-
-	// Create path item with multiple methods
-	pathItem := &openapi3.PathItem{
-		Get: &openapi3.Operation{
-			Summary:     "Get item",
-			Description: "Retrieves an item by ID",
-			OperationID: "getItem",
-			Tags:        []string{"Items"},
-		},
-		Put: &openapi3.Operation{
-			Summary:     "Update item",
-			Description: "Updates an existing item",
-			OperationID: "updateItem",
-			Tags:        []string{"Items"},
-		},
-		Delete: &openapi3.Operation{
-			Summary:     "Delete item",
-			Description: "Deletes an item by ID",
-			OperationID: "deleteItem",
-			Tags:        []string{"Items"},
-		},
 	}
 
 	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items/{id}", pathItem, "")
-
-	// Verify all three methods appear in output
-	if !strings.Contains(markdown, "## DELETE /items/{id}") {
-		t.Error("Expected DELETE method in output when no filter specified")
-	}
-	if !strings.Contains(markdown, "## GET /items/{id}") {
-		t.Error("Expected GET method in output when no filter specified")
-	}
-	if !strings.Contains(markdown, "## PUT /items/{id}") {
-		t.Error("Expected PUT method in output when no filter specified")
-	}
-
-	// Verify operation details are present
-	if !strings.Contains(markdown, "Get item") {
-		t.Error("Expected GET operation summary in output")
-	}
-	if !strings.Contains(markdown, "Update item") {
-		t.Error("Expected PUT operation summary in output")
-	}
-	if !strings.Contains(markdown, "Delete item") {
-		t.Error("Expected DELETE operation summary in output")
-	}
-}
-
-func TestGenerateMarkdown_MethodFilterGET(t *testing.T) {
-	doc := &openapi3.T{
-		Info: &openapi3.Info{
-			Title:   "Test API",
-			Version: "1.0.0",
-		},
-	}
-
-	pathItem := &openapi3.PathItem{
-		Get: &openapi3.Operation{
-			Summary:     "Get item",
-			Description: "Retrieves an item by ID",
-			OperationID: "getItem",
-		},
-		Put: &openapi3.Operation{
-			Summary:     "Update item",
-			Description: "Updates an existing item",
-			OperationID: "updateItem",
-		},
-		Delete: &openapi3.Operation{
-			Summary:     "Delete item",
-			Description: "Deletes an item by ID",
-			OperationID: "deleteItem",
-		},
-	}
-
-	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items/{id}", pathItem, "GET")
-
-	// Verify only GET method appears
-	if !strings.Contains(markdown, "## GET /items/{id}") {
-		t.Error("Expected GET method in output when filtering by GET")
-	}
-	if strings.Contains(markdown, "## PUT /items/{id}") {
-		t.Error("Did not expect PUT method in output when filtering by GET")
-	}
-	if strings.Contains(markdown, "## DELETE /items/{id}") {
-		t.Error("Did not expect DELETE method in output when filtering by GET")
-	}
+	markdown := gen.GenerateMarkdown("/items", nil, "")
 
-	// Verify only GET operation details are present
-	if !strings.Contains(markdown, "Get item") {
-		t.Error("Expected GET operation summary in output")
-	}
-	if strings.Contains(markdown, "Update item") {
-		t.Error("Did not expect PUT operation summary when filtering by GET")
-	}
-	if strings.Contains(markdown, "Delete item") {
-		t.Error("Did not expect DELETE operation summary when filtering by GET")
+	if markdown != "" {
+		t.Error("Expected empty string for nil pathItem")
 	}
 }
 
-func TestGenerateMarkdown_MethodFilterPUT(t *testing.T) {
-	doc := &openapi3.T{
-		Info: &openapi3.Info{
-			Title:   "Test API",
-			Version: "1.0.0",
-		},
-	}
-
-	pathItem := &openapi3.PathItem{
-		Get: &openapi3.Operation{
-			Summary: "Get item",
-		},
-		Put: &openapi3.Operation{
-			Summary: "Update item",
-		},
-		Delete: &openapi3.Operation{
-			Summary: "Delete item",
-		},
-	}
-
-	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items/{id}", pathItem, "PUT")
+// panickingAttacher simulates a renderer failure deep inside an operation.
+type panickingAttacher struct{}
 
-	// Verify only PUT method appears
-	if strings.Contains(markdown, "## GET /items/{id}") {
-		t.Error("Did not expect GET method in output when filtering by PUT")
-	}
-	if !strings.Contains(markdown, "## PUT /items/{id}") {
-		t.Error("Expected PUT method in output when filtering by PUT")
-	}
-	if strings.Contains(markdown, "## DELETE /items/{id}") {
-		t.Error("Did not expect DELETE method in output when filtering by PUT")
-	}
+func (panickingAttacher) AttachExample(string, []byte) (string, error) {
+	panic("attachment store unavailable")
 }
 
-func TestGenerateMarkdown_MethodFilterDELETE(t *testing.T) {
+func TestGenerateMarkdown_RecoversPerOperation(t *testing.T) {
 	doc := &openapi3.T{
 		Info: &openapi3.Info{
 			Title:   "Test API",
@@ -165,34 +38,21 @@ func TestGenerateMarkdown_MethodFilterDELETE(t *testing.T) {
 		},
 	}
 
-	pathItem := &openapi3.PathItem{
-		Get: &openapi3.Operation{
-			Summary: "Get item",
-		},
-		Put: &openapi3.Operation{
-			Summary: "Update item",
-		},
-		Delete: &openapi3.Operation{
-			Summary: "Delete item",
-		},
-	}
+	pathItem := bigExamplePathItem()
+	pathItem.Get = &openapi3.Operation{Summary: "List items"}
 
-	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items/{id}", pathItem, "DELETE")
+	gen := New(doc, WithMaxExampleLines(5), WithExampleAttacher(panickingAttacher{}))
+	markdown := gen.GenerateMarkdown("/items", pathItem, "")
 
-	// Verify only DELETE method appears
-	if strings.Contains(markdown, "## GET /items/{id}") {
-		t.Error("Did not expect GET method in output when filtering by DELETE")
-	}
-	if strings.Contains(markdown, "## PUT /items/{id}") {
-		t.Error("Did not expect PUT method in output when filtering by DELETE")
+	if !strings.Contains(markdown, "## POST /items\n\n> ⚠ could not render: attachment store unavailable") {
+		t.Errorf("Expected inline render error for POST, got:\n%s", markdown)
 	}
-	if !strings.Contains(markdown, "## DELETE /items/{id}") {
-		t.Error("Expected DELETE method in output when filtering by DELETE")
+	if !strings.Contains(markdown, "List items") {
+		t.Error("Expected GET to render despite POST failure")
 	}
 }
 
-func TestGenerateMarkdown_SingleMethodEndpoint(t *testing.T) {
+func TestGenerateMarkdown_NilCompositionRef(t *testing.T) {
 	doc := &openapi3.T{
 		Info: &openapi3.Info{
 			Title:   "Test API",
@@ -200,67 +60,15 @@ func TestGenerateMarkdown_SingleMethodEndpoint(t *testing.T) {
 		},
 	}
 
-	// Endpoint with only POST method
+	schema := &openapi3.Schema{OneOf: openapi3.SchemaRefs{nil, openapi3.NewSchemaRef("", openapi3.NewStringSchema())}}
 	pathItem := &openapi3.PathItem{
 		Post: &openapi3.Operation{
-			Summary:     "Create item",
-			Description: "Creates a new item",
-			OperationID: "createItem",
+			RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(schema)},
 		},
 	}
 
-	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items", pathItem, "")
-
-	// Verify only POST appears
-	if !strings.Contains(markdown, "## POST /items") {
-		t.Error("Expected POST method in output")
-	}
-	if strings.Contains(markdown, "## GET /items") {
-		t.Error("Did not expect GET method for POST-only endpoint")
-	}
-	if strings.Contains(markdown, "Create item") {
-		// Good - summary is present
-	} else {
-		t.Error("Expected operation summary in output")
-	}
-}
-
-func TestGenerateMarkdown_NilPathItem(t *testing.T) {
-	doc := &openapi3.T{
-		Info: &openapi3.Info{
-			Title:   "Test API",
-			Version: "1.0.0",
-		},
-	}
-
-	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items", nil, "")
-
-	if markdown != "" {
-		t.Error("Expected empty string for nil pathItem")
-	}
-}
-
-func TestGenerateMarkdown_EmptyPathItem(t *testing.T) {
-	doc := &openapi3.T{
-		Info: &openapi3.Info{
-			Title:   "Test API",
-			Version: "1.0.0",
-		},
-	}
-
-	// PathItem with no operations
-	pathItem := &openapi3.PathItem{}
-
-	gen := New(doc)
-	markdown := gen.GenerateMarkdown("/items", pathItem, "")
-
-	// Should generate header but no operations
-	if !strings.Contains(markdown, "# API Endpoint: /items") {
-		t.Error("Expected endpoint header in output")
-	}
-	if strings.Contains(markdown, "## GET") || strings.Contains(markdown, "## POST") {
-		t.Error("Did not expect any operation headers for empty pathItem")
+	markdown := New(doc).GenerateMarkdown("/items", pathItem, "")
+	if !strings.Contains(markdown, "Option 2:") || strings.Contains(markdown, "could not render") {
+		t.Errorf("Expected nil oneOf branch to be skipped, got:\n%s", markdown)
 	}
 }
@@ -0,0 +1,244 @@
+package generator
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The github-comment output format is markdown meant to be posted as a
+// GitHub issue or pull request comment. Each operation is wrapped in a
+// collapsible <details> block. @mentions and issue references are broken up
+// so posting docs doesn't notify people or cross-link issues, and HTML that
+// would close the blocks early is escaped. Comments larger than the limit
+// are shortened: examples first, then operations are cut at a line boundary
+// and the rest are listed as omitted.
+
+// GitHubCommentLimit is the maximum size of a GitHub comment body in
+// characters. Sizes are counted in bytes, which never undercounts.
+const GitHubCommentLimit = 65536
+
+// commentExampleLines is the example length used when the comment doesn't
+// fit with the configured one.
+const commentExampleLines = 10
+
+// MarkerCommentTruncated ends a comment shortened to fit the size limit.
+const MarkerCommentTruncated = "> ⚠ Truncated to fit GitHub's comment size limit."
+
+// zeroWidthSpace is inserted into mentions and issue references so GitHub
+// doesn't link them while they still read the same.
+const zeroWidthSpace = "\u200b"
+
+var (
+	mentionPattern  = regexp.MustCompile(`(^|[^\w/])@(\w)`)
+	issueRefPattern = regexp.MustCompile(`(^|[^&])#(\d)`)
+	// blockTagPattern matches HTML that would end or hide the collapsible
+	// blocks: <details>, <summary>, and comments.
+	blockTagPattern = regexp.MustCompile(`(?i)<(/?(?:details|summary)\b|!--)`)
+	// foldOpenPattern matches the line opening a folded schema.
+	foldOpenPattern = regexp.MustCompile(`^<details><summary>[\w ]+</summary>$`)
+)
+
+// generateGitHubComment renders the endpoint as a GitHub comment.
+func (g *Generator) generateGitHubComment(path string, pathItem *openapi3.PathItem, servers openapi3.Servers) string {
+	limit := g.opts.CommentLimit
+	if limit <= 0 {
+		limit = GitHubCommentLimit
+	}
+
+	var header strings.Builder
+	g.writeHeader(&header, path, servers)
+	head := escapeComment(header.String())
+
+	blocks := g.commentBlocks(path, pathItem)
+	if comment := head + strings.Join(blocks.texts(), ""); len(comment) <= limit {
+		return comment
+	}
+
+	// Examples are the bulk of most operations, so shorten them first
+	if g.opts.MaxExampleLines <= 0 || g.opts.MaxExampleLines > commentExampleLines {
+		short := *g
+		short.opts.MaxExampleLines = commentExampleLines
+		blocks = short.commentBlocks(path, pathItem)
+		if comment := head + strings.Join(blocks.texts(), ""); len(comment) <= limit {
+			return comment
+		}
+	}
+
+	return truncateComment(head, blocks, limit)
+}
+
+// commentBlock is the collapsible block of one operation.
+type commentBlock struct {
+	// name is the operation, e.g. "GET /users/{id}".
+	name    string
+	summary string
+	body    string
+}
+
+type commentBlocks []commentBlock
+
+func (blocks commentBlocks) texts() []string {
+	texts := make([]string, len(blocks))
+	for i, b := range blocks {
+		texts[i] = b.text(b.body)
+	}
+	return texts
+}
+
+// text returns the block with the given body.
+func (b commentBlock) text(body string) string {
+	return fmt.Sprintf("<details>\n%s\n\n%s\n\n</details>\n\n", b.summary, strings.TrimRight(body, "\n"))
+}
+
+// commentBlocks renders the operations of the endpoint as escaped blocks.
+func (g *Generator) commentBlocks(path string, pathItem *openapi3.PathItem) commentBlocks {
+	var blocks commentBlocks
+	for _, method := range model.MethodOrder {
+		operation := pathItem.GetOperation(method)
+		if operation == nil {
+			continue
+		}
+		if g.opts.Method != "" && method != g.opts.Method {
+			continue
+		}
+		if !availableIn(operation.Extensions, g.opts.MinVersion) {
+			continue
+		}
+
+		var op strings.Builder
+		g.writeOperationSafely(&op, method, path, operation)
+		// The summary names the operation, so drop the heading
+		body := strings.TrimPrefix(op.String(), fmt.Sprintf("## %s %s\n\n", method, path))
+		body = strings.TrimSuffix(body, SeparatorOperation)
+
+		summary := fmt.Sprintf("<summary><b>%s</b> <code>%s</code>", method, html.EscapeString(path))
+		if operation.Summary != "" {
+			summary += " — " + escapeMentions(html.EscapeString(operation.Summary))
+		}
+		if operation.Deprecated {
+			summary += " (deprecated)"
+		}
+		summary += "</summary>"
+
+		blocks = append(blocks, commentBlock{
+			name:    method + " " + path,
+			summary: summary,
+			body:    escapeComment(body),
+		})
+	}
+	return blocks
+}
+
+// truncateComment fits head and blocks into limit bytes: blocks that fit are
+// kept whole, the first that doesn't is cut at a line boundary, and the rest
+// are listed as omitted.
+func truncateComment(head string, blocks commentBlocks, limit int) string {
+	notice := func(omitted commentBlocks) string {
+		if len(omitted) == 0 {
+			return MarkerCommentTruncated + "\n"
+		}
+		names := make([]string, len(omitted))
+		for i, b := range omitted {
+			names[i] = "`" + b.name + "`"
+		}
+		return fmt.Sprintf("%s Omitted: %s.\n", MarkerCommentTruncated, strings.Join(names, ", "))
+	}
+
+	var out strings.Builder
+	out.WriteString(head)
+	for i, b := range blocks {
+		rest := blocks[i+1:]
+		if text := b.text(b.body); out.Len()+len(text)+len(notice(rest)) <= limit {
+			out.WriteString(text)
+			continue
+		}
+
+		// Cut this block to what's left, if that leaves anything of it
+		room := limit - out.Len() - len(b.text("")) - len(notice(rest))
+		if body := cutMarkdown(b.body, room); strings.TrimSpace(body) != "" {
+			out.WriteString(b.text(body))
+		} else {
+			rest = blocks[i:]
+		}
+		out.WriteString(notice(rest))
+		return out.String()
+	}
+
+	// Only the header was too large
+	return cutMarkdown(out.String(), limit-len(notice(nil))) + notice(nil)
+}
+
+// cutMarkdown returns the longest prefix of md that ends at a line boundary
+// and fits in size bytes, closing a code fence left open.
+func cutMarkdown(md string, size int) string {
+	var b strings.Builder
+	var fence string // closing line of the open code fence, if any
+	for _, line := range strings.SplitAfter(md, "\n") {
+		next := fence
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") {
+			if fence == "" {
+				next = line[:len(line)-len(strings.TrimLeft(line, " "))] + "```\n"
+			} else {
+				next = ""
+			}
+		}
+		if b.Len()+len(line)+len(next) > size {
+			break
+		}
+		b.WriteString(line)
+		fence = next
+	}
+	if fence != "" {
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString(fence)
+	}
+	return b.String()
+}
+
+// escapeComment escapes mentions, issue references, and block-breaking HTML
+// outside code in md. The <details> blocks of folded schemas are kept.
+func escapeComment(md string) string {
+	lines := strings.SplitAfter(md, "\n")
+	inFence := false
+	folds := 0
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		// Keep the balanced blocks of folded schemas
+		switch trimmed := strings.TrimSpace(line); {
+		case foldOpenPattern.MatchString(trimmed):
+			folds++
+			continue
+		case trimmed == MarkerFoldClose && folds > 0:
+			folds--
+			continue
+		}
+
+		// Even segments are outside inline code spans
+		segments := strings.Split(line, "`")
+		for j := 0; j < len(segments); j += 2 {
+			segments[j] = blockTagPattern.ReplaceAllString(escapeMentions(segments[j]), "&lt;$1")
+		}
+		lines[i] = strings.Join(segments, "`")
+	}
+	return strings.Join(lines, "")
+}
+
+// escapeMentions breaks up @mentions and #123 issue references in text.
+func escapeMentions(text string) string {
+	text = mentionPattern.ReplaceAllString(text, "${1}@"+zeroWidthSpace+"${2}")
+	return issueRefPattern.ReplaceAllString(text, "${1}#"+zeroWidthSpace+"${2}")
+}
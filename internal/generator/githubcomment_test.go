@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_GitHubComment(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary:     "Get a user",
+			Description: "Ask @platform-team, see #42 and `@literal`. </details> ends here.",
+		},
+		Delete: &openapi3.Operation{Summary: "Delete a user", Deprecated: true},
+	}
+
+	comment, err := New(doc).Generate("/users/{id}", pathItem, WithFormat(FormatGitHubComment))
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"<details>\n<summary><b>GET</b> <code>/users/{id}</code> — Get a user</summary>\n\n",
+		"<summary><b>DELETE</b> <code>/users/{id}</code> — Delete a user (deprecated)</summary>",
+		"@" + zeroWidthSpace + "platform-team",
+		"#" + zeroWidthSpace + "42",
+		"`@literal`",
+		"&lt;/details> ends here.",
+		"\n\n</details>\n\n",
+	} {
+		if !strings.Contains(comment, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, comment)
+		}
+	}
+	for _, unexpected := range []string{"## GET /users/{id}", SeparatorOperation, MarkerCommentTruncated} {
+		if strings.Contains(comment, unexpected) {
+			t.Errorf("Did not expect %q in output:\n%s", unexpected, comment)
+		}
+	}
+	if got := strings.Count(comment, "<details>"); got != 2 {
+		t.Errorf("Expected 2 collapsible blocks, got %d:\n%s", got, comment)
+	}
+}
+
+func TestGenerate_GitHubCommentTruncated(t *testing.T) {
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", 60)
+	}
+	example := "Payload:\n\n```json\n" + strings.Join(lines, "\n") + "\n```"
+	pathItem := &openapi3.PathItem{
+		Get:  &openapi3.Operation{Summary: "Get", Description: example},
+		Put:  &openapi3.Operation{Summary: "Replace", Description: example},
+		Post: &openapi3.Operation{Summary: "Create", Description: example},
+	}
+
+	const limit = 4000
+	comment, err := New(nil).Generate("/items", pathItem, WithFormat(FormatGitHubComment), WithCommentLimit(limit))
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	if len(comment) > limit {
+		t.Errorf("Expected at most %d bytes, got %d", limit, len(comment))
+	}
+	expected := MarkerCommentTruncated + " Omitted: `POST /items`.\n"
+	if !strings.HasSuffix(comment, expected) {
+		t.Errorf("Expected output to end with %q:\n%s", expected, comment)
+	}
+	if got := strings.Count(comment, "<details>"); got != strings.Count(comment, "</details>") {
+		t.Errorf("Unbalanced collapsible blocks:\n%s", comment)
+	}
+	if got := strings.Count(comment, "```"); got%2 != 0 {
+		t.Errorf("Unclosed code fence:\n%s", comment)
+	}
+}
+
+func TestCutMarkdown(t *testing.T) {
+	md := "intro\n   ```http\n   GET /items\n   Accept: */*\n   ```\nafter\n"
+
+	got := cutMarkdown(md, 40)
+	expected := "intro\n   ```http\n   GET /items\n   ```\n"
+	if got != expected {
+		t.Errorf("cutMarkdown() = %q, want %q", got, expected)
+	}
+	if got := cutMarkdown(md, len(md)); got != md {
+		t.Errorf("cutMarkdown() = %q, want %q", got, md)
+	}
+}
+
+func TestEscapeMentions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ping @alice", "ping @" + zeroWidthSpace + "alice"},
+		{"@org/team", "@" + zeroWidthSpace + "org/team"},
+		{"mail a@example.com", "mail a@example.com"},
+		{"fixes #12", "fixes #" + zeroWidthSpace + "12"},
+		{"owner/repo#7", "owner/repo#" + zeroWidthSpace + "7"},
+		{"&#8203; and ## Heading", "&#8203; and ## Heading"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeMentions(tt.input); got != tt.expected {
+			t.Errorf("escapeMentions(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,496 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// The JSON output format describes the same elements as the markdown output
+// as a structured document. Every element carries the JSON pointer of its
+// source location in the spec, such as
+// "#/paths/~1events~1{id}/get/responses/200", so tools can map rendered
+// content back to the spec. Elements reached through a local $ref point at
+// the referenced component; those reached through an external $ref carry
+// the $ref itself. Elements not in the spec, like platform headers and team
+// notes, have no pointer.
+
+// JSONDocument is the JSON output for one endpoint.
+type JSONDocument struct {
+	Pointer    string          `json:"pointer"`
+	Path       string          `json:"path"`
+	API        *JSONAPI        `json:"api,omitempty"`
+	Servers    []JSONServer    `json:"servers,omitempty"`
+	Operations []JSONOperation `json:"operations"`
+}
+
+// JSONAPI identifies the API.
+type JSONAPI struct {
+	Pointer string `json:"pointer"`
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// JSONServer is a base URL of the API.
+type JSONServer struct {
+	Pointer     string `json:"pointer"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// JSONOperation is a rendered operation.
+type JSONOperation struct {
+	Pointer     string                    `json:"pointer"`
+	Method      string                    `json:"method"`
+	Path        string                    `json:"path"`
+	OperationID string                    `json:"operationId,omitempty"`
+	Summary     string                    `json:"summary,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Tags        []string                  `json:"tags,omitempty"`
+	Deprecated  bool                      `json:"deprecated,omitempty"`
+	Extensions  map[string]any            `json:"extensions,omitempty"`
+	Owners      []string                  `json:"owners,omitempty"`
+	TeamNotes   []TeamNote                `json:"teamNotes,omitempty"`
+	EnumUsage   []EnumUsage               `json:"enumUsage,omitempty"`
+	TypeCaveats []TypeCaveat              `json:"typeCaveats,omitempty"`
+	Parameters  []JSONParameter           `json:"parameters,omitempty"`
+	RequestBody *JSONRequestBody          `json:"requestBody,omitempty"`
+	Responses   []JSONResponse            `json:"responses,omitempty"`
+	Security    []JSONSecurityRequirement `json:"security,omitempty"`
+	// Error is set instead of the sections when the operation could not be
+	// rendered.
+	Error string `json:"error,omitempty"`
+}
+
+// JSONParameter is an operation parameter.
+type JSONParameter struct {
+	Pointer string `json:"pointer"`
+	Name    string `json:"name"`
+	In      string `json:"in"`
+	// Group is the ParamGroup of a query parameter.
+	Group       ParamGroup  `json:"group,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+	Schema      *JSONSchema `json:"schema,omitempty"`
+	Example     any         `json:"example,omitempty"`
+}
+
+// JSONRequestBody is an operation's request body.
+type JSONRequestBody struct {
+	Pointer     string          `json:"pointer"`
+	Description string          `json:"description,omitempty"`
+	Required    bool            `json:"required,omitempty"`
+	Content     []JSONMediaType `json:"content,omitempty"`
+}
+
+// JSONResponse is a response of an operation.
+type JSONResponse struct {
+	Pointer     string          `json:"pointer"`
+	Status      string          `json:"status"`
+	Reason      string          `json:"reason,omitempty"`
+	Category    StatusCategory  `json:"category,omitempty"`
+	NonStandard bool            `json:"nonStandard,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Headers     []JSONHeader    `json:"headers,omitempty"`
+	Content     []JSONMediaType `json:"content,omitempty"`
+}
+
+// JSONHeader is a response header or multipart part header.
+type JSONHeader struct {
+	Pointer     string      `json:"pointer,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Platform    bool        `json:"platform,omitempty"`
+	Schema      *JSONSchema `json:"schema,omitempty"`
+}
+
+// JSONMediaType is the body of a request or response in one content type.
+type JSONMediaType struct {
+	Pointer     string         `json:"pointer"`
+	ContentType string         `json:"contentType"`
+	Schema      *JSONSchema    `json:"schema,omitempty"`
+	Encoding    []JSONEncoding `json:"encoding,omitempty"`
+	Example     any            `json:"example,omitempty"`
+	Examples    []JSONExample  `json:"examples,omitempty"`
+}
+
+// JSONEncoding describes how a property of a multipart or form body is
+// serialized.
+type JSONEncoding struct {
+	Pointer       string       `json:"pointer"`
+	Property      string       `json:"property"`
+	ContentType   string       `json:"contentType,omitempty"`
+	Style         string       `json:"style,omitempty"`
+	Explode       *bool        `json:"explode,omitempty"`
+	AllowReserved bool         `json:"allowReserved,omitempty"`
+	Headers       []JSONHeader `json:"headers,omitempty"`
+}
+
+// JSONExample is a named example.
+type JSONExample struct {
+	Pointer     string `json:"pointer"`
+	Name        string `json:"name"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Value       any    `json:"value,omitempty"`
+}
+
+// JSONSchema is a schema and its nested properties.
+type JSONSchema struct {
+	Pointer     string         `json:"pointer"`
+	Type        string         `json:"type,omitempty"`
+	Format      string         `json:"format,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Nullable    bool           `json:"nullable,omitempty"`
+	Deprecated  bool           `json:"deprecated,omitempty"`
+	Default     any            `json:"default,omitempty"`
+	Example     any            `json:"example,omitempty"`
+	Enum        []any          `json:"enum,omitempty"`
+	Constraints string         `json:"constraints,omitempty"`
+	Properties  []JSONProperty `json:"properties,omitempty"`
+	Items       *JSONSchema    `json:"items,omitempty"`
+	OneOf       []*JSONSchema  `json:"oneOf,omitempty"`
+	AnyOf       []*JSONSchema  `json:"anyOf,omitempty"`
+	AllOf       []*JSONSchema  `json:"allOf,omitempty"`
+	// Truncated is set when MaxDepth stopped the recursion here.
+	Truncated bool `json:"truncated,omitempty"`
+	// Unresolved explains why the SchemaPath option addresses nothing in
+	// this body.
+	Unresolved string `json:"unresolved,omitempty"`
+}
+
+// JSONProperty is a property of an object schema.
+type JSONProperty struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required,omitempty"`
+	*JSONSchema
+}
+
+// JSONSecurityRequirement is one alternative of an operation's security.
+type JSONSecurityRequirement struct {
+	Pointer string               `json:"pointer"`
+	Schemes []JSONSecurityScheme `json:"schemes"`
+}
+
+// JSONSecurityScheme is a scheme of a security requirement.
+type JSONSecurityScheme struct {
+	Pointer string   `json:"pointer"`
+	Name    string   `json:"name"`
+	Scopes  []string `json:"scopes,omitempty"`
+}
+
+// generateJSON renders the endpoint as a JSONDocument.
+func (g *Generator) generateJSON(path string, pathItem *openapi3.PathItem, servers openapi3.Servers) (string, error) {
+	pathPointer := appendPointer("#", "paths", path)
+	out := JSONDocument{Pointer: pathPointer, Path: path, Operations: []JSONOperation{}}
+
+	if g.doc != nil {
+		if g.doc.Info != nil {
+			out.API = &JSONAPI{Pointer: "#/info", Title: g.doc.Info.Title, Version: g.doc.Info.Version}
+		}
+		for _, server := range servers {
+			out.Servers = append(out.Servers, JSONServer{
+				Pointer:     appendPointer("#", "servers", strconv.Itoa(serverIndex(g.doc.Servers, server))),
+				URL:         server.URL,
+				Description: server.Description,
+			})
+		}
+	}
+
+	for _, method := range model.MethodOrder {
+		operation := pathItem.GetOperation(method)
+		if operation == nil || (g.opts.Method != "" && method != g.opts.Method) {
+			continue
+		}
+		if !availableIn(operation.Extensions, g.opts.MinVersion) {
+			continue
+		}
+
+		pointer := appendPointer(pathPointer, strings.ToLower(method))
+		var op JSONOperation
+		if err := renderSafely(func() { op = g.jsonOperation(method, path, pointer, operation) }); err != nil {
+			op = JSONOperation{Pointer: pointer, Method: method, Path: path, Error: err.Error()}
+		}
+		out.Operations = append(out.Operations, op)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err == nil && g.fields != nil {
+		data, err = selectFields(data, g.fields)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// serverIndex returns the position of server in servers.
+func serverIndex(servers openapi3.Servers, server *openapi3.Server) int {
+	for i, s := range servers {
+		if s == server {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Generator) jsonOperation(method, path, pointer string, operation *openapi3.Operation) JSONOperation {
+	op := JSONOperation{Pointer: pointer, Method: method, Path: path}
+
+	if g.opts.hasSection(SectionMetadata) {
+		op.OperationID = operation.OperationID
+		op.Summary = operation.Summary
+		op.Description = operation.Description
+		op.Tags = operation.Tags
+		op.Deprecated = operation.Deprecated
+		if g.opts.IncludeExtensions && len(operation.Extensions) > 0 {
+			op.Extensions = operation.Extensions
+		}
+		op.Owners = g.opts.Owners[NoteKey(method, path)]
+		op.TeamNotes = g.opts.TeamNotes[NoteKey(method, path)]
+	}
+	op.EnumUsage = g.opts.EnumUsage[NoteKey(method, path)]
+	if g.opts.TypeCaveats {
+		op.TypeCaveats = TypeCaveats(operation)
+	}
+
+	if g.opts.hasSection(SectionParameters) {
+		for i, paramRef := range operation.Parameters {
+			if paramRef == nil || paramRef.Value == nil || !availableIn(paramRef.Value.Extensions, g.opts.MinVersion) {
+				continue
+			}
+			param := paramRef.Value
+			paramPointer := refPointer(paramRef.Ref, appendPointer(pointer, "parameters", strconv.Itoa(i)))
+			out := JSONParameter{
+				Pointer:     paramPointer,
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required,
+				Deprecated:  param.Deprecated,
+				Schema:      g.jsonSchema(param.Schema, appendPointer(paramPointer, "schema"), g.opts.MaxDepth),
+				Example:     param.Example,
+			}
+			if param.In == openapi3.ParameterInQuery {
+				out.Group = ClassifyQueryParameter(param)
+			}
+			op.Parameters = append(op.Parameters, out)
+		}
+	}
+
+	if g.opts.hasSection(SectionRequestBody) && operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		body := operation.RequestBody.Value
+		bodyPointer := refPointer(operation.RequestBody.Ref, appendPointer(pointer, "requestBody"))
+		op.RequestBody = &JSONRequestBody{
+			Pointer:     bodyPointer,
+			Description: body.Description,
+			Required:    body.Required,
+			Content:     g.jsonContent(body.Content, g.bodyContentTypes(body.Content), appendPointer(bodyPointer, "content")),
+		}
+	}
+
+	if g.opts.hasSection(SectionResponses) && operation.Responses != nil {
+		platform := g.platformHeaders()
+		for _, status := range getSortedStatusCodes(operation.Responses.Map()) {
+			respRef := operation.Responses.Value(status)
+			if respRef == nil || respRef.Value == nil {
+				continue
+			}
+			resp := respRef.Value
+			respPointer := refPointer(respRef.Ref, appendPointer(pointer, "responses", status))
+
+			semantics := ParseStatus(status)
+			out := JSONResponse{Pointer: respPointer, Status: status, Reason: semantics.Reason, Category: semantics.Category, NonStandard: semantics.NonStandard}
+			if resp.Description != nil {
+				out.Description = *resp.Description
+			}
+			for _, h := range mergeResponseHeaders(resp.Headers, platform) {
+				header := JSONHeader{Name: h.name, Description: h.header.Description, Required: h.header.Required, Platform: h.platform}
+				if !h.platform {
+					header.Pointer = refPointer(resp.Headers[h.name].Ref, appendPointer(respPointer, "headers", h.name))
+					header.Schema = g.jsonSchema(h.header.Schema, appendPointer(header.Pointer, "schema"), g.opts.MaxDepth)
+				} else if h.header.Schema != nil {
+					header.Schema = g.jsonSchema(h.header.Schema, "", g.opts.MaxDepth)
+				}
+				out.Headers = append(out.Headers, header)
+			}
+			out.Content = g.jsonContent(resp.Content, g.responseContentTypes(resp.Content), appendPointer(respPointer, "content"))
+			op.Responses = append(op.Responses, out)
+		}
+	}
+
+	if g.opts.hasSection(SectionSecurity) && operation.Security != nil {
+		for i, req := range *operation.Security {
+			requirement := JSONSecurityRequirement{Pointer: appendPointer(pointer, "security", strconv.Itoa(i)), Schemes: []JSONSecurityScheme{}}
+			for _, name := range getSortedKeys(req) {
+				requirement.Schemes = append(requirement.Schemes, JSONSecurityScheme{
+					Pointer: appendPointer("#", "components", "securitySchemes", name),
+					Name:    name,
+					Scopes:  req[name],
+				})
+			}
+			op.Security = append(op.Security, requirement)
+		}
+	}
+
+	return op
+}
+
+// jsonContent converts the given media types of a body.
+func (g *Generator) jsonContent(content openapi3.Content, contentTypes []string, pointer string) []JSONMediaType {
+	var out []JSONMediaType
+	for _, contentType := range contentTypes {
+		mediaType := content[contentType]
+		mediaPointer := appendPointer(pointer, contentType)
+		m := JSONMediaType{Pointer: mediaPointer, ContentType: contentType}
+
+		if mediaType.Schema != nil {
+			at := appendPointer(mediaPointer, "schema")
+			if g.schemaPath.IsZero() {
+				m.Schema = g.jsonSchema(mediaType.Schema, at, g.opts.MaxDepth)
+			} else if sub, subPointer, err := g.schemaPath.resolve(mediaType.Schema, at); err != nil {
+				m.Schema = &JSONSchema{Pointer: schemaPointer(mediaType.Schema, at), Unresolved: err.Error()}
+			} else {
+				m.Schema = g.jsonSchema(sub, subPointer, g.opts.MaxDepth)
+			}
+		}
+
+		for _, name := range getSortedKeys(mediaType.Encoding) {
+			enc := mediaType.Encoding[name]
+			if enc == nil {
+				continue
+			}
+			encPointer := appendPointer(mediaPointer, "encoding", name)
+			e := JSONEncoding{Pointer: encPointer, Property: name, ContentType: enc.ContentType, Style: enc.Style, Explode: enc.Explode, AllowReserved: enc.AllowReserved}
+			for _, headerName := range getSortedKeys(enc.Headers) {
+				ref := enc.Headers[headerName]
+				if ref == nil || ref.Value == nil {
+					continue
+				}
+				headerPointer := refPointer(ref.Ref, appendPointer(encPointer, "headers", headerName))
+				e.Headers = append(e.Headers, JSONHeader{
+					Pointer:     headerPointer,
+					Name:        headerName,
+					Description: ref.Value.Description,
+					Required:    ref.Value.Required,
+					Schema:      g.jsonSchema(ref.Value.Schema, appendPointer(headerPointer, "schema"), g.opts.MaxDepth),
+				})
+			}
+			m.Encoding = append(m.Encoding, e)
+		}
+
+		if g.opts.hasSection(SectionExamples) {
+			m.Example = mediaType.Example
+			for _, name := range getSortedExampleNames(mediaType.Examples) {
+				ref := mediaType.Examples[name]
+				if ref == nil || ref.Value == nil {
+					continue
+				}
+				m.Examples = append(m.Examples, JSONExample{
+					Pointer:     refPointer(ref.Ref, appendPointer(mediaPointer, "examples", name)),
+					Name:        name,
+					Summary:     ref.Value.Summary,
+					Description: ref.Value.Description,
+					Value:       ref.Value.Value,
+				})
+			}
+		}
+
+		out = append(out, m)
+	}
+	return out
+}
+
+// jsonSchema converts a schema, recursing into properties, items, and
+// compositions until depth runs out.
+func (g *Generator) jsonSchema(ref *openapi3.SchemaRef, pointer string, depth int) *JSONSchema {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	pointer = schemaPointer(ref, pointer)
+	if depth <= 0 {
+		return &JSONSchema{Pointer: pointer, Truncated: true}
+	}
+
+	schema := ref.Value
+	out := &JSONSchema{
+		Pointer:     pointer,
+		Description: schema.Description,
+		Format:      schema.Format,
+		Nullable:    IsNullable(schema),
+		Deprecated:  schema.Deprecated,
+		Default:     schema.Default,
+		Example:     schema.Example,
+		Enum:        schema.Enum,
+		Constraints: FormatConstraints(schema),
+	}
+	if schema.Type.Slice() != nil {
+		out.Type = FormatNonNullType(schema)
+	}
+
+	required := buildRequiredMap(schema.Required)
+	for _, name := range propertyNames(schema.Properties, g.opts.PropertyOrder) {
+		prop := schema.Properties[name]
+		if prop == nil || prop.Value == nil || !availableIn(prop.Value.Extensions, g.opts.MinVersion) {
+			continue
+		}
+		out.Properties = append(out.Properties, JSONProperty{
+			Name:       name,
+			Required:   required[name],
+			JSONSchema: g.jsonSchema(prop, appendPointer(pointer, "properties", name), depth-1),
+		})
+	}
+
+	out.Items = g.jsonSchema(schema.Items, appendPointer(pointer, "items"), depth-1)
+	out.OneOf = g.jsonSchemas(schema.OneOf, appendPointer(pointer, "oneOf"), depth-1)
+	out.AnyOf = g.jsonSchemas(schema.AnyOf, appendPointer(pointer, "anyOf"), depth-1)
+	out.AllOf = g.jsonSchemas(schema.AllOf, appendPointer(pointer, "allOf"), depth-1)
+
+	return out
+}
+
+func (g *Generator) jsonSchemas(refs openapi3.SchemaRefs, pointer string, depth int) []*JSONSchema {
+	var out []*JSONSchema
+	for i, ref := range refs {
+		if s := g.jsonSchema(ref, appendPointer(pointer, strconv.Itoa(i)), depth); s != nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// appendPointer appends tokens to a JSON pointer, escaping "~" and "/" as
+// RFC 6901 requires. An empty base stays empty: the element has no known
+// location.
+func appendPointer(base string, tokens ...string) string {
+	if base == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(base)
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
+
+// refPointer returns the location of an element reached through ref, or
+// pointer if it is defined inline.
+func refPointer(ref, pointer string) string {
+	if ref != "" {
+		return ref
+	}
+	return pointer
+}
+
+// schemaPointer returns the location of the schema ref defined at pointer.
+func schemaPointer(ref *openapi3.SchemaRef, pointer string) string {
+	return refPointer(ref.Ref, pointer)
+}
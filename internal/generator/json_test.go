@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const jsonSpec = `
+openapi: 3.0.3
+info: {title: Events API, version: 1.0.0}
+servers:
+  - {url: https://api.example.com}
+paths:
+  /events/{id}:
+    get:
+      operationId: getEvent
+      parameters:
+        - $ref: '#/components/parameters/EventID'
+        - {name: fields, in: query, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Event'}
+              examples:
+                basic: {value: {id: e1}}
+      security:
+        - api_key: []
+components:
+  parameters:
+    EventID: {name: id, in: path, required: true, schema: {type: string}}
+  schemas:
+    Event:
+      type: object
+      properties:
+        id: {type: string}
+        a~b/c: {type: string}
+  securitySchemes:
+    api_key: {type: apiKey, in: header, name: X-API-Key}
+`
+
+func TestGenerate_JSONPointers(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(jsonSpec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	out, err := New(doc).Generate("/events/{id}", doc.Paths.Value("/events/{id}"), WithFormat(FormatJSONDocument))
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	var result JSONDocument
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+
+	if len(result.Operations) != 1 {
+		t.Fatalf("Expected 1 operation, got %d", len(result.Operations))
+	}
+	op := result.Operations[0]
+	response := op.Responses[0]
+	media := response.Content[0]
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"Path", result.Pointer, "#/paths/~1events~1{id}"},
+		{"Server", result.Servers[0].Pointer, "#/servers/0"},
+		{"Operation", op.Pointer, "#/paths/~1events~1{id}/get"},
+		{"Referenced parameter", op.Parameters[0].Pointer, "#/components/parameters/EventID"},
+		{"Referenced parameter schema", op.Parameters[0].Schema.Pointer, "#/components/parameters/EventID/schema"},
+		{"Inline parameter", op.Parameters[1].Pointer, "#/paths/~1events~1{id}/get/parameters/1"},
+		{"Response", response.Pointer, "#/paths/~1events~1{id}/get/responses/200"},
+		{"Media type", media.Pointer, "#/paths/~1events~1{id}/get/responses/200/content/application~1json"},
+		{"Referenced schema", media.Schema.Pointer, "#/components/schemas/Event"},
+		{"Escaped property", media.Schema.Properties[0].Pointer, "#/components/schemas/Event/properties/a~0b~1c"},
+		{"Example", media.Examples[0].Pointer, "#/paths/~1events~1{id}/get/responses/200/content/application~1json/examples/basic"},
+		{"Security requirement", op.Security[0].Pointer, "#/paths/~1events~1{id}/get/security/0"},
+		{"Security scheme", op.Security[0].Schemes[0].Pointer, "#/components/securitySchemes/api_key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("pointer = %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSONDocument {
+		t.Errorf("ParseFormat(JSON) = %q, %v", f, err)
+	}
+	if f, err := ParseFormat("github-comment"); err != nil || f != FormatGitHubComment {
+		t.Errorf("ParseFormat(github-comment) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("html"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestGenerate_JSONFields(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(jsonSpec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	pathItem := doc.Paths.Value("/events/{id}")
+
+	tests := []struct {
+		name     string
+		fields   []string
+		expected string
+	}{
+		{
+			"Operation field",
+			[]string{"operation.operationId"},
+			`{"operations":[{"method":"GET","operationId":"getEvent","path":"/events/{id}"}],"path":"/events/{id}"}`,
+		},
+		{
+			"Array elements keep identity",
+			[]string{"parameters.required"},
+			`{"operations":[{"method":"GET","parameters":[{"in":"path","name":"id","required":true}],"path":"/events/{id}"}],"path":"/events/{id}"}`,
+		},
+		{
+			"Element by status through content",
+			[]string{"responses.200.schema.type", "api.title"},
+			`{"api":{"title":"Events API"},"operations":[{"method":"GET","path":"/events/{id}","responses":[{"content":[{"contentType":"application/json","schema":{"type":"object"}}],"status":"200"}]}],"path":"/events/{id}"}`,
+		},
+		{
+			"Whole value wins",
+			[]string{"security.schemes.name", "security"},
+			`{"operations":[{"method":"GET","path":"/events/{id}","security":[{"pointer":"#/paths/~1events~1{id}/get/security/0","schemes":[{"name":"api_key","pointer":"#/components/securitySchemes/api_key"}]}]}],"path":"/events/{id}"}`,
+		},
+		{
+			"Nothing selected",
+			[]string{"requestBody"},
+			`{"operations":[{"method":"GET","path":"/events/{id}"}],"path":"/events/{id}"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := New(doc).Generate("/events/{id}", pathItem, WithFormat(FormatJSONDocument), WithFields(tt.fields...))
+			if err != nil {
+				t.Fatalf("Generate() error: %v", err)
+			}
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, []byte(out)); err != nil {
+				t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+			}
+			if compact.String() != tt.expected {
+				t.Errorf("Generate() =\n%s\nwant\n%s", compact.String(), tt.expected)
+			}
+		})
+	}
+
+	if _, err := New(doc).Generate("/events/{id}", pathItem, WithFields("summary")); err == nil {
+		t.Error("Expected error selecting fields of markdown output")
+	}
+	if _, err := New(doc).Generate("/events/{id}", pathItem, WithFormat(FormatJSONDocument), WithFields("responses..schema")); err == nil {
+		t.Error("Expected error for an empty segment")
+	}
+}
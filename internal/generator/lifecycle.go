@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/version"
+)
+
+// Extensions tracking when an element was introduced and removed.
+const (
+	ExtensionSince     = "x-since"
+	ExtensionRemovedIn = "x-removed-in"
+	// ExtensionReplacedBy names the replacement of a deprecated element: on
+	// an operation, "METHOD /path", "/path", or an operationId; on a
+	// parameter or property, the name of the one replacing it.
+	ExtensionReplacedBy = "x-replaced-by"
+)
+
+// availableIn reports whether an element with the given extensions exists in
+// apiVersion. An empty apiVersion matches everything.
+func availableIn(extensions map[string]any, apiVersion string) bool {
+	if apiVersion == "" {
+		return true
+	}
+
+	if since := extensionString(extensions, ExtensionSince); since != "" && version.Compare(since, apiVersion) > 0 {
+		return false
+	}
+	if removed := extensionString(extensions, ExtensionRemovedIn); removed != "" && version.Compare(removed, apiVersion) <= 0 {
+		return false
+	}
+	return true
+}
+
+// formatLifecycle returns an annotation such as "Available since v2.3; removed
+// in v4.0", or empty string if the element has no lifecycle extensions.
+func formatLifecycle(extensions map[string]any) string {
+	var parts []string
+	if since := extensionString(extensions, ExtensionSince); since != "" {
+		parts = append(parts, "Available since "+versionLabel(since))
+	}
+	if removed := extensionString(extensions, ExtensionRemovedIn); removed != "" {
+		parts = append(parts, "Removed in "+versionLabel(removed))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// versionLabel prefixes numeric versions with "v".
+func versionLabel(v string) string {
+	if v != "" && v[0] >= '0' && v[0] <= '9' && !strings.Contains(v, "-") {
+		return "v" + v
+	}
+	return v
+}
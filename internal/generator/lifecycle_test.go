@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func lifecyclePathItem() *openapi3.PathItem {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewStringSchema()).
+		WithProperty("labels", &openapi3.Schema{
+			Type:       &openapi3.Types{"array"},
+			Extensions: map[string]any{ExtensionSince: "2.3"},
+		}).
+		WithProperty("legacy_name", &openapi3.Schema{
+			Type:       &openapi3.Types{"string"},
+			Extensions: map[string]any{ExtensionRemovedIn: "3.0"},
+		})
+
+	return &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary: "Get item",
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewPathParameter("id").WithSchema(openapi3.NewStringSchema())},
+				{Value: &openapi3.Parameter{
+					Name: "expand", In: "query",
+					Schema:     openapi3.NewStringSchema().NewRef(),
+					Extensions: map[string]any{ExtensionSince: "v2.5"},
+				}},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").
+					WithContent(openapi3.NewContentWithJSONSchema(schema))}),
+			),
+		},
+		Patch: &openapi3.Operation{
+			Summary:    "Update item",
+			Extensions: map[string]any{ExtensionSince: "2.4", ExtensionRemovedIn: "4.0"},
+		},
+	}
+}
+
+func TestGenerate_Lifecycle(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	t.Run("Annotations", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/items/{id}", lifecyclePathItem(), "")
+
+		for _, want := range []string{
+			"**Availability:** Available since v2.4; Removed in v4.0",
+			"  - Availability: Available since v2.5",
+			"    - Availability: Available since v2.3",
+			"    - Availability: Removed in v3.0",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+	})
+
+	tests := []struct {
+		version string
+		present []string
+		absent  []string
+	}{
+		{"2.2", []string{"**legacy_name**"}, []string{"## PATCH", "**expand**", "**labels**"}},
+		{"2.4", []string{"## PATCH", "**labels**", "**legacy_name**"}, []string{"**expand**"}},
+		{"v3.0", []string{"## PATCH", "**expand**", "**labels**"}, []string{"**legacy_name**"}},
+		{"4.0", []string{"## GET"}, []string{"## PATCH"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("MinVersion "+tt.version, func(t *testing.T) {
+			markdown := New(doc, WithMinVersion(tt.version)).GenerateMarkdown("/items/{id}", lifecyclePathItem(), "")
+
+			for _, want := range tt.present {
+				if !strings.Contains(markdown, want) {
+					t.Errorf("Expected %q for version %s", want, tt.version)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(markdown, unwanted) {
+					t.Errorf("Did not expect %q for version %s", unwanted, tt.version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The mdx output format is the markdown output made safe for MDX, as used by
+// Docusaurus. MDX parses "{" as the start of a JavaScript expression and "<"
+// as the start of a JSX element, so both are escaped outside code. Code
+// samples are wrapped in the Tabs and TabItem theme components, imported at
+// the top of the document.
+
+// MDXImports imports the Docusaurus components used by code samples.
+const MDXImports = "import Tabs from '@theme/Tabs';\nimport TabItem from '@theme/TabItem';\n\n"
+
+// mdxTagPattern matches the lines of JSX the generator emits itself, which
+// are kept as they are: tabs and folded schemas.
+var mdxTagPattern = regexp.MustCompile(`^(</?Tabs\b.*>|</?TabItem\b.*>|<details><summary>[\w ]+</summary>|</details>)$`)
+
+// writeSnippetTabs writes one tab per language, sharing the groupId so the
+// choice of language is kept across operations and pages.
+func writeSnippetTabs(md *strings.Builder, languages []SnippetLanguage, req SnippetRequest) {
+	md.WriteString("<Tabs groupId=\"language\">\n")
+	for _, language := range languages {
+		fmt.Fprintf(md, "<TabItem value=%q label=%q>\n\n", language.Name(), language.Label())
+		fmt.Fprintf(md, "```%s\n%s\n```\n\n", language.Fence(), strings.TrimRight(language.Render(req), "\n"))
+		md.WriteString("</TabItem>\n")
+	}
+	md.WriteString("</Tabs>\n\n")
+}
+
+// mdx converts rendered markdown to MDX, importing the tab components if
+// code samples use them.
+func mdx(md string) string {
+	var b strings.Builder
+	if strings.Contains(md, "\n<Tabs ") {
+		b.WriteString(MDXImports)
+	}
+
+	inFence := false
+	for i, line := range strings.Split(md, "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			b.WriteString(line)
+			continue
+		}
+		if inFence || mdxTagPattern.MatchString(line) {
+			b.WriteString(line)
+			continue
+		}
+		b.WriteString(escapeMDXLine(line))
+	}
+	return b.String()
+}
+
+// escapeMDXLine escapes the characters MDX would parse as expressions or
+// JSX, leaving code spans alone.
+func escapeMDXLine(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		if line[i] == '`' {
+			run := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+			delimiter := line[i : i+run]
+			if end := strings.Index(line[i+run:], delimiter); end >= 0 {
+				span := i + run + end + run
+				b.WriteString(line[i:span])
+				i = span
+				continue
+			}
+			b.WriteString(delimiter)
+			i += run
+			continue
+		}
+		switch line[i] {
+		case '{', '}':
+			b.WriteByte('\\')
+			b.WriteByte(line[i])
+		case '<':
+			b.WriteString("&lt;")
+		default:
+			b.WriteByte(line[i])
+		}
+		i++
+	}
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+)
+
+// MediaType describes a content type of a request or response body.
+type MediaType struct {
+	// Type and Subtype are the parts of the media type, e.g. "application"
+	// and "vnd.acme.v2+json"; either is "*" in a media range.
+	Type    string
+	Subtype string
+	// Suffix is the structured syntax suffix without "+", e.g. "json".
+	Suffix string
+	// Vendor is the vendor of a vnd. subtype, e.g. "acme".
+	Vendor string
+	// Version is the version named in a vnd. subtype, e.g. "v2", or in a
+	// version parameter.
+	Version string
+}
+
+// versionSegment matches the version segment of a vendor subtype.
+var versionSegment = regexp.MustCompile(`^v\d+(\.\d+)*$`)
+
+// ParseMediaType parses a content type such as
+// "application/vnd.acme.v2+json" or "application/*".
+func ParseMediaType(contentType string) MediaType {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(contentType)), ";")
+	}
+
+	var m MediaType
+	m.Type, m.Subtype, _ = strings.Cut(mediaType, "/")
+	name := m.Subtype
+	if i := strings.LastIndex(name, "+"); i >= 0 {
+		name, m.Suffix = name[:i], name[i+1:]
+	}
+
+	if vendor, ok := strings.CutPrefix(name, "vnd."); ok {
+		var rest []string
+		for _, segment := range strings.Split(vendor, ".") {
+			if m.Version == "" && versionSegment.MatchString(segment) {
+				m.Version = segment
+				continue
+			}
+			rest = append(rest, segment)
+		}
+		m.Vendor = strings.Join(rest, ".")
+	}
+	if v := params["version"]; v != "" && m.Version == "" {
+		m.Version = v
+	}
+	return m
+}
+
+// IsRange reports whether m is a media range such as "application/*".
+func (m MediaType) IsRange() bool {
+	return m.Type == "*" || m.Subtype == "*"
+}
+
+// IsJSON reports whether m carries JSON: application/json, a +json
+// structured syntax suffix, or a range JSON falls in, such as
+// "application/*".
+func (m MediaType) IsJSON() bool {
+	switch {
+	case m.Type == "" && m.Subtype == "":
+		return true
+	case m.Subtype == "json", m.Suffix == "json":
+		return true
+	case m.IsRange():
+		return m.Type == "*" || m.Type == "application"
+	}
+	return false
+}
+
+// note returns what the Content-Type line says about m beyond the media
+// type itself: the vendor, version, and syntax of vendor types, and what a
+// range covers. It is empty for other types.
+func (m MediaType) note() string {
+	if m.IsRange() {
+		if m.Type == "*" {
+			return "any media type"
+		}
+		return fmt.Sprintf("any %s type", m.Type)
+	}
+
+	var parts []string
+	if m.Vendor != "" {
+		parts = append(parts, "vendor "+m.Vendor)
+	}
+	if m.Version != "" {
+		parts = append(parts, "version "+m.Version)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if m.Suffix != "" {
+		parts = append(parts, strings.ToUpper(m.Suffix))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// isJSONContentType reports whether a media type carries JSON, including
+// structured syntax suffixes such as application/problem+json and ranges
+// such as application/*.
+func isJSONContentType(contentType string) bool {
+	return ParseMediaType(contentType).IsJSON()
+}
+
+// writeContentType writes the Content-Type line of a body, calling out the
+// vendor and version of vendor types and what a media range covers.
+func writeContentType(md *strings.Builder, contentType string) {
+	if note := ParseMediaType(contentType).note(); note != "" {
+		fmt.Fprintf(md, "**Content-Type:** `%s` (%s)\n\n", contentType, note)
+		return
+	}
+	fmt.Fprintf(md, "**Content-Type:** `%s`\n\n", contentType)
+}
+
+// requestContentType returns the Content-Type header a request with a body
+// of contentType sends: the type itself, or for a range a type in it.
+func requestContentType(contentType string) string {
+	m := ParseMediaType(contentType)
+	switch {
+	case !m.IsRange():
+		return contentType
+	case m.IsJSON():
+		return "application/json"
+	case m.Type == "text":
+		return "text/plain"
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParseMediaType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    MediaType
+	}{
+		{"application/json", MediaType{Type: "application", Subtype: "json"}},
+		{"application/vnd.company.v2+json", MediaType{Type: "application", Subtype: "vnd.company.v2+json", Suffix: "json", Vendor: "company", Version: "v2"}},
+		{"application/vnd.github.raw", MediaType{Type: "application", Subtype: "vnd.github.raw", Vendor: "github.raw"}},
+		{"application/vnd.acme+json; version=3", MediaType{Type: "application", Subtype: "vnd.acme+json", Suffix: "json", Vendor: "acme", Version: "3"}},
+		{"Application/*", MediaType{Type: "application", Subtype: "*"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseMediaType(tt.contentType); got != tt.expected {
+			t.Errorf("ParseMediaType(%q) = %+v, want %+v", tt.contentType, got, tt.expected)
+		}
+	}
+}
+
+func TestIsJSONContentType(t *testing.T) {
+	tests := map[string]bool{
+		"":                                true,
+		"application/json":                true,
+		"application/json; charset=utf-8": true,
+		"application/problem+json":        true,
+		"application/vnd.company.v2+json": true,
+		"application/*":                   true,
+		"*/*":                             true,
+		"text/*":                          false,
+		"application/xml":                 false,
+		"application/vnd.company.v2+xml":  false,
+	}
+
+	for contentType, expected := range tests {
+		if got := isJSONContentType(contentType); got != expected {
+			t.Errorf("isJSONContentType(%q) = %v, want %v", contentType, got, expected)
+		}
+	}
+}
+
+const mediaTypeSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+paths:
+  /accounts:
+    post:
+      requestBody:
+        content:
+          application/*:
+            example:
+              name: ops
+      responses:
+        "201":
+          description: Created
+          content:
+            application/vnd.company.v2+json:
+              example: '{"id": 1}'
+            text/plain:
+              example: created
+`
+
+func TestGenerate_MediaTypes(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(mediaTypeSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	markdown := New(doc, WithSnippets(curlSnippet{})).GenerateMarkdown("/accounts", doc.Paths.Find("/accounts"), "POST")
+
+	for _, want := range []string{
+		"**Content-Type:** `application/*` (any application type)\n",
+		"**Content-Type:** `application/vnd.company.v2+json` (vendor company, version v2, JSON)\n",
+		"**Content-Type:** `text/plain`\n",
+		// A JSON string example of a vendor JSON type is shown as JSON
+		"```json\n\"{\\\"id\\\": 1}\"\n```",
+		"-H 'Content-Type: application/json'",
+		"--data-raw '{\n  \"name\": \"ops\"\n}'",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+}
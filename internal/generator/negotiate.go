@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// MediaRange is one entry of a content preference, like an entry of an
+// Accept header: "application/json", "application/*", or "*/*" with a
+// quality between 0 and 1.
+type MediaRange struct {
+	Type    string
+	Quality float64
+}
+
+// ParsePreference parses an Accept-style content preference such as
+// "application/json;q=1, application/xml;q=0.5". Entries without q have
+// quality 1.
+func ParsePreference(s string) ([]MediaRange, error) {
+	var ranges []MediaRange
+	for _, entry := range strings.Split(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid media range %q: %w", strings.TrimSpace(entry), err)
+		}
+
+		r := MediaRange{Type: mediaType, Quality: 1}
+		if q, ok := params["q"]; ok {
+			if r.Quality, err = strconv.ParseFloat(q, 64); err != nil || r.Quality < 0 || r.Quality > 1 {
+				return nil, fmt.Errorf("invalid quality %q for %s (expected 0 to 1)", q, mediaType)
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// negotiate returns the content type a client with the given preference
+// would receive: the one with the highest quality, taken from the most
+// specific matching range. Ties go to the range listed first, then to the
+// first content type. It returns false if no content type is acceptable.
+func negotiate(contentTypes []string, prefer []MediaRange) (string, bool) {
+	best, bestQuality, bestRank := "", 0.0, 0
+	for _, contentType := range contentTypes {
+		quality, rank, ok := acceptQuality(contentType, prefer)
+		if !ok || quality == 0 {
+			continue
+		}
+		if best == "" || quality > bestQuality || (quality == bestQuality && rank < bestRank) {
+			best, bestQuality, bestRank = contentType, quality, rank
+		}
+	}
+	return best, best != ""
+}
+
+// acceptQuality returns the quality prefer gives contentType and the index
+// of the range it comes from. The most specific matching range wins, as in
+// HTTP content negotiation.
+func acceptQuality(contentType string, prefer []MediaRange) (quality float64, rank int, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+
+	specificity := -1
+	for i, r := range prefer {
+		rangeType, rangeSubtype, _ := strings.Cut(r.Type, "/")
+		s := -1
+		switch {
+		case r.Type == mediaType:
+			s = 2
+		case rangeType == typ && (rangeSubtype == "*" || subtype == "*"):
+			s = 1
+		case rangeType == "*" || typ == "*":
+			s = 0
+		}
+		if s > specificity {
+			specificity, quality, rank, ok = s, r.Quality, i, true
+		}
+	}
+	return quality, rank, ok
+}
+
+// bodyContentTypes returns the sorted content types of content that pass
+// the ContentTypes filter.
+func (g *Generator) bodyContentTypes(content openapi3.Content) []string {
+	var types []string
+	for _, contentType := range getSortedContentTypes(content) {
+		if content[contentType] != nil && g.opts.includesContentType(contentType) {
+			types = append(types, contentType)
+		}
+	}
+	return types
+}
+
+// responseContentTypes returns the content types of a response to render:
+// those passing the ContentTypes filter or, with a Prefer option, the one
+// a client with that preference would receive.
+func (g *Generator) responseContentTypes(content openapi3.Content) []string {
+	types := g.bodyContentTypes(content)
+	if len(g.opts.Prefer) == 0 {
+		return types
+	}
+	if best, ok := negotiate(types, g.opts.Prefer); ok {
+		return []string{best}
+	}
+	return nil
+}
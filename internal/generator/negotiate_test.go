@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParsePreference(t *testing.T) {
+	ranges, err := ParsePreference("application/json;q=1, application/xml;q=0.5, text/*")
+	if err != nil {
+		t.Fatalf("ParsePreference() error: %v", err)
+	}
+	expected := []MediaRange{{"application/json", 1}, {"application/xml", 0.5}, {"text/*", 1}}
+	if len(ranges) != len(expected) {
+		t.Fatalf("ParsePreference() = %v, want %v", ranges, expected)
+	}
+	for i := range expected {
+		if ranges[i] != expected[i] {
+			t.Errorf("ParsePreference()[%d] = %v, want %v", i, ranges[i], expected[i])
+		}
+	}
+
+	for _, invalid := range []string{"application/json;q=2", "application/json;q=high", "not a type;;"} {
+		if _, err := ParsePreference(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefer       string
+		contentTypes []string
+		expected     string
+	}{
+		{"highest quality", "application/json;q=0.5, application/xml", []string{"application/json", "application/xml"}, "application/xml"},
+		{"tie goes to first range", "application/xml, application/json", []string{"application/json", "application/xml"}, "application/xml"},
+		{"wildcard subtype", "text/*", []string{"application/json", "text/csv"}, "text/csv"},
+		{"specific range wins over wildcard", "application/*, application/xml;q=0", []string{"application/xml", "application/json"}, "application/json"},
+		{"parameters ignored", "application/json", []string{"application/json; charset=utf-8"}, "application/json; charset=utf-8"},
+		{"spec wildcard", "application/json", []string{"*/*"}, "*/*"},
+		{"nothing acceptable", "application/json", []string{"application/xml"}, ""},
+		{"refused", "*/*, application/xml;q=0", []string{"application/xml"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefer, err := ParsePreference(tt.prefer)
+			if err != nil {
+				t.Fatalf("ParsePreference() error: %v", err)
+			}
+			if got, _ := negotiate(tt.contentTypes, prefer); got != tt.expected {
+				t.Errorf("negotiate() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateMarkdown_Prefer(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /events:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+            application/xml:
+              schema:
+                type: object
+        "204":
+          description: No content
+        "406":
+          description: Not acceptable
+          content:
+            text/plain:
+              schema:
+                type: string
+`))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	prefer, _ := ParsePreference("application/json;q=1, application/xml;q=0.5")
+	markdown, err := New(doc).Generate("/events", doc.Paths.Value("/events"), WithPrefer(prefer...))
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"**Content-Type:** `application/json`",
+		"*No content type matches the preference. Available: `text/plain`.*",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, markdown)
+		}
+	}
+	if strings.Contains(markdown, "application/xml") {
+		t.Errorf("Did not expect %q in output:\n%s", "application/xml", markdown)
+	}
+	if got := strings.Count(markdown, "No content type matches"); got != 1 {
+		t.Errorf("Expected one not-acceptable note, got %d:\n%s", got, markdown)
+	}
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TeamNote is a note about an operation kept outside the spec, such as
+// operational knowledge that would otherwise live in chat threads.
+type TeamNote struct {
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"`
+	// Date is when the note was added, e.g. "2026-03-01".
+	Date string `json:"date,omitempty"`
+}
+
+// NoteKey returns the key of an operation's notes in
+// GenerateOptions.TeamNotes, e.g. "GET /events/{id}".
+func NoteKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// writeTeamNotes writes the notes kept for an operation.
+func (g *Generator) writeTeamNotes(md *strings.Builder, method, path string) {
+	notes := g.opts.TeamNotes[NoteKey(method, path)]
+	if len(notes) == 0 {
+		return
+	}
+
+	md.WriteString(heading(g.opts.Vocabulary.TeamNotes))
+	for _, note := range notes {
+		text := strings.Join(strings.Fields(note.Text), " ")
+		var byline []string
+		for _, s := range []string{note.Author, note.Date} {
+			if s != "" {
+				byline = append(byline, s)
+			}
+		}
+		if len(byline) > 0 {
+			fmt.Fprintf(md, "- %s — *%s*\n", text, strings.Join(byline, ", "))
+		} else {
+			fmt.Fprintf(md, "- %s\n", text)
+		}
+	}
+	md.WriteString("\n")
+}
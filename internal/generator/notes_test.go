@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_TeamNotes(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Get:    &openapi3.Operation{Summary: "Get event"},
+		Delete: &openapi3.Operation{Summary: "Delete event"},
+	}
+	gen := New(&openapi3.T{}, WithTeamNotes(map[string][]TeamNote{
+		NoteKey("get", "/events/{id}"): {
+			{Text: "rate limited to 10 rps\nin prod", Author: "alice", Date: "2026-03-01"},
+			{Text: "cached for 30s"},
+		},
+	}))
+
+	result, err := gen.Generate("/events/{id}", pathItem)
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	expected := "**Summary:** Get event\n\n" + HeaderTeamNotes +
+		"- rate limited to 10 rps in prod — *alice, 2026-03-01*\n- cached for 30s\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, result)
+	}
+	if strings.Count(result, HeaderTeamNotes) != 1 {
+		t.Errorf("Expected notes only for GET:\n%s", result)
+	}
+
+	result, _ = gen.Generate("/events/{id}", pathItem, WithSections(SectionParameters))
+	if strings.Contains(result, HeaderTeamNotes) {
+		t.Errorf("Did not expect notes without the metadata section:\n%s", result)
+	}
+}
+
+func TestGenerate_Owners(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Get:    &openapi3.Operation{Summary: "Get payment"},
+		Delete: &openapi3.Operation{Summary: "Delete payment"},
+	}
+	gen := New(&openapi3.T{}, WithOwners(map[string][]string{
+		NoteKey("delete", "/payments/{id}"): {"payments-team", "fraud-team"},
+	}))
+
+	result, err := gen.Generate("/payments/{id}", pathItem)
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	expected := "**Summary:** Delete payment\n\n**Owners:** payments-team, fraud-team\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, result)
+	}
+	if strings.Count(result, "**Owners:**") != 1 {
+		t.Errorf("Expected owners only for DELETE:\n%s", result)
+	}
+}
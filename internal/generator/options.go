@@ -0,0 +1,554 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section identifies a part of the rendered operation documentation.
+type Section string
+
+const (
+	SectionMetadata    Section = "metadata"
+	SectionParameters  Section = "parameters"
+	SectionRequestBody Section = "request-body"
+	SectionResponses   Section = "responses"
+	SectionSecurity    Section = "security"
+	SectionExamples    Section = "examples"
+)
+
+// AllSections lists every section in rendering order.
+var AllSections = []Section{
+	SectionMetadata,
+	SectionParameters,
+	SectionRequestBody,
+	SectionResponses,
+	SectionSecurity,
+	SectionExamples,
+}
+
+// ParseSections parses a comma-separated list of section names.
+func ParseSections(s string) ([]Section, error) {
+	var sections []Section
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		section, ok := sectionByName(name)
+		if !ok {
+			names := make([]string, len(AllSections))
+			for i, sec := range AllSections {
+				names[i] = string(sec)
+			}
+			return nil, fmt.Errorf("unknown section '%s'. Available sections: %s", name, strings.Join(names, ", "))
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+func sectionByName(name string) (Section, bool) {
+	for _, section := range AllSections {
+		if string(section) == name {
+			return section, true
+		}
+	}
+	return "", false
+}
+
+// Format is an output format.
+type Format string
+
+// Output formats.
+const (
+	// FormatMarkdown is the default output format.
+	FormatMarkdown Format = "markdown"
+	// FormatJSONDocument renders a JSONDocument whose elements carry the
+	// JSON pointers of their source locations.
+	FormatJSONDocument Format = "json"
+	// FormatGitHubComment renders markdown for a GitHub comment: each
+	// operation in a collapsible block, mentions and issue references
+	// escaped, and the whole kept under the comment size limit.
+	FormatGitHubComment Format = "github-comment"
+	// FormatMDX renders markdown that MDX, as used by Docusaurus, parses
+	// as written, with code samples in tabs.
+	FormatMDX Format = "mdx"
+	// FormatTerm renders text for reading in a terminal, without markdown
+	// syntax and, with WithColor, styled with ANSI escape codes.
+	FormatTerm Format = "term"
+)
+
+// ParseFormat parses an output format name.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatMarkdown, FormatJSONDocument, FormatGitHubComment, FormatMDX, FormatTerm:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown format: %s (expected markdown, json, github-comment, mdx, or term)", s)
+}
+
+// PropertyOrder is the order schema properties are listed in.
+type PropertyOrder string
+
+// Property orders.
+const (
+	// PropertyOrderSpec lists properties in the order the spec declares
+	// them. Schemas whose declaration order is unknown, such as those built
+	// in memory, fall back to PropertyOrderAlpha.
+	PropertyOrderSpec PropertyOrder = "spec"
+	// PropertyOrderAlpha lists properties alphabetically.
+	PropertyOrderAlpha PropertyOrder = "alpha"
+)
+
+// ParsePropertyOrder parses a property order name.
+func ParsePropertyOrder(s string) (PropertyOrder, error) {
+	switch o := PropertyOrder(strings.ToLower(strings.TrimSpace(s))); o {
+	case PropertyOrderSpec, PropertyOrderAlpha:
+		return o, nil
+	}
+	return "", fmt.Errorf("unknown property order: %s (expected spec or alpha)", s)
+}
+
+// NullableStyle is how types that accept null are displayed.
+type NullableStyle string
+
+// Nullable styles. Both 3.0 "nullable: true" and a 3.1 "null" in the type
+// list are displayed the same way.
+const (
+	// NullableSuffix displays the non-null type followed by "(nullable)",
+	// e.g. "string (nullable)".
+	NullableSuffix NullableStyle = "suffix"
+	// NullableField displays the non-null type and a separate
+	// "Nullable: true" line.
+	NullableField NullableStyle = "field"
+	// NullableUnion displays null as one of the types, e.g. "string | null".
+	NullableUnion NullableStyle = "union"
+)
+
+// ParseNullableStyle parses a nullable style name.
+func ParseNullableStyle(s string) (NullableStyle, error) {
+	switch style := NullableStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case NullableSuffix, NullableField, NullableUnion:
+		return style, nil
+	}
+	return "", fmt.Errorf("unknown nullable style: %s (expected suffix, field, or union)", s)
+}
+
+// GenerateOptions controls what the Generator renders.
+type GenerateOptions struct {
+	// Method restricts output to one uppercase HTTP method. Empty means all methods.
+	Method string
+	// Sections restricts output to the listed sections. Nil means all sections.
+	Sections []Section
+	// Format is the output format.
+	Format Format
+	// MaxDepth limits schema recursion depth.
+	MaxDepth int
+	// ContentTypes restricts request and response bodies to matching media
+	// types. Entries may use a "type/*" wildcard. Nil means all content types.
+	ContentTypes []string
+	// Prefer renders, for each response, only the content type a client
+	// sending this preference in its Accept header would receive. Nil
+	// renders every content type.
+	Prefer []MediaRange
+	// IncludeExtensions renders operation-level x- vendor extensions.
+	IncludeExtensions bool
+	// Badges enables shields.io badges. Nil disables them.
+	Badges *BadgeConfig
+	// MaxExampleLines truncates longer examples. Zero disables truncation.
+	MaxExampleLines int
+	// ExampleAttacher stores the full payload of truncated or trimmed
+	// examples.
+	ExampleAttacher ExampleAttacher
+	// TrimExamples removes null-valued fields and fields matching TrimKeys
+	// from rendered examples.
+	TrimExamples bool
+	// TrimKeys are the dotted field paths removed by TrimExamples (see
+	// DefaultTrimKeys).
+	TrimKeys []string
+	// MarkdownDescriptions renders descriptions as sanitized markdown blocks
+	// nested under their list items instead of inlining them.
+	MarkdownDescriptions bool
+	// MinVersion is the API version a client is pinned to. Operations,
+	// parameters, and properties introduced after it (x-since) or removed at
+	// or before it (x-removed-in) are hidden. Empty shows everything.
+	MinVersion string
+	// Vocabulary holds the words used for section headings and labels.
+	Vocabulary Vocabulary
+	// PlatformHeaders are response headers added to every response by the
+	// platform, merged with the document's x-platform-headers.
+	PlatformHeaders map[string]PlatformHeader
+	// Diagrams lists the Mermaid diagrams rendered for each operation.
+	Diagrams []Diagram
+	// TOCMinOperations is the number of rendered operations from which a
+	// table of contents is added. Zero disables it.
+	TOCMinOperations int
+	// TeamNotes holds notes kept outside the spec, keyed by NoteKey, and
+	// rendered in a Team Notes section of each operation.
+	TeamNotes map[string][]TeamNote
+	// Owners holds the teams owning each operation, keyed by NoteKey.
+	Owners map[string][]string
+	// EnumUsage holds the observed frequency of enum values, keyed by
+	// NoteKey, and rendered in an optional section of each operation.
+	EnumUsage map[string][]EnumUsage
+	// TypeCaveats adds a section listing body fields whose examples
+	// contradict their declared types.
+	TypeCaveats bool
+	// Environment restricts the listed servers to those whose x-environment
+	// matches. Empty lists every server.
+	Environment string
+	// SchemaPath narrows rendered body schemas to the sub-schema addressed
+	// by a JSONPath-like expression (see ParseSchemaPath). Empty renders
+	// whole schemas.
+	SchemaPath string
+	// ParamGroupMin is the number of query parameters from which they are
+	// rendered under filter, sort, pagination, and field selection
+	// sub-headings. Zero disables grouping.
+	ParamGroupMin int
+	// QuickReference lists the required parameters and request body fields
+	// of each operation ahead of its detailed sections.
+	QuickReference bool
+	// CommentLimit is the size in bytes the github-comment format is kept
+	// under. Zero means GitHubCommentLimit.
+	CommentLimit int
+	// PropertyOrder is the order schema properties are listed in.
+	PropertyOrder PropertyOrder
+	// Nullable is how types that accept null are displayed.
+	Nullable NullableStyle
+	// Snippets lists the languages each operation's request is shown in.
+	Snippets []SnippetLanguage
+	// FoldDepth is the number of schema nesting levels rendered expanded;
+	// deeper schemas are folded into collapsible <details> blocks. Zero
+	// disables folding.
+	FoldDepth int
+	// Color styles the term format with ANSI escape codes.
+	Color bool
+	// Outline renders only the heading structure of the markdown output,
+	// with the content types of each body (see Outline).
+	Outline bool
+	// Fields selects the parts of the JSON output to render, as dotted
+	// paths such as "parameters.name" or "responses.200.schema". Nil renders
+	// the whole document.
+	Fields []string
+}
+
+// DefaultOptions returns the options used when none are given.
+func DefaultOptions() GenerateOptions {
+	return GenerateOptions{
+		Format:           FormatMarkdown,
+		MaxDepth:         MaxRecursionDepth,
+		MaxExampleLines:  DefaultMaxExampleLines,
+		Vocabulary:       DefaultVocabulary(),
+		TOCMinOperations: DefaultTOCMinOperations,
+		ParamGroupMin:    DefaultParamGroupMin,
+		QuickReference:   true,
+		PropertyOrder:    PropertyOrderSpec,
+		Nullable:         NullableSuffix,
+	}
+}
+
+// Option configures GenerateOptions.
+type Option func(*GenerateOptions)
+
+// WithMethod restricts output to one HTTP method (case-insensitive).
+func WithMethod(method string) Option {
+	return func(o *GenerateOptions) {
+		o.Method = strings.ToUpper(strings.TrimSpace(method))
+	}
+}
+
+// WithSections restricts output to the given sections.
+func WithSections(sections ...Section) Option {
+	return func(o *GenerateOptions) {
+		o.Sections = sections
+	}
+}
+
+// WithFormat sets the output format.
+func WithFormat(format Format) Option {
+	return func(o *GenerateOptions) {
+		o.Format = format
+	}
+}
+
+// WithMaxDepth limits schema recursion depth.
+func WithMaxDepth(depth int) Option {
+	return func(o *GenerateOptions) {
+		o.MaxDepth = depth
+	}
+}
+
+// WithContentTypes restricts bodies to matching media types.
+func WithContentTypes(contentTypes ...string) Option {
+	return func(o *GenerateOptions) {
+		o.ContentTypes = contentTypes
+	}
+}
+
+// WithPrefer renders only the preferred content type of each response.
+func WithPrefer(ranges ...MediaRange) Option {
+	return func(o *GenerateOptions) {
+		o.Prefer = ranges
+	}
+}
+
+// WithExtensions toggles rendering of x- vendor extensions.
+func WithExtensions(include bool) Option {
+	return func(o *GenerateOptions) {
+		o.IncludeExtensions = include
+	}
+}
+
+// WithTypeCaveats toggles the section listing body fields whose examples
+// contradict their declared types.
+func WithTypeCaveats(enabled bool) Option {
+	return func(o *GenerateOptions) {
+		o.TypeCaveats = enabled
+	}
+}
+
+// WithBadges enables badges. Unset fields fall back to DefaultBadgeConfig.
+func WithBadges(cfg BadgeConfig) Option {
+	return func(o *GenerateOptions) {
+		cfg = cfg.withDefaults()
+		o.Badges = &cfg
+	}
+}
+
+// WithMaxExampleLines sets the line count after which examples are truncated.
+// Zero or a negative value disables truncation.
+func WithMaxExampleLines(lines int) Option {
+	return func(o *GenerateOptions) {
+		o.MaxExampleLines = lines
+	}
+}
+
+// WithExampleAttacher sets where full payloads of truncated or trimmed
+// examples are stored.
+func WithExampleAttacher(attacher ExampleAttacher) Option {
+	return func(o *GenerateOptions) {
+		o.ExampleAttacher = attacher
+	}
+}
+
+// WithTrimExamples removes null-valued fields and fields matching keys from
+// rendered examples. No keys means DefaultTrimKeys.
+func WithTrimExamples(keys ...string) Option {
+	return func(o *GenerateOptions) {
+		o.TrimExamples = true
+		o.TrimKeys = keys
+		if len(keys) == 0 {
+			o.TrimKeys = DefaultTrimKeys
+		}
+	}
+}
+
+// WithMarkdownDescriptions toggles rendering descriptions as markdown blocks.
+func WithMarkdownDescriptions(enabled bool) Option {
+	return func(o *GenerateOptions) {
+		o.MarkdownDescriptions = enabled
+	}
+}
+
+// WithMinVersion hides elements not available in the given API version.
+func WithMinVersion(version string) Option {
+	return func(o *GenerateOptions) {
+		o.MinVersion = strings.TrimSpace(version)
+	}
+}
+
+// WithVocabulary overrides section headings and labels. Unset fields fall
+// back to DefaultVocabulary.
+func WithVocabulary(v Vocabulary) Option {
+	return func(o *GenerateOptions) {
+		o.Vocabulary = v.withDefaults()
+	}
+}
+
+// WithPlatformHeaders documents response headers injected by the platform
+// in every response. They take precedence over x-platform-headers.
+func WithPlatformHeaders(headers map[string]PlatformHeader) Option {
+	return func(o *GenerateOptions) {
+		o.PlatformHeaders = headers
+	}
+}
+
+// WithDiagrams renders the given Mermaid diagrams for each operation.
+func WithDiagrams(diagrams ...Diagram) Option {
+	return func(o *GenerateOptions) {
+		o.Diagrams = diagrams
+	}
+}
+
+// WithTOCMinOperations sets the number of rendered operations from which a
+// table of contents is added. Zero disables it.
+func WithTOCMinOperations(n int) Option {
+	return func(o *GenerateOptions) {
+		o.TOCMinOperations = n
+	}
+}
+
+// WithTeamNotes renders the given notes, keyed by NoteKey, with their
+// operations.
+func WithTeamNotes(notes map[string][]TeamNote) Option {
+	return func(o *GenerateOptions) {
+		o.TeamNotes = notes
+	}
+}
+
+// WithOwners shows the teams owning each operation, keyed by NoteKey.
+func WithOwners(owners map[string][]string) Option {
+	return func(o *GenerateOptions) {
+		o.Owners = owners
+	}
+}
+
+// WithEnumUsage annotates enum values with their observed frequency, keyed
+// by NoteKey.
+func WithEnumUsage(usage map[string][]EnumUsage) Option {
+	return func(o *GenerateOptions) {
+		o.EnumUsage = usage
+	}
+}
+
+// WithEnvironment lists only the servers tagged with env via x-environment.
+func WithEnvironment(env string) Option {
+	return func(o *GenerateOptions) {
+		o.Environment = strings.ToLower(strings.TrimSpace(env))
+	}
+}
+
+// WithFields renders only the selected fields of the JSON output, such as
+// "operation.summary" or "responses.200.schema".
+func WithFields(fields ...string) Option {
+	return func(o *GenerateOptions) {
+		o.Fields = fields
+	}
+}
+
+// WithSchemaPath renders only the sub-schema of each body addressed by expr,
+// such as "$.data.items[*].attributes".
+func WithSchemaPath(expr string) Option {
+	return func(o *GenerateOptions) {
+		o.SchemaPath = strings.TrimSpace(expr)
+	}
+}
+
+// WithParamGroupMin sets the number of query parameters from which they are
+// rendered in groups. Zero disables grouping.
+func WithParamGroupMin(n int) Option {
+	return func(o *GenerateOptions) {
+		o.ParamGroupMin = n
+	}
+}
+
+// WithQuickReference toggles the list of required inputs at the start of
+// each operation.
+func WithQuickReference(enabled bool) Option {
+	return func(o *GenerateOptions) {
+		o.QuickReference = enabled
+	}
+}
+
+// WithCommentLimit sets the size in bytes the github-comment format is kept
+// under. Zero or a negative value means GitHubCommentLimit.
+func WithCommentLimit(limit int) Option {
+	return func(o *GenerateOptions) {
+		o.CommentLimit = limit
+	}
+}
+
+// WithPropertyOrder sets the order schema properties are listed in.
+func WithPropertyOrder(order PropertyOrder) Option {
+	return func(o *GenerateOptions) {
+		o.PropertyOrder = order
+	}
+}
+
+// WithColor toggles ANSI styles in the term format.
+func WithColor(enabled bool) Option {
+	return func(o *GenerateOptions) {
+		o.Color = enabled
+	}
+}
+
+// WithNullable sets how types that accept null are displayed.
+func WithNullable(style NullableStyle) Option {
+	return func(o *GenerateOptions) {
+		o.Nullable = style
+	}
+}
+
+// WithSnippets shows each operation's request as code in the given
+// languages, in order.
+func WithSnippets(languages ...SnippetLanguage) Option {
+	return func(o *GenerateOptions) {
+		o.Snippets = languages
+	}
+}
+
+// WithFoldDepth folds schemas nested deeper than n levels into collapsible
+// <details> blocks. Zero disables folding.
+func WithFoldDepth(n int) Option {
+	return func(o *GenerateOptions) {
+		o.FoldDepth = n
+	}
+}
+
+// WithOutline renders only the heading structure of the output instead of
+// the full documentation.
+func WithOutline(enabled bool) Option {
+	return func(o *GenerateOptions) {
+		o.Outline = enabled
+	}
+}
+
+// hasSection reports whether a section should be rendered.
+func (o *GenerateOptions) hasSection(section Section) bool {
+	if o.Sections == nil {
+		return true
+	}
+	for _, s := range o.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// includesContentType reports whether a media type passes the content type filter.
+func (o *GenerateOptions) includesContentType(contentType string) bool {
+	if o.ContentTypes == nil {
+		return true
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	for _, want := range o.ContentTypes {
+		want = strings.ToLower(strings.TrimSpace(want))
+		if want == mediaType || want == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(want, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// wantsTOC reports whether a document of count operations gets a table of
+// contents.
+func (o *GenerateOptions) wantsTOC(count int) bool {
+	return o.TOCMinOperations > 0 && count >= o.TOCMinOperations
+}
+
+// hasDiagram reports whether a diagram should be rendered.
+func (o *GenerateOptions) hasDiagram(diagram Diagram) bool {
+	for _, d := range o.Diagrams {
+		if d == diagram {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func optionsPathItem() *openapi3.PathItem {
+	return &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Summary:    "Get item",
+			Extensions: map[string]any{"x-internal": true},
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewPathParameter("id").WithSchema(openapi3.NewStringSchema())},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").
+					WithContent(openapi3.Content{
+						"application/json": openapi3.NewMediaType().WithSchema(openapi3.NewObjectSchema()),
+						"application/xml":  openapi3.NewMediaType().WithSchema(openapi3.NewObjectSchema()),
+						"text/csv":         openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema()),
+					})}),
+			),
+		},
+		Delete: &openapi3.Operation{Summary: "Delete item"},
+	}
+}
+
+func TestGenerate_Options(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	t.Run("Sections", func(t *testing.T) {
+		markdown, err := New(doc).Generate("/items/{id}", optionsPathItem(),
+			WithMethod("get"), WithSections(SectionResponses))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(markdown, HeaderParameters) {
+			t.Error("Did not expect parameters when only responses are selected")
+		}
+		if strings.Contains(markdown, "**Summary:**") {
+			t.Error("Did not expect metadata when only responses are selected")
+		}
+		if !strings.Contains(markdown, HeaderResponses) {
+			t.Error("Expected responses section")
+		}
+		if strings.Contains(markdown, "## DELETE") {
+			t.Error("Did not expect DELETE when filtering by method")
+		}
+	})
+
+	t.Run("ContentTypes", func(t *testing.T) {
+		markdown, err := New(doc).Generate("/items/{id}", optionsPathItem(), WithContentTypes("application/*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(markdown, "`application/json`") || !strings.Contains(markdown, "`application/xml`") {
+			t.Error("Expected application/* media types")
+		}
+		if strings.Contains(markdown, "`text/csv`") {
+			t.Error("Did not expect text/csv with application/* filter")
+		}
+	})
+
+	t.Run("Extensions", func(t *testing.T) {
+		without, _ := New(doc).Generate("/items/{id}", optionsPathItem())
+		if strings.Contains(without, "x-internal") {
+			t.Error("Did not expect extensions by default")
+		}
+
+		with, _ := New(doc, WithExtensions(true)).Generate("/items/{id}", optionsPathItem())
+		if !strings.Contains(with, "- `x-internal`: `true`") {
+			t.Error("Expected extensions when enabled")
+		}
+	})
+
+	t.Run("PerCallOverridesDefaults", func(t *testing.T) {
+		gen := New(doc, WithMethod("DELETE"))
+		markdown, _ := gen.Generate("/items/{id}", optionsPathItem(), WithMethod("GET"))
+		if !strings.Contains(markdown, "## GET") || strings.Contains(markdown, "## DELETE") {
+			t.Error("Expected per-call method to override the generator default")
+		}
+
+		markdown, _ = gen.Generate("/items/{id}", optionsPathItem())
+		if !strings.Contains(markdown, "## DELETE") || strings.Contains(markdown, "## GET") {
+			t.Error("Expected generator default method to be unchanged by previous call")
+		}
+	})
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		if _, err := New(doc).Generate("/items/{id}", optionsPathItem(), WithFormat("pdf")); err == nil {
+			t.Error("Expected error for unsupported format")
+		}
+	})
+}
+
+func TestParseSections(t *testing.T) {
+	sections, err := ParseSections("parameters, Responses,,security")
+	if err != nil {
+		t.Fatalf("ParseSections() error: %v", err)
+	}
+	want := []Section{SectionParameters, SectionResponses, SectionSecurity}
+	if len(sections) != len(want) {
+		t.Fatalf("ParseSections() = %v, want %v", sections, want)
+	}
+	for i := range want {
+		if sections[i] != want[i] {
+			t.Errorf("ParseSections()[%d] = %s, want %s", i, sections[i], want[i])
+		}
+	}
+
+	if _, err := ParseSections("parameters,bogus"); err == nil {
+		t.Error("Expected error for unknown section")
+	}
+}
+
+func TestWithVocabulary(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Parameters: openapi3.Parameters{
+				{Value: openapi3.NewQueryParameter("q").WithSchema(openapi3.NewStringSchema())},
+			},
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").
+					WithContent(openapi3.NewContentWithJSONSchema(openapi3.NewStringSchema()))}),
+			),
+			Security: &openapi3.SecurityRequirements{{"bearer": {}}},
+		},
+	}
+
+	markdown := New(doc, WithVocabulary(Vocabulary{
+		Parameters: "Query & Path Arguments",
+		Security:   "Authentication",
+	})).GenerateMarkdown("/items", pathItem, "")
+
+	for _, want := range []string{"### Query & Path Arguments\n\n", "### Authentication\n\n", HeaderResponses, HeaderSchema} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+	for _, unwanted := range []string{HeaderParameters, HeaderSecurity} {
+		if strings.Contains(markdown, unwanted) {
+			t.Errorf("Did not expect %q in output", unwanted)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// contentTypeLine matches the line naming the content type of a body.
+var contentTypeLine = regexp.MustCompile("^\\*\\*Content-Type:\\*\\* `([^`]+)`$")
+
+// outlineEntry is a heading of the outline and the content types of the
+// bodies under it.
+type outlineEntry struct {
+	level        int
+	text         string
+	contentTypes []string
+}
+
+// outline reduces rendered markdown to its heading structure: the title,
+// then a nested list of operations, their sections, and sub-headings such
+// as response codes. Headings with bodies list their content types, and
+// section headings name the -sections value that selects them.
+func (g *Generator) outline(md string) string {
+	var entries []*outlineEntry
+	inFence := false
+
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if m := contentTypeLine.FindStringSubmatch(line); m != nil && len(entries) > 0 {
+			last := entries[len(entries)-1]
+			last.contentTypes = append(last.contentTypes, m[1])
+			continue
+		}
+
+		text := strings.TrimLeft(line, "#")
+		if text == line || text == "" || text[0] != ' ' {
+			continue
+		}
+		entries = append(entries, &outlineEntry{level: len(line) - len(text), text: strings.TrimSpace(text)})
+	}
+
+	sections := g.headingSections()
+	var b strings.Builder
+	for _, entry := range entries {
+		text := entry.text
+		if entry.level == 3 {
+			if section, ok := sections[text]; ok {
+				text += fmt.Sprintf(" [%s]", section)
+			}
+		}
+		if len(entry.contentTypes) > 0 {
+			text += " (" + strings.Join(entry.contentTypes, ", ") + ")"
+		}
+
+		if entry.level == 1 {
+			fmt.Fprintf(&b, "%s\n\n", text)
+			continue
+		}
+		fmt.Fprintf(&b, "%s- %s\n", strings.Repeat("  ", entry.level-2), text)
+	}
+	return b.String()
+}
+
+// headingSections maps section headings to the Section rendering them.
+func (g *Generator) headingSections() map[string]Section {
+	v := g.opts.Vocabulary
+	return map[string]Section{
+		v.TeamNotes:   SectionMetadata,
+		v.Parameters:  SectionParameters,
+		v.RequestBody: SectionRequestBody,
+		v.Responses:   SectionResponses,
+		v.Security:    SectionSecurity,
+		v.Snippets:    SectionExamples,
+		v.Flows:       SectionExamples,
+	}
+}
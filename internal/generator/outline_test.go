@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_Outline(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /orders/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+              example:
+                id: ord_1
+            application/xml:
+              schema:
+                type: object
+        "404":
+          description: Missing
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "204":
+          description: Updated
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathItem := doc.Paths.Find("/orders/{id}")
+
+	t.Run("Headings", func(t *testing.T) {
+		outline, err := New(doc, WithTOCMinOperations(1)).Generate("/orders/{id}", pathItem, WithOutline(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "API Endpoint: /orders/{id}\n" +
+			"\n" +
+			"- GET /orders/{id}\n" +
+			"  - Parameters [parameters]\n" +
+			"  - Responses [responses]\n" +
+			"    - 200 OK `success` (application/json, application/xml)\n" +
+			"    - 404 Not Found `client error`\n" +
+			"- PUT /orders/{id}\n" +
+			"  - Request Body [request-body] (application/json)\n" +
+			"  - Responses [responses]\n" +
+			"    - 204 No Content `success`\n"
+		if outline != want {
+			t.Errorf("Outline mismatch.\nGot:\n%s\nWant:\n%s", outline, want)
+		}
+	})
+
+	t.Run("Follows options", func(t *testing.T) {
+		outline, err := New(doc).Generate("/orders/{id}", pathItem,
+			WithOutline(true),
+			WithMethod("GET"),
+			WithSections(SectionResponses),
+			WithContentTypes("application/json"),
+			WithVocabulary(Vocabulary{Responses: "Replies"}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "- GET /orders/{id}\n  - Replies [responses]\n    - 200 OK `success` (application/json)\n    - 404 Not Found `client error`\n"
+		if !strings.HasSuffix(outline, want) {
+			t.Errorf("Expected outline to end with %q:\n%s", want, outline)
+		}
+	})
+
+	t.Run("Markdown only", func(t *testing.T) {
+		if _, err := New(doc).Generate("/orders/{id}", pathItem, WithOutline(true), WithFormat(FormatJSONDocument)); err == nil {
+			t.Error("Expected an error for an outline in the json format")
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExtensionParamGroup assigns a query parameter to a group explicitly,
+// overriding the naming heuristics: filter, sort, pagination, or fields.
+const ExtensionParamGroup = "x-param-group"
+
+// DefaultParamGroupMin is the number of query parameters from which they are
+// rendered in groups.
+const DefaultParamGroupMin = 8
+
+// ParamGroup is a semantic group of query parameters.
+type ParamGroup string
+
+// Query parameter groups, in rendering order.
+const (
+	ParamGroupFilter     ParamGroup = "filter"
+	ParamGroupSort       ParamGroup = "sort"
+	ParamGroupPagination ParamGroup = "pagination"
+	ParamGroupFields     ParamGroup = "fields"
+	ParamGroupOther      ParamGroup = "other"
+)
+
+// paramGroupOrder lists the groups in rendering order.
+var paramGroupOrder = []ParamGroup{
+	ParamGroupFilter, ParamGroupSort, ParamGroupPagination, ParamGroupFields, ParamGroupOther,
+}
+
+// paramGroupNames maps lowercase parameter names, with any "[...]" suffix
+// and "-" or "_" removed, to their group.
+var paramGroupNames = map[string]ParamGroup{
+	"filter": ParamGroupFilter, "filters": ParamGroupFilter, "q": ParamGroupFilter,
+	"query": ParamGroupFilter, "search": ParamGroupFilter, "since": ParamGroupFilter,
+	"until": ParamGroupFilter,
+
+	"sort": ParamGroupSort, "sortby": ParamGroupSort, "sortorder": ParamGroupSort,
+	"sortdir": ParamGroupSort, "sortdirection": ParamGroupSort, "order": ParamGroupSort,
+	"orderby": ParamGroupSort, "direction": ParamGroupSort, "dir": ParamGroupSort,
+
+	"page": ParamGroupPagination, "pagesize": ParamGroupPagination, "perpage": ParamGroupPagination,
+	"pagenumber": ParamGroupPagination, "pagetoken": ParamGroupPagination, "limit": ParamGroupPagination,
+	"offset": ParamGroupPagination, "cursor": ParamGroupPagination, "after": ParamGroupPagination,
+	"before": ParamGroupPagination, "startingafter": ParamGroupPagination, "endingbefore": ParamGroupPagination,
+	"nexttoken": ParamGroupPagination, "continuationtoken": ParamGroupPagination, "maxresults": ParamGroupPagination,
+	"skip": ParamGroupPagination, "take": ParamGroupPagination, "top": ParamGroupPagination,
+
+	"fields": ParamGroupFields, "field": ParamGroupFields, "select": ParamGroupFields,
+	"include": ParamGroupFields, "exclude": ParamGroupFields, "expand": ParamGroupFields,
+	"embed": ParamGroupFields,
+}
+
+// filterSuffixes mark range and comparison filters such as created_gte.
+var filterSuffixes = []string{
+	"_gt", "_gte", "_lt", "_lte", "_eq", "_ne", "_in", "_nin", "_from", "_to",
+	"_contains", "_startswith", "_before", "_after", "[gt]", "[gte]", "[lt]", "[lte]",
+}
+
+// ClassifyQueryParameter returns the group of a query parameter: the one set
+// with ExtensionParamGroup, else one guessed from its name.
+func ClassifyQueryParameter(param *openapi3.Parameter) ParamGroup {
+	if value, ok := param.Extensions[ExtensionParamGroup].(string); ok {
+		group := ParamGroup(strings.ToLower(strings.TrimSpace(value)))
+		for _, known := range paramGroupOrder {
+			if group == known {
+				return group
+			}
+		}
+	}
+
+	// "filter[status]" and "page[size]" are grouped by their prefix, as are
+	// JSON:API sparse fieldsets like "fields[events]".
+	name := strings.ToLower(param.Name)
+	base, _, _ := strings.Cut(name, "[")
+	base = strings.NewReplacer("_", "", "-", "").Replace(base)
+	if group, ok := paramGroupNames[base]; ok {
+		return group
+	}
+
+	if strings.HasPrefix(base, "filter") {
+		return ParamGroupFilter
+	}
+	for _, suffix := range filterSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return ParamGroupFilter
+		}
+	}
+	return ParamGroupOther
+}
+
+// paramGroupLabel returns the sub-heading of a group.
+func (g *Generator) paramGroupLabel(group ParamGroup) string {
+	switch group {
+	case ParamGroupFilter:
+		return g.opts.Vocabulary.ParamFilters
+	case ParamGroupSort:
+		return g.opts.Vocabulary.ParamSorting
+	case ParamGroupPagination:
+		return g.opts.Vocabulary.ParamPagination
+	case ParamGroupFields:
+		return g.opts.Vocabulary.ParamFields
+	}
+	return g.opts.Vocabulary.ParamOther
+}
+
+// groupQueryParameters splits the query parameters from the others and
+// groups them, if there are at least ParamGroupMin of them. ok is false when
+// the parameters should be rendered as one list.
+func (g *Generator) groupQueryParameters(params []*openapi3.Parameter) (others []*openapi3.Parameter, groups map[ParamGroup][]*openapi3.Parameter, ok bool) {
+	var query []*openapi3.Parameter
+	for _, param := range params {
+		if param.In == openapi3.ParameterInQuery {
+			query = append(query, param)
+		} else {
+			others = append(others, param)
+		}
+	}
+	if g.opts.ParamGroupMin <= 0 || len(query) < g.opts.ParamGroupMin {
+		return nil, nil, false
+	}
+
+	groups = make(map[ParamGroup][]*openapi3.Parameter)
+	for _, param := range query {
+		group := ClassifyQueryParameter(param)
+		groups[group] = append(groups[group], param)
+	}
+	return others, groups, true
+}
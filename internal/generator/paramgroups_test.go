@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestClassifyQueryParameter(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    ParamGroup
+		override string
+	}{
+		{"filter[status]", ParamGroupFilter, ""},
+		{"created_gte", ParamGroupFilter, ""},
+		{"q", ParamGroupFilter, ""},
+		{"sort_by", ParamGroupSort, ""},
+		{"orderBy", ParamGroupSort, ""},
+		{"page[size]", ParamGroupPagination, ""},
+		{"starting_after", ParamGroupPagination, ""},
+		{"per-page", ParamGroupPagination, ""},
+		{"fields[events]", ParamGroupFields, ""},
+		{"expand", ParamGroupFields, ""},
+		{"status", ParamGroupOther, ""},
+		{"status", ParamGroupFilter, "filter"},
+		{"limit", ParamGroupPagination, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.override, func(t *testing.T) {
+			param := &openapi3.Parameter{Name: tt.name, In: openapi3.ParameterInQuery}
+			if tt.override != "" {
+				param.Extensions = map[string]any{ExtensionParamGroup: tt.override}
+			}
+			if got := ClassifyQueryParameter(param); got != tt.group {
+				t.Errorf("ClassifyQueryParameter(%q) = %q, want %q", tt.name, got, tt.group)
+			}
+		})
+	}
+}
+
+func TestGenerateMarkdown_ParamGroups(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	var params openapi3.Parameters
+	params = append(params, &openapi3.ParameterRef{Value: openapi3.NewHeaderParameter("X-Tenant")})
+	for _, name := range []string{"status", "created_gte", "sort", "limit", "cursor", "fields", "q", "region"} {
+		params = append(params, &openapi3.ParameterRef{Value: openapi3.NewQueryParameter(name)})
+	}
+	pathItem := &openapi3.PathItem{Get: &openapi3.Operation{Parameters: params}}
+
+	markdown := New(doc).GenerateMarkdown("/events", pathItem, "GET")
+	expected := "### " + LabelParameters + "\n\n- **X-Tenant** (header)\n\n" +
+		"#### " + LabelParamFilters + "\n\n- **created_gte** (query)\n- **q** (query)\n\n" +
+		"#### " + LabelParamSorting + "\n\n- **sort** (query)\n\n" +
+		"#### " + LabelParamPagination + "\n\n- **limit** (query)\n- **cursor** (query)\n\n" +
+		"#### " + LabelParamFields + "\n\n- **fields** (query)\n\n" +
+		"#### " + LabelParamOther + "\n\n- **status** (query)\n- **region** (query)\n\n"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, markdown)
+	}
+
+	markdown, _ = New(doc).Generate("/events", pathItem, WithParamGroupMin(0))
+	if strings.Contains(markdown, "#### "+LabelParamFilters) {
+		t.Errorf("Did not expect %q in output:\n%s", LabelParamFilters, markdown)
+	}
+}
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExtensionPlatformHeaders is the document-level extension listing response
+// headers added to every response by the platform (e.g. an API gateway).
+// Each entry is a header object or a $ref to one in components/headers.
+const ExtensionPlatformHeaders = "x-platform-headers"
+
+// MarkerPlatform marks response headers provided by the platform rather
+// than the operation.
+const MarkerPlatform = " *(platform)*"
+
+// PlatformHeader documents a response header injected by the platform.
+type PlatformHeader struct {
+	// Description explains the header.
+	Description string `yaml:"description"`
+	// Type is the header value's schema type. Defaults to string.
+	Type string `yaml:"type"`
+}
+
+// responseHeader is a response header ready for rendering.
+type responseHeader struct {
+	name     string
+	header   *openapi3.Header
+	platform bool
+}
+
+// platformHeaders returns the platform headers from the document's
+// x-platform-headers extension merged with those configured through
+// WithPlatformHeaders, which take precedence.
+func (g *Generator) platformHeaders() map[string]*openapi3.Header {
+	headers := make(map[string]*openapi3.Header)
+
+	if g.doc != nil {
+		if entries, ok := g.doc.Extensions[ExtensionPlatformHeaders].(map[string]any); ok {
+			for name, entry := range entries {
+				if header := g.decodePlatformHeader(entry); header != nil {
+					headers[name] = header
+				}
+			}
+		}
+	}
+
+	for name, ph := range g.opts.PlatformHeaders {
+		typ := ph.Type
+		if typ == "" {
+			typ = openapi3.TypeString
+		}
+		headers[name] = &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: ph.Description,
+			Schema:      (&openapi3.Schema{Type: &openapi3.Types{typ}}).NewRef(),
+		}}
+	}
+
+	return headers
+}
+
+// decodePlatformHeader decodes an x-platform-headers entry, resolving a $ref
+// to components/headers. It returns nil for entries it cannot decode.
+func (g *Generator) decodePlatformHeader(entry any) *openapi3.Header {
+	if m, ok := entry.(map[string]any); ok {
+		if ref, ok := m["$ref"].(string); ok {
+			name, found := strings.CutPrefix(ref, "#/components/headers/")
+			if !found || g.doc.Components == nil {
+				return nil
+			}
+			if headerRef := g.doc.Components.Headers[name]; headerRef != nil {
+				return headerRef.Value
+			}
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil
+	}
+	var header openapi3.Header
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil
+	}
+	return &header
+}
+
+// mergeResponseHeaders returns a response's own headers followed by the
+// platform headers it doesn't already declare, each group sorted by name.
+// Header names are compared case-insensitively.
+func mergeResponseHeaders(headers openapi3.Headers, platform map[string]*openapi3.Header) []responseHeader {
+	var merged []responseHeader
+	declared := make(map[string]bool)
+
+	for _, name := range getSortedHeaderNames(headers) {
+		headerRef := headers[name]
+		if headerRef == nil || headerRef.Value == nil {
+			continue
+		}
+		merged = append(merged, responseHeader{name: name, header: headerRef.Value})
+		declared[strings.ToLower(name)] = true
+	}
+
+	names := make([]string, 0, len(platform))
+	for name := range platform {
+		if !declared[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		merged = append(merged, responseHeader{name: name, header: platform[name], platform: true})
+	}
+
+	return merged
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerate_PlatformHeaders(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+x-platform-headers:
+  X-Request-Id:
+    $ref: '#/components/headers/RequestId'
+  Traceparent:
+    description: W3C trace context
+    schema: {type: string}
+paths:
+  /items:
+    get:
+      responses:
+        '200':
+          description: OK
+          headers:
+            x-request-id:
+              description: Echoed request ID
+              schema: {type: string}
+        '404':
+          description: Not found
+components:
+  headers:
+    RequestId:
+      description: Unique request identifier
+      schema: {type: string, format: uuid}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	pathItem := doc.Paths.Value("/items")
+
+	t.Run("Extension", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/items", pathItem, "")
+
+		for _, want := range []string{
+			"#### 200 OK `success`\n\nOK\n\n**Headers:**\n\n- `x-request-id` - Echoed request ID\n  - Type: `string`\n- `Traceparent` *(platform)* - W3C trace context\n",
+			"#### 404 Not Found `client error`\n\nNot found\n\n**Headers:**\n\n- `Traceparent` *(platform)* - W3C trace context\n  - Type: `string`\n- `X-Request-Id` *(platform)* - Unique request identifier\n",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		markdown := New(doc, WithPlatformHeaders(map[string]PlatformHeader{
+			"Traceparent":           {Description: "Trace context set by the gateway"},
+			"X-RateLimit-Remaining": {Description: "Requests left", Type: "integer"},
+		})).GenerateMarkdown("/items", pathItem, "")
+
+		for _, want := range []string{
+			"- `Traceparent` *(platform)* - Trace context set by the gateway\n",
+			"- `X-RateLimit-Remaining` *(platform)* - Requests left\n  - Type: `integer`\n",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+	})
+}
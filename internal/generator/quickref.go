@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// quickRefLocations is the order required parameters are listed in.
+var quickRefLocations = []string{
+	openapi3.ParameterInPath, openapi3.ParameterInQuery,
+	openapi3.ParameterInHeader, openapi3.ParameterInCookie,
+}
+
+// writeQuickReference writes a compact list of the required parameters and
+// request body fields of an operation ahead of the detailed sections, so
+// mandatory inputs aren't missed. Nothing is written if nothing is required.
+func (g *Generator) writeQuickReference(md *strings.Builder, operation *openapi3.Operation) {
+	var lines []string
+
+	if g.opts.hasSection(SectionParameters) {
+		required := make(map[string][]string)
+		for _, ref := range operation.Parameters {
+			if ref == nil || ref.Value == nil || !ref.Value.Required {
+				continue
+			}
+			if !availableIn(ref.Value.Extensions, g.opts.MinVersion) {
+				continue
+			}
+			required[ref.Value.In] = append(required[ref.Value.In], "`"+ref.Value.Name+"`")
+		}
+		for _, in := range quickRefLocations {
+			if len(required[in]) > 0 {
+				lines = append(lines, fmt.Sprintf("- Required %s: %s", in, strings.Join(required[in], ", ")))
+			}
+		}
+	}
+
+	if g.opts.hasSection(SectionRequestBody) && operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		body := operation.RequestBody.Value
+		for _, contentType := range g.bodyContentTypes(body.Content) {
+			var fields []string
+			if mediaType := body.Content[contentType]; mediaType.Schema != nil {
+				fields = g.requiredFields(mediaType.Schema.Value, "", 0, make(map[*openapi3.Schema]bool))
+			}
+			if len(fields) == 0 && !body.Required {
+				continue
+			}
+
+			line := fmt.Sprintf("- Required body (`%s`)", contentType)
+			if !body.Required {
+				line = fmt.Sprintf("- Body, if sent (`%s`)", contentType)
+			}
+			if len(fields) > 0 {
+				line += ": `" + strings.Join(fields, "`, `") + "`"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+	md.WriteString(blockLabel(g.opts.Vocabulary.QuickReference))
+	md.WriteString(strings.Join(lines, "\n"))
+	md.WriteString("\n\n")
+}
+
+// requiredFields returns the paths of the required, writable properties of
+// schema, descending into required objects and arrays of objects, e.g.
+// "items[].sku". Properties of allOf members count as the schema's own.
+func (g *Generator) requiredFields(schema *openapi3.Schema, prefix string, depth int, visiting map[*openapi3.Schema]bool) []string {
+	if schema == nil || depth > g.opts.MaxDepth || visiting[schema] {
+		return nil
+	}
+	visiting[schema] = true
+	defer delete(visiting, schema)
+
+	properties := make(map[string]*openapi3.Schema)
+	var required []string
+	var collect func(s *openapi3.Schema)
+	collect = func(s *openapi3.Schema) {
+		for name, ref := range s.Properties {
+			if ref != nil && ref.Value != nil {
+				properties[name] = ref.Value
+			}
+		}
+		required = append(required, s.Required...)
+		for _, ref := range s.AllOf {
+			if ref != nil && ref.Value != nil && !visiting[ref.Value] {
+				collect(ref.Value)
+			}
+		}
+	}
+	collect(schema)
+	if schema.Items != nil && schema.Items.Value != nil && len(properties) == 0 {
+		return g.requiredFields(schema.Items.Value, strings.TrimSuffix(prefix, ".")+"[].", depth+1, visiting)
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range required {
+		prop := properties[name]
+		if seen[name] || (prop != nil && (prop.ReadOnly || !availableIn(prop.Extensions, g.opts.MinVersion))) {
+			continue
+		}
+		seen[name] = true
+		fields = append(fields, prefix+name)
+
+		if prop == nil {
+			continue
+		}
+		if prop.Items != nil && prop.Items.Value != nil {
+			fields = append(fields, g.requiredFields(prop.Items.Value, prefix+name+"[].", depth+1, visiting)...)
+		} else {
+			fields = append(fields, g.requiredFields(prop, prefix+name+".", depth+1, visiting)...)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const quickRefSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /orders/{id}:
+    put:
+      summary: Replace an order
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Base'
+                - type: object
+                  required: [items, note]
+                  properties:
+                    items:
+                      type: array
+                      items:
+                        type: object
+                        required: [sku]
+                        properties:
+                          sku:
+                            type: string
+                          qty:
+                            type: integer
+                    note:
+                      type: string
+                    shipping:
+                      type: object
+                      required: [city]
+                      properties:
+                        city:
+                          type: string
+      responses:
+        "204":
+          description: Replaced
+    get:
+      summary: Get an order
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Base:
+      type: object
+      required: [id, customer]
+      properties:
+        id:
+          type: string
+          readOnly: true
+        customer:
+          type: object
+          required: [email]
+          properties:
+            email:
+              type: string
+`
+
+func TestGenerateMarkdown_QuickReference(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(quickRefSpec))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+	pathItem := doc.Paths.Value("/orders/{id}")
+
+	markdown, err := New(doc).Generate("/orders/{id}", pathItem, WithMethod("PUT"))
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	expected := "## PUT /orders/{id}\n\n" + HeaderQuickRef +
+		"- Required path: `id`\n" +
+		"- Required header: `X-Tenant`\n" +
+		"- Required body (`application/json`): `customer`, `customer.email`, `items`, `items[].sku`, `note`\n\n" +
+		"**Summary:**"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, markdown)
+	}
+
+	// No required inputs, no quick reference
+	markdown, _ = New(doc).Generate("/orders/{id}", pathItem, WithMethod("GET"))
+	if strings.Contains(markdown, LabelQuickRef) {
+		t.Errorf("Did not expect %q in output:\n%s", LabelQuickRef, markdown)
+	}
+
+	markdown, _ = New(doc).Generate("/orders/{id}", pathItem, WithMethod("PUT"), WithQuickReference(false))
+	if strings.Contains(markdown, LabelQuickRef) {
+		t.Errorf("Did not expect %q in output:\n%s", LabelQuickRef, markdown)
+	}
+
+	// Sections left out are left out of the quick reference too
+	markdown, _ = New(doc).Generate("/orders/{id}", pathItem, WithMethod("PUT"), WithSections(SectionRequestBody))
+	if strings.Contains(markdown, "Required path") || !strings.Contains(markdown, "Required body") {
+		t.Errorf("Expected only the body in the quick reference:\n%s", markdown)
+	}
+}
+
+func TestGenerateMarkdown_QuickReferenceOptionalBody(t *testing.T) {
+	pathItem := &openapi3.PathItem{Post: &openapi3.Operation{
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(
+			openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema()).WithRequired([]string{"name"}),
+		)},
+	}}
+
+	markdown, err := New(nil).Generate("/users", pathItem)
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	expected := "- Body, if sent (`application/json`): `name`\n"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Expected %q in output:\n%s", expected, markdown)
+	}
+}
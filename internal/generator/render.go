@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RenderParameters writes the parameters section for params to w, as in an
+// operation's documentation. opts override the Generator's defaults for
+// this call only.
+func (g *Generator) RenderParameters(w io.Writer, params openapi3.Parameters, opts ...Option) error {
+	return g.render(w, opts, func(r *Generator, md *strings.Builder) {
+		r.writeParameters(md, params)
+	})
+}
+
+// RenderRequestBody writes the request body section for body to w. Example
+// attachments are named after scope, e.g. "post-events".
+func (g *Generator) RenderRequestBody(w io.Writer, body *openapi3.RequestBodyRef, scope string, opts ...Option) error {
+	return g.render(w, opts, func(r *Generator, md *strings.Builder) {
+		r.writeRequestBody(md, body, scope)
+	})
+}
+
+// RenderResponses writes the responses section for responses to w. Example
+// attachments are named after scope, e.g. "get-events".
+func (g *Generator) RenderResponses(w io.Writer, responses *openapi3.Responses, scope string, opts ...Option) error {
+	return g.render(w, opts, func(r *Generator, md *strings.Builder) {
+		r.writeResponses(md, responses, scope)
+	})
+}
+
+// RenderSchemaRef writes the schema block for schema to w, as under a
+// request or response content type, honoring SchemaPath.
+func (g *Generator) RenderSchemaRef(w io.Writer, schema *openapi3.SchemaRef, opts ...Option) error {
+	return g.render(w, opts, func(r *Generator, md *strings.Builder) {
+		if schema != nil && schema.Value != nil {
+			r.writeBodySchema(md, schema.Value)
+		}
+	})
+}
+
+// render writes the markdown written by fn, converted to the output format,
+// to w. Formats rendering whole operations only are rejected.
+func (g *Generator) render(w io.Writer, opts []Option, fn func(r *Generator, md *strings.Builder)) error {
+	r, err := g.prepare(opts)
+	if err != nil {
+		return err
+	}
+	if r.opts.Format == FormatJSONDocument || r.opts.Format == FormatGitHubComment {
+		return fmt.Errorf("only whole operations can be rendered in the %s format", r.opts.Format)
+	}
+	if r.opts.Outline {
+		return errors.New("only whole operations can be outlined")
+	}
+
+	var md strings.Builder
+	if err := renderSafely(func() { fn(r, &md) }); err != nil {
+		return err
+	}
+
+	out := md.String()
+	switch r.opts.Format {
+	case FormatMDX:
+		out = mdx(out)
+	case FormatTerm:
+		out = r.term(out)
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
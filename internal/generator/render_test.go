@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestRenderParameters(t *testing.T) {
+	params := openapi3.Parameters{{Value: openapi3.NewPathParameter("id").WithSchema(openapi3.NewStringSchema())}}
+	gen := New(nil, WithVocabulary(Vocabulary{Parameters: "Arguments"}))
+
+	var md strings.Builder
+	if err := gen.RenderParameters(&md, params); err != nil {
+		t.Fatalf("RenderParameters() error: %v", err)
+	}
+	for _, expected := range []string{"### Arguments", "**id** (path)"} {
+		if !strings.Contains(md.String(), expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, md.String())
+		}
+	}
+
+	var text strings.Builder
+	if err := gen.RenderParameters(&text, params, WithFormat(FormatTerm)); err != nil {
+		t.Fatalf("RenderParameters() error: %v", err)
+	}
+	if strings.Contains(text.String(), "###") || !strings.Contains(text.String(), "Arguments") {
+		t.Errorf("Expected terminal text without markdown, got:\n%s", text.String())
+	}
+
+	if err := gen.RenderParameters(&md, params, WithFormat(FormatJSONDocument)); err == nil {
+		t.Error("Expected error for the json format")
+	}
+}
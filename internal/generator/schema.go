@@ -12,6 +12,28 @@ import (
 // maxDepth limits recursion depth to prevent stack overflow on circular references.
 // Returns a markdown-formatted string representation of the schema.
 func FormatSchema(schema *openapi3.Schema, indent, maxDepth int) string {
+	return formatSchema(schema, indent, maxDepth, schemaStyle{})
+}
+
+// schemaStyle carries the generator options that affect schema rendering.
+type schemaStyle struct {
+	// markdownDescriptions renders property descriptions as sanitized markdown blocks.
+	markdownDescriptions bool
+	// minVersion hides properties not available in this API version.
+	minVersion string
+	// propertyOrder is the order properties are listed in.
+	propertyOrder PropertyOrder
+	// foldDepth is the number of nesting levels rendered expanded; deeper
+	// schemas are folded into a <details> block. Zero disables folding.
+	foldDepth int
+	// level is the nesting level of the schema being rendered.
+	level int
+	// nullable is how types that accept null are displayed.
+	nullable NullableStyle
+}
+
+// formatSchema implements FormatSchema with the given style.
+func formatSchema(schema *openapi3.Schema, indent, maxDepth int, style schemaStyle) string {
 	if schema == nil {
 		return ""
 	}
@@ -26,57 +48,76 @@ func FormatSchema(schema *openapi3.Schema, indent, maxDepth int) string {
 
 	// Handle schema composition (oneOf, anyOf, allOf)
 	if len(schema.OneOf) > 0 {
-		formatSchemaComposition(&result, "oneOf", "one of the following", schema.OneOf, prefix, indent, maxDepth)
+		formatSchemaComposition(&result, "oneOf", "one of the following", schema.OneOf, prefix, indent, maxDepth, style)
 		return result.String()
 	}
 
 	if len(schema.AnyOf) > 0 {
-		formatSchemaComposition(&result, "anyOf", "any of the following", schema.AnyOf, prefix, indent, maxDepth)
+		formatSchemaComposition(&result, "anyOf", "any of the following", schema.AnyOf, prefix, indent, maxDepth, style)
 		return result.String()
 	}
 
 	if len(schema.AllOf) > 0 {
-		formatSchemaComposition(&result, "allOf", "all of the following", schema.AllOf, prefix, indent, maxDepth)
+		formatSchemaComposition(&result, "allOf", "all of the following", schema.AllOf, prefix, indent, maxDepth, style)
 		return result.String()
 	}
 
 	// Handle object type
-	if schema.Type.Is("object") {
-		formatObjectSchema(&result, schema, prefix, indent, maxDepth)
+	if hasType(schema, "object") {
+		formatObjectSchema(&result, schema, prefix, indent, maxDepth, style)
 		return result.String()
 	}
 
 	// Handle array type
-	if schema.Type.Is("array") {
-		formatArraySchema(&result, schema, prefix, indent, maxDepth)
+	if hasType(schema, "array") {
+		formatArraySchema(&result, schema, prefix, indent, maxDepth, style)
 		return result.String()
 	}
 
 	// Handle primitive types
 	if schema.Type.Slice() != nil {
-		formatPrimitiveSchema(&result, schema, prefix)
+		formatPrimitiveSchema(&result, schema, prefix, style)
 		return result.String()
 	}
 
 	return result.String()
 }
 
+// formatNested formats a schema nested one level below its parent, folding
+// it into a <details> block when it is the first level past the fold depth.
+func formatNested(schema *openapi3.Schema, indent, maxDepth int, style schemaStyle) string {
+	style.level++
+	nested := formatSchema(schema, indent, maxDepth, style)
+	if style.foldDepth <= 0 || style.level != style.foldDepth+1 || nested == "" {
+		return nested
+	}
+
+	prefix := strings.Repeat("  ", indent)
+	summary := "nested schema"
+	if n := len(schema.Properties); n == 1 {
+		summary = "1 property"
+	} else if n > 1 {
+		summary = fmt.Sprintf("%d properties", n)
+	}
+	return fmt.Sprintf("%s"+MarkerFoldOpen+"\n\n%s\n%s"+MarkerFoldClose+"\n\n", prefix, summary, nested, prefix)
+}
+
 // formatSchemaComposition formats oneOf/anyOf/allOf schemas.
-func formatSchemaComposition(result *strings.Builder, keyword, description string, schemas openapi3.SchemaRefs, prefix string, indent, maxDepth int) {
+func formatSchemaComposition(result *strings.Builder, keyword, description string, schemas openapi3.SchemaRefs, prefix string, indent, maxDepth int, style schemaStyle) {
 	fmt.Fprintf(result, "%s- **%s** (%s):\n", prefix, keyword, description)
 	for i, schemaRef := range schemas {
 		fmt.Fprintf(result, "%s  - Option %d:\n", prefix, i+1)
-		if schemaRef.Value != nil {
-			result.WriteString(FormatSchema(schemaRef.Value, indent+2, maxDepth-1))
+		if schemaRef != nil && schemaRef.Value != nil {
+			result.WriteString(formatNested(schemaRef.Value, indent+2, maxDepth-1, style))
 		}
 	}
 }
 
 // formatObjectSchema formats an object type schema.
-func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix string, indent, maxDepth int) {
-	fmt.Fprintf(result, "%s- Type: `object`\n", prefix)
+func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix string, indent, maxDepth int, style schemaStyle) {
+	fmt.Fprintf(result, "%s- Type: `%s`\n", prefix, FormatNullableType(schema, style.nullable))
 
-	if schema.Nullable {
+	if style.nullable == NullableField && IsNullable(schema) {
 		fmt.Fprintf(result, "%s- Nullable: `true`\n", prefix)
 	}
 
@@ -89,8 +130,7 @@ func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix
 	// Build required map for O(1) lookup
 	requiredMap := buildRequiredMap(schema.Required)
 
-	// Sort properties for deterministic output
-	propNames := getSortedPropertyNames(schema.Properties)
+	propNames := propertyNames(schema.Properties, style.propertyOrder)
 
 	for _, propName := range propNames {
 		propRef := schema.Properties[propName]
@@ -99,6 +139,10 @@ func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix
 		}
 
 		prop := propRef.Value
+		if !availableIn(prop.Extensions, style.minVersion) {
+			continue
+		}
+
 		required := ""
 		if requiredMap[propName] {
 			required = MarkerRequired
@@ -108,14 +152,18 @@ func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix
 			deprecated = MarkerDeprecated
 		}
 
-		fmt.Fprintf(result, "%s  - **%s**%s%s", prefix, propName, required, deprecated)
 		if prop.Description != "" {
-			fmt.Fprintf(result, ": %s\n", prop.Description)
+			lead := fmt.Sprintf("%s  - **%s**%s%s:", prefix, propName, required, deprecated)
+			writeListDescription(result, lead, prop.Description, prefix+"    ", style.markdownDescriptions)
 		} else {
-			result.WriteString("\n")
+			fmt.Fprintf(result, "%s  - **%s**%s%s\n", prefix, propName, required, deprecated)
 		}
 
-		fmt.Fprintf(result, "%s    - Type: `%s`\n", prefix, FormatType(prop))
+		fmt.Fprintf(result, "%s    - Type: `%s`\n", prefix, FormatNullableType(prop, style.nullable))
+
+		if lifecycle := formatLifecycle(prop.Extensions); lifecycle != "" {
+			fmt.Fprintf(result, "%s    - Availability: %s\n", prefix, lifecycle)
+		}
 
 		if prop.Format != "" {
 			fmt.Fprintf(result, "%s    - Format: `%s`\n", prefix, prop.Format)
@@ -126,7 +174,7 @@ func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix
 		if prop.Example != nil {
 			fmt.Fprintf(result, "%s    - Example: `%v`\n", prefix, prop.Example)
 		}
-		if prop.Nullable {
+		if style.nullable == NullableField && IsNullable(prop) {
 			fmt.Fprintf(result, "%s    - Nullable: `true`\n", prefix)
 		}
 
@@ -140,21 +188,21 @@ func formatObjectSchema(result *strings.Builder, schema *openapi3.Schema, prefix
 		}
 
 		// Recurse for nested objects and arrays
-		if prop.Type.Is("object") && len(prop.Properties) > 0 {
-			result.WriteString(FormatSchema(prop, indent+2, maxDepth-1))
+		if hasType(prop, "object") && len(prop.Properties) > 0 {
+			result.WriteString(formatNested(prop, indent+2, maxDepth-1, style))
 		}
-		if prop.Type.Is("array") && prop.Items != nil && prop.Items.Value != nil {
+		if hasType(prop, "array") && prop.Items != nil && prop.Items.Value != nil {
 			fmt.Fprintf(result, "%s    - Items:\n", prefix)
-			result.WriteString(FormatSchema(prop.Items.Value, indent+3, maxDepth-1))
+			result.WriteString(formatNested(prop.Items.Value, indent+3, maxDepth-1, style))
 		}
 	}
 }
 
 // formatArraySchema formats an array type schema.
-func formatArraySchema(result *strings.Builder, schema *openapi3.Schema, prefix string, indent, maxDepth int) {
-	fmt.Fprintf(result, "%s- Type: `array`\n", prefix)
+func formatArraySchema(result *strings.Builder, schema *openapi3.Schema, prefix string, indent, maxDepth int, style schemaStyle) {
+	fmt.Fprintf(result, "%s- Type: `%s`\n", prefix, FormatNullableType(schema, style.nullable))
 
-	if schema.Nullable {
+	if style.nullable == NullableField && IsNullable(schema) {
 		fmt.Fprintf(result, "%s- Nullable: `true`\n", prefix)
 	}
 
@@ -165,18 +213,18 @@ func formatArraySchema(result *strings.Builder, schema *openapi3.Schema, prefix
 
 	if schema.Items != nil && schema.Items.Value != nil {
 		fmt.Fprintf(result, "%s- Items:\n", prefix)
-		result.WriteString(FormatSchema(schema.Items.Value, indent+1, maxDepth-1))
+		result.WriteString(formatNested(schema.Items.Value, indent+1, maxDepth-1, style))
 	}
 }
 
 // formatPrimitiveSchema formats a primitive type schema (string, number, boolean, etc.).
-func formatPrimitiveSchema(result *strings.Builder, schema *openapi3.Schema, prefix string) {
-	fmt.Fprintf(result, "%s- Type: `%s`\n", prefix, FormatType(schema))
+func formatPrimitiveSchema(result *strings.Builder, schema *openapi3.Schema, prefix string, style schemaStyle) {
+	fmt.Fprintf(result, "%s- Type: `%s`\n", prefix, FormatNullableType(schema, style.nullable))
 
 	if schema.Format != "" {
 		fmt.Fprintf(result, "%s- Format: `%s`\n", prefix, schema.Format)
 	}
-	if schema.Nullable {
+	if style.nullable == NullableField && IsNullable(schema) {
 		fmt.Fprintf(result, "%s- Nullable: `true`\n", prefix)
 	}
 	if schema.Default != nil {
@@ -195,3 +243,36 @@ func formatPrimitiveSchema(result *strings.Builder, schema *openapi3.Schema, pre
 		fmt.Fprintf(result, "%s- Allowed values: %v\n", prefix, schema.Enum)
 	}
 }
+
+// formatSchemaSafely formats a schema, returning an inline warning instead of
+// aborting when the schema has a structure the formatter cannot handle.
+func (g *Generator) formatSchemaSafely(schema *openapi3.Schema) string {
+	var formatted string
+	if err := renderSafely(func() { formatted = formatSchema(schema, 0, g.opts.MaxDepth, g.schemaStyle()) }); err != nil {
+		return fmt.Sprintf(MarkerRenderError+"\n", err)
+	}
+	return formatted
+}
+
+// schemaStyle returns the schema rendering style for the generator's options.
+func (g *Generator) schemaStyle() schemaStyle {
+	return schemaStyle{
+		markdownDescriptions: g.opts.MarkdownDescriptions,
+		minVersion:           g.opts.MinVersion,
+		propertyOrder:        g.opts.PropertyOrder,
+		foldDepth:            g.opts.FoldDepth,
+		nullable:             g.opts.Nullable,
+	}
+}
+
+// renderSafely runs fn and converts a panic into an error.
+func renderSafely(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	fn()
+	return nil
+}
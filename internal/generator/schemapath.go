@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SchemaPath addresses a sub-schema of a body schema with a JSONPath-like
+// expression such as "$.data.items[*].attributes". Property names select
+// object properties; "[*]" (or an index like "[0]") selects array items.
+type SchemaPath struct {
+	expr     string
+	segments []string
+}
+
+// itemsSegment is the segment stepping into array items.
+const itemsSegment = "[*]"
+
+// ParseSchemaPath parses a schema path expression. The leading "$" is
+// optional, and properties may be quoted as ['name'] when they contain dots.
+func ParseSchemaPath(expr string) (SchemaPath, error) {
+	expr = strings.TrimSpace(expr)
+	p := SchemaPath{expr: expr}
+
+	rest := strings.TrimPrefix(expr, "$")
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return SchemaPath{}, fmt.Errorf("invalid schema path %q: empty property name", expr)
+			}
+			p.segments = append(p.segments, rest[:end])
+			rest = rest[end:]
+
+		case strings.HasPrefix(rest, "['"), strings.HasPrefix(rest, `["`):
+			quote := rest[1:2]
+			end := strings.Index(rest[2:], quote+"]")
+			if end < 0 {
+				return SchemaPath{}, fmt.Errorf("invalid schema path %q: unterminated %s", expr, rest[:2])
+			}
+			p.segments = append(p.segments, rest[2:2+end])
+			rest = rest[2+end+2:]
+
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return SchemaPath{}, fmt.Errorf("invalid schema path %q: unterminated [", expr)
+			}
+			index := rest[1:end]
+			if index == "" || (index != "*" && strings.Trim(index, "0123456789") != "") {
+				return SchemaPath{}, fmt.Errorf("invalid schema path %q: unsupported index [%s], use [*]", expr, index)
+			}
+			p.segments = append(p.segments, itemsSegment)
+			rest = rest[end+1:]
+
+		default:
+			if p.segments == nil && !strings.HasPrefix(expr, "$") {
+				// Allow a bare "data.items[*]".
+				rest = "." + rest
+				continue
+			}
+			return SchemaPath{}, fmt.Errorf("invalid schema path %q: unexpected %q", expr, rest[:1])
+		}
+	}
+
+	if len(p.segments) == 0 {
+		return SchemaPath{}, fmt.Errorf("invalid schema path %q: no properties selected", expr)
+	}
+	return p, nil
+}
+
+// String returns the expression the path was parsed from.
+func (p SchemaPath) String() string {
+	return p.expr
+}
+
+// IsZero reports whether p is unset.
+func (p SchemaPath) IsZero() bool {
+	return len(p.segments) == 0
+}
+
+// Resolve returns the sub-schema of schema addressed by p. Properties are
+// also looked up in allOf, oneOf, and anyOf members, in that order.
+func (p SchemaPath) Resolve(schema *openapi3.Schema) (*openapi3.Schema, error) {
+	ref, _, err := p.resolve(&openapi3.SchemaRef{Value: schema}, "")
+	if err != nil {
+		return nil, err
+	}
+	return ref.Value, nil
+}
+
+// resolve implements Resolve, also tracking the JSON pointer of the
+// addressed schema given the pointer of ref.
+func (p SchemaPath) resolve(ref *openapi3.SchemaRef, pointer string) (*openapi3.SchemaRef, string, error) {
+	walked := "$"
+	for _, segment := range p.segments {
+		if segment == itemsSegment {
+			items, itemsPointer := arrayItems(ref, pointer, 0)
+			if items == nil {
+				return nil, "", fmt.Errorf("%s is not an array", walked)
+			}
+			ref, pointer = items, itemsPointer
+			walked += itemsSegment
+			continue
+		}
+
+		prop, propPointer := findProperty(ref, pointer, segment, 0)
+		if prop == nil {
+			if items, _ := arrayItems(ref, pointer, 0); items != nil {
+				return nil, "", fmt.Errorf("%s is an array; use %s[*].%s", walked, walked, segment)
+			}
+			return nil, "", fmt.Errorf("%s has no property %q", walked, segment)
+		}
+		ref, pointer = prop, propPointer
+		walked += "." + segment
+	}
+	return ref, schemaPointer(ref, pointer), nil
+}
+
+// compositions lists the composition keywords searched for properties and
+// items, with the accessor of each.
+var compositions = []struct {
+	keyword string
+	members func(*openapi3.Schema) openapi3.SchemaRefs
+}{
+	{"allOf", func(s *openapi3.Schema) openapi3.SchemaRefs { return s.AllOf }},
+	{"oneOf", func(s *openapi3.Schema) openapi3.SchemaRefs { return s.OneOf }},
+	{"anyOf", func(s *openapi3.Schema) openapi3.SchemaRefs { return s.AnyOf }},
+}
+
+// findProperty looks up a property of ref, searching composed schemas, and
+// returns it with its pointer. depth guards against circular compositions.
+func findProperty(ref *openapi3.SchemaRef, pointer, name string, depth int) (*openapi3.SchemaRef, string) {
+	if ref == nil || ref.Value == nil || depth > MaxRecursionDepth {
+		return nil, ""
+	}
+	pointer = schemaPointer(ref, pointer)
+	if prop := ref.Value.Properties[name]; prop != nil && prop.Value != nil {
+		return prop, appendPointer(pointer, "properties", name)
+	}
+	for _, c := range compositions {
+		for i, member := range c.members(ref.Value) {
+			if prop, propPointer := findProperty(member, appendPointer(pointer, c.keyword, strconv.Itoa(i)), name, depth+1); prop != nil {
+				return prop, propPointer
+			}
+		}
+	}
+	return nil, ""
+}
+
+// arrayItems returns the item schema of an array schema with its pointer,
+// searching composed schemas, or nil if ref is not an array.
+func arrayItems(ref *openapi3.SchemaRef, pointer string, depth int) (*openapi3.SchemaRef, string) {
+	if ref == nil || ref.Value == nil || depth > MaxRecursionDepth {
+		return nil, ""
+	}
+	pointer = schemaPointer(ref, pointer)
+	if items := ref.Value.Items; items != nil && items.Value != nil {
+		return items, appendPointer(pointer, "items")
+	}
+	for _, c := range compositions {
+		for i, member := range c.members(ref.Value) {
+			if items, itemsPointer := arrayItems(member, appendPointer(pointer, c.keyword, strconv.Itoa(i)), depth+1); items != nil {
+				return items, itemsPointer
+			}
+		}
+	}
+	return nil, ""
+}
+
+// writeBodySchema writes the schema of a request or response body, narrowed
+// to the SchemaPath option when one is set.
+func (g *Generator) writeBodySchema(md *strings.Builder, schema *openapi3.Schema) {
+	if g.schemaPath.IsZero() {
+		md.WriteString(blockLabel(g.opts.Vocabulary.Schema))
+		md.WriteString(g.formatSchemaSafely(schema))
+		return
+	}
+
+	md.WriteString(blockLabel(fmt.Sprintf("%s (`%s`)", g.opts.Vocabulary.Schema, g.schemaPath)))
+	sub, err := g.schemaPath.Resolve(schema)
+	if err != nil {
+		fmt.Fprintf(md, "- *(not in this schema: %s)*\n", err)
+		return
+	}
+	md.WriteString(g.formatSchemaSafely(sub))
+}
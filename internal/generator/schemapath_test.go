@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParseSchemaPath(t *testing.T) {
+	tests := []struct {
+		expr        string
+		segments    []string
+		expectError bool
+	}{
+		{"$.data.items[*].attributes", []string{"data", "items", "[*]", "attributes"}, false},
+		{"data.items[0]", []string{"data", "items", "[*]"}, false},
+		{"$['x.y'].z", []string{"x.y", "z"}, false},
+		{"$", nil, true},
+		{"$.data[", nil, true},
+		{"$.data[first]", nil, true},
+		{"$..data", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			p, err := ParseSchemaPath(tt.expr)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %v", tt.expr, p.segments)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSchemaPath(%q) error: %v", tt.expr, err)
+			}
+			if strings.Join(p.segments, "|") != strings.Join(tt.segments, "|") {
+				t.Errorf("ParseSchemaPath(%q) = %v, want %v", tt.expr, p.segments, tt.segments)
+			}
+		})
+	}
+}
+
+func TestGenerate_SchemaPath(t *testing.T) {
+	attributes := openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())
+	item := &openapi3.Schema{AllOf: openapi3.SchemaRefs{
+		openapi3.NewSchemaRef("", openapi3.NewObjectSchema().
+			WithProperty("id", openapi3.NewStringSchema()).
+			WithProperty("attributes", attributes)),
+	}}
+	body := openapi3.NewObjectSchema().WithProperty("data",
+		openapi3.NewObjectSchema().WithProperty("items", openapi3.NewArraySchema().WithItems(item)))
+
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+					WithDescription("OK").WithJSONSchema(body)}),
+			),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		expr        string
+		expected    []string
+		notExpected []string
+	}{
+		{
+			name:        "Nested through array and allOf",
+			expr:        "$.data.items[*].attributes",
+			expected:    []string{"**Schema (`$.data.items[*].attributes`):**", "**name**"},
+			notExpected: []string{"**data**", "**id**"},
+		},
+		{
+			name:     "Array without items selector",
+			expr:     "$.data.items.attributes",
+			expected: []string{"not in this schema: $.data.items is an array; use $.data.items[*].attributes"},
+		},
+		{
+			name:     "Missing property",
+			expr:     "$.meta",
+			expected: []string{`not in this schema: $ has no property "meta"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown, err := New(doc).Generate("/items", pathItem, WithSchemaPath(tt.expr))
+			if err != nil {
+				t.Fatalf("Generate() error: %v", err)
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(markdown, s) {
+					t.Errorf("Expected %q in output:\n%s", s, markdown)
+				}
+			}
+			for _, s := range tt.notExpected {
+				if strings.Contains(markdown, s) {
+					t.Errorf("Did not expect %q in output:\n%s", s, markdown)
+				}
+			}
+		})
+	}
+
+	if _, err := New(doc).Generate("/items", pathItem, WithSchemaPath("$.data[")); err == nil {
+		t.Error("Expected error for an invalid schema path")
+	}
+}
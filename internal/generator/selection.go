@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Selection selects operations across all paths of a spec. Operations must
+// meet every criterion set.
+type Selection struct {
+	// Tag selects operations carrying this tag, ignoring case.
+	Tag string
+	// PathRegex selects operations of paths it matches.
+	PathRegex *regexp.Regexp
+}
+
+// String describes the selection, e.g. in errors.
+func (s Selection) String() string {
+	var parts []string
+	if s.Tag != "" {
+		parts = append(parts, "tagged "+s.Tag)
+	}
+	if s.PathRegex != nil {
+		parts = append(parts, "with paths matching "+s.PathRegex.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// GenerateTag renders one document for every operation carrying tag, across
+// all paths, introduced by the tag's description from the spec's tags
+// section. Tags match case-insensitively. opts override the Generator's
+// defaults for this call only.
+func (g *Generator) GenerateTag(tag string, opts ...Option) (string, error) {
+	return g.GenerateSelection(Selection{Tag: tag}, opts...)
+}
+
+// GenerateSelection renders one document for every operation sel selects,
+// ordered by path. With a tag, the document is introduced by the tag's
+// description from the spec's tags section. opts override the Generator's
+// defaults for this call only.
+func (g *Generator) GenerateSelection(sel Selection, opts ...Option) (string, error) {
+	r, err := g.prepare(opts)
+	if err != nil {
+		return "", err
+	}
+	if r.opts.Format == FormatJSONDocument || r.opts.Format == FormatGitHubComment {
+		return "", fmt.Errorf("documents of several paths are not available in the %s format", r.opts.Format)
+	}
+	if r.doc == nil || r.doc.Paths == nil {
+		return "", fmt.Errorf("no operations %s", sel)
+	}
+
+	servers, err := FilterServers(r.doc.Servers, r.opts.Environment)
+	if err != nil {
+		return "", err
+	}
+
+	paths := r.doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+
+	var operations strings.Builder
+	count, tag := 0, sel.Tag
+	for _, path := range paths {
+		pathItem := r.doc.Paths.Value(path)
+		if pathItem == nil || (sel.PathRegex != nil && !sel.PathRegex.MatchString(path)) {
+			continue
+		}
+		for _, method := range model.MethodOrder {
+			operation := pathItem.GetOperation(method)
+			if operation == nil {
+				continue
+			}
+			i := slices.IndexFunc(operation.Tags, func(t string) bool { return strings.EqualFold(t, sel.Tag) })
+			if sel.Tag != "" && i < 0 {
+				continue
+			}
+			if r.opts.Method != "" && method != r.opts.Method {
+				continue
+			}
+			if !availableIn(operation.Extensions, r.opts.MinVersion) {
+				continue
+			}
+			// Title undeclared tags as the first operation spells them
+			if count == 0 && sel.Tag != "" {
+				tag = operation.Tags[i]
+			}
+			r.writeOperationSafely(&operations, method, path, operation)
+			count++
+		}
+	}
+	if count == 0 {
+		return "", fmt.Errorf("no operations %s", sel)
+	}
+
+	var header strings.Builder
+	if sel.Tag != "" {
+		r.writeTagHeader(&header, tag)
+	} else if sel.PathRegex != nil {
+		fmt.Fprintf(&header, "# API Endpoints: `%s`\n\n", sel.PathRegex)
+	} else {
+		header.WriteString("# API Endpoints\n\n")
+	}
+	if r.doc.Info != nil {
+		fmt.Fprintf(&header, "**API:** %s %s\n\n", r.doc.Info.Title, r.doc.Info.Version)
+	}
+	r.writeServers(&header, servers)
+	return r.finish(header.String(), operations.String(), r.opts.wantsTOC(count)), nil
+}
+
+// writeTagHeader writes the tag's name and description, as declared in the
+// spec's tags section.
+func (g *Generator) writeTagHeader(md *strings.Builder, tag string) {
+	info := g.tagInfo(tag)
+	if info != nil {
+		tag = info.Name
+	}
+	fmt.Fprintf(md, "# Tag: %s\n\n", tag)
+	g.writeTagDescription(md, info)
+}
+
+// tagInfo returns the declaration of tag in the spec's tags section, matched
+// case-insensitively, or nil if it is not declared.
+func (g *Generator) tagInfo(tag string) *openapi3.Tag {
+	if g.doc == nil {
+		return nil
+	}
+	if i := slices.IndexFunc(g.doc.Tags, func(t *openapi3.Tag) bool { return t != nil && strings.EqualFold(t.Name, tag) }); i >= 0 {
+		return g.doc.Tags[i]
+	}
+	return nil
+}
+
+// writeTagDescription writes the description and external docs link of a
+// declared tag.
+func (g *Generator) writeTagDescription(md *strings.Builder, info *openapi3.Tag) {
+	if info == nil {
+		return
+	}
+	if info.Description != "" {
+		fmt.Fprintf(md, "%s\n\n", g.blockDescription(info.Description))
+	}
+	if info.ExternalDocs != nil && info.ExternalDocs.URL != "" {
+		text := info.ExternalDocs.Description
+		if text == "" {
+			text = info.ExternalDocs.URL
+		}
+		fmt.Fprintf(md, "**See also:** [%s](%s)\n\n", text, info.ExternalDocs.URL)
+	}
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestGenerateSelection_Tag(t *testing.T) {
+	op := func(summary string, tags ...string) *openapi3.Operation {
+		return &openapi3.Operation{Summary: summary, Tags: tags, Responses: openapi3.NewResponses()}
+	}
+	doc := &openapi3.T{
+		Info: &openapi3.Info{Title: "Events API", Version: "1.0.0"},
+		Tags: openapi3.Tags{{
+			Name:         "Events",
+			Description:  "Events record what happened to an account.",
+			ExternalDocs: &openapi3.ExternalDocs{URL: "https://docs.example.com/events"},
+		}},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/events/{id}", &openapi3.PathItem{Get: op("Get an event", "Events")}),
+			openapi3.WithPath("/events", &openapi3.PathItem{Get: op("List events", "Events"), Post: op("Create an event", "events")}),
+			openapi3.WithPath("/users", &openapi3.PathItem{Get: op("List users", "Users")}),
+		),
+	}
+
+	markdown, err := New(doc).GenerateTag("EVENTS")
+	if err != nil {
+		t.Fatalf("GenerateTag() error: %v", err)
+	}
+	for _, s := range []string{"# Tag: Events\n", "Events record what happened to an account.", "**See also:** [https://docs.example.com/events]", "**API:** Events API 1.0.0", HeaderContents} {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Expected %q in output:\n%s", s, markdown)
+		}
+	}
+	if strings.Contains(markdown, "List users") {
+		t.Errorf("Expected operations of other tags to be left out:\n%s", markdown)
+	}
+
+	// Paths in order, then methods in the usual order
+	var order []int
+	for _, s := range []string{"## GET /events\n", "## POST /events\n", "## GET /events/{id}\n"} {
+		order = append(order, strings.Index(markdown, s))
+	}
+	if order[0] < 0 || order[0] > order[1] || order[1] > order[2] {
+		t.Errorf("Expected operations sorted by path and method, got positions %v:\n%s", order, markdown)
+	}
+
+	markdown, err = New(doc, WithMethod("POST")).GenerateTag("Events")
+	if err != nil || strings.Contains(markdown, "## GET") || !strings.Contains(markdown, "## POST /events") {
+		t.Errorf("Expected only POST /events with WithMethod, got %v:\n%s", err, markdown)
+	}
+
+	if _, err := New(doc).GenerateTag("Billing"); err == nil || err.Error() != "no operations tagged Billing" {
+		t.Errorf("Expected error for an unused tag, got %v", err)
+	}
+	markdown, err = New(doc).GenerateSelection(Selection{PathRegex: regexp.MustCompile(`^/(events/|users)`)})
+	if err != nil {
+		t.Fatalf("GenerateSelection() error: %v", err)
+	}
+	for _, s := range []string{"# API Endpoints: `^/(events/|users)`", "## GET /events/{id}", "## GET /users"} {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Expected %q in output:\n%s", s, markdown)
+		}
+	}
+	if strings.Contains(markdown, "## GET /events\n") {
+		t.Errorf("Expected paths not matching to be left out:\n%s", markdown)
+	}
+
+	sel := Selection{Tag: "Users", PathRegex: regexp.MustCompile(`^/events`)}
+	if _, err := New(doc).GenerateSelection(sel); err == nil || err.Error() != "no operations tagged Users with paths matching ^/events" {
+		t.Errorf("Expected error for a selection matching nothing, got %v", err)
+	}
+	if _, err := New(doc, WithFormat(FormatJSONDocument)).GenerateTag("Events"); err == nil {
+		t.Error("Expected error for the json format")
+	}
+}
@@ -0,0 +1,85 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/arthur-s/docfinder"
+	"github.com/arthur-s/docfinder/generatortest"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestSnapshots(t *testing.T) {
+	load := func(name string) *openapi3.T {
+		doc, err := openapi3.NewLoader().LoadFromFile("testdata/" + name)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", name, err)
+		}
+		return doc
+	}
+	subscriptions := load("subscriptions.yaml")
+	orders := load("orders.yaml")
+	items := load("items.yaml")
+	fold := load("fold.yaml")
+
+	tests := []struct {
+		name string
+		op   generatortest.Operation
+	}{
+		{"subscriptions", generatortest.Operation{Doc: subscriptions, Path: "/subscriptions", Method: "POST"}},
+		{"sequence_diagram", generatortest.Operation{
+			Doc: subscriptions, Path: "/subscriptions", Method: "POST",
+			Options: []docfinder.Option{generator.WithDiagrams(generator.DiagramSequence), generator.WithSections(generator.SectionMetadata)},
+		}},
+		// Unauthenticated operations have no authorization server
+		{"sequence_diagram_unauthenticated", generatortest.Operation{
+			Doc: subscriptions, Path: "/health",
+			Options: []docfinder.Option{generator.WithDiagrams(generator.DiagramSequence)},
+		}},
+		{"health", generatortest.Operation{Doc: subscriptions, Path: "/health"}},
+		{"orders", generatortest.Operation{Doc: orders, Path: "/orders", Method: "POST"}},
+		{"schema_diagram", generatortest.Operation{
+			Doc: orders, Path: "/orders", Method: "POST",
+			Options: []docfinder.Option{generator.WithDiagrams(generator.DiagramSchema), generator.WithSections(generator.SectionMetadata)},
+		}},
+		// Operations without structured bodies have no schema diagram
+		{"schema_diagram_unstructured", generatortest.Operation{
+			Doc: orders, Path: "/health",
+			Options: []docfinder.Option{generator.WithDiagrams(generator.DiagramSchema)},
+		}},
+		// Operations are ordered GET, PUT, POST, DELETE, ..., PATCH
+		{"items_all_methods", generatortest.Operation{Doc: items, Path: "/items/{id}"}},
+		{"items_get", generatortest.Operation{Doc: items, Path: "/items/{id}", Method: "GET"}},
+		{"items_put", generatortest.Operation{Doc: items, Path: "/items/{id}", Method: "PUT"}},
+		{"items_delete", generatortest.Operation{Doc: items, Path: "/items/{id}", Method: "DELETE"}},
+		{"items_single_method", generatortest.Operation{Doc: items, Path: "/items"}},
+		{"empty_path_item", generatortest.Operation{Doc: items, Path: "/empty"}},
+		{"fold_depth", generatortest.Operation{
+			Doc: fold, Path: "/orders", Method: "POST",
+			Options: []docfinder.Option{generator.WithFoldDepth(1)},
+		}},
+		{"fold_depth_unfolded", generatortest.Operation{Doc: fold, Path: "/orders", Method: "POST"}},
+		{"fold_depth_comment", generatortest.Operation{
+			Doc: fold, Path: "/orders", Method: "POST",
+			Options: []docfinder.Option{generator.WithFoldDepth(1), generator.WithFormat(generator.FormatGitHubComment)},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generatortest.AssertMarkdownSnapshot(t, tt.op, "testdata/"+tt.name+".golden.md")
+		})
+	}
+}
+
+// Iterating PathItem.Operations() would order operations differently between
+// runs, so the snapshot of every method of a path is rendered repeatedly.
+func TestSnapshots_MethodOrderDeterministic(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromFile("testdata/items.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		generatortest.AssertMarkdownSnapshot(t, generatortest.Operation{Doc: doc, Path: "/items/{id}"}, "testdata/items_all_methods.golden.md")
+	}
+}
@@ -0,0 +1,399 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SnippetBaseURL is the server URL used in snippets when the spec lists none.
+const SnippetBaseURL = "https://api.example.com"
+
+// SnippetRequest is the language-neutral request a snippet makes. It is
+// built once per operation and handed to each SnippetLanguage.
+type SnippetRequest struct {
+	// Method is the uppercase HTTP method.
+	Method string
+	// URL is the full request URL, with path parameters and required query
+	// parameters filled in from their examples.
+	URL string
+	// Headers are the request headers in the order they should be set.
+	Headers []SnippetHeader
+	// Body is the request body, or empty string for none. JSON bodies are
+	// indented.
+	Body string
+}
+
+// SnippetHeader is a request header.
+type SnippetHeader struct {
+	Name  string
+	Value string
+}
+
+// SnippetLanguage renders a SnippetRequest as code in one language.
+// Implement it to add languages beyond the built-in ones and pass it to
+// WithSnippets.
+type SnippetLanguage interface {
+	// Name identifies the language on the command line, e.g. "python".
+	Name() string
+	// Label is shown above the snippet, e.g. "Python".
+	Label() string
+	// Fence is the code block language, e.g. "python".
+	Fence() string
+	// Render returns code making req.
+	Render(req SnippetRequest) string
+}
+
+// SnippetLanguages lists the built-in languages.
+var SnippetLanguages = []SnippetLanguage{curlSnippet{}, goSnippet{}, pythonSnippet{}, jsSnippet{}}
+
+// ParseSnippets parses a comma-separated list of built-in snippet languages.
+func ParseSnippets(s string) ([]SnippetLanguage, error) {
+	var languages []SnippetLanguage
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		language := snippetLanguage(name)
+		if language == nil {
+			names := make([]string, len(SnippetLanguages))
+			for i, l := range SnippetLanguages {
+				names[i] = l.Name()
+			}
+			return nil, fmt.Errorf("unknown snippet language: %s (expected one of %s)", name, strings.Join(names, ", "))
+		}
+		languages = append(languages, language)
+	}
+	return languages, nil
+}
+
+func snippetLanguage(name string) SnippetLanguage {
+	if name == "javascript" {
+		name = "js"
+	}
+	for _, language := range SnippetLanguages {
+		if language.Name() == name {
+			return language
+		}
+	}
+	return nil
+}
+
+// writeSnippets writes the operation's request in each configured language,
+// one labelled code block after another, or in tabs for MDX.
+func (g *Generator) writeSnippets(md *strings.Builder, method, path string, operation *openapi3.Operation) {
+	if len(g.opts.Snippets) == 0 {
+		return
+	}
+
+	req := g.snippetRequest(method, path, operation)
+	md.WriteString(heading(g.opts.Vocabulary.Snippets))
+	if g.opts.Format == FormatMDX {
+		writeSnippetTabs(md, g.opts.Snippets, req)
+		return
+	}
+	for _, language := range g.opts.Snippets {
+		fmt.Fprintf(md, "**%s**\n\n```%s\n%s\n```\n\n", language.Label(), language.Fence(), strings.TrimRight(language.Render(req), "\n"))
+	}
+}
+
+var snippetPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// snippetRequest builds the request shown in snippets: the first server,
+// the operation's required parameters, credentials for its first security
+// requirement as placeholders, and the first example of its request body.
+func (g *Generator) snippetRequest(method, path string, operation *openapi3.Operation) SnippetRequest {
+	req := SnippetRequest{Method: strings.ToUpper(method)}
+
+	base := SnippetBaseURL
+	if g.doc != nil {
+		if servers, err := FilterServers(g.doc.Servers, g.opts.Environment); err == nil && len(servers) > 0 && servers[0] != nil {
+			base = servers[0].URL
+			for name, variable := range servers[0].Variables {
+				if variable != nil {
+					base = strings.ReplaceAll(base, "{"+name+"}", variable.Default)
+				}
+			}
+		}
+	}
+
+	pathValues := make(map[string]string)
+	var query [][2]string
+	for _, ref := range operation.Parameters {
+		if ref == nil || ref.Value == nil || !availableIn(ref.Value.Extensions, g.opts.MinVersion) {
+			continue
+		}
+		param := ref.Value
+		value := ParameterExample(param)
+		switch {
+		case param.In == openapi3.ParameterInPath && value != "":
+			pathValues[param.Name] = url.PathEscape(value)
+		case param.In == openapi3.ParameterInQuery && param.Required:
+			query = append(query, [2]string{param.Name, value})
+		case param.In == openapi3.ParameterInHeader && param.Required:
+			req.Headers = append(req.Headers, SnippetHeader{Name: param.Name, Value: value})
+		}
+	}
+
+	path = snippetPathParam.ReplaceAllStringFunc(path, func(m string) string {
+		if value, ok := pathValues[m[1:len(m)-1]]; ok {
+			return value
+		}
+		return m
+	})
+	req.URL = strings.TrimSuffix(base, "/") + path
+
+	credentials, keys := g.snippetCredentials(operation)
+	req.Headers = append(credentials, req.Headers...)
+	query = append(query, keys...)
+
+	if len(query) > 0 {
+		// Keep the declaration order rather than url.Values' sorted encoding
+		pairs := make([]string, len(query))
+		for i, pair := range query {
+			pairs[i] = url.QueryEscape(pair[0]) + "=" + url.QueryEscape(pair[1])
+		}
+		req.URL += "?" + strings.Join(pairs, "&")
+	}
+
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		content := operation.RequestBody.Value.Content
+		for _, contentType := range g.bodyContentTypes(content) {
+			body, ok := snippetBody(content[contentType], contentType)
+			if !ok {
+				continue
+			}
+			req.Headers = append(req.Headers, SnippetHeader{Name: "Content-Type", Value: requestContentType(contentType)})
+			req.Body = body
+			break
+		}
+	}
+
+	return req
+}
+
+// snippetCredentials returns the headers and query parameters carrying
+// placeholder credentials for the first security requirement of the
+// operation, or of the document if the operation has none.
+func (g *Generator) snippetCredentials(operation *openapi3.Operation) (headers []SnippetHeader, query [][2]string) {
+	security := operation.Security
+	if security == nil && g.doc != nil {
+		security = &g.doc.Security
+	}
+	if security == nil || len(*security) == 0 || g.doc == nil || g.doc.Components == nil {
+		return nil, nil
+	}
+
+	for _, name := range getSortedKeys((*security)[0]) {
+		ref := g.doc.Components.SecuritySchemes[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		scheme := ref.Value
+		switch {
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			headers = append(headers, SnippetHeader{Name: "Authorization", Value: "Basic <credentials>"})
+		case scheme.Type == "http", scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+			headers = append(headers, SnippetHeader{Name: "Authorization", Value: "Bearer <token>"})
+		case scheme.Type == "apiKey" && scheme.In == openapi3.ParameterInHeader:
+			headers = append(headers, SnippetHeader{Name: scheme.Name, Value: "<api-key>"})
+		case scheme.Type == "apiKey" && scheme.In == openapi3.ParameterInQuery:
+			query = append(query, [2]string{scheme.Name, "<api-key>"})
+		}
+	}
+	return headers, query
+}
+
+// ParameterExample returns the example of a parameter as a string: its own
+// example, its schema's example, default, or first enum value. It returns
+// the empty string if there is none.
+func ParameterExample(param *openapi3.Parameter) string {
+	if param.Example != nil {
+		return fmt.Sprint(param.Example)
+	}
+	for _, name := range getSortedExampleNames(param.Examples) {
+		if ref := param.Examples[name]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return fmt.Sprint(ref.Value.Value)
+		}
+	}
+	if param.Schema != nil && param.Schema.Value != nil {
+		schema := param.Schema.Value
+		switch {
+		case schema.Example != nil:
+			return fmt.Sprint(schema.Example)
+		case schema.Default != nil:
+			return fmt.Sprint(schema.Default)
+		case len(schema.Enum) > 0:
+			return fmt.Sprint(schema.Enum[0])
+		}
+	}
+	return ""
+}
+
+// snippetBody returns the example of a media type as request body text.
+func snippetBody(mediaType *openapi3.MediaType, contentType string) (string, bool) {
+	if mediaType == nil {
+		return "", false
+	}
+	value := mediaType.Example
+	if value == nil {
+		for _, name := range getSortedExampleNames(mediaType.Examples) {
+			if ref := mediaType.Examples[name]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+				value = ref.Value.Value
+				break
+			}
+		}
+	}
+	if value == nil && mediaType.Schema != nil && mediaType.Schema.Value != nil {
+		value = mediaType.Schema.Value.Example
+	}
+	if value == nil {
+		return "", false
+	}
+
+	if text, ok := value.(string); ok && !isJSONContentType(contentType) {
+		return text, true
+	}
+	text, err := FormatJSON(value)
+	if err != nil {
+		return "", false
+	}
+	return text, true
+}
+
+// indentLines indents every line of s but the first by prefix.
+func indentLines(s, prefix string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+// curlSnippet renders a curl command line.
+type curlSnippet struct{}
+
+func (curlSnippet) Name() string  { return "curl" }
+func (curlSnippet) Label() string { return "curl" }
+func (curlSnippet) Fence() string { return "bash" }
+
+func (curlSnippet) Render(req SnippetRequest) string {
+	command := "curl"
+	if req.Method != "GET" || req.Body != "" {
+		command += " -X " + req.Method
+	}
+	parts := []string{command + " " + shellQuote(req.URL)}
+	for _, h := range req.Headers {
+		parts = append(parts, "-H "+shellQuote(h.Name+": "+h.Value))
+	}
+	if req.Body != "" {
+		parts = append(parts, "--data-raw "+shellQuote(req.Body))
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// goSnippet renders Go using net/http.
+type goSnippet struct{}
+
+func (goSnippet) Name() string  { return "go" }
+func (goSnippet) Label() string { return "Go" }
+func (goSnippet) Fence() string { return "go" }
+
+func (goSnippet) Render(req SnippetRequest) string {
+	var b strings.Builder
+	body := "nil"
+	if req.Body != "" {
+		literal := strconv.Quote(req.Body)
+		if !strings.Contains(req.Body, "`") {
+			literal = "`" + req.Body + "`"
+		}
+		fmt.Fprintf(&b, "body := strings.NewReader(%s)\n", literal)
+		body = "body"
+	}
+	fmt.Fprintf(&b, "req, err := http.NewRequest(%q, %q, %s)\n", req.Method, req.URL, body)
+	b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	for _, h := range req.Headers {
+		fmt.Fprintf(&b, "req.Header.Set(%q, %q)\n", h.Name, h.Value)
+	}
+	b.WriteString("\nresp, err := http.DefaultClient.Do(req)\n")
+	b.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	b.WriteString("defer resp.Body.Close()\n")
+	return b.String()
+}
+
+// pythonSnippet renders Python using requests.
+type pythonSnippet struct{}
+
+func (pythonSnippet) Name() string  { return "python" }
+func (pythonSnippet) Label() string { return "Python" }
+func (pythonSnippet) Fence() string { return "python" }
+
+func (pythonSnippet) Render(req SnippetRequest) string {
+	var b strings.Builder
+	b.WriteString("import requests\n\n")
+	fmt.Fprintf(&b, "response = requests.request(\n    %s,\n    %s,\n", quotedString(req.Method), quotedString(req.URL))
+	if len(req.Headers) > 0 {
+		b.WriteString("    headers={\n")
+		for _, h := range req.Headers {
+			fmt.Fprintf(&b, "        %s: %s,\n", quotedString(h.Name), quotedString(h.Value))
+		}
+		b.WriteString("    },\n")
+	}
+	if req.Body != "" {
+		literal := quotedString(req.Body)
+		if !strings.Contains(req.Body, "'''") && !strings.HasSuffix(req.Body, `\`) {
+			literal = "r'''" + req.Body + "'''"
+		}
+		fmt.Fprintf(&b, "    data=%s,\n", literal)
+	}
+	b.WriteString(")\nprint(response.status_code)\n")
+	return b.String()
+}
+
+// jsSnippet renders JavaScript using fetch.
+type jsSnippet struct{}
+
+func (jsSnippet) Name() string  { return "js" }
+func (jsSnippet) Label() string { return "JavaScript" }
+func (jsSnippet) Fence() string { return "javascript" }
+
+func (jsSnippet) Render(req SnippetRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "const response = await fetch(%s, {\n", quotedString(req.URL))
+	fmt.Fprintf(&b, "  method: %s,\n", quotedString(req.Method))
+	if len(req.Headers) > 0 {
+		b.WriteString("  headers: {\n")
+		for _, h := range req.Headers {
+			fmt.Fprintf(&b, "    %s: %s,\n", quotedString(h.Name), quotedString(h.Value))
+		}
+		b.WriteString("  },\n")
+	}
+	if req.Body != "" {
+		if json.Valid([]byte(req.Body)) {
+			fmt.Fprintf(&b, "  body: JSON.stringify(%s),\n", indentLines(req.Body, "  "))
+		} else {
+			fmt.Fprintf(&b, "  body: %s,\n", quotedString(req.Body))
+		}
+	}
+	b.WriteString("});\nconsole.log(response.status);\n")
+	return b.String()
+}
+
+// quotedString returns s as a double-quoted string literal valid in both
+// JavaScript and Python.
+func quotedString(s string) string {
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
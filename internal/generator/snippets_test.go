@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const snippetsSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: eu
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+    key:
+      type: apiKey
+      in: query
+      name: api_key
+security:
+  - bearer: []
+paths:
+  /orders/{order_id}/items:
+    post:
+      parameters:
+        - name: order_id
+          in: path
+          required: true
+          schema:
+            type: string
+            example: ord_1
+        - name: dry_run
+          in: query
+          required: true
+          schema:
+            type: boolean
+            default: false
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: Idempotency-Key
+          in: header
+          required: true
+          example: abc
+      requestBody:
+        content:
+          application/json:
+            example:
+              sku: it's-1
+      responses:
+        "201":
+          description: Created
+    get:
+      security:
+        - key: []
+      parameters:
+        - name: order_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+`
+
+func TestGenerate_Snippets(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(snippetsSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathItem := doc.Paths.Find("/orders/{order_id}/items")
+
+	t.Run("Languages", func(t *testing.T) {
+		markdown := New(doc, WithSnippets(SnippetLanguages...)).GenerateMarkdown("/orders/{order_id}/items", pathItem, "POST")
+
+		for _, want := range []string{
+			HeaderSnippets,
+			"**curl**\n\n```bash\ncurl -X POST 'https://eu.example.com/v1/orders/ord_1/items?dry_run=false' \\\n" +
+				"  -H 'Authorization: Bearer <token>' \\\n" +
+				"  -H 'Idempotency-Key: abc' \\\n" +
+				"  -H 'Content-Type: application/json' \\\n" +
+				"  --data-raw '{\n  \"sku\": \"it'\\''s-1\"\n}'\n```",
+			"**Go**\n\n```go\nbody := strings.NewReader(`{\n  \"sku\": \"it's-1\"\n}`)\n" +
+				"req, err := http.NewRequest(\"POST\", \"https://eu.example.com/v1/orders/ord_1/items?dry_run=false\", body)\n",
+			"req.Header.Set(\"Idempotency-Key\", \"abc\")\n",
+			"**Python**\n\n```python\nimport requests\n",
+			"        \"Authorization\": \"Bearer <token>\",\n",
+			"    data=r'''{\n  \"sku\": \"it's-1\"\n}''',\n",
+			"**JavaScript**\n\n```javascript\nconst response = await fetch(\"https://eu.example.com/v1/orders/ord_1/items?dry_run=false\", {\n",
+			"  body: JSON.stringify({\n    \"sku\": \"it's-1\"\n  }),\n",
+		} {
+			if !strings.Contains(markdown, want) {
+				t.Errorf("Expected %q in output:\n%s", want, markdown)
+			}
+		}
+		if strings.Contains(markdown, "limit=") {
+			t.Errorf("Did not expect optional query parameters in snippets:\n%s", markdown)
+		}
+	})
+
+	t.Run("Query API key", func(t *testing.T) {
+		markdown := New(doc, WithSnippets(SnippetLanguages[0])).GenerateMarkdown("/orders/{order_id}/items", pathItem, "GET")
+
+		want := "curl 'https://eu.example.com/v1/orders/{order_id}/items?api_key=%3Capi-key%3E'\n```"
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	})
+
+	t.Run("Custom language", func(t *testing.T) {
+		markdown := New(doc, WithSnippets(httpieSnippet{})).GenerateMarkdown("/orders/{order_id}/items", pathItem, "POST")
+
+		want := "**HTTPie**\n\n```bash\nhttp POST https://eu.example.com/v1/orders/ord_1/items?dry_run=false\n```"
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		markdown := New(doc).GenerateMarkdown("/orders/{order_id}/items", pathItem, "POST")
+		if strings.Contains(markdown, HeaderSnippets) {
+			t.Error("Did not expect code samples without WithSnippets")
+		}
+	})
+
+	t.Run("Examples section excluded", func(t *testing.T) {
+		markdown := New(doc, WithSnippets(SnippetLanguages...), WithSections(SectionParameters)).GenerateMarkdown("/orders/{order_id}/items", pathItem, "POST")
+		if strings.Contains(markdown, HeaderSnippets) {
+			t.Error("Did not expect code samples when the examples section is excluded")
+		}
+	})
+}
+
+type httpieSnippet struct{}
+
+func (httpieSnippet) Name() string  { return "httpie" }
+func (httpieSnippet) Label() string { return "HTTPie" }
+func (httpieSnippet) Fence() string { return "bash" }
+
+func (httpieSnippet) Render(req SnippetRequest) string {
+	return "http " + req.Method + " " + req.URL
+}
+
+func TestParseSnippets(t *testing.T) {
+	languages, err := ParseSnippets("curl, Python,javascript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, language := range languages {
+		names = append(names, language.Name())
+	}
+	if got := strings.Join(names, ","); got != "curl,python,js" {
+		t.Errorf("ParseSnippets() = %s, want curl,python,js", got)
+	}
+
+	if _, err := ParseSnippets("curl,ruby"); err == nil || !strings.Contains(err.Error(), "ruby") {
+		t.Errorf("Expected an error naming ruby, got %v", err)
+	}
+}
+
+func TestGenerate_MDX(t *testing.T) {
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Description: "Returns {id} as <b>bold</b>, see `{id}` and ``a ` b {c}``",
+			Responses:   openapi3.NewResponses(),
+		},
+	}
+	gen := New(&openapi3.T{}, WithFormat(FormatMDX), WithSnippets(SnippetLanguages[0], SnippetLanguages[2]), WithFoldDepth(0))
+
+	result, err := gen.Generate("/events/{id}", pathItem)
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	for _, want := range []string{
+		MDXImports + "# API Endpoint: /events/\\{id\\}\n",
+		"Returns \\{id\\} as &lt;b>bold&lt;/b>, see `{id}` and ``a ` b {c}``",
+		"<Tabs groupId=\"language\">\n<TabItem value=\"curl\" label=\"curl\">\n\n```bash\n",
+		"</TabItem>\n<TabItem value=\"python\" label=\"Python\">\n",
+		"'https://api.example.com/events/{id}'",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in output:\n%s", want, result)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// StatusCategory is the class of a response status code, given by its first
+// digit.
+type StatusCategory string
+
+// Status code categories.
+const (
+	StatusInformational StatusCategory = "informational"
+	StatusSuccess       StatusCategory = "success"
+	StatusRedirect      StatusCategory = "redirect"
+	StatusClientError   StatusCategory = "client error"
+	StatusServerError   StatusCategory = "server error"
+)
+
+var statusCategories = map[byte]StatusCategory{
+	'1': StatusInformational,
+	'2': StatusSuccess,
+	'3': StatusRedirect,
+	'4': StatusClientError,
+	'5': StatusServerError,
+}
+
+// StatusSemantics describes a documented response status.
+type StatusSemantics struct {
+	// Reason is the standard reason phrase, e.g. "Not Found". It is empty
+	// for ranges such as "4XX", "default", and non-standard codes.
+	Reason string
+	// Category is the class of the code. It is empty for "default".
+	Category StatusCategory
+	// NonStandard reports a code that is not registered with IANA, such as
+	// 299. Clients may only understand its class.
+	NonStandard bool
+}
+
+// ParseStatus returns the semantics of a response key of an OpenAPI
+// responses object: a status code, a range such as "4XX", or "default".
+func ParseStatus(status string) StatusSemantics {
+	if len(status) != 3 {
+		return StatusSemantics{}
+	}
+	category, ok := statusCategories[status[0]]
+	if !ok {
+		return StatusSemantics{}
+	}
+	if strings.EqualFold(status[1:], "XX") {
+		return StatusSemantics{Category: category}
+	}
+
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return StatusSemantics{}
+	}
+	reason := http.StatusText(code)
+	return StatusSemantics{Reason: reason, Category: category, NonStandard: reason == ""}
+}
+
+// statusHeading returns the heading text of a response: the status, its
+// reason phrase, and its category as an inline badge.
+func statusHeading(status string) string {
+	s := ParseStatus(status)
+	text := status
+	if s.Reason != "" {
+		text += " " + s.Reason
+	}
+	if s.Category != "" {
+		text += " `" + string(s.Category) + "`"
+	}
+	return text
+}
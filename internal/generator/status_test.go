@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   StatusSemantics
+	}{
+		{"200", StatusSemantics{Reason: "OK", Category: StatusSuccess}},
+		{"101", StatusSemantics{Reason: "Switching Protocols", Category: StatusInformational}},
+		{"304", StatusSemantics{Reason: "Not Modified", Category: StatusRedirect}},
+		{"422", StatusSemantics{Reason: "Unprocessable Entity", Category: StatusClientError}},
+		{"503", StatusSemantics{Reason: "Service Unavailable", Category: StatusServerError}},
+		{"4XX", StatusSemantics{Category: StatusClientError}},
+		{"5xx", StatusSemantics{Category: StatusServerError}},
+		{"299", StatusSemantics{Category: StatusSuccess, NonStandard: true}},
+		{"default", StatusSemantics{}},
+		{"700", StatusSemantics{}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseStatus(tt.status); got != tt.want {
+			t.Errorf("ParseStatus(%q) = %+v, want %+v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate_StatusSemantics(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+	pathItem := &openapi3.PathItem{
+		Get: &openapi3.Operation{
+			Responses: openapi3.NewResponses(
+				openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Found")}),
+				openapi3.WithStatus(299, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Found, stale")}),
+				openapi3.WithName("5XX", openapi3.NewResponse().WithDescription("Outage")),
+				openapi3.WithName("default", openapi3.NewResponse().WithDescription("Anything else")),
+			),
+		},
+	}
+
+	markdown := New(doc).GenerateMarkdown("/items", pathItem, "GET")
+	for _, want := range []string{
+		"#### 200 OK `success`\n\nFound",
+		"#### 299 `success`\n\n⚠️ **Non-standard status code:** 299 is not a registered HTTP status; clients may only understand it as 2xx.\n\nFound, stale",
+		"#### 5XX `server error`\n\nOutage",
+		"#### default\n\nAnything else",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+	if strings.Count(markdown, "Non-standard") != 1 {
+		t.Errorf("Expected only 299 to be flagged:\n%s", markdown)
+	}
+}
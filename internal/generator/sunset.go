@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Response headers announcing deprecation (RFC 9745) and removal (RFC 8594).
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+)
+
+// ExtensionSunset gives the date an operation is removed, when its responses
+// don't document a Sunset header: either a date, or a mapping with "date" and
+// "link" keys.
+const ExtensionSunset = "x-sunset"
+
+// DateFormat is how deprecation and sunset dates are rendered.
+const DateFormat = "2006-01-02"
+
+// Sunset describes when a deprecated operation was deprecated and when it is
+// removed, as documented by its Deprecation and Sunset response headers or
+// the x-sunset extension.
+type Sunset struct {
+	// Deprecated reports whether the operation is deprecated: marked
+	// deprecated, or documenting either header or x-sunset.
+	Deprecated bool `json:"deprecated"`
+	// Since is when the operation was deprecated, if known.
+	Since time.Time `json:"since,omitzero"`
+	// Date is when the operation is removed, if known.
+	Date time.Time `json:"date,omitzero"`
+	// Link points to details such as a migration guide, from a Link header
+	// with rel="sunset" or rel="deprecation", or x-sunset.
+	Link string `json:"link,omitempty"`
+}
+
+// linkRelPattern matches a Link header value with a sunset or deprecation
+// relation, capturing its URI.
+var linkRelPattern = regexp.MustCompile(`<([^>]+)>\s*;[^,]*\brel="?(?:sunset|deprecation)"?`)
+
+// OperationSunset returns the deprecation and sunset details of operation.
+// Header values come from their example, default, or single enum value.
+func OperationSunset(operation *openapi3.Operation) Sunset {
+	s := Sunset{Deprecated: operation.Deprecated}
+
+	if operation.Responses != nil {
+		for _, status := range getSortedStatusCodes(operation.Responses.Map()) {
+			ref := operation.Responses.Value(status)
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			for _, name := range getSortedHeaderNames(ref.Value.Headers) {
+				header := ref.Value.Headers[name]
+				if header == nil || header.Value == nil {
+					continue
+				}
+				value := headerValue(header.Value)
+				switch http.CanonicalHeaderKey(name) {
+				case HeaderDeprecation:
+					s.Deprecated = true
+					if s.Since.IsZero() {
+						s.Since = parseDeprecationDate(value)
+					}
+				case HeaderSunset:
+					s.Deprecated = true
+					if s.Date.IsZero() {
+						s.Date = parseSunsetDate(value)
+					}
+				case "Link":
+					if m := linkRelPattern.FindStringSubmatch(value); m != nil && s.Link == "" {
+						s.Link = m[1]
+					}
+				}
+			}
+		}
+	}
+
+	switch v := operation.Extensions[ExtensionSunset].(type) {
+	case nil:
+	case map[string]any:
+		s.Deprecated = true
+		if s.Date.IsZero() {
+			s.Date = parseSunsetDate(extensionValue(v["date"]))
+		}
+		if s.Link == "" {
+			s.Link = extensionValue(v["link"])
+		}
+	default:
+		s.Deprecated = true
+		if s.Date.IsZero() {
+			s.Date = parseSunsetDate(extensionValue(v))
+		}
+	}
+
+	return s
+}
+
+// headerValue returns the documented value of a header, or "" if it has
+// none.
+func headerValue(header *openapi3.Header) string {
+	if header.Example != nil {
+		return extensionValue(header.Example)
+	}
+	for _, name := range getSortedExampleNames(header.Examples) {
+		if ex := header.Examples[name]; ex != nil && ex.Value != nil && ex.Value.Value != nil {
+			return extensionValue(ex.Value.Value)
+		}
+	}
+	if header.Schema == nil || header.Schema.Value == nil {
+		return ""
+	}
+	schema := header.Schema.Value
+	switch {
+	case schema.Example != nil:
+		return extensionValue(schema.Example)
+	case schema.Default != nil:
+		return extensionValue(schema.Default)
+	case len(schema.Enum) == 1:
+		return extensionValue(schema.Enum[0])
+	}
+	return ""
+}
+
+func extensionValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
+
+// parseSunsetDate parses an HTTP-date as RFC 8594 specifies, or an RFC 3339
+// timestamp or plain date as specs often write them. It returns the zero time
+// if value is none of these.
+func parseSunsetDate(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.UTC()
+	}
+	for _, layout := range []string{time.RFC3339, DateFormat} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// parseDeprecationDate parses a Deprecation header value: a structured
+// field date such as "@1688169599" (RFC 9745), or a date accepted by
+// parseSunsetDate as earlier drafts used.
+func parseDeprecationDate(value string) time.Time {
+	if seconds, ok := strings.CutPrefix(value, "@"); ok {
+		if n, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			return time.Unix(n, 0).UTC()
+		}
+		return time.Time{}
+	}
+	return parseSunsetDate(value)
+}
+
+// writeDeprecation writes the deprecation banner of operation, if it is
+// deprecated.
+func writeDeprecation(md *strings.Builder, operation *openapi3.Operation) {
+	s := OperationSunset(operation)
+	if !s.Deprecated {
+		return
+	}
+
+	md.WriteString("⚠️ **DEPRECATED** - This operation is deprecated")
+	if !s.Since.IsZero() {
+		fmt.Fprintf(md, " since %s", s.Since.Format(DateFormat))
+	}
+	if s.Date.IsZero() {
+		md.WriteString(" and may be removed in a future version.")
+	} else {
+		fmt.Fprintf(md, " and will be removed on **%s**.", s.Date.Format(DateFormat))
+	}
+	if replacement := extensionString(operation.Extensions, ExtensionReplacedBy); replacement != "" {
+		fmt.Fprintf(md, " Use `%s` instead.", replacement)
+	}
+	if s.Link != "" {
+		fmt.Fprintf(md, " See %s.", s.Link)
+	}
+	md.WriteString("\n\n")
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestOperationSunset(t *testing.T) {
+	const spec = `
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+paths:
+  /headers:
+    get:
+      x-replaced-by: GET /v2/items
+      responses:
+        '200':
+          description: OK
+          headers:
+            Deprecation: {schema: {type: string, example: '@1688169599'}}
+            Sunset: {schema: {type: string}, example: 'Sat, 31 Jan 2026 23:59:59 GMT'}
+            Link: {schema: {type: string, example: '<https://example.com/migrate>; rel="sunset"'}}
+  /extension:
+    get:
+      x-sunset: {date: '2026-06-30', link: https://example.com/v2}
+      responses: {'200': {description: OK}}
+  /flag:
+    get:
+      deprecated: true
+      responses: {'200': {description: OK}}
+  /current:
+    get:
+      responses: {'200': {description: OK}}
+`
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := func(path string) *openapi3.Operation { return doc.Paths.Value(path).Get }
+
+	headers := OperationSunset(op("/headers"))
+	if !headers.Deprecated || headers.Link != "https://example.com/migrate" ||
+		!headers.Since.Equal(time.Unix(1688169599, 0)) || !headers.Date.Equal(time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("Unexpected sunset from headers: %+v", headers)
+	}
+	extension := OperationSunset(op("/extension"))
+	if !extension.Deprecated || extension.Link != "https://example.com/v2" || extension.Date.Format(DateFormat) != "2026-06-30" {
+		t.Errorf("Unexpected sunset from x-sunset: %+v", extension)
+	}
+	if flag := OperationSunset(op("/flag")); !flag.Deprecated || !flag.Date.IsZero() {
+		t.Errorf("Unexpected sunset of a deprecated operation: %+v", flag)
+	}
+	if current := OperationSunset(op("/current")); current.Deprecated {
+		t.Errorf("Expected /current not deprecated, got %+v", current)
+	}
+
+	for path, want := range map[string]string{
+		"/headers":   "⚠️ **DEPRECATED** - This operation is deprecated since 2023-06-30 and will be removed on **2026-01-31**. Use `GET /v2/items` instead. See https://example.com/migrate.\n",
+		"/extension": "⚠️ **DEPRECATED** - This operation is deprecated and will be removed on **2026-06-30**. See https://example.com/v2.\n",
+		"/flag":      "⚠️ **DEPRECATED** - This operation is deprecated and may be removed in a future version.\n",
+	} {
+		markdown := New(doc).GenerateMarkdown(path, doc.Paths.Value(path), "GET")
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+)
+
+// The term output format is the markdown output rewritten for reading in a
+// terminal: headings, emphasis, and code are shown with ANSI styles instead
+// of markdown syntax, methods are colored, and code blocks are indented.
+// Without WithColor, the same text is produced without escape codes, for
+// output piped to a file or another program.
+
+// ANSI escape sequences used by the term format.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiItalic    = "\x1b[3m"
+	ansiUnderline = "\x1b[4m"
+	ansiRed       = "\x1b[31m"
+	ansiGreen     = "\x1b[32m"
+	ansiYellow    = "\x1b[33m"
+	ansiBlue      = "\x1b[34m"
+	ansiMagenta   = "\x1b[35m"
+	ansiCyan      = "\x1b[36m"
+)
+
+// termMethodColors colors HTTP methods by how they affect the server.
+var termMethodColors = map[string]string{
+	"GET":    ansiGreen,
+	"HEAD":   ansiGreen,
+	"POST":   ansiYellow,
+	"PUT":    ansiBlue,
+	"PATCH":  ansiCyan,
+	"DELETE": ansiRed,
+}
+
+var (
+	termLinkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	termBoldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	termItalicPattern = regexp.MustCompile(`(^|[^*\w])\*([^*\s][^*]*?)\*`)
+	termMethodPattern = regexp.MustCompile(`^([A-Z]+) (/\S*)(.*)$`)
+	termListPattern   = regexp.MustCompile(`^(\s*)- `)
+)
+
+// termWriter renders markdown lines for a terminal.
+type termWriter struct {
+	color bool
+}
+
+// style wraps s in an ANSI style when colors are enabled.
+func (t termWriter) style(code, s string) string {
+	if !t.color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// term converts rendered markdown to terminal text.
+func (g *Generator) term(md string) string {
+	t := termWriter{color: g.opts.Color}
+
+	var b strings.Builder
+	inFence := false
+	blank := false
+	for _, line := range strings.Split(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			b.WriteString("    " + t.style(ansiDim, line) + "\n")
+			blank = false
+			continue
+		}
+
+		var out string
+		switch {
+		case mdxTagPattern.MatchString(trimmed):
+			// Folded schemas are shown expanded
+			continue
+		case trimmed == strings.TrimSpace(SeparatorOperation):
+			out = t.style(ansiDim, strings.Repeat("─", 60))
+		case strings.HasPrefix(line, "#"):
+			out = t.heading(line)
+		case strings.HasPrefix(line, "> "):
+			out = t.style(ansiDim, "│ ") + t.inline(line[2:])
+		default:
+			out = termListPattern.ReplaceAllString(line, "$1• ")
+			out = t.inline(out)
+		}
+
+		// Collapse the runs of blank lines markdown leaves between blocks
+		if out == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		b.WriteString(out + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// heading renders a markdown heading, coloring the method of "## GET /path"
+// operation headings.
+func (t termWriter) heading(line string) string {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	text := strings.TrimSpace(line[level:])
+
+	if m := termMethodPattern.FindStringSubmatch(text); m != nil && slices.Contains(model.MethodOrder, m[1]) {
+		color, ok := termMethodColors[m[1]]
+		if !ok {
+			color = ansiMagenta
+		}
+		return t.style(ansiBold+color, m[1]) + " " + t.style(ansiBold, m[2]) + t.inline(m[3])
+	}
+
+	switch level {
+	case 1:
+		return t.style(ansiBold+ansiUnderline, t.plain(text))
+	case 2:
+		return t.style(ansiBold, t.plain(text))
+	}
+	return t.style(ansiBold+ansiBlue, t.plain(text))
+}
+
+// plain strips inline markdown from text without styling it, for headings
+// that are styled as a whole.
+func (t termWriter) plain(text string) string {
+	return termWriter{}.inline(text)
+}
+
+// inline replaces inline markdown in text: code spans, links, required
+// markers, bold, and italics. Code spans are left otherwise untouched.
+func (t termWriter) inline(text string) string {
+	var b strings.Builder
+	for text != "" {
+		start := strings.IndexByte(text, '`')
+		if start < 0 {
+			b.WriteString(t.emphasis(text))
+			break
+		}
+		b.WriteString(t.emphasis(text[:start]))
+
+		run := len(text[start:]) - len(strings.TrimLeft(text[start:], "`"))
+		delimiter := text[start : start+run]
+		end := strings.Index(text[start+run:], delimiter)
+		if end < 0 {
+			b.WriteString(delimiter)
+			text = text[start+run:]
+			continue
+		}
+		code := strings.TrimSpace(text[start+run : start+run+end])
+		b.WriteString(t.style(ansiCyan, code))
+		text = text[start+run+end+run:]
+	}
+	return b.String()
+}
+
+// emphasis replaces links, required markers, bold, and italics in text
+// without code spans.
+func (t termWriter) emphasis(text string) string {
+	text = termLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		m := termLinkPattern.FindStringSubmatch(link)
+		if strings.HasPrefix(m[2], "#") {
+			return m[1]
+		}
+		return m[1] + " (" + t.style(ansiUnderline, m[2]) + ")"
+	})
+	text = strings.ReplaceAll(text, strings.TrimSpace(MarkerRequired), t.style(ansiBold+ansiRed, "(required)"))
+	text = termBoldPattern.ReplaceAllStringFunc(text, func(s string) string {
+		return t.style(ansiBold, s[2:len(s)-2])
+	})
+	text = termItalicPattern.ReplaceAllStringFunc(text, func(s string) string {
+		m := termItalicPattern.FindStringSubmatch(s)
+		return m[1] + t.style(ansiItalic, m[2])
+	})
+	return text
+}
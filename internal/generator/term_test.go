@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func termPathItem() *openapi3.PathItem {
+	return &openapi3.PathItem{
+		Delete: &openapi3.Operation{
+			Summary:     "Delete a user",
+			Description: "Removes the *user*, see [docs](https://example.com/docs).",
+			Parameters: openapi3.Parameters{
+				{Value: &openapi3.Parameter{Name: "id", In: "path", Required: true, Schema: openapi3.NewStringSchema().NewRef()}},
+			},
+			Responses: openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: &openapi3.Response{
+				Content: openapi3.Content{"application/json": &openapi3.MediaType{Example: map[string]any{"id": "u_1"}}},
+			}})),
+		},
+	}
+}
+
+func TestGenerate_Term(t *testing.T) {
+	gen := New(&openapi3.T{}, WithFormat(FormatTerm), WithQuickReference(false))
+
+	result, err := gen.Generate("/users/{id}", termPathItem())
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	for _, want := range []string{
+		"API Endpoint: /users/{id}\n\nDELETE /users/{id}\n",
+		"Description: Removes the user, see docs (https://example.com/docs).\n",
+		"• id (path) (required)\n  • Type: string\n",
+		"Example:\n\n    {\n      \"id\": \"u_1\"\n    }\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in output:\n%s", want, result)
+		}
+	}
+	for _, syntax := range []string{"**", "```", "## ", "\x1b["} {
+		if strings.Contains(result, syntax) {
+			t.Errorf("Did not expect %q in plain terminal output:\n%s", syntax, result)
+		}
+	}
+	if strings.Contains(result, "\n\n\n") {
+		t.Errorf("Expected blank lines to be collapsed:\n%s", result)
+	}
+}
+
+func TestGenerate_TermColor(t *testing.T) {
+	gen := New(&openapi3.T{}, WithFormat(FormatTerm), WithColor(true))
+
+	result, err := gen.Generate("/users/{id}", termPathItem())
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	for _, want := range []string{
+		ansiBold + ansiRed + "DELETE" + ansiReset + " " + ansiBold + "/users/{id}" + ansiReset,
+		ansiBold + ansiRed + "(required)" + ansiReset,
+		ansiItalic + "user" + ansiReset,
+		ansiCyan + "string" + ansiReset,
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected %q in output:\n%s", want, result)
+		}
+	}
+}
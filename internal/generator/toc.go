@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTOCMinOperations is the number of rendered operations from which a
+// table of contents is added.
+const DefaultTOCMinOperations = 3
+
+// Heading is a markdown heading with its GitHub-style anchor.
+type Heading struct {
+	Level  int
+	Text   string
+	Anchor string
+}
+
+// Headings returns the headings of md in order, with GitHub-style anchors.
+// Repeated headings get "-1", "-2", ... suffixes as on GitHub. Headings inside
+// fenced code blocks are ignored.
+func Headings(md string) []Heading {
+	var headings []Heading
+	seen := make(map[string]int)
+	inFence := false
+
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		text := strings.TrimLeft(line, "#")
+		if text == "" || text[0] != ' ' {
+			continue
+		}
+		text = strings.TrimSpace(text)
+
+		anchor := anchorSlug(text)
+		if n := seen[anchor]; n > 0 {
+			seen[anchor] = n + 1
+			anchor = fmt.Sprintf("%s-%d", anchor, n)
+		} else {
+			seen[anchor] = 1
+		}
+		headings = append(headings, Heading{Level: len(line) - len(strings.TrimLeft(line, "#")), Text: text, Anchor: anchor})
+	}
+
+	return headings
+}
+
+// anchorSlug converts heading text to an anchor the way GitHub does:
+// lowercase, punctuation other than "-" and "_" dropped, spaces to "-".
+func anchorSlug(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_':
+			b.WriteRune(r)
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r > 127:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// writeTOC writes a table of contents linking each operation heading of doc
+// and, nested under it, the operation's sections.
+func (g *Generator) writeTOC(md *strings.Builder, doc string) {
+	md.WriteString(blockLabel(g.opts.Vocabulary.Contents))
+
+	for _, h := range Headings(doc) {
+		switch h.Level {
+		case 2:
+			fmt.Fprintf(md, "- [%s](#%s)\n", h.Text, h.Anchor)
+		case 3:
+			fmt.Fprintf(md, "  - [%s](#%s)\n", h.Text, h.Anchor)
+		}
+	}
+
+	md.WriteString("\n")
+}
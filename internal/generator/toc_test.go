@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestHeadings(t *testing.T) {
+	md := "# API\n\n## GET /items\n\n### Parameters\n\n```\n# not a heading\n```\n\n## POST /items\n\n### Parameters\n\n#hashtag\n"
+	expected := []Heading{
+		{1, "API", "api"},
+		{2, "GET /items", "get-items"},
+		{3, "Parameters", "parameters"},
+		{2, "POST /items", "post-items"},
+		{3, "Parameters", "parameters-1"},
+	}
+
+	headings := Headings(md)
+	if len(headings) != len(expected) {
+		t.Fatalf("Headings() = %v, want %v", headings, expected)
+	}
+	for i, want := range expected {
+		if headings[i] != want {
+			t.Errorf("heading %d = %+v, want %+v", i, headings[i], want)
+		}
+	}
+}
+
+func TestGenerate_TOC(t *testing.T) {
+	doc := &openapi3.T{Info: &openapi3.Info{Title: "Test API", Version: "1.0.0"}}
+
+	body := &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(openapi3.NewObjectSchema())}
+	responses := func() *openapi3.Responses {
+		return openapi3.NewResponses(openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("OK")}))
+	}
+	pathItem := &openapi3.PathItem{
+		Get:    &openapi3.Operation{Responses: responses()},
+		Put:    &openapi3.Operation{RequestBody: body, Responses: responses()},
+		Patch:  &openapi3.Operation{RequestBody: body, Responses: responses()},
+		Delete: &openapi3.Operation{Responses: responses()},
+	}
+
+	markdown := New(doc).GenerateMarkdown("/items/{id}", pathItem, "")
+
+	toc := markdown[strings.Index(markdown, HeaderContents):strings.Index(markdown, "\n## ")]
+	for _, h := range Headings(markdown) {
+		var entry string
+		switch h.Level {
+		case 2:
+			entry = "- [" + h.Text + "](#" + h.Anchor + ")\n"
+		case 3:
+			entry = "  - [" + h.Text + "](#" + h.Anchor + ")\n"
+		default:
+			continue
+		}
+		if !strings.Contains(toc, entry) {
+			t.Errorf("Expected %q in table of contents:\n%s", entry, toc)
+		}
+	}
+	for _, want := range []string{"](#request-body)", "](#request-body-1)", "](#responses-3)"} {
+		if !strings.Contains(toc, want) {
+			t.Errorf("Expected link %q in table of contents:\n%s", want, toc)
+		}
+	}
+
+	t.Run("Few operations", func(t *testing.T) {
+		if strings.Contains(New(doc).GenerateMarkdown("/items/{id}", pathItem, "GET"), HeaderContents) {
+			t.Error("Did not expect a table of contents for a single operation")
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		if strings.Contains(New(doc, WithTOCMinOperations(0)).GenerateMarkdown("/items/{id}", pathItem, ""), HeaderContents) {
+			t.Error("Did not expect a table of contents when disabled")
+		}
+	})
+}
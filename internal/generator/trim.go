@@ -0,0 +1,68 @@
+package generator
+
+import "strings"
+
+// DefaultTrimKeys are the boilerplate fields removed from examples by
+// WithTrimExamples when no keys are configured.
+var DefaultTrimKeys = []string{"_links", "meta.debug"}
+
+// trimExample returns a copy of an example payload without null-valued
+// object fields and without fields matching keys, and the number of fields
+// removed. A key is a dotted path matched against the end of a field's path,
+// with arrays transparent: "_links" removes _links fields at any depth, and
+// "meta.debug" removes debug fields of any meta object. value is not
+// modified.
+func trimExample(value any, keys []string) (any, int) {
+	patterns := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			patterns = append(patterns, strings.Split(key, "."))
+		}
+	}
+	removed := 0
+	return trimValue(value, nil, patterns, &removed), removed
+}
+
+func trimValue(value any, path []string, patterns [][]string, removed *int) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for name, field := range v {
+			fieldPath := append(path[:len(path):len(path)], name)
+			if field == nil || matchesTrimKey(fieldPath, patterns) {
+				*removed++
+				continue
+			}
+			out[name] = trimValue(field, fieldPath, patterns, removed)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = trimValue(item, path, patterns, removed)
+		}
+		return out
+	}
+	return value
+}
+
+// matchesTrimKey reports whether path ends with one of patterns.
+func matchesTrimKey(path []string, patterns [][]string) bool {
+	for _, pattern := range patterns {
+		if len(pattern) > len(path) {
+			continue
+		}
+		tail := path[len(path)-len(pattern):]
+		match := true
+		for i := range pattern {
+			if tail[i] != pattern[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// CaveatInRequestBody is the location of type caveats in the request body;
+// those in responses are located like "response `200`".
+const CaveatInRequestBody = "request body"
+
+// TypeCaveat is a body field whose examples contradict its declared type,
+// e.g. the string "123" for an integer, as loosely typed services send.
+type TypeCaveat struct {
+	// In is CaveatInRequestBody or a response such as "response `200`".
+	In string `json:"in"`
+	// Field is the property path, e.g. "items[].id", or "" for the body.
+	Field string `json:"field"`
+	// Declared is the schema type, e.g. "integer".
+	Declared string `json:"declared"`
+	// Actual is the JSON type of the example value, e.g. "string".
+	Actual string `json:"actual"`
+	// Example is the contradicting value as JSON, e.g. `"123"`.
+	Example string `json:"example"`
+}
+
+// TypeCaveats returns the fields of the request and response bodies of
+// operation whose examples, in media types or on properties, don't match
+// their declared types, ordered by location and field.
+func TypeCaveats(operation *openapi3.Operation) []TypeCaveat {
+	c := &caveatCollector{seen: make(map[TypeCaveat]bool)}
+
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		c.content(CaveatInRequestBody, operation.RequestBody.Value.Content)
+	}
+	if operation.Responses != nil {
+		for _, status := range getSortedStatusCodes(operation.Responses.Map()) {
+			ref := operation.Responses.Value(status)
+			if ref != nil && ref.Value != nil {
+				c.content(fmt.Sprintf("response `%s`", status), ref.Value.Content)
+			}
+		}
+	}
+	return c.caveats
+}
+
+// caveatCollector gathers type caveats, once each.
+type caveatCollector struct {
+	caveats []TypeCaveat
+	seen    map[TypeCaveat]bool
+	// in is the location of the body being checked.
+	in string
+}
+
+func (c *caveatCollector) content(in string, content openapi3.Content) {
+	c.in = in
+	start := len(c.caveats)
+	defer func() {
+		found := c.caveats[start:]
+		sort.SliceStable(found, func(i, j int) bool { return found[i].Field < found[j].Field })
+	}()
+	for _, ct := range getSortedContentTypes(content) {
+		media := content[ct]
+		if media == nil || media.Schema == nil || media.Schema.Value == nil || !isJSONContentType(ct) {
+			continue
+		}
+		schema := media.Schema.Value
+
+		if media.Example != nil {
+			c.value("", schema, media.Example, 0)
+		}
+		for _, name := range getSortedExampleNames(media.Examples) {
+			if ex := media.Examples[name]; ex != nil && ex.Value != nil && ex.Value.Value != nil {
+				c.value("", schema, ex.Value.Value, 0)
+			}
+		}
+		c.schemaExamples("", schema, make(map[*openapi3.Schema]bool))
+	}
+}
+
+// schemaExamples checks the example of schema and of its nested properties
+// and items against their own types.
+func (c *caveatCollector) schemaExamples(field string, schema *openapi3.Schema, visited map[*openapi3.Schema]bool) {
+	if visited[schema] {
+		return
+	}
+	visited[schema] = true
+
+	if schema.Example != nil {
+		c.value(field, schema, schema.Example, 0)
+	}
+	for _, name := range getSortedPropertyNames(schema.Properties) {
+		if prop := schema.Properties[name]; prop != nil && prop.Value != nil {
+			c.schemaExamples(joinField(field, name), prop.Value, visited)
+		}
+	}
+	if schema.Items != nil && schema.Items.Value != nil {
+		c.schemaExamples(field+"[]", schema.Items.Value, visited)
+	}
+	for _, sub := range schema.AllOf {
+		if sub != nil && sub.Value != nil {
+			c.schemaExamples(field, sub.Value, visited)
+		}
+	}
+}
+
+// value checks value against schema, recursing into objects and arrays.
+func (c *caveatCollector) value(field string, schema *openapi3.Schema, value any, depth int) {
+	if depth > MaxRecursionDepth {
+		return
+	}
+	for _, sub := range schema.AllOf {
+		if sub != nil && sub.Value != nil {
+			c.value(field, sub.Value, value, depth+1)
+		}
+	}
+	if value == nil {
+		return
+	}
+	// Composed and multi-typed schemas accept several types on purpose, so
+	// only a single declared type is checked. Fields are checked either way:
+	// object schemas often leave their type out, e.g. next to allOf.
+	if schema.Type != nil && len(*schema.Type) == 1 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 {
+		declared := (*schema.Type)[0]
+		if actual := model.JSONType(value); actual != "" && !model.TypeAllows(declared, actual) {
+			c.add(field, declared, actual, value)
+			return
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if prop := schema.Properties[name]; prop != nil && prop.Value != nil {
+				c.value(joinField(field, name), prop.Value, v[name], depth+1)
+			}
+		}
+	case []any:
+		if schema.Items != nil && schema.Items.Value != nil {
+			for _, item := range v {
+				c.value(field+"[]", schema.Items.Value, item, depth+1)
+			}
+		}
+	}
+}
+
+func (c *caveatCollector) add(field, declared, actual string, value any) {
+	caveat := TypeCaveat{In: c.in, Field: field, Declared: declared, Actual: actual, Example: formatInlineJSON(value)}
+	if c.seen[caveat] {
+		return
+	}
+	c.seen[caveat] = true
+	c.caveats = append(c.caveats, caveat)
+}
+
+func formatInlineJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func joinField(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+// writeTypeCaveats writes the fields of an operation whose examples
+// contradict their declared types.
+func (g *Generator) writeTypeCaveats(md *strings.Builder, operation *openapi3.Operation) {
+	if !g.opts.TypeCaveats {
+		return
+	}
+	caveats := TypeCaveats(operation)
+	if len(caveats) == 0 {
+		return
+	}
+
+	md.WriteString(heading(g.opts.Vocabulary.TypeCaveats))
+	md.WriteString("*Examples contradict the declared types of these fields, so the service may send either form. Parse them leniently.*\n\n")
+	for _, caveat := range caveats {
+		field := caveat.Field
+		if field == "" {
+			field = "(body)"
+		}
+		fmt.Fprintf(md, "- **%s** (%s): declared `%s`, but an example has %s `%s`\n", field, caveat.In, caveat.Declared, caveat.Actual, caveat.Example)
+	}
+	md.WriteString("\n")
+}
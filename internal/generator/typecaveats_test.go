@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const typeCaveatsSpec = `
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+paths:
+  /orders:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                quantity: {type: integer, example: '3'}
+                note: {type: string, example: hello}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id: {type: string}
+                  total: {type: number}
+                  paid: {type: boolean}
+                  lines:
+                    type: array
+                    items:
+                      type: object
+                      properties:
+                        sku: {type: string}
+                        qty: {type: integer}
+                  code:
+                    oneOf: [{type: string}, {type: integer}]
+              examples:
+                legacy:
+                  value: {id: 42, total: '19.99', paid: 'true', lines: [{sku: 1001, qty: 2}, {sku: A-1, qty: 1.5}], code: 7}
+                current:
+                  value: {id: '42', total: 19.99, paid: true, lines: [{sku: A-1, qty: 2}], code: A}
+        '202':
+          description: Accepted
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - properties:
+                      status: {type: string}
+                properties:
+                  eta: {type: integer}
+              example: {status: 1, eta: soon}
+`
+
+func TestTypeCaveats(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(typeCaveatsSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := doc.Paths.Value("/orders").Post
+
+	var got []string
+	for _, c := range TypeCaveats(op) {
+		got = append(got, c.In+" "+c.Field+" "+c.Declared+" "+c.Actual+" "+c.Example)
+	}
+	want := []string{
+		"request body quantity integer string \"3\"",
+		"response `200` id string integer 42",
+		"response `200` lines[].qty integer number 1.5",
+		"response `200` lines[].sku string integer 1001",
+		"response `200` paid boolean string \"true\"",
+		"response `200` total number string \"19.99\"",
+		"response `202` eta integer string \"soon\"",
+		"response `202` status string integer 1",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("TypeCaveats() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	pathItem := doc.Paths.Value("/orders")
+	if markdown := New(doc).GenerateMarkdown("/orders", pathItem, "POST"); strings.Contains(markdown, LabelTypeCaveats) {
+		t.Errorf("Expected no type caveats by default:\n%s", markdown)
+	}
+	markdown := New(doc, WithTypeCaveats(true)).GenerateMarkdown("/orders", pathItem, "POST")
+	for _, s := range []string{
+		HeaderTypeCaveats + "*Examples contradict the declared types",
+		"- **quantity** (request body): declared `integer`, but an example has string `\"3\"`\n",
+		"- **id** (response `200`): declared `string`, but an example has integer `42`\n",
+	} {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Expected %q in output:\n%s", s, markdown)
+		}
+	}
+}
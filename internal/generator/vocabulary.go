@@ -0,0 +1,127 @@
+package generator
+
+import "fmt"
+
+// Vocabulary holds the words used for section headings and block labels, so
+// teams can match their style guide (e.g. "Authentication" for "Security").
+// Empty fields fall back to DefaultVocabulary.
+type Vocabulary struct {
+	Parameters  string `yaml:"parameters"`
+	RequestBody string `yaml:"request_body"`
+	Responses   string `yaml:"responses"`
+	Security    string `yaml:"security"`
+	Flows       string `yaml:"flows"`
+	ErrorFormat string `yaml:"error_format"`
+	Example     string `yaml:"example"`
+	Examples    string `yaml:"examples"`
+	Headers     string `yaml:"headers"`
+	Schema      string `yaml:"schema"`
+	Extensions  string `yaml:"extensions"`
+	Contents    string `yaml:"contents"`
+	TeamNotes   string `yaml:"team_notes"`
+	Encoding    string `yaml:"encoding"`
+	EnumUsage   string `yaml:"enum_usage"`
+	TypeCaveats string `yaml:"type_caveats"`
+
+	ParamFilters    string `yaml:"param_filters"`
+	ParamSorting    string `yaml:"param_sorting"`
+	ParamPagination string `yaml:"param_pagination"`
+	ParamFields     string `yaml:"param_fields"`
+	ParamOther      string `yaml:"param_other"`
+
+	QuickReference string `yaml:"quick_reference"`
+
+	Snippets string `yaml:"snippets"`
+
+	SequenceDiagram string `yaml:"sequence_diagram"`
+	SchemaDiagram   string `yaml:"schema_diagram"`
+}
+
+// DefaultVocabulary returns the built-in headings and labels.
+func DefaultVocabulary() Vocabulary {
+	return Vocabulary{
+		Parameters:  LabelParameters,
+		RequestBody: LabelRequestBody,
+		Responses:   LabelResponses,
+		Security:    LabelSecurity,
+		Flows:       LabelFlows,
+		ErrorFormat: LabelErrorFormat,
+		Example:     LabelExample,
+		Examples:    LabelExamples,
+		Headers:     LabelHeaders,
+		Schema:      LabelSchema,
+		Extensions:  LabelExtensions,
+		Contents:    LabelContents,
+		TeamNotes:   LabelTeamNotes,
+		Encoding:    LabelEncoding,
+		EnumUsage:   LabelEnumUsage,
+		TypeCaveats: LabelTypeCaveats,
+
+		ParamFilters:    LabelParamFilters,
+		ParamSorting:    LabelParamSorting,
+		ParamPagination: LabelParamPagination,
+		ParamFields:     LabelParamFields,
+		ParamOther:      LabelParamOther,
+
+		QuickReference: LabelQuickRef,
+
+		Snippets: LabelSnippets,
+
+		SequenceDiagram: LabelSequenceDiagram,
+		SchemaDiagram:   LabelSchemaDiagram,
+	}
+}
+
+// withDefaults fills unset fields of v from DefaultVocabulary.
+func (v Vocabulary) withDefaults() Vocabulary {
+	def := DefaultVocabulary()
+	return Vocabulary{
+		Parameters:  orDefault(v.Parameters, def.Parameters),
+		RequestBody: orDefault(v.RequestBody, def.RequestBody),
+		Responses:   orDefault(v.Responses, def.Responses),
+		Security:    orDefault(v.Security, def.Security),
+		Flows:       orDefault(v.Flows, def.Flows),
+		ErrorFormat: orDefault(v.ErrorFormat, def.ErrorFormat),
+		Example:     orDefault(v.Example, def.Example),
+		Examples:    orDefault(v.Examples, def.Examples),
+		Headers:     orDefault(v.Headers, def.Headers),
+		Schema:      orDefault(v.Schema, def.Schema),
+		Extensions:  orDefault(v.Extensions, def.Extensions),
+		Contents:    orDefault(v.Contents, def.Contents),
+		TeamNotes:   orDefault(v.TeamNotes, def.TeamNotes),
+		Encoding:    orDefault(v.Encoding, def.Encoding),
+		EnumUsage:   orDefault(v.EnumUsage, def.EnumUsage),
+		TypeCaveats: orDefault(v.TypeCaveats, def.TypeCaveats),
+
+		ParamFilters:    orDefault(v.ParamFilters, def.ParamFilters),
+		ParamSorting:    orDefault(v.ParamSorting, def.ParamSorting),
+		ParamPagination: orDefault(v.ParamPagination, def.ParamPagination),
+		ParamFields:     orDefault(v.ParamFields, def.ParamFields),
+		ParamOther:      orDefault(v.ParamOther, def.ParamOther),
+
+		QuickReference: orDefault(v.QuickReference, def.QuickReference),
+
+		Snippets: orDefault(v.Snippets, def.Snippets),
+
+		SequenceDiagram: orDefault(v.SequenceDiagram, def.SequenceDiagram),
+		SchemaDiagram:   orDefault(v.SchemaDiagram, def.SchemaDiagram),
+	}
+}
+
+// orDefault returns s, or def if s is empty.
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// heading formats a section heading such as "### Parameters".
+func heading(label string) string {
+	return fmt.Sprintf("### %s\n\n", label)
+}
+
+// blockLabel formats a bold block label such as "**Schema:**".
+func blockLabel(label string) string {
+	return fmt.Sprintf("**%s:**\n\n", label)
+}
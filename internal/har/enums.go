@@ -0,0 +1,314 @@
+package har
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxEnumDepth bounds recursion through nested bodies.
+const maxEnumDepth = 32
+
+// locationOrder is the order enum usages of an operation are listed in.
+var locationOrder = []string{
+	openapi3.ParameterInPath, openapi3.ParameterInQuery, openapi3.ParameterInHeader,
+	openapi3.ParameterInCookie, generator.EnumInRequestBody, generator.EnumInResponseBody,
+}
+
+// enumCounter counts the values of one enum parameter or property.
+type enumCounter struct {
+	usage generator.EnumUsage
+	enum  []string
+}
+
+// EnumUsage counts the values of enum parameters and JSON body properties in
+// the recorded requests and responses, keyed by generator.NoteKey. Values
+// outside the enum and requests that match no operation are ignored.
+func EnumUsage(doc *openapi3.T, entries []Entry) map[string][]generator.EnumUsage {
+	matcher := NewMatcher(doc)
+	counters := make(map[string]map[string]*enumCounter)
+
+	for _, entry := range entries {
+		match, ok := matcher.Match(entry.Request)
+		if !ok {
+			continue
+		}
+		key := generator.NoteKey(match.Method, match.Path)
+		if counters[key] == nil {
+			counters[key] = make(map[string]*enumCounter)
+		}
+		record := func(in, name string, schema *openapi3.Schema, value string) {
+			enum := enumValues(schema)
+			if len(enum) == 0 || !slices.Contains(enum, value) {
+				return
+			}
+			c := counters[key][in+" "+name]
+			if c == nil {
+				c = &enumCounter{usage: generator.EnumUsage{In: in, Name: name, Counts: make(map[string]int)}, enum: enum}
+				counters[key][in+" "+name] = c
+			}
+			c.usage.Counts[value]++
+		}
+
+		for _, param := range parameters(match.PathItem, match.Operation) {
+			schema := parameterSchema(param)
+			for _, value := range parameterValues(entry.Request, match, param) {
+				record(param.In, param.Name, schema, value)
+			}
+		}
+
+		if body := match.Operation.RequestBody; body != nil && body.Value != nil && entry.Request.PostData != nil {
+			walkEnums(jsonSchema(body.Value.Content, entry.Request.PostData.MimeType), entry.Request.PostData.Text, generator.EnumInRequestBody, record)
+		}
+
+		if resp := response(match.Operation, entry.Response.Status); resp != nil && entry.Response.Content.Encoding == "" {
+			walkEnums(jsonSchema(resp.Content, entry.Response.Content.MimeType), entry.Response.Content.Text, generator.EnumInResponseBody, record)
+		}
+	}
+
+	result := make(map[string][]generator.EnumUsage, len(counters))
+	for key, byElement := range counters {
+		usages := make([]generator.EnumUsage, 0, len(byElement))
+		for _, c := range byElement {
+			for _, value := range c.enum {
+				if c.usage.Counts[value] == 0 {
+					c.usage.Unobserved = append(c.usage.Unobserved, value)
+				}
+			}
+			usages = append(usages, c.usage)
+		}
+		sort.Slice(usages, func(i, j int) bool {
+			if a, b := locationIndex(usages[i].In), locationIndex(usages[j].In); a != b {
+				return a < b
+			}
+			return usages[i].Name < usages[j].Name
+		})
+		result[key] = usages
+	}
+	return result
+}
+
+// parameters returns the parameters of an operation, including those
+// inherited from its path item and not overridden.
+func parameters(pathItem *openapi3.PathItem, op *openapi3.Operation) []*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter)
+	var order []string
+	for _, list := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+		for _, ref := range list {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			key := ref.Value.In + " " + ref.Value.Name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = ref.Value
+		}
+	}
+
+	result := make([]*openapi3.Parameter, len(order))
+	for i, key := range order {
+		result[i] = params[key]
+	}
+	return result
+}
+
+// parameterSchema returns the schema whose enum applies to each value of
+// param: that of its items for array parameters.
+func parameterSchema(param *openapi3.Parameter) *openapi3.Schema {
+	if param.Schema == nil || param.Schema.Value == nil {
+		return nil
+	}
+	schema := param.Schema.Value
+	if schema.Type.Is("array") && schema.Items != nil {
+		return schema.Items.Value
+	}
+	return schema
+}
+
+// parameterValues returns the values of param sent with req. Values of
+// array parameters are split on commas.
+func parameterValues(req Request, match Match, param *openapi3.Parameter) []string {
+	var values []string
+	switch param.In {
+	case openapi3.ParameterInPath:
+		if value, ok := match.PathParams[param.Name]; ok {
+			values = []string{value}
+		}
+	case openapi3.ParameterInQuery:
+		for _, p := range req.QueryString {
+			if p.Name == param.Name {
+				values = append(values, p.Value)
+			}
+		}
+	case openapi3.ParameterInHeader:
+		if value, ok := req.Header(param.Name); ok {
+			values = []string{value}
+		}
+	case openapi3.ParameterInCookie:
+		if cookies, ok := req.Header("Cookie"); ok {
+			if cookie, err := (&http.Request{Header: http.Header{"Cookie": {cookies}}}).Cookie(param.Name); err == nil {
+				values = []string{cookie.Value}
+			}
+		}
+	}
+
+	if param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Type.Is("array") {
+		var split []string
+		for _, value := range values {
+			for _, part := range strings.Split(value, ",") {
+				split = append(split, strings.TrimSpace(part))
+			}
+		}
+		return split
+	}
+	return values
+}
+
+// response returns the documented response for a recorded status code,
+// falling back to the default response.
+func response(op *openapi3.Operation, status int) *openapi3.Response {
+	if op.Responses == nil {
+		return nil
+	}
+	if ref := op.Responses.Status(status); ref != nil && ref.Value != nil {
+		return ref.Value
+	}
+	if ref := op.Responses.Default(); ref != nil && ref.Value != nil {
+		return ref.Value
+	}
+	return nil
+}
+
+// jsonSchema returns the schema of content for a recorded JSON media type,
+// or nil if the media type is not JSON or not documented.
+func jsonSchema(content openapi3.Content, mimeType string) *openapi3.Schema {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil || !strings.Contains(mediaType, "json") {
+		return nil
+	}
+	mt := content.Get(mediaType)
+	if mt == nil || mt.Schema == nil {
+		return nil
+	}
+	return mt.Schema.Value
+}
+
+// walkEnums records the values of the enum properties of schema in the JSON
+// text, by property path.
+func walkEnums(schema *openapi3.Schema, text, in string, record func(in, name string, schema *openapi3.Schema, value string)) {
+	if schema == nil || text == "" {
+		return
+	}
+	var body any
+	if err := json.Unmarshal([]byte(text), &body); err != nil {
+		return
+	}
+
+	var walk func(schema *openapi3.Schema, value any, path string, depth int)
+	walk = func(schema *openapi3.Schema, value any, path string, depth int) {
+		if schema == nil || depth > maxEnumDepth {
+			return
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			for name, child := range v {
+				for _, s := range composedSchemas(schema) {
+					if ref := s.Properties[name]; ref != nil && ref.Value != nil {
+						walk(ref.Value, child, joinPath(path, name), depth+1)
+						break
+					}
+				}
+			}
+		case []any:
+			for _, item := range v {
+				for _, s := range composedSchemas(schema) {
+					if s.Items != nil {
+						walk(s.Items.Value, item, path+"[]", depth+1)
+						break
+					}
+				}
+			}
+		default:
+			if path == "" {
+				return
+			}
+			for _, s := range composedSchemas(schema) {
+				if len(s.Enum) > 0 {
+					record(in, path, s, formatValue(v))
+					return
+				}
+			}
+		}
+	}
+	walk(schema, body, "", 0)
+}
+
+// composedSchemas returns schema followed by its direct allOf, oneOf, and
+// anyOf members.
+func composedSchemas(schema *openapi3.Schema) []*openapi3.Schema {
+	result := []*openapi3.Schema{schema}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref != nil && ref.Value != nil {
+				result = append(result, ref.Value)
+			}
+		}
+	}
+	return result
+}
+
+// enumValues returns the enum of schema as strings, or nil.
+func enumValues(schema *openapi3.Schema) []string {
+	if schema == nil || len(schema.Enum) == 0 {
+		return nil
+	}
+	values := make([]string, len(schema.Enum))
+	for i, v := range schema.Enum {
+		values[i] = formatValue(v)
+	}
+	return values
+}
+
+// formatValue formats a JSON or enum value the way it appears on the wire.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func locationIndex(in string) int {
+	for i, loc := range locationOrder {
+		if loc == in {
+			return i
+		}
+	}
+	return len(locationOrder)
+}
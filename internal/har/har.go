@@ -0,0 +1,98 @@
+// Package har reads HTTP Archive (HAR 1.2) files and matches the recorded
+// requests to the operations of an OpenAPI document.
+package har
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// File is a HAR document.
+type File struct {
+	Log Log `json:"log"`
+}
+
+// Log holds the recorded entries.
+type Log struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is one recorded request/response exchange.
+type Entry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Request         Request   `json:"request"`
+	Response        Response  `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status  int         `json:"status"`
+	Headers []NameValue `json:"headers"`
+	Content Content     `json:"content"`
+}
+
+// Content is a recorded response body.
+type Content struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// NameValue is a header, query parameter, or cookie.
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PostData is a recorded request body.
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Header returns the first value of the named header, matched
+// case-insensitively, and whether it is present.
+func (r Request) Header(name string) (string, bool) {
+	return lookup(r.Headers, name, strings.EqualFold)
+}
+
+// Query returns the first value of the named query parameter and whether it
+// is present.
+func (r Request) Query(name string) (string, bool) {
+	return lookup(r.QueryString, name, func(a, b string) bool { return a == b })
+}
+
+func lookup(pairs []NameValue, name string, equal func(a, b string) bool) (string, bool) {
+	for _, p := range pairs {
+		if equal(p.Name, name) {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// Load reads the entries of a HAR file.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HAR file: %w", err)
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR file %s: %w", path, err)
+	}
+	return f.Log.Entries, nil
+}
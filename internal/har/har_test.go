@@ -0,0 +1,172 @@
+package har
+
+import (
+	"fmt"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.har")
+	data := `{"log": {"version": "1.2", "entries": [{
+		"startedDateTime": "2026-03-01T10:00:00.000Z",
+		"request": {
+			"method": "GET",
+			"url": "https://api.example.com/v1/items?limit=5",
+			"headers": [{"name": "user-agent", "value": "billing/2.1"}],
+			"queryString": [{"name": "limit", "value": "5"}]
+		},
+		"response": {"status": 200, "headers": [], "content": {"mimeType": "application/json", "text": "[]"}}
+	}]}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Load() returned %d entries, want 1", len(entries))
+	}
+
+	req := entries[0].Request
+	if ua, ok := req.Header("User-Agent"); !ok || ua != "billing/2.1" {
+		t.Errorf("Header(User-Agent) = %q, %v", ua, ok)
+	}
+	if limit, ok := req.Query("limit"); !ok || limit != "5" {
+		t.Errorf("Query(limit) = %q, %v", limit, ok)
+	}
+	if entries[0].Response.Status != 200 {
+		t.Errorf("Response.Status = %d, want 200", entries[0].Response.Status)
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	op := func(summary string) *openapi3.Operation { return &openapi3.Operation{Summary: summary} }
+	doc := &openapi3.T{
+		Servers: openapi3.Servers{{URL: "https://api.example.com/v1"}},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/items", &openapi3.PathItem{Get: op("list")}),
+			openapi3.WithPath("/items/{id}", &openapi3.PathItem{Get: op("get"), Delete: op("delete")}),
+			openapi3.WithPath("/items/search", &openapi3.PathItem{Get: op("search")}),
+		),
+	}
+	m := NewMatcher(doc)
+
+	tests := []struct {
+		method  string
+		url     string
+		path    string
+		summary string
+	}{
+		{"GET", "https://api.example.com/v1/items", "/items", "list"},
+		{"get", "https://api.example.com/v1/items/42?expand=owner", "/items/{id}", "get"},
+		{"GET", "https://api.example.com/v1/items/search", "/items/search", "search"},
+		{"DELETE", "http://localhost:8080/items/42", "/items/{id}", "delete"},
+		{"POST", "https://api.example.com/v1/items/42", "", ""},
+		{"GET", "https://api.example.com/v1/users", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			match, ok := m.Match(Request{Method: tt.method, URL: tt.url})
+			if tt.path == "" {
+				if ok {
+					t.Errorf("Did not expect a match, got %s %s", match.Method, match.Path)
+				}
+				return
+			}
+			if !ok || match.Path != tt.path || match.Operation.Summary != tt.summary {
+				t.Errorf("Match() = %+v, %v; want %s (%s)", match, ok, tt.path, tt.summary)
+			}
+			if tt.path == "/items/{id}" && match.PathParams["id"] != "42" {
+				t.Errorf("Match().PathParams = %v, want id=42", match.PathParams)
+			}
+		})
+	}
+}
+
+func TestEnumUsage(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Events, version: 1.0.0}
+servers: [{url: "https://api.example.com/v1"}]
+paths:
+  /events/{kind}:
+    parameters:
+      - {name: kind, in: path, required: true, schema: {type: string, enum: [meeting, call]}}
+    post:
+      parameters:
+        - {name: status, in: query, schema: {type: string, enum: [active, archived, deleted]}}
+        - {name: fields, in: query, schema: {type: array, items: {type: string, enum: [id, name]}}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                items:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      priority: {type: integer, enum: [1, 2, 3]}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - type: object
+                    properties:
+                      state: {type: string, enum: [queued, sent]}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := func(url, body, response string) Entry {
+		u, _ := neturl.Parse(url)
+		var query []NameValue
+		for name, values := range u.Query() {
+			for _, value := range values {
+				query = append(query, NameValue{Name: name, Value: value})
+			}
+		}
+		return Entry{
+			Request: Request{Method: "POST", URL: url, QueryString: query,
+				PostData: &PostData{MimeType: "application/json", Text: body}},
+			Response: Response{Status: 201, Content: Content{MimeType: "application/json; charset=utf-8", Text: response}},
+		}
+	}
+	entries := []Entry{
+		entry("https://api.example.com/v1/events/meeting?status=active&fields=id,name", `{"items": [{"priority": 1}, {"priority": 1}]}`, `{"state": "queued"}`),
+		entry("https://api.example.com/v1/events/meeting?status=active", `{"items": [{"priority": 2}]}`, `{"state": "queued"}`),
+		entry("https://api.example.com/v1/events/call?status=unknown", `{}`, `{"state": "sent"}`),
+		entry("https://api.example.com/v1/users", `{}`, `{}`),
+	}
+
+	usages := EnumUsage(doc, entries)["POST /events/{kind}"]
+	var got []string
+	for _, u := range usages {
+		got = append(got, fmt.Sprintf("%s %s %v %v", u.In, u.Name, u.Counts, u.Unobserved))
+	}
+	want := []string{
+		"path kind map[call:1 meeting:2] []",
+		"query fields map[id:1 name:1] []",
+		"query status map[active:2] [archived deleted]",
+		"request body items[].priority map[1:2 2:1] [3]",
+		"response body state map[queued:2 sent:1] []",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EnumUsage() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
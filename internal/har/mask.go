@@ -0,0 +1,242 @@
+package har
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Built-in masking rules, which MaskConfig.Disable can name.
+const (
+	RuleBearer = "bearer"
+	RuleJWT    = "jwt"
+	RuleEmail  = "email"
+	RuleCard   = "card"
+)
+
+// MaskConfig configures how secrets and personal data in recorded traffic
+// are masked before any value reaches the docs.
+type MaskConfig struct {
+	// Rules mask values matching their patterns, after the built-in rules.
+	Rules []MaskRule `yaml:"rules"`
+	// Headers lists headers, besides the built-in credential headers, whose
+	// values are masked whole.
+	Headers []string `yaml:"headers"`
+	// Disable turns off built-in rules by name, e.g. "card" when order
+	// numbers look like card numbers.
+	Disable []string `yaml:"disable"`
+	// Off turns masking off, for traffic known to be synthetic.
+	Off bool `yaml:"off"`
+}
+
+// MaskRule replaces the matches of a regular expression.
+type MaskRule struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
+	// Replacement replaces each match, expanding $1-style groups. Empty
+	// means a placeholder such as "<name:1a2b3c>" that is the same for
+	// equal values, so masked values can still be told apart.
+	Replacement string `yaml:"replacement"`
+}
+
+// credentialHeaders are masked whole.
+var credentialHeaders = []string{
+	"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie",
+	"X-Api-Key", "Api-Key", "X-Auth-Token", "X-Access-Token",
+}
+
+// builtinRules are applied in order, JWTs before bearer tokens so a bearer
+// JWT is masked as a JWT.
+var builtinRules = []MaskRule{
+	{Name: RuleJWT, Pattern: `eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`},
+	{Name: RuleBearer, Pattern: `(?i)\b(bearer|token)\s+[A-Za-z0-9._~+/=-]{8,}`, Replacement: "$1 <token>"},
+	{Name: RuleEmail, Pattern: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+	{Name: RuleCard, Pattern: `\b\d(?:[ -]?\d){12,18}\b`},
+}
+
+// Masker masks secrets and personal data in recorded traffic.
+type Masker struct {
+	rules   []maskRule
+	headers []string
+}
+
+type maskRule struct {
+	MaskRule
+	pattern *regexp.Regexp
+}
+
+// NewMasker compiles cfg's rules. It returns nil, which masks nothing, if
+// masking is off.
+func NewMasker(cfg MaskConfig) (*Masker, error) {
+	if cfg.Off {
+		return nil, nil
+	}
+
+	m := &Masker{headers: append(slices.Clone(credentialHeaders), cfg.Headers...)}
+	for _, rule := range builtinRules {
+		if !slices.Contains(cfg.Disable, rule.Name) {
+			m.rules = append(m.rules, maskRule{MaskRule: rule, pattern: regexp.MustCompile(rule.Pattern)})
+		}
+	}
+	for _, rule := range cfg.Rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid masking rule %q: %w", rule.Name, err)
+		}
+		if rule.Name == "" {
+			rule.Name = "masked"
+		}
+		m.rules = append(m.rules, maskRule{MaskRule: rule, pattern: pattern})
+	}
+	return m, nil
+}
+
+// Value returns s with the matches of every rule masked.
+func (m *Masker) Value(s string) string {
+	if m == nil {
+		return s
+	}
+	for _, rule := range m.rules {
+		s = rule.pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.Name == RuleCard && !luhn(match) {
+				return match
+			}
+			if rule.Replacement != "" {
+				return rule.pattern.ReplaceAllString(match, rule.Replacement)
+			}
+			return placeholder(rule.Name, match)
+		})
+	}
+	return s
+}
+
+// Entries masks entries in place: credential header values whole, and
+// rule matches in the URL, query string, other headers, and bodies.
+func (m *Masker) Entries(entries []Entry) {
+	if m == nil {
+		return
+	}
+	for i := range entries {
+		req := &entries[i].Request
+		req.URL = m.url(req.URL)
+		m.pairs(req.Headers, true)
+		m.pairs(req.QueryString, false)
+		if req.PostData != nil {
+			req.PostData.Text = m.body(req.PostData.Text)
+		}
+
+		resp := &entries[i].Response
+		m.pairs(resp.Headers, true)
+		if resp.Content.Encoding == "" {
+			resp.Content.Text = m.body(resp.Content.Text)
+		}
+	}
+}
+
+// pairs masks the values of headers or query parameters.
+func (m *Masker) pairs(pairs []NameValue, headers bool) {
+	for i, p := range pairs {
+		if headers && slices.ContainsFunc(m.headers, func(name string) bool { return strings.EqualFold(name, p.Name) }) {
+			pairs[i].Value = placeholder("header", p.Value)
+			continue
+		}
+		pairs[i].Value = m.Value(p.Value)
+	}
+}
+
+// url masks each path segment and query value of a recorded URL.
+func (m *Masker) url(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return m.Value(raw)
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = m.Value(segment)
+	}
+	u.Path, u.RawPath = strings.Join(segments, "/"), ""
+
+	query := u.Query()
+	for name, values := range query {
+		for i, v := range values {
+			values[i] = m.Value(v)
+		}
+		query[name] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// body masks a request or response body. JSON bodies are masked value by
+// value, so masking keeps them valid JSON; other bodies are masked as text.
+func (m *Masker) body(text string) string {
+	var value any
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return m.Value(text)
+	}
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(m.json(value)); err != nil {
+		return m.Value(text)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m *Masker) json(value any) any {
+	switch v := value.(type) {
+	case string:
+		return m.Value(v)
+	case json.Number:
+		// Card numbers are often sent as numbers
+		if masked := m.Value(v.String()); masked != v.String() {
+			return masked
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = m.json(item)
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = m.json(item)
+		}
+	}
+	return value
+}
+
+// placeholder replaces value with a name and a short hash of it, the same
+// for equal values.
+func placeholder(name, value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "<" + name + ":" + hex.EncodeToString(sum[:3]) + ">"
+}
+
+// luhn reports whether the digits of s pass the Luhn check card numbers
+// satisfy, so most other long numbers are left alone.
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
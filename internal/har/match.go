@@ -0,0 +1,137 @@
+package har
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Match is the operation a recorded request was routed to.
+type Match struct {
+	// Path is the path template, e.g. "/items/{id}".
+	Path      string
+	Method    string
+	PathItem  *openapi3.PathItem
+	Operation *openapi3.Operation
+	// PathParams holds the values of the path parameters, by name.
+	PathParams map[string]string
+}
+
+// Matcher routes recorded requests to the operations of a document.
+type Matcher struct {
+	doc       *openapi3.T
+	templates []template
+	prefixes  []string
+}
+
+type template struct {
+	path     string
+	segments []string
+	literals int
+}
+
+// NewMatcher builds a Matcher for doc. Base paths of the document's servers
+// (e.g. "/v1") are stripped from request paths before matching.
+func NewMatcher(doc *openapi3.T) *Matcher {
+	m := &Matcher{doc: doc}
+
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.InMatchingOrder() {
+			t := template{path: path, segments: splitPath(path)}
+			for _, seg := range t.segments {
+				if !isParam(seg) {
+					t.literals++
+				}
+			}
+			m.templates = append(m.templates, t)
+		}
+	}
+	// Prefer templates with more literal segments, e.g. /items/new over /items/{id}
+	sort.SliceStable(m.templates, func(i, j int) bool {
+		if m.templates[i].literals != m.templates[j].literals {
+			return m.templates[i].literals > m.templates[j].literals
+		}
+		return m.templates[i].path < m.templates[j].path
+	})
+
+	for _, server := range doc.Servers {
+		if server == nil {
+			continue
+		}
+		if u, err := url.Parse(server.URL); err == nil {
+			if prefix := strings.TrimRight(u.Path, "/"); prefix != "" && !strings.Contains(prefix, "{") {
+				m.prefixes = append(m.prefixes, prefix)
+			}
+		}
+	}
+
+	return m
+}
+
+// Match returns the operation handling req, or false if none does.
+func (m *Matcher) Match(req Request) (Match, bool) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return Match{}, false
+	}
+	method := strings.ToUpper(req.Method)
+
+	candidates := []string{u.Path}
+	for _, prefix := range m.prefixes {
+		if rest, ok := strings.CutPrefix(u.Path, prefix); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			candidates = append(candidates, rest)
+		}
+	}
+
+	for _, path := range candidates {
+		segments := splitPath(path)
+		for _, t := range m.templates {
+			if !t.matches(segments) {
+				continue
+			}
+			pathItem := m.doc.Paths.Value(t.path)
+			if op := pathItem.GetOperation(method); op != nil {
+				return Match{Path: t.path, Method: method, PathItem: pathItem, Operation: op, PathParams: t.params(segments)}, true
+			}
+		}
+	}
+
+	return Match{}, false
+}
+
+func (t template) matches(segments []string) bool {
+	if len(segments) != len(t.segments) {
+		return false
+	}
+	for i, seg := range t.segments {
+		if !isParam(seg) && seg != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// params returns the values of the parameter segments of t in segments.
+func (t template) params(segments []string) map[string]string {
+	params := make(map[string]string)
+	for i, seg := range t.segments {
+		if isParam(seg) {
+			params[strings.Trim(seg, "{}")] = segments[i]
+		}
+	}
+	return params
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,210 @@
+// Package headers builds a matrix of custom request headers across
+// operations and flags headers used inconsistently.
+package headers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ignoredHeaders are header parameters the OpenAPI specification says to
+// ignore, since they are described elsewhere.
+var ignoredHeaders = map[string]bool{
+	"Accept":        true,
+	"Content-Type":  true,
+	"Authorization": true,
+}
+
+// Usage describes how one operation declares a header.
+type Usage struct {
+	Required bool
+	Type     string
+}
+
+// Row lists the headers of one operation.
+type Row struct {
+	Method  string
+	Path    string
+	Headers map[string]Usage
+}
+
+// Matrix is the set of custom request headers used across operations.
+type Matrix struct {
+	// Headers are the canonical header names, sorted.
+	Headers []string
+	// Rows has one entry per operation that declares at least one header.
+	Rows []Row
+}
+
+// Inconsistency is a header declared differently across operations.
+type Inconsistency struct {
+	Header string
+	// Detail explains the difference, e.g. "required in 2 operations, optional in 1".
+	Detail string
+	// Operations lists "METHOD path" of the minority declarations.
+	Operations []string
+}
+
+// Analyze collects header parameters from every operation in doc, including
+// path-level parameters. Header names are compared case-insensitively.
+func Analyze(doc *openapi3.T) Matrix {
+	var m Matrix
+	if doc == nil || doc.Paths == nil {
+		return m
+	}
+
+	seen := make(map[string]bool)
+
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pathItem := doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		for _, method := range model.MethodOrder {
+			op := pathItem.GetOperation(method)
+			if op == nil {
+				continue
+			}
+
+			row := Row{Method: method, Path: path, Headers: make(map[string]Usage)}
+			for _, params := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+				for _, ref := range params {
+					if ref == nil || ref.Value == nil || ref.Value.In != openapi3.ParameterInHeader {
+						continue
+					}
+					name := http.CanonicalHeaderKey(ref.Value.Name)
+					if ignoredHeaders[name] {
+						continue
+					}
+					row.Headers[name] = Usage{Required: ref.Value.Required, Type: generator.FormatParameterType(ref.Value)}
+					seen[name] = true
+				}
+			}
+
+			if len(row.Headers) > 0 {
+				m.Rows = append(m.Rows, row)
+			}
+		}
+	}
+
+	for name := range seen {
+		m.Headers = append(m.Headers, name)
+	}
+	sort.Strings(m.Headers)
+
+	return m
+}
+
+// Inconsistencies returns headers that are required in some operations but
+// optional in others, or declared with different types.
+func (m Matrix) Inconsistencies() []Inconsistency {
+	var found []Inconsistency
+
+	for _, name := range m.Headers {
+		var required, optional []string
+		types := make(map[string][]string)
+
+		for _, row := range m.Rows {
+			usage, ok := row.Headers[name]
+			if !ok {
+				continue
+			}
+			op := row.Method + " " + row.Path
+			if usage.Required {
+				required = append(required, op)
+			} else {
+				optional = append(optional, op)
+			}
+			types[usage.Type] = append(types[usage.Type], op)
+		}
+
+		if len(required) > 0 && len(optional) > 0 {
+			minority := optional
+			if len(required) < len(optional) {
+				minority = required
+			}
+			found = append(found, Inconsistency{
+				Header:     name,
+				Detail:     fmt.Sprintf("required in %d operation(s), optional in %d", len(required), len(optional)),
+				Operations: minority,
+			})
+		}
+
+		if len(types) > 1 {
+			var names []string
+			for t := range types {
+				names = append(names, "`"+t+"`")
+			}
+			sort.Strings(names)
+			found = append(found, Inconsistency{
+				Header: name,
+				Detail: "declared with different types: " + strings.Join(names, ", "),
+			})
+		}
+	}
+
+	return found
+}
+
+// Format renders the matrix and its inconsistencies as markdown.
+func Format(m Matrix) string {
+	var md strings.Builder
+
+	md.WriteString("# Request Headers\n\n")
+
+	if len(m.Headers) == 0 {
+		md.WriteString("No custom request headers are declared.\n")
+		return md.String()
+	}
+
+	md.WriteString("| Operation |")
+	for _, name := range m.Headers {
+		fmt.Fprintf(&md, " `%s` |", name)
+	}
+	md.WriteString("\n|---|")
+	md.WriteString(strings.Repeat("---|", len(m.Headers)))
+	md.WriteString("\n")
+
+	for _, row := range m.Rows {
+		fmt.Fprintf(&md, "| %s %s |", row.Method, row.Path)
+		for _, name := range m.Headers {
+			usage, ok := row.Headers[name]
+			switch {
+			case !ok:
+				md.WriteString(" — |")
+			case usage.Required:
+				md.WriteString(" required |")
+			default:
+				md.WriteString(" optional |")
+			}
+		}
+		md.WriteString("\n")
+	}
+
+	inconsistencies := m.Inconsistencies()
+	md.WriteString("\n## Inconsistencies\n\n")
+	if len(inconsistencies) == 0 {
+		md.WriteString("None found.\n")
+		return md.String()
+	}
+
+	for _, inc := range inconsistencies {
+		fmt.Fprintf(&md, "- ⚠️ `%s` is %s", inc.Header, inc.Detail)
+		if len(inc.Operations) > 0 {
+			fmt.Fprintf(&md, " (outliers: %s)", strings.Join(inc.Operations, ", "))
+		}
+		md.WriteString("\n")
+	}
+
+	return md.String()
+}
@@ -0,0 +1,70 @@
+package headers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func header(name string, required bool) *openapi3.ParameterRef {
+	p := openapi3.NewHeaderParameter(name).WithSchema(openapi3.NewStringSchema())
+	p.Required = required
+	return &openapi3.ParameterRef{Value: p}
+}
+
+func headersDoc() *openapi3.T {
+	return &openapi3.T{Paths: openapi3.NewPaths(
+		openapi3.WithPath("/events", &openapi3.PathItem{
+			Parameters: openapi3.Parameters{header("x-tenant", true)},
+			Get:        &openapi3.Operation{Parameters: openapi3.Parameters{header("X-Request-Id", false), header("Accept", true)}},
+			Post:       &openapi3.Operation{Parameters: openapi3.Parameters{header("X-Tenant", false)}},
+		}),
+		openapi3.WithPath("/users", &openapi3.PathItem{
+			Get:    &openapi3.Operation{Parameters: openapi3.Parameters{header("X-Tenant", true)}},
+			Delete: &openapi3.Operation{},
+		}),
+	)}
+}
+
+func TestAnalyze(t *testing.T) {
+	m := Analyze(headersDoc())
+
+	if strings.Join(m.Headers, ",") != "X-Request-Id,X-Tenant" {
+		t.Errorf("Headers = %v, want [X-Request-Id X-Tenant]", m.Headers)
+	}
+	if len(m.Rows) != 3 {
+		t.Fatalf("Expected 3 operations with headers, got %d", len(m.Rows))
+	}
+	if post := m.Rows[1]; post.Method != "POST" || post.Headers["X-Tenant"].Required {
+		t.Errorf("Expected operation-level parameter to override path-level one, got %+v", post)
+	}
+
+	inconsistencies := m.Inconsistencies()
+	if len(inconsistencies) != 1 {
+		t.Fatalf("Expected 1 inconsistency, got %v", inconsistencies)
+	}
+	inc := inconsistencies[0]
+	if inc.Header != "X-Tenant" || len(inc.Operations) != 1 || inc.Operations[0] != "POST /events" {
+		t.Errorf("Unexpected inconsistency: %+v", inc)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	markdown := Format(Analyze(headersDoc()))
+
+	for _, want := range []string{
+		"| Operation | `X-Request-Id` | `X-Tenant` |",
+		"| GET /events | optional | required |",
+		"| POST /events | — | optional |",
+		"- ⚠️ `X-Tenant` is required in 2 operation(s), optional in 1 (outliers: POST /events)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in output:\n%s", want, markdown)
+		}
+	}
+
+	if strings.Contains(markdown, "Accept") {
+		t.Error("Did not expect ignored Accept header")
+	}
+}
@@ -0,0 +1,178 @@
+// Package history keeps the lookups run on this machine, so the endpoints
+// people look up every day can be repeated by number and starred as
+// favorites.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EnvPath overrides where the history is kept. "off" disables it.
+const EnvPath = "DOCFINDER_HISTORY"
+
+// MaxEntries bounds the number of lookups kept; the oldest are dropped.
+// Favorites are kept regardless.
+const MaxEntries = 200
+
+// Entry is a lookup: the arguments docfinder was run with and the directory
+// it was run in, which relative spec paths and the config file are found
+// from.
+type Entry struct {
+	Args []string  `json:"args"`
+	Dir  string    `json:"dir"`
+	Time time.Time `json:"time"`
+}
+
+// String returns the command line of the lookup, quoting arguments that
+// contain spaces.
+func (e Entry) String() string {
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
+
+// same reports whether e and o are the same lookup, run at any time.
+func (e Entry) same(o Entry) bool {
+	return e.Dir == o.Dir && slices.Equal(e.Args, o.Args)
+}
+
+// History is the lookup history file.
+type History struct {
+	// Path is where the history is saved.
+	Path string `json:"-"`
+	// Entries are the recent lookups, most recent first.
+	Entries []Entry `json:"entries"`
+	// Favorites are the starred lookups, in the order they were starred.
+	Favorites []Entry `json:"favorites,omitempty"`
+}
+
+// DefaultPath returns the history file: EnvPath if set, else history.json in
+// the user config directory. It returns empty string if history is
+// disabled or no config directory is available.
+func DefaultPath() string {
+	if path := os.Getenv(EnvPath); path != "" {
+		if path == "off" {
+			return ""
+		}
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "docfinder", "history.json")
+}
+
+// Load reads the history at path. A missing file is an empty history that
+// Save creates.
+func Load(path string) (*History, error) {
+	h := &History{Path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return h, nil
+		}
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("failed to parse history %s: %w", path, err)
+	}
+	return h, nil
+}
+
+// Save writes the history, creating its directory. The file is readable
+// only by its owner, since command lines may name private hosts and paths.
+func (h *History) Save() error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	// Write and rename, so concurrent runs never see a partial file
+	tmp := h.Path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := os.Rename(tmp, h.Path); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// Add records e as the most recent lookup, replacing an earlier run of the
+// same lookup.
+func (h *History) Add(e Entry) {
+	h.Entries = slices.DeleteFunc(h.Entries, e.same)
+	h.Entries = append([]Entry{e}, h.Entries...)
+	if len(h.Entries) > MaxEntries {
+		h.Entries = h.Entries[:MaxEntries]
+	}
+}
+
+// Picks returns the lookups in the order they are offered: favorites
+// first, then the other recent lookups, most recent first. Lookups are
+// numbered by their position in Picks, from 1.
+func (h *History) Picks() []Entry {
+	picks := slices.Clone(h.Favorites)
+	for _, e := range h.Entries {
+		if !h.IsFavorite(e) {
+			picks = append(picks, e)
+		}
+	}
+	return picks
+}
+
+// Get returns the n-th lookup of Picks.
+func (h *History) Get(n int) (Entry, error) {
+	picks := h.Picks()
+	if len(picks) == 0 {
+		return Entry{}, errors.New("history is empty")
+	}
+	if n < 1 || n > len(picks) {
+		return Entry{}, fmt.Errorf("history has %d lookup(s); no lookup #%d", len(picks), n)
+	}
+	return picks[n-1], nil
+}
+
+// IsFavorite reports whether e is starred.
+func (h *History) IsFavorite(e Entry) bool {
+	return slices.ContainsFunc(h.Favorites, e.same)
+}
+
+// Star adds e to the favorites, reporting whether it wasn't one already.
+func (h *History) Star(e Entry) bool {
+	if h.IsFavorite(e) {
+		return false
+	}
+	h.Favorites = append(h.Favorites, e)
+	return true
+}
+
+// Unstar removes e from the favorites, reporting whether it was one.
+func (h *History) Unstar(e Entry) bool {
+	n := len(h.Favorites)
+	h.Favorites = slices.DeleteFunc(h.Favorites, e.same)
+	return len(h.Favorites) < n
+}
+
+// Clear forgets the recent lookups, keeping the favorites.
+func (h *History) Clear() {
+	h.Entries = nil
+}
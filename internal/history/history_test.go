@@ -0,0 +1,101 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func entry(args ...string) Entry {
+	return Entry{Args: args, Dir: "/work", Time: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)}
+}
+
+func TestHistory_AddAndPicks(t *testing.T) {
+	h := &History{}
+	h.Add(entry("GET", "/users", "openapi.yaml"))
+	h.Add(entry("GET", "/orders", "openapi.yaml"))
+	h.Add(entry("GET", "/users", "openapi.yaml"))
+
+	if len(h.Entries) != 2 || h.Entries[0].String() != "GET /users openapi.yaml" {
+		t.Fatalf("Expected repeated lookup to move to the top, got %v", h.Entries)
+	}
+
+	orders := h.Entries[1]
+	if !h.Star(orders) || h.Star(orders) {
+		t.Error("Expected Star to report only the first starring")
+	}
+	var got []string
+	for _, e := range h.Picks() {
+		got = append(got, e.String())
+	}
+	if want := []string{"GET /orders openapi.yaml", "GET /users openapi.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Picks() = %v, want %v", got, want)
+	}
+
+	if e, err := h.Get(1); err != nil || !e.same(orders) {
+		t.Errorf("Get(1) = %v, %v; want the favorite", e, err)
+	}
+	if _, err := h.Get(3); err == nil {
+		t.Error("Expected error for a number past the history")
+	}
+
+	h.Clear()
+	if len(h.Picks()) != 1 || !h.IsFavorite(orders) {
+		t.Error("Expected Clear to keep favorites")
+	}
+	if !h.Unstar(orders) || h.Unstar(orders) {
+		t.Error("Expected Unstar to report only the first removal")
+	}
+}
+
+func TestHistory_MaxEntries(t *testing.T) {
+	h := &History{}
+	for i := range MaxEntries + 10 {
+		h.Add(entry("GET", "/items/"+strconv.Itoa(i), "openapi.yaml"))
+	}
+	if len(h.Entries) != MaxEntries {
+		t.Errorf("Expected %d entries, got %d", MaxEntries, len(h.Entries))
+	}
+}
+
+func TestHistory_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.json")
+	h, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() of missing file error: %v", err)
+	}
+	h.Add(entry("-format", "term", "GET", "/users", "my spec.yaml"))
+	h.Star(h.Entries[0])
+	if err := h.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected history readable only by its owner, got %v, %v", info.Mode().Perm(), err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Entries, h.Entries) || !reflect.DeepEqual(loaded.Favorites, h.Favorites) {
+		t.Errorf("Loaded %+v, want %+v", loaded, h)
+	}
+	if got := loaded.Entries[0].String(); got != `-format term GET /users "my spec.yaml"` {
+		t.Errorf("String() = %s", got)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(EnvPath, "off")
+	if path := DefaultPath(); path != "" {
+		t.Errorf("Expected history disabled, got %s", path)
+	}
+	t.Setenv(EnvPath, "/tmp/history.json")
+	if path := DefaultPath(); path != "/tmp/history.json" {
+		t.Errorf("DefaultPath() = %s", path)
+	}
+}
@@ -0,0 +1,453 @@
+// Package insomnia converts OpenAPI operations into an Insomnia v4 export
+// that can be imported as a request collection.
+package insomnia
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ExportFormat is the Insomnia export format version produced.
+const ExportFormat = 4
+
+// Resource types in an Insomnia export.
+const (
+	TypeWorkspace    = "workspace"
+	TypeEnvironment  = "environment"
+	TypeRequestGroup = "request_group"
+	TypeRequest      = "request"
+)
+
+// baseURLVar is the environment variable holding the server URL.
+const baseURLVar = "base_url"
+
+// Export is an Insomnia v4 export document.
+type Export struct {
+	Type      string     `json:"_type"`
+	Format    int        `json:"__export_format"`
+	Date      string     `json:"__export_date"`
+	Source    string     `json:"__export_source"`
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is a workspace, environment, folder, or request. Only the fields
+// relevant to its type are set.
+type Resource struct {
+	ID       string  `json:"_id"`
+	Type     string  `json:"_type"`
+	ParentID *string `json:"parentId"`
+	Name     string  `json:"name"`
+
+	// Workspace
+	Scope string `json:"scope,omitempty"`
+
+	// Environment
+	Data map[string]string `json:"data,omitempty"`
+
+	// Request
+	Method         string         `json:"method,omitempty"`
+	URL            string         `json:"url,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	Body           *Body          `json:"body,omitempty"`
+	Headers        []Pair         `json:"headers,omitempty"`
+	Parameters     []Pair         `json:"parameters,omitempty"`
+	Authentication map[string]any `json:"authentication,omitempty"`
+}
+
+// Body is a request body.
+type Body struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Pair is a header or query parameter.
+type Pair struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// Options selects which operations to export.
+type Options struct {
+	// Path restricts the export to one endpoint. Empty means all endpoints.
+	Path string
+	// Method restricts the export to one uppercase HTTP method.
+	Method string
+	// Tag restricts the export to operations with this tag.
+	Tag string
+	// Environment restricts the exported environments to servers whose
+	// x-environment matches. Empty exports every server.
+	Environment string
+	// Date is recorded as the export date.
+	Date time.Time
+}
+
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Build converts the selected operations of doc into an Insomnia export.
+// Operations are grouped into one folder per tag. Servers become
+// environments, and security schemes become authentication templates whose
+// secrets are environment variables.
+func Build(doc *openapi3.T, opts Options) (*Export, error) {
+	b := &builder{doc: doc, vars: map[string]string{}, folders: map[string]string{}}
+
+	title := "API"
+	if doc.Info != nil && doc.Info.Title != "" {
+		title = doc.Info.Title
+	}
+	b.workspaceID = "wrk_" + generator.Slugify(title)
+	b.add(Resource{ID: b.workspaceID, Type: TypeWorkspace, Name: title, Scope: "collection"})
+
+	if err := b.addRequests(opts); err != nil {
+		return nil, err
+	}
+	servers, err := generator.FilterServers(doc.Servers, opts.Environment)
+	if err != nil {
+		return nil, err
+	}
+	b.addEnvironments(servers)
+
+	return &Export{
+		Type:      "export",
+		Format:    ExportFormat,
+		Date:      opts.Date.UTC().Format(time.RFC3339),
+		Source:    "docfinder",
+		Resources: b.resources,
+	}, nil
+}
+
+// Marshal encodes the export as indented JSON.
+func (e *Export) Marshal() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// builder accumulates resources.
+type builder struct {
+	doc         *openapi3.T
+	workspaceID string
+	resources   []Resource
+	// vars are base environment variables referenced by requests.
+	vars map[string]string
+	// folders maps tag names to request group IDs.
+	folders map[string]string
+}
+
+func (b *builder) add(r Resource) {
+	b.resources = append(b.resources, r)
+}
+
+// addRequests adds a request for every selected operation.
+func (b *builder) addRequests(opts Options) error {
+	if b.doc.Paths == nil {
+		return fmt.Errorf("OpenAPI document has no paths defined")
+	}
+
+	paths := b.doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+
+	count := 0
+	for _, path := range paths {
+		if opts.Path != "" && path != opts.Path {
+			continue
+		}
+		pathItem := b.doc.Paths.Value(path)
+		if pathItem == nil {
+			continue
+		}
+
+		for _, method := range model.MethodOrder {
+			op := pathItem.GetOperation(method)
+			if op == nil || (opts.Method != "" && method != opts.Method) {
+				continue
+			}
+			if opts.Tag != "" && !hasTag(op, opts.Tag) {
+				continue
+			}
+
+			b.addRequest(method, path, pathItem, op)
+			count++
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no operations match the selection")
+	}
+	return nil
+}
+
+// addRequest adds a request resource for one operation.
+func (b *builder) addRequest(method, path string, pathItem *openapi3.PathItem, op *openapi3.Operation) {
+	name := op.Summary
+	if name == "" {
+		name = method + " " + path
+	}
+
+	req := Resource{
+		ID:          "req_" + generator.Slugify(method+"-"+path),
+		Type:        TypeRequest,
+		ParentID:    b.folder(op),
+		Name:        name,
+		Method:      method,
+		URL:         "{{ _." + baseURLVar + " }}" + b.templatePath(path),
+		Description: op.Description,
+	}
+
+	for _, params := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+		for _, ref := range params {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			param := ref.Value
+			pair := Pair{Name: param.Name, Value: exampleString(param), Disabled: !param.Required}
+			switch param.In {
+			case openapi3.ParameterInQuery:
+				req.Parameters = append(req.Parameters, pair)
+			case openapi3.ParameterInHeader:
+				req.Headers = append(req.Headers, pair)
+			case openapi3.ParameterInPath:
+				b.vars[param.Name] = exampleString(param)
+			}
+		}
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if body := requestBody(op.RequestBody.Value); body != nil {
+			req.Body = body
+			req.Headers = append(req.Headers, Pair{Name: "Content-Type", Value: body.MimeType})
+		}
+	}
+
+	b.applySecurity(&req, op)
+	b.add(req)
+}
+
+// folder returns the parent ID for an operation: a request group for its
+// first tag, created on first use, or the workspace.
+func (b *builder) folder(op *openapi3.Operation) *string {
+	if len(op.Tags) == 0 {
+		return &b.workspaceID
+	}
+
+	tag := op.Tags[0]
+	if id, ok := b.folders[tag]; ok {
+		return &id
+	}
+
+	id := "fld_" + generator.Slugify(tag)
+	b.folders[tag] = id
+	b.add(Resource{ID: id, Type: TypeRequestGroup, ParentID: &b.workspaceID, Name: tag})
+	return &id
+}
+
+// templatePath converts "{id}" path parameters into Insomnia template tags.
+func (b *builder) templatePath(path string) string {
+	return pathParam.ReplaceAllStringFunc(path, func(m string) string {
+		name := pathParam.FindStringSubmatch(m)[1]
+		if _, ok := b.vars[name]; !ok {
+			b.vars[name] = ""
+		}
+		return "{{ _." + name + " }}"
+	})
+}
+
+// applySecurity adds an authentication template for the first security
+// scheme that applies to op.
+func (b *builder) applySecurity(req *Resource, op *openapi3.Operation) {
+	requirements := b.doc.Security
+	if op.Security != nil {
+		requirements = *op.Security
+	}
+	if len(requirements) == 0 || b.doc.Components == nil {
+		return
+	}
+
+	names := make([]string, 0, len(requirements[0]))
+	for name := range requirements[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return
+	}
+
+	ref := b.doc.Components.SecuritySchemes[names[0]]
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	scheme := ref.Value
+
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+		req.Authentication = map[string]any{"type": "bearer", "token": b.variable("bearer_token")}
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		req.Authentication = map[string]any{"type": "basic", "username": b.variable("username"), "password": b.variable("password")}
+	case scheme.Type == "apiKey":
+		pair := Pair{Name: scheme.Name, Value: b.variable("api_key")}
+		switch scheme.In {
+		case "query":
+			req.Parameters = append(req.Parameters, pair)
+		case "header":
+			req.Headers = append(req.Headers, pair)
+		}
+	case scheme.Type == "oauth2" && scheme.Flows != nil:
+		req.Authentication = b.oauth2(scheme.Flows)
+	}
+}
+
+// oauth2 returns an OAuth 2 authentication template for the first supported flow.
+func (b *builder) oauth2(flows *openapi3.OAuthFlows) map[string]any {
+	auth := map[string]any{
+		"type":         "oauth2",
+		"clientId":     b.variable("client_id"),
+		"clientSecret": b.variable("client_secret"),
+	}
+
+	switch {
+	case flows.AuthorizationCode != nil:
+		auth["grantType"] = "authorization_code"
+		auth["authorizationUrl"] = flows.AuthorizationCode.AuthorizationURL
+		auth["accessTokenUrl"] = flows.AuthorizationCode.TokenURL
+	case flows.ClientCredentials != nil:
+		auth["grantType"] = "client_credentials"
+		auth["accessTokenUrl"] = flows.ClientCredentials.TokenURL
+	case flows.Password != nil:
+		auth["grantType"] = "password"
+		auth["accessTokenUrl"] = flows.Password.TokenURL
+		auth["username"] = b.variable("username")
+		auth["password"] = b.variable("password")
+	case flows.Implicit != nil:
+		auth["grantType"] = "implicit"
+		auth["authorizationUrl"] = flows.Implicit.AuthorizationURL
+	}
+
+	return auth
+}
+
+// variable registers an empty base environment variable and returns its template tag.
+func (b *builder) variable(name string) string {
+	if _, ok := b.vars[name]; !ok {
+		b.vars[name] = ""
+	}
+	return "{{ _." + name + " }}"
+}
+
+// addEnvironments adds the base environment holding all variables and one
+// sub-environment per server.
+func (b *builder) addEnvironments(servers openapi3.Servers) {
+	base := Resource{ID: "env_base", Type: TypeEnvironment, ParentID: &b.workspaceID, Name: "Base Environment", Data: b.vars}
+	if len(servers) > 0 && servers[0] != nil {
+		base.Data[baseURLVar] = serverURL(servers[0])
+	} else {
+		base.Data[baseURLVar] = ""
+	}
+	b.add(base)
+
+	for i, server := range servers {
+		if server == nil {
+			continue
+		}
+		name := server.Description
+		if name == "" {
+			name = server.URL
+		}
+		b.add(Resource{
+			ID:       fmt.Sprintf("env_server_%d", i+1),
+			Type:     TypeEnvironment,
+			ParentID: &base.ID,
+			Name:     name,
+			Data:     map[string]string{baseURLVar: serverURL(server)},
+		})
+	}
+}
+
+// serverURL returns the server URL with variables replaced by their defaults.
+func serverURL(server *openapi3.Server) string {
+	url := server.URL
+	for name, variable := range server.Variables {
+		if variable != nil {
+			url = strings.ReplaceAll(url, "{"+name+"}", variable.Default)
+		}
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// requestBody returns a body with the first example of the preferred media type.
+func requestBody(rb *openapi3.RequestBody) *Body {
+	contentTypes := make([]string, 0, len(rb.Content))
+	for ct := range rb.Content {
+		contentTypes = append(contentTypes, ct)
+	}
+	sort.Strings(contentTypes)
+	sort.SliceStable(contentTypes, func(i, j int) bool {
+		return strings.Contains(contentTypes[i], "json") && !strings.Contains(contentTypes[j], "json")
+	})
+	if len(contentTypes) == 0 {
+		return nil
+	}
+
+	ct := contentTypes[0]
+	body := &Body{MimeType: ct}
+
+	if mt := rb.Content[ct]; mt != nil {
+		if value, ok := mediaTypeExample(mt); ok {
+			if data, err := json.MarshalIndent(value, "", "  "); err == nil {
+				body.Text = string(data)
+			}
+		}
+	}
+
+	return body
+}
+
+// mediaTypeExample returns the media type's example, or its first named example.
+func mediaTypeExample(mt *openapi3.MediaType) (any, bool) {
+	if mt.Example != nil {
+		return mt.Example, true
+	}
+
+	names := make([]string, 0, len(mt.Examples))
+	for name := range mt.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if ref := mt.Examples[name]; ref != nil && ref.Value != nil && ref.Value.Value != nil {
+			return ref.Value.Value, true
+		}
+	}
+	return nil, false
+}
+
+// exampleString returns a parameter's example or default as a string.
+func exampleString(param *openapi3.Parameter) string {
+	if param.Example != nil {
+		return fmt.Sprint(param.Example)
+	}
+	if param.Schema != nil && param.Schema.Value != nil {
+		if param.Schema.Value.Example != nil {
+			return fmt.Sprint(param.Schema.Value.Example)
+		}
+		if param.Schema.Value.Default != nil {
+			return fmt.Sprint(param.Schema.Value.Default)
+		}
+	}
+	return ""
+}
+
+func hasTag(op *openapi3.Operation, tag string) bool {
+	for _, t := range op.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package insomnia
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func insomniaDoc() *openapi3.T {
+	return &openapi3.T{
+		Info: &openapi3.Info{Title: "Events API", Version: "1.0.0"},
+		Servers: openapi3.Servers{
+			{URL: "https://{region}.api.example.com/", Description: "Production",
+				Variables: map[string]*openapi3.ServerVariable{"region": {Default: "eu"}}},
+			{URL: "http://localhost:8080", Extensions: map[string]any{"x-environment": "local"}},
+		},
+		Security: openapi3.SecurityRequirements{{"bearer": []string{}}},
+		Components: &openapi3.Components{SecuritySchemes: openapi3.SecuritySchemes{
+			"bearer": {Value: openapi3.NewJWTSecurityScheme()},
+			"key":    {Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName("X-API-Key")},
+		}},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/events/{id}", &openapi3.PathItem{
+				Parameters: openapi3.Parameters{{Value: openapi3.NewPathParameter("id").
+					WithSchema(openapi3.NewStringSchema().WithDefault("evt_1"))}},
+				Get: &openapi3.Operation{
+					Summary:    "Get event",
+					Tags:       []string{"Events"},
+					Parameters: openapi3.Parameters{{Value: openapi3.NewQueryParameter("expand")}},
+				},
+				Put: &openapi3.Operation{
+					Tags:     []string{"Events"},
+					Security: &openapi3.SecurityRequirements{{"key": []string{}}},
+					RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithContent(openapi3.Content{
+						"application/json": &openapi3.MediaType{Example: map[string]any{"title": "Launch"}},
+					})},
+				},
+			}),
+			openapi3.WithPath("/health", &openapi3.PathItem{Get: &openapi3.Operation{Tags: []string{"Ops"}}}),
+		),
+	}
+}
+
+func byID(e *Export) map[string]Resource {
+	index := make(map[string]Resource)
+	for _, r := range e.Resources {
+		index[r.ID] = r
+	}
+	return index
+}
+
+func TestBuild(t *testing.T) {
+	export, err := Build(insomniaDoc(), Options{Date: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+
+	if export.Format != ExportFormat || export.Date != "2024-01-02T03:04:05Z" {
+		t.Errorf("Unexpected export header: %+v", export)
+	}
+
+	resources := byID(export)
+
+	get, ok := resources["req_get-events-id"]
+	if !ok {
+		t.Fatal("Expected GET request")
+	}
+	if get.URL != "{{ _.base_url }}/events/{{ _.id }}" || *get.ParentID != "fld_events" {
+		t.Errorf("Unexpected GET request: %+v", get)
+	}
+	if len(get.Parameters) != 1 || get.Parameters[0].Name != "expand" || !get.Parameters[0].Disabled {
+		t.Errorf("Expected optional query parameter to be disabled, got %+v", get.Parameters)
+	}
+	if get.Authentication["type"] != "bearer" || get.Authentication["token"] != "{{ _.bearer_token }}" {
+		t.Errorf("Expected bearer auth template, got %v", get.Authentication)
+	}
+
+	put := resources["req_put-events-id"]
+	if put.Body == nil || put.Body.Text != "{\n  \"title\": \"Launch\"\n}" {
+		t.Errorf("Expected example body, got %+v", put.Body)
+	}
+	if put.Authentication != nil {
+		t.Errorf("Did not expect authentication for API key scheme, got %v", put.Authentication)
+	}
+	var hasKey bool
+	for _, h := range put.Headers {
+		hasKey = hasKey || (h.Name == "X-API-Key" && h.Value == "{{ _.api_key }}")
+	}
+	if !hasKey {
+		t.Errorf("Expected API key header, got %+v", put.Headers)
+	}
+
+	base := resources["env_base"]
+	if base.Data["base_url"] != "https://eu.api.example.com" || base.Data["id"] != "evt_1" {
+		t.Errorf("Unexpected base environment: %v", base.Data)
+	}
+	if _, ok := base.Data["api_key"]; !ok {
+		t.Error("Expected api_key variable in base environment")
+	}
+	if env := resources["env_server_2"]; env.Name != "http://localhost:8080" || *env.ParentID != "env_base" {
+		t.Errorf("Unexpected server environment: %+v", env)
+	}
+
+	data, err := export.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+}
+
+func TestBuild_Selection(t *testing.T) {
+	export, err := Build(insomniaDoc(), Options{Tag: "ops"})
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	resources := byID(export)
+	if _, ok := resources["req_get-health"]; !ok || len(resources) != 6 {
+		t.Errorf("Expected only the Ops request, got %v", resources)
+	}
+
+	if _, err := Build(insomniaDoc(), Options{Path: "/events/{id}", Method: "DELETE"}); err == nil {
+		t.Error("Expected error when no operations match")
+	}
+}
+
+func TestBuild_Environment(t *testing.T) {
+	export, err := Build(insomniaDoc(), Options{Environment: "local"})
+	if err != nil {
+		t.Fatalf("Build() error: %v", err)
+	}
+	resources := byID(export)
+	if _, ok := resources["env_server_1"]; !ok {
+		t.Errorf("Expected localhost environment, got %v", resources)
+	}
+	if _, ok := resources["env_server_2"]; ok {
+		t.Errorf("Did not expect a second server environment")
+	}
+	if got := resources["env_base"].Data[baseURLVar]; got != "http://localhost:8080" {
+		t.Errorf("Expected localhost base URL, got %q", got)
+	}
+
+	if _, err := Build(insomniaDoc(), Options{Environment: "prod"}); err == nil {
+		t.Error("Expected error for unknown environment")
+	}
+}
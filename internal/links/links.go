@@ -0,0 +1,402 @@
+// Package links finds the URLs in the prose of an OpenAPI document
+// (descriptions, externalDocs, and the license and contact of the API) and
+// checks that they still resolve.
+package links
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Defaults for checking links.
+const (
+	DefaultConcurrency = 8
+	DefaultTimeout     = 10 * time.Second
+)
+
+// Config controls which links are checked and how.
+type Config struct {
+	// Allow restricts checking to these hosts, optionally with a leading
+	// "*." wildcard. Empty allows every host.
+	Allow []string `yaml:"allow"`
+	// Deny skips these hosts, e.g. ones that reject automated requests.
+	Deny []string `yaml:"deny"`
+	// Concurrency bounds the requests in flight. Zero means
+	// DefaultConcurrency.
+	Concurrency int `yaml:"concurrency"`
+	// Timeout bounds each link's requests. Zero means DefaultTimeout.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Link is a URL and where the document mentions it.
+type Link struct {
+	URL       string   `json:"url"`
+	Locations []string `json:"locations"`
+}
+
+// Status of a checked link.
+const (
+	StatusOK      = "ok"
+	StatusDead    = "dead"
+	StatusSkipped = "skipped"
+)
+
+// Result is the outcome of checking a link.
+type Result struct {
+	Link
+	Status string `json:"status"`
+	// Code is the final HTTP status code, 0 if no response was received.
+	Code int `json:"code,omitempty"`
+	// Error describes why the link is dead or skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// String formats the result as a line followed by the link's locations.
+func (r Result) String() string {
+	var b strings.Builder
+	switch {
+	case r.Code != 0:
+		fmt.Fprintf(&b, "%s %d %s", r.Status, r.Code, r.URL)
+	case r.Error != "":
+		fmt.Fprintf(&b, "%s %s: %s", r.Status, r.URL, r.Error)
+	default:
+		fmt.Fprintf(&b, "%s %s", r.Status, r.URL)
+	}
+	for _, location := range r.Locations {
+		b.WriteString("\n    " + location)
+	}
+	return b.String()
+}
+
+// urlPattern matches http(s) URLs in prose, up to whitespace, quotes,
+// brackets, or markdown link syntax.
+var urlPattern = regexp.MustCompile("https?://[^\\s<>\"'`()\\[\\]{}|\\\\^]+")
+
+// Extract returns the links of doc, sorted by URL, each with the sorted
+// locations that mention it.
+func Extract(doc *openapi3.T) []Link {
+	e := &extractor{locations: make(map[string][]string), seen: make(map[*openapi3.Schema]bool)}
+	if doc == nil {
+		return nil
+	}
+
+	if info := doc.Info; info != nil {
+		e.text("info.description", info.Description)
+		e.url("info.termsOfService", info.TermsOfService)
+		if info.Contact != nil {
+			e.url("info.contact.url", info.Contact.URL)
+		}
+		if info.License != nil {
+			e.url("info.license.url", info.License.URL)
+		}
+	}
+	e.externalDocs("externalDocs", doc.ExternalDocs)
+	for _, server := range doc.Servers {
+		e.text("servers "+server.URL+" description", server.Description)
+	}
+	for _, tag := range doc.Tags {
+		e.text("tags."+tag.Name+".description", tag.Description)
+		e.externalDocs("tags."+tag.Name+".externalDocs", tag.ExternalDocs)
+	}
+
+	// Components first, so what operations share is reported there
+	if c := doc.Components; c != nil {
+		for _, name := range sortedKeys(c.Schemas) {
+			e.schema("components.schemas."+name, c.Schemas[name])
+		}
+		for _, name := range sortedKeys(c.Parameters) {
+			if ref := c.Parameters[name]; ref != nil && ref.Value != nil {
+				e.parameter("components.parameters."+name, ref.Value)
+			}
+		}
+		for _, name := range sortedKeys(c.RequestBodies) {
+			if ref := c.RequestBodies[name]; ref != nil && ref.Value != nil {
+				e.text("components.requestBodies."+name+".description", ref.Value.Description)
+				e.content("components.requestBodies."+name, ref.Value.Content)
+			}
+		}
+		for _, name := range sortedKeys(c.Responses) {
+			e.response("components.responses."+name, c.Responses[name])
+		}
+		for _, name := range sortedKeys(c.SecuritySchemes) {
+			if ref := c.SecuritySchemes[name]; ref != nil && ref.Value != nil {
+				e.text("components.securitySchemes."+name+".description", ref.Value.Description)
+			}
+		}
+	}
+
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.InMatchingOrder() {
+			item := doc.Paths.Value(path)
+			if item == nil {
+				continue
+			}
+			e.text(path+" description", item.Description)
+			e.parameters(path, item.Parameters)
+			for method, op := range item.Operations() {
+				e.operation(method+" "+path, op)
+			}
+		}
+	}
+
+	links := make([]Link, 0, len(e.locations))
+	for u, locations := range e.locations {
+		sort.Strings(locations)
+		links = append(links, Link{URL: u, Locations: locations})
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].URL < links[j].URL })
+	return links
+}
+
+// extractor collects the URLs of a document by location.
+type extractor struct {
+	locations map[string][]string
+	seen      map[*openapi3.Schema]bool
+}
+
+// text records the URLs mentioned in prose.
+func (e *extractor) text(location, s string) {
+	for _, u := range urlPattern.FindAllString(s, -1) {
+		// Sentence punctuation after a URL isn't part of it
+		e.url(location, strings.TrimRight(u, ".,;:!?*_~"))
+	}
+}
+
+// url records a field holding a single URL.
+func (e *extractor) url(location, u string) {
+	u = strings.TrimSpace(u)
+	if u == "" {
+		return
+	}
+	for _, seen := range e.locations[u] {
+		if seen == location {
+			return
+		}
+	}
+	e.locations[u] = append(e.locations[u], location)
+}
+
+func (e *extractor) externalDocs(location string, docs *openapi3.ExternalDocs) {
+	if docs == nil {
+		return
+	}
+	e.text(location+".description", docs.Description)
+	e.url(location+".url", docs.URL)
+}
+
+func (e *extractor) operation(location string, op *openapi3.Operation) {
+	e.text(location+" summary", op.Summary)
+	e.text(location+" description", op.Description)
+	e.externalDocs(location+" externalDocs", op.ExternalDocs)
+	e.parameters(location, op.Parameters)
+	if op.RequestBody != nil && op.RequestBody.Value != nil && !isComponent(op.RequestBody.Ref) {
+		e.text(location+" requestBody.description", op.RequestBody.Value.Description)
+		e.content(location+" requestBody", op.RequestBody.Value.Content)
+	}
+	if op.Responses != nil {
+		for _, status := range sortedKeys(op.Responses.Map()) {
+			e.response(location+" responses."+status, op.Responses.Value(status))
+		}
+	}
+}
+
+func (e *extractor) parameters(location string, params openapi3.Parameters) {
+	for _, ref := range params {
+		if ref != nil && ref.Value != nil && !isComponent(ref.Ref) {
+			e.parameter(location+" parameters."+ref.Value.Name, ref.Value)
+		}
+	}
+}
+
+func (e *extractor) parameter(location string, param *openapi3.Parameter) {
+	e.text(location+".description", param.Description)
+	e.schema(location+".schema", param.Schema)
+	e.content(location, param.Content)
+}
+
+func (e *extractor) response(location string, ref *openapi3.ResponseRef) {
+	if ref == nil || ref.Value == nil || isComponent(ref.Ref) {
+		return
+	}
+	if ref.Value.Description != nil {
+		e.text(location+".description", *ref.Value.Description)
+	}
+	for _, name := range sortedKeys(ref.Value.Headers) {
+		if header := ref.Value.Headers[name]; header != nil && header.Value != nil {
+			e.text(location+".headers."+name+".description", header.Value.Description)
+		}
+	}
+	e.content(location, ref.Value.Content)
+}
+
+func (e *extractor) content(location string, content openapi3.Content) {
+	for _, contentType := range sortedKeys(content) {
+		if mediaType := content[contentType]; mediaType != nil {
+			e.schema(location+" "+contentType+".schema", mediaType.Schema)
+		}
+	}
+}
+
+// schema records the URLs of a schema and its subschemas, visiting each
+// schema once so shared schemas are reported where first reached.
+func (e *extractor) schema(location string, ref *openapi3.SchemaRef) {
+	if ref == nil || ref.Value == nil || e.seen[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	e.seen[schema] = true
+
+	e.text(location+".description", schema.Description)
+	e.externalDocs(location+".externalDocs", schema.ExternalDocs)
+	for _, name := range sortedKeys(schema.Properties) {
+		e.schema(location+".properties."+name, schema.Properties[name])
+	}
+	e.schema(location+".items", schema.Items)
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i, sub := range group {
+			e.schema(fmt.Sprintf("%s[%d]", location, i), sub)
+		}
+	}
+}
+
+// Check checks links concurrently, returning a result for each, in order.
+// Links to hosts not allowed by cfg are skipped. client is used for the
+// requests, or http.DefaultClient if nil.
+func Check(ctx context.Context, links []Link, cfg Config, client *http.Client) []Result {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	results := make([]Result, len(links))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, link := range links {
+		results[i] = Result{Link: link}
+		u, err := url.Parse(link.URL)
+		if err != nil || u.Host == "" {
+			results[i].Status, results[i].Error = StatusDead, "invalid URL"
+			continue
+		}
+		if reason := cfg.skip(u.Hostname()); reason != "" {
+			results[i].Status, results[i].Error = StatusSkipped, reason
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *Result) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			code, err := fetch(ctx, client, r.URL)
+			r.Code = code
+			switch {
+			case err != nil:
+				r.Status, r.Error = StatusDead, err.Error()
+			case r.Code >= 400:
+				r.Status = StatusDead
+			default:
+				r.Status = StatusOK
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// Dead returns the results of dead links.
+func Dead(results []Result) []Result {
+	var dead []Result
+	for _, r := range results {
+		if r.Status == StatusDead {
+			dead = append(dead, r)
+		}
+	}
+	return dead
+}
+
+// fetch returns the status code of u, asking with HEAD and falling back to
+// GET for servers that don't answer HEAD properly.
+func fetch(ctx context.Context, client *http.Client, u string) (int, error) {
+	code, err := request(ctx, client, http.MethodHead, u)
+	if err == nil && code < 400 {
+		return code, nil
+	}
+	return request(ctx, client, http.MethodGet, u)
+}
+
+func request(ctx context.Context, client *http.Client, method, u string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "docfinder-check-links")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// skip returns why links to host are not checked, or "" to check them.
+func (c Config) skip(host string) string {
+	if hostListed(host, c.Deny) {
+		return "host denied"
+	}
+	if len(c.Allow) > 0 && !hostListed(host, c.Allow) {
+		return "host not allowed"
+	}
+	return ""
+}
+
+// hostListed reports whether host matches an entry of list, where
+// "*.example.com" matches subdomains of example.com.
+func hostListed(host string, list []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range list {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if suffix, ok := strings.CutPrefix(entry, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+		} else if host == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// isComponent reports whether ref points into the document's components,
+// which are scanned on their own.
+func isComponent(ref string) bool {
+	return strings.HasPrefix(ref, "#/components/")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,136 @@
+package links
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func linkDoc() *openapi3.T {
+	event := openapi3.NewObjectSchema().WithProperty("when", &openapi3.Schema{
+		Type:        &openapi3.Types{"string"},
+		Description: "An RFC 3339 timestamp (https://www.rfc-editor.org/rfc/rfc3339).",
+	})
+	errorResponse := &openapi3.ResponseRef{Value: openapi3.NewResponse().
+		WithDescription("See https://docs.example.com/errors for codes.")}
+
+	return &openapi3.T{
+		Info: &openapi3.Info{
+			Title:       "Events API",
+			Description: "Read the [guide](https://docs.example.com/guide), then https://docs.example.com/start.",
+			License:     &openapi3.License{Name: "MIT", URL: "https://opensource.org/license/mit"},
+			Contact:     &openapi3.Contact{URL: "https://example.com/support"},
+		},
+		ExternalDocs: &openapi3.ExternalDocs{URL: "https://docs.example.com/guide"},
+		Components: &openapi3.Components{
+			Schemas:   openapi3.Schemas{"Event": openapi3.NewSchemaRef("", event)},
+			Responses: openapi3.ResponseBodies{"Error": errorResponse},
+		},
+		Paths: openapi3.NewPaths(openapi3.WithPath("/events", &openapi3.PathItem{
+			Get: &openapi3.Operation{
+				Description: "Lists events. Deprecated in favor of http://legacy.example.com/feed; see below.",
+				Responses: openapi3.NewResponses(
+					openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("OK").
+						WithJSONSchemaRef(openapi3.NewSchemaRef("#/components/schemas/Event", event))}),
+					openapi3.WithStatus(400, &openapi3.ResponseRef{Ref: "#/components/responses/Error", Value: errorResponse.Value}),
+				),
+			},
+		})),
+	}
+}
+
+func TestExtract(t *testing.T) {
+	got := make(map[string][]string)
+	for _, link := range Extract(linkDoc()) {
+		got[link.URL] = link.Locations
+	}
+
+	want := map[string][]string{
+		"https://docs.example.com/guide":         {"externalDocs.url", "info.description"},
+		"https://docs.example.com/start":         {"info.description"},
+		"https://opensource.org/license/mit":     {"info.license.url"},
+		"https://example.com/support":            {"info.contact.url"},
+		"http://legacy.example.com/feed":         {"GET /events description"},
+		"https://www.rfc-editor.org/rfc/rfc3339": {"components.schemas.Event.properties.when.description"},
+		"https://docs.example.com/errors":        {"components.responses.Error.description"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("Extract() found %d links, want %d: %v", len(got), len(want), got)
+	}
+	for u, locations := range want {
+		if !slices.Equal(got[u], locations) {
+			t.Errorf("Locations of %s = %v, want %v", u, got[u], locations)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{
+		{URL: server.URL + "/ok"},
+		{URL: server.URL + "/no-head"},
+		{URL: server.URL + "/gone"},
+		{URL: "https://denied.example.com/page"},
+		{URL: "https://other.test/page"},
+	}
+	cfg := Config{Allow: []string{"127.0.0.1", "*.example.com"}, Deny: []string{"denied.example.com"}, Concurrency: 2}
+	results := Check(context.Background(), links, cfg, server.Client())
+
+	want := []struct {
+		status string
+		code   int
+	}{
+		{StatusOK, 200},
+		{StatusOK, 200},
+		{StatusDead, 404},
+		{StatusSkipped, 0},
+		{StatusSkipped, 0},
+	}
+	for i, w := range want {
+		if results[i].Status != w.status || results[i].Code != w.code {
+			t.Errorf("Check(%s) = %s %d, want %s %d", links[i].URL, results[i].Status, results[i].Code, w.status, w.code)
+		}
+	}
+
+	dead := Dead(results)
+	if len(dead) != 1 || !strings.HasSuffix(dead[0].URL, "/gone") {
+		t.Errorf("Dead() = %v, want the /gone link", dead)
+	}
+}
+
+func TestHostListed(t *testing.T) {
+	tests := []struct {
+		host string
+		list []string
+		want bool
+	}{
+		{"docs.example.com", []string{"docs.example.com"}, true},
+		{"Docs.Example.com", []string{"*.example.com"}, true},
+		{"example.com", []string{"*.example.com"}, false},
+		{"evilexample.com", []string{"*.example.com"}, false},
+		{"docs.example.com", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := hostListed(tt.host, tt.list); got != tt.want {
+			t.Errorf("hostListed(%q, %v) = %v, want %v", tt.host, tt.list, got, tt.want)
+		}
+	}
+}
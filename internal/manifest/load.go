@@ -0,0 +1,126 @@
+package manifest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Defaults for loading every spec of a manifest.
+const (
+	DefaultConcurrency = 8
+	DefaultTimeout     = 30 * time.Second
+)
+
+// LoadConfig controls how LoadAll loads the specs of a manifest.
+type LoadConfig struct {
+	// Concurrency bounds the specs loaded at once. Zero means
+	// DefaultConcurrency.
+	Concurrency int `yaml:"concurrency"`
+	// Timeout bounds loading each spec, $refs included. Zero means
+	// DefaultTimeout.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Loaded is the outcome of loading the spec of one service.
+type Loaded struct {
+	Service string
+	// Path is where the spec location resolves to.
+	Path string
+	Doc  *openapi3.T
+	// Err is set when the spec failed to load or timed out; Doc is nil.
+	Err error
+}
+
+// Opened is the outcome of opening the spec of one service.
+type Opened struct {
+	Service string
+	// Path is where the spec location resolves to.
+	Path string
+	Spec *spec.Lazy
+	// Err is set when the spec failed to open or timed out; Spec is nil.
+	Err error
+}
+
+// LoadAll loads the specs of services concurrently with load, returning a
+// result for each, in order. A spec that fails to load doesn't affect the
+// others. A load that exceeds the timeout is abandoned: its result reports
+// the timeout, but it keeps its slot until load returns, so abandoned loads
+// still count toward the concurrency and don't pile up.
+func (m *Manifest) LoadAll(services []string, cfg LoadConfig, load func(path string) (*openapi3.T, error)) []Loaded {
+	results := make([]Loaded, len(services))
+	for i, r := range loadAll(m, services, cfg, load) {
+		results[i] = Loaded{Service: services[i], Path: r.path, Doc: r.value, Err: r.err}
+	}
+	return results
+}
+
+// OpenAll opens the specs of services with open as LoadAll loads them, for
+// callers that only need the documents of some of them, such as those
+// containing an endpoint.
+func (m *Manifest) OpenAll(services []string, cfg LoadConfig, open func(path string) (*spec.Lazy, error)) []Opened {
+	results := make([]Opened, len(services))
+	for i, r := range loadAll(m, services, cfg, open) {
+		results[i] = Opened{Service: services[i], Path: r.path, Spec: r.value, Err: r.err}
+	}
+	return results
+}
+
+// result is the outcome of loading the spec of one service with loadAll.
+type result[T any] struct {
+	path  string
+	value T
+	err   error
+}
+
+// loadAll implements LoadAll for any kind of loaded spec.
+func loadAll[T any](m *Manifest, services []string, cfg LoadConfig, load func(path string) (T, error)) []result[T] {
+	concurrency := cfg.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	results := make([]result[T], len(services))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, service := range services {
+		path, err := m.SpecPath(service)
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		results[i].path = path
+
+		wg.Add(1)
+		go func(r *result[T]) {
+			defer wg.Done()
+			sem <- struct{}{}
+
+			// Buffered so an abandoned load can finish without blocking
+			done := make(chan result[T], 1)
+			go func() {
+				defer func() { <-sem }()
+				value, err := load(r.path)
+				done <- result[T]{value: value, err: err}
+			}()
+
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case o := <-done:
+				r.value, r.err = o.value, o.err
+			case <-timer.C:
+				r.err = fmt.Errorf("timed out after %s", timeout)
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
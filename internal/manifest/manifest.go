@@ -0,0 +1,183 @@
+// Package manifest resolves service names to OpenAPI spec locations using a
+// specs.yaml manifest.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/findup"
+	versionpkg "github.com/arthur-s/docfinder/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the manifest file name searched for from the working directory upward.
+const FileName = "specs.yaml"
+
+// Manifest maps service names to their OpenAPI specifications.
+//
+// Example:
+//
+//	services:
+//	  notify: specs/notify.yaml
+//	  billing:
+//	    spec: specs/billing/openapi.yaml
+//	    description: Billing API
+//	    versions:
+//	      "2023-10": specs/billing/history/2023-10.yaml
+//	      "2024-04": specs/billing/history/2024-04.yaml
+type Manifest struct {
+	// Path is the file the manifest was loaded from.
+	Path string `yaml:"-"`
+
+	Services map[string]Service `yaml:"services"`
+}
+
+// Service describes a single registered API.
+type Service struct {
+	// Spec is the spec location, relative to the manifest directory unless absolute.
+	Spec        string `yaml:"spec"`
+	Description string `yaml:"description"`
+
+	// Versions maps historical API versions to spec snapshots.
+	Versions map[string]string `yaml:"versions"`
+}
+
+// Snapshot is a spec location for one version of a service.
+type Snapshot struct {
+	Version string
+	Spec    string
+}
+
+// CurrentVersion labels the service's main spec in snapshot lists.
+const CurrentVersion = "current"
+
+// UnmarshalYAML allows a service to be written as a bare spec path.
+func (s *Service) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		s.Spec = node.Value
+		return nil
+	}
+
+	type plain Service
+	return node.Decode((*plain)(s))
+}
+
+// ErrNotFound is returned by Find when no manifest exists in the directory tree.
+var ErrNotFound = errors.New("no " + FileName + " manifest found")
+
+// Find searches dir and its parents for FileName and returns the first match.
+func Find(dir string) (string, error) {
+	path, err := findup.Find(dir, FileName)
+	if errors.Is(err, findup.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return path, err
+}
+
+// Load reads and parses the manifest at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	m.Path = path
+
+	return &m, nil
+}
+
+// SpecPath returns the spec location registered for service.
+// Relative locations are resolved against the manifest's directory.
+func (m *Manifest) SpecPath(service string) (string, error) {
+	svc, ok := m.Services[service]
+	if !ok {
+		return "", fmt.Errorf("service '%s' not found in %s. Available services: %s",
+			service, m.Path, strings.Join(m.ServiceNames(), ", "))
+	}
+
+	if svc.Spec == "" {
+		return "", fmt.Errorf("service '%s' has no spec location in %s", service, m.Path)
+	}
+
+	return m.resolve(svc.Spec), nil
+}
+
+// Snapshot returns the spec location of a historical version of service.
+func (m *Manifest) Snapshot(service, version string) (string, error) {
+	svc, ok := m.Services[service]
+	if !ok {
+		return "", fmt.Errorf("service '%s' not found in %s. Available services: %s",
+			service, m.Path, strings.Join(m.ServiceNames(), ", "))
+	}
+
+	location, ok := svc.Versions[version]
+	if !ok {
+		return "", fmt.Errorf("version '%s' not found for service '%s'. Available versions: %s",
+			version, service, strings.Join(svc.sortedVersions(), ", "))
+	}
+
+	return m.resolve(location), nil
+}
+
+// LaterSnapshots returns the snapshots of service newer than version, oldest
+// first, followed by the current spec (labelled CurrentVersion) if set.
+func (m *Manifest) LaterSnapshots(service, version string) []Snapshot {
+	svc := m.Services[service]
+
+	var snapshots []Snapshot
+	for _, v := range svc.sortedVersions() {
+		if versionpkg.Compare(v, version) > 0 {
+			snapshots = append(snapshots, Snapshot{Version: v, Spec: m.resolve(svc.Versions[v])})
+		}
+	}
+
+	if svc.Spec != "" {
+		snapshots = append(snapshots, Snapshot{Version: CurrentVersion, Spec: m.resolve(svc.Spec)})
+	}
+
+	return snapshots
+}
+
+// sortedVersions returns the service's snapshot versions, oldest first.
+func (s Service) sortedVersions() []string {
+	versions := make([]string, 0, len(s.Versions))
+	for v := range s.Versions {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versionpkg.Compare(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// ServiceNames returns the registered service names in sorted order.
+func (m *Manifest) ServiceNames() []string {
+	names := make([]string, 0, len(m.Services))
+	for name := range m.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolve makes a spec location relative to the manifest directory.
+func (m *Manifest) resolve(location string) string {
+	if filepath.IsAbs(location) || m.Path == "" || isURL(location) {
+		return location
+	}
+	return filepath.Join(filepath.Dir(m.Path), location)
+}
+
+// isURL reports whether location is an http(s) URL, which is used as is.
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
@@ -0,0 +1,202 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func writeManifest(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "notify", "handlers")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	want := writeManifest(t, root, "services: {}\n")
+
+	got, err := Find(nested)
+	if err != nil {
+		t.Fatalf("Find() error: %v", err)
+	}
+	if got != want {
+		t.Errorf("Find() = %q, want %q", got, want)
+	}
+}
+
+func TestFind_NotFound(t *testing.T) {
+	_, err := Find(t.TempDir())
+	// A manifest may exist above the temp dir on some machines; only check
+	// the sentinel when nothing was found.
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		t.Errorf("Find() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSpecPath(t *testing.T) {
+	dir := t.TempDir()
+	path := writeManifest(t, dir, `services:
+  notify: specs/notify.yaml
+  billing:
+    spec: /srv/billing/openapi.yaml
+    description: Billing API
+  payments: https://api.example.com/payments/openapi.yaml
+  empty:
+    description: No spec yet
+`)
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	tests := []struct {
+		service     string
+		expected    string
+		expectError bool
+	}{
+		{"notify", filepath.Join(dir, "specs", "notify.yaml"), false},
+		{"billing", "/srv/billing/openapi.yaml", false},
+		{"payments", "https://api.example.com/payments/openapi.yaml", false},
+		{"empty", "", true},
+		{"unknown", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			result, err := m.SpecPath(tt.service)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SpecPath() error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("SpecPath(%q) = %q, want %q", tt.service, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	m := &Manifest{Services: map[string]Service{
+		"billing": {Spec: "billing.yaml"},
+		"broken":  {Spec: "broken.yaml"},
+		"notify":  {Spec: "notify.yaml"},
+		"slow":    {Spec: "slow.yaml"},
+		"unset":   {},
+	}}
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	release := make(chan struct{})
+	defer close(release)
+	load := func(path string) (*openapi3.T, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		switch path {
+		case "broken.yaml":
+			return nil, errors.New("invalid spec")
+		case "slow.yaml":
+			<-release
+		}
+		time.Sleep(10 * time.Millisecond)
+		return &openapi3.T{Info: &openapi3.Info{Title: path}}, nil
+	}
+
+	results := m.LoadAll(m.ServiceNames(), LoadConfig{Concurrency: 2, Timeout: 200 * time.Millisecond}, load)
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(results))
+	}
+
+	for _, r := range results {
+		switch r.Service {
+		case "billing", "notify":
+			if r.Err != nil || r.Doc == nil || r.Doc.Info.Title != r.Service+".yaml" {
+				t.Errorf("Unexpected result for %s: %+v", r.Service, r)
+			}
+		case "broken":
+			if r.Err == nil || r.Err.Error() != "invalid spec" {
+				t.Errorf("Expected the load error for broken, got %v", r.Err)
+			}
+		case "slow":
+			if r.Err == nil || !strings.Contains(r.Err.Error(), "timed out after 200ms") {
+				t.Errorf("Expected a timeout for slow, got %v", r.Err)
+			}
+		case "unset":
+			if r.Err == nil || !strings.Contains(r.Err.Error(), "has no spec location") {
+				t.Errorf("Expected a missing location error, got %v", r.Err)
+			}
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > 2 {
+		t.Errorf("Expected at most 2 loads in flight, got %d", peak)
+	}
+}
+
+func TestLoadAll_AbandonedLoadKeepsSlot(t *testing.T) {
+	m := &Manifest{Services: map[string]Service{
+		"a": {Spec: "a.yaml"},
+		"b": {Spec: "b.yaml"},
+	}}
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	release := make(chan struct{})
+	time.AfterFunc(100*time.Millisecond, func() { close(release) })
+	load := func(path string) (*openapi3.T, error) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		<-release
+		return &openapi3.T{}, nil
+	}
+
+	// The first load times out; the second starts once it returns, after
+	// the release, and so succeeds
+	results := m.LoadAll(m.ServiceNames(), LoadConfig{Concurrency: 1, Timeout: 20 * time.Millisecond}, load)
+	if (results[0].Err == nil) == (results[1].Err == nil) {
+		t.Errorf("Expected one load to time out and the other to succeed, got %v and %v", results[0].Err, results[1].Err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > 1 {
+		t.Errorf("Expected the second load to wait for the abandoned one, got %d loads in flight", peak)
+	}
+}
@@ -0,0 +1,260 @@
+// Package middleware generates Go HTTP middleware that validates incoming
+// requests against an operation of an OpenAPI document, for common routers.
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Router identifies the framework the middleware is generated for.
+type Router string
+
+// Supported routers. Chi uses standard net/http middleware, so the chi
+// output also works with net/http and any compatible router.
+const (
+	RouterChi  Router = "chi"
+	RouterEcho Router = "echo"
+	RouterGin  Router = "gin"
+)
+
+// Routers lists every supported router.
+var Routers = []Router{RouterChi, RouterEcho, RouterGin}
+
+// ParseRouter parses a router name.
+func ParseRouter(s string) (Router, error) {
+	name := Router(strings.ToLower(strings.TrimSpace(s)))
+	for _, r := range Routers {
+		if name == r {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("unknown router: %s (expected chi, echo, or gin)", s)
+}
+
+// Options configures the generated middleware.
+type Options struct {
+	Router Router
+	// Package is the Go package name of the generated file.
+	Package string
+	// Method and Path identify the operation, e.g. "POST" and "/events".
+	Method string
+	Path   string
+	// OperationID names the generated functions when set; otherwise they are
+	// named after the method and path.
+	OperationID string
+	// SpecFile is the spec the code was generated from, noted in comments.
+	SpecFile string
+}
+
+// Generate returns gofmt-ed Go source for a middleware validating request
+// parameters and body against the operation.
+func Generate(opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "middleware"
+	}
+
+	name := identifier(opts.OperationID)
+	if name == "" {
+		name = identifier(strings.ToLower(opts.Method) + " " + opts.Path)
+	}
+
+	data := struct {
+		Options
+		Method      string
+		Constructor string
+		Validator   string
+	}{
+		Options:     opts,
+		Method:      strings.ToUpper(opts.Method),
+		Constructor: "Validate" + name,
+		Validator:   lowerFirst(name) + "Validator",
+	}
+
+	var buf bytes.Buffer
+	if err := sourceTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to generate middleware: %w", err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated middleware: %w", err)
+	}
+	return src, nil
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// identifier converts text such as "post /events/{id}" or "create_event"
+// into an exported Go identifier such as "PostEventsID" or "CreateEvent".
+func identifier(s string) string {
+	var b strings.Builder
+	for _, word := range nonIdentifier.Split(s, -1) {
+		if word == "" {
+			continue
+		}
+		if strings.EqualFold(word, "id") {
+			b.WriteString("ID")
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	id := b.String()
+	if id != "" && unicode.IsDigit([]rune(id)[0]) {
+		id = "Op" + id
+	}
+	return id
+}
+
+// lowerFirst lowercases the leading run of upper-case letters of an
+// identifier, e.g. "IDLookup" becomes "idLookup".
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+var sourceTemplate = template.Must(template.New("middleware").Parse(`// Code generated by docfinder middleware; DO NOT EDIT.
+{{- if .SpecFile}}
+// Source: {{.SpecFile}}, {{.Method}} {{.Path}}
+{{- end}}
+
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+{{if eq .Router "echo"}}
+	"github.com/labstack/echo/v4"
+{{- else if eq .Router "gin"}}
+	"github.com/gin-gonic/gin"
+{{- end}}
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+{{if eq .Router "chi" -}}
+// {{.Constructor}} returns net/http middleware, usable with chi, that rejects
+// requests to {{.Method}} {{.Path}} whose parameters or body don't match the
+// OpenAPI document at specPath with 400 Bad Request. Other requests pass
+// through unchanged.
+//
+//	r.With(mw).Method("{{.Method}}", "{{.Path}}", handler)
+func {{.Constructor}}(specPath string) (func(http.Handler) http.Handler, error) {
+	validate, err := {{.Validator}}(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := validate(r); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+{{- else if eq .Router "echo" -}}
+// {{.Constructor}} returns echo middleware that rejects requests to
+// {{.Method}} {{.Path}} whose parameters or body don't match the OpenAPI
+// document at specPath with 400 Bad Request. Other requests pass through
+// unchanged.
+func {{.Constructor}}(specPath string) (echo.MiddlewareFunc, error) {
+	validate, err := {{.Validator}}(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := validate(c.Request()); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return next(c)
+		}
+	}, nil
+}
+{{- else if eq .Router "gin" -}}
+// {{.Constructor}} returns gin middleware that rejects requests to
+// {{.Method}} {{.Path}} whose parameters or body don't match the OpenAPI
+// document at specPath with 400 Bad Request. Other requests pass through
+// unchanged.
+func {{.Constructor}}(specPath string) (gin.HandlerFunc, error) {
+	validate, err := {{.Validator}}(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		if err := validate(c.Request); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Next()
+	}, nil
+}
+{{- end}}
+
+// {{.Validator}} loads the OpenAPI document at specPath and returns a
+// function validating requests to {{.Method}} {{.Path}}.
+func {{.Validator}}(specPath string) (func(*http.Request) error, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", specPath, err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document %s: %w", specPath, err)
+	}
+
+	// Match on paths only, so requests are validated whatever host they
+	// reach; mount the middleware below any server base path.
+	doc.Servers = nil
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build router: %w", err)
+	}
+
+	options := &openapi3filter.Options{
+		// Authentication is left to the application
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(r *http.Request) error {
+		route, pathParams, err := router.FindRoute(r)
+		if err != nil || route.Method != "{{.Method}}" || route.Path != "{{.Path}}" {
+			// Not this operation
+			return nil
+		}
+
+		return openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		})
+	}, nil
+}
+`))
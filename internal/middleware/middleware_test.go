@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		router  Router
+		present []string
+		absent  []string
+	}{
+		{RouterChi, []string{
+			"func ValidateCreateEvent(specPath string) (func(http.Handler) http.Handler, error)",
+			`r.With(mw).Method("POST", "/events", handler)`,
+		}, []string{"echo", "gin"}},
+		{RouterEcho, []string{
+			`"github.com/labstack/echo/v4"`,
+			"func ValidateCreateEvent(specPath string) (echo.MiddlewareFunc, error)",
+			"return echo.NewHTTPError(http.StatusBadRequest, err.Error())",
+		}, []string{"gin"}},
+		{RouterGin, []string{
+			`"github.com/gin-gonic/gin"`,
+			"func ValidateCreateEvent(specPath string) (gin.HandlerFunc, error)",
+			"c.AbortWithStatusJSON(http.StatusBadRequest",
+		}, []string{"echo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.router), func(t *testing.T) {
+			src, err := Generate(Options{Router: tt.router, Package: "api", Method: "post", Path: "/events", OperationID: "create_event"})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			file, err := parser.ParseFile(token.NewFileSet(), "mw.go", src, 0)
+			if err != nil {
+				t.Fatalf("generated code does not parse: %v\n%s", err, src)
+			}
+			if file.Name.Name != "api" {
+				t.Errorf("package = %s, want api", file.Name.Name)
+			}
+
+			code := string(src)
+			for _, want := range append(tt.present,
+				"func createEventValidator(specPath string) (func(*http.Request) error, error)",
+				`route.Method != "POST" || route.Path != "/events"`,
+				"openapi3filter.ValidateRequest(",
+			) {
+				if !strings.Contains(code, want) {
+					t.Errorf("Expected %q in generated code:\n%s", want, code)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(code, unwanted) {
+					t.Errorf("Did not expect %q in generated code for %s", unwanted, tt.router)
+				}
+			}
+		})
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"create_event", "CreateEvent"},
+		{"listEvents", "ListEvents"},
+		{"post /events/{id}", "PostEventsID"},
+		{"get /v1/{event_id}/attachments", "GetV1EventIDAttachments"},
+		{"2fa-verify", "Op2faVerify"},
+	}
+
+	for _, tt := range tests {
+		if got := identifier(tt.input); got != tt.expected {
+			t.Errorf("identifier(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+
+	if got := lowerFirst("IDLookup"); got != "idLookup" {
+		t.Errorf("lowerFirst(IDLookup) = %q, want idLookup", got)
+	}
+}
+
+func TestParseRouter(t *testing.T) {
+	if r, err := ParseRouter(" Echo "); err != nil || r != RouterEcho {
+		t.Errorf("ParseRouter(Echo) = %q, %v", r, err)
+	}
+	if _, err := ParseRouter("fiber"); err == nil {
+		t.Error("Expected error for unsupported router")
+	}
+}
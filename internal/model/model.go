@@ -0,0 +1,216 @@
+// Package model is a representation of an API's operations, parameters,
+// bodies, and schemas independent of the format it is described in, for
+// reports that should work the same for every input format an adapter such
+// as FromOpenAPI converts. The testplan, list, serve, and returns commands
+// use it; the generator and the other reports still read openapi3 documents
+// directly and move over as they are reworked.
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// API is the operations and named schemas of a spec.
+type API struct {
+	Title   string
+	Version string
+	// Operations are ordered by path and then method.
+	Operations []*Operation
+	// Schemas are the schemas defined by name, e.g. OpenAPI components.
+	Schemas map[string]*Schema
+}
+
+// Operation is a method of an endpoint path.
+type Operation struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	// Parameters are those of the operation and its path, each at most once
+	// per name and location.
+	Parameters []*Parameter
+	// Security is the effective security of the operation: any one of the
+	// requirements grants access, and an empty one allows anonymous calls.
+	Security    []SecurityRequirement
+	RequestBody *Body
+	// Responses are ordered by status, "default" last.
+	Responses []*Response
+	// Extensions are the format-specific extensions of the operation, such
+	// as OpenAPI's x- fields.
+	Extensions map[string]any
+}
+
+// SecurityRequirement maps the names of the security schemes that must all
+// be satisfied to the scopes they require.
+type SecurityRequirement map[string][]string
+
+// Parameter locations.
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InCookie = "cookie"
+)
+
+// Parameter is an input of an operation outside its body.
+type Parameter struct {
+	Name string
+	// In is the location of the parameter, e.g. InQuery.
+	In          string
+	Description string
+	Required    bool
+	Deprecated  bool
+	Schema      *Schema
+	Example     any
+}
+
+// Body is a request body.
+type Body struct {
+	Description string
+	Required    bool
+	// Content is ordered by content type.
+	Content []*Media
+}
+
+// Response is a documented response of an operation.
+type Response struct {
+	// Status is a status code such as "200", a range such as "2XX", or
+	// "default".
+	Status      string
+	Description string
+	// Headers are ordered by name.
+	Headers []*Header
+	// Content is ordered by content type.
+	Content []*Media
+}
+
+// Header is a response header.
+type Header struct {
+	Name        string
+	Description string
+	Required    bool
+	Schema      *Schema
+	Example     any
+}
+
+// Media is the content of a body in one content type.
+type Media struct {
+	ContentType string
+	Schema      *Schema
+	Example     any
+	// Examples are the named examples, by name.
+	Examples map[string]any
+}
+
+// Schema describes a value. Schemas may be recursive: a schema reached again
+// through its properties or items is the same *Schema.
+type Schema struct {
+	// Name is the name the schema is defined by, or "" if it is inline.
+	Name        string
+	Types       []string
+	Format      string
+	Description string
+	Nullable    bool
+	Deprecated  bool
+	ReadOnly    bool
+	WriteOnly   bool
+	Enum        []any
+	Default     any
+	Example     any
+	// Min and Max are the numeric bounds, if any; the Exclusive flags tell
+	// whether the bounds themselves are allowed.
+	Min, Max                   *float64
+	ExclusiveMin, ExclusiveMax bool
+	Required                   []string
+	Properties                 map[string]*Schema
+	Items                      *Schema
+	AllOf, OneOf, AnyOf        []*Schema
+}
+
+// Is reports whether typ is one of the types of s.
+func (s *Schema) Is(typ string) bool {
+	for _, t := range s.Types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// PropertyNames returns the names of the properties of s, sorted.
+func (s *Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Response returns the response documented for status, or nil.
+func (op *Operation) Response(status string) *Response {
+	for _, resp := range op.Responses {
+		if resp.Status == status {
+			return resp
+		}
+	}
+	return nil
+}
+
+// Media returns the content of b in contentType, or nil.
+func (b *Body) Media(contentType string) *Media {
+	for _, media := range b.Content {
+		if media.ContentType == contentType {
+			return media
+		}
+	}
+	return nil
+}
+
+// paramPattern matches a path parameter of a path template.
+var paramPattern = regexp.MustCompile(`\{[^}/]*\}`)
+
+// Find returns the path template of api matching path, comparing templates
+// regardless of the names of their parameters, and whether one was found.
+func (api *API) Find(path string) (string, bool) {
+	want := paramPattern.ReplaceAllString(path, "{}")
+	for _, op := range api.Operations {
+		if op.Path == path {
+			return op.Path, true
+		}
+	}
+	for _, op := range api.Operations {
+		if paramPattern.ReplaceAllString(op.Path, "{}") == want {
+			return op.Path, true
+		}
+	}
+	return "", false
+}
+
+// PathOperations returns the operations of the path template path, ordered
+// by method.
+func (api *API) PathOperations(path string) []*Operation {
+	var ops []*Operation
+	for _, op := range api.Operations {
+		if op.Path == path {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// Operation returns the operation of method on the path template path, or
+// nil.
+func (api *API) Operation(method, path string) *Operation {
+	for _, op := range api.Operations {
+		if op.Path == path && strings.EqualFold(op.Method, method) {
+			return op
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package model
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// MethodOrder is the order the operations of a path are listed, rendered,
+// and checked in. Iterating PathItem.Operations() instead would make output
+// vary between runs.
+var MethodOrder = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace, http.MethodConnect,
+}
+
+// FromOpenAPI converts an OpenAPI 3 document, or a Swagger 2 document
+// converted to one, to its model.
+func FromOpenAPI(doc *openapi3.T) *API {
+	c := &converter{schemas: make(map[*openapi3.Schema]*Schema)}
+	api := &API{Schemas: make(map[string]*Schema)}
+	if doc == nil {
+		return api
+	}
+	if doc.Info != nil {
+		api.Title, api.Version = doc.Info.Title, doc.Info.Version
+	}
+
+	// Convert the components first so the schemas referencing them are named
+	if doc.Components != nil {
+		for name, ref := range doc.Components.Schemas {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			schema := c.schema(ref)
+			schema.Name = name
+			api.Schemas[name] = schema
+		}
+	}
+
+	if doc.Paths == nil {
+		return api
+	}
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := doc.Paths.Value(path)
+		if item == nil {
+			continue
+		}
+		for _, method := range MethodOrder {
+			if op := item.GetOperation(method); op != nil {
+				api.Operations = append(api.Operations, c.operation(doc, method, path, item, op))
+			}
+		}
+	}
+	return api
+}
+
+// converter converts the parts of an OpenAPI document, converting each
+// schema once so that recursive schemas stay finite.
+type converter struct {
+	schemas map[*openapi3.Schema]*Schema
+}
+
+func (c *converter) operation(doc *openapi3.T, method, path string, item *openapi3.PathItem, op *openapi3.Operation) *Operation {
+	o := &Operation{
+		Method:      method,
+		Path:        path,
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+		Extensions:  op.Extensions,
+	}
+
+	// Operation parameters override the path's with the same name and location
+	index := make(map[string]int)
+	for _, list := range []openapi3.Parameters{item.Parameters, op.Parameters} {
+		for _, ref := range list {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			param := c.parameter(ref.Value)
+			key := param.In + ":" + param.Name
+			if i, ok := index[key]; ok {
+				o.Parameters[i] = param
+				continue
+			}
+			index[key] = len(o.Parameters)
+			o.Parameters = append(o.Parameters, param)
+		}
+	}
+
+	security := doc.Security
+	if op.Security != nil {
+		security = *op.Security
+	}
+	for _, requirement := range security {
+		o.Security = append(o.Security, SecurityRequirement(requirement))
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		body := op.RequestBody.Value
+		o.RequestBody = &Body{Description: body.Description, Required: body.Required, Content: c.content(body.Content)}
+	}
+
+	if op.Responses != nil {
+		statuses := make([]string, 0, op.Responses.Len())
+		for status := range op.Responses.Map() {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			ref := op.Responses.Value(status)
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			o.Responses = append(o.Responses, c.response(status, ref.Value))
+		}
+	}
+	return o
+}
+
+func (c *converter) parameter(param *openapi3.Parameter) *Parameter {
+	return &Parameter{
+		Name:        param.Name,
+		In:          param.In,
+		Description: param.Description,
+		Required:    param.Required,
+		Deprecated:  param.Deprecated,
+		Schema:      c.schema(param.Schema),
+		Example:     param.Example,
+	}
+}
+
+func (c *converter) response(status string, resp *openapi3.Response) *Response {
+	r := &Response{Status: status, Content: c.content(resp.Content)}
+	if resp.Description != nil {
+		r.Description = *resp.Description
+	}
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := resp.Headers[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		r.Headers = append(r.Headers, &Header{
+			Name:        name,
+			Description: ref.Value.Description,
+			Required:    ref.Value.Required,
+			Schema:      c.schema(ref.Value.Schema),
+			Example:     ref.Value.Example,
+		})
+	}
+	return r
+}
+
+func (c *converter) content(content openapi3.Content) []*Media {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+
+	var media []*Media
+	for _, contentType := range types {
+		mt := content[contentType]
+		if mt == nil {
+			continue
+		}
+		m := &Media{ContentType: contentType, Schema: c.schema(mt.Schema), Example: mt.Example}
+		for name, ex := range mt.Examples {
+			if ex == nil || ex.Value == nil {
+				continue
+			}
+			if m.Examples == nil {
+				m.Examples = make(map[string]any)
+			}
+			m.Examples[name] = ex.Value.Value
+		}
+		media = append(media, m)
+	}
+	return media
+}
+
+// schema returns the model of ref, or nil if it has no value.
+func (c *converter) schema(ref *openapi3.SchemaRef) *Schema {
+	if ref == nil || ref.Value == nil {
+		return nil
+	}
+	if s, ok := c.schemas[ref.Value]; ok {
+		if s.Name == "" {
+			s.Name = refName(ref.Ref)
+		}
+		return s
+	}
+
+	v := ref.Value
+	s := &Schema{
+		Name:         refName(ref.Ref),
+		Format:       v.Format,
+		Description:  v.Description,
+		Nullable:     v.Nullable,
+		Deprecated:   v.Deprecated,
+		ReadOnly:     v.ReadOnly,
+		WriteOnly:    v.WriteOnly,
+		Enum:         v.Enum,
+		Default:      v.Default,
+		Example:      v.Example,
+		Min:          v.Min,
+		Max:          v.Max,
+		ExclusiveMin: v.ExclusiveMin,
+		ExclusiveMax: v.ExclusiveMax,
+		Required:     v.Required,
+	}
+	if v.Type != nil {
+		s.Types = append([]string(nil), (*v.Type)...)
+	}
+	// Register before converting the children, which may lead back here
+	c.schemas[v] = s
+
+	if len(v.Properties) > 0 {
+		s.Properties = make(map[string]*Schema, len(v.Properties))
+		for name, prop := range v.Properties {
+			if p := c.schema(prop); p != nil {
+				s.Properties[name] = p
+			}
+		}
+	}
+	s.Items = c.schema(v.Items)
+	s.AllOf = c.schemaList(v.AllOf)
+	s.OneOf = c.schemaList(v.OneOf)
+	s.AnyOf = c.schemaList(v.AnyOf)
+	return s
+}
+
+func (c *converter) schemaList(refs openapi3.SchemaRefs) []*Schema {
+	var schemas []*Schema
+	for _, ref := range refs {
+		if s := c.schema(ref); s != nil {
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas
+}
+
+// refName returns the name of the schema a $ref points to, e.g. "Event" for
+// "#/components/schemas/Event", or "" if it points to no named schema.
+func refName(ref string) string {
+	_, fragment, ok := strings.Cut(ref, "#")
+	if !ok {
+		return ""
+	}
+	const marker = "/schemas/"
+	if i := strings.LastIndex(fragment, marker); i >= 0 {
+		return fragment[i+len(marker):]
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestFromOpenAPI(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+security:
+  - bearer: [read]
+components:
+  securitySchemes:
+    bearer: {type: http, scheme: bearer}
+  schemas:
+    Node:
+      type: object
+      properties:
+        name: {type: string}
+        children: {type: array, items: {$ref: '#/components/schemas/Node'}}
+paths:
+  /nodes/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+      - {name: depth, in: query, schema: {type: integer}}
+    get:
+      parameters:
+        - {name: depth, in: query, required: true, schema: {type: integer, minimum: 1}}
+      responses:
+        default: {description: Error}
+        '200':
+          description: The node.
+          headers:
+            ETag: {schema: {type: string}}
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Node'}
+    delete:
+      security: []
+      responses:
+        '204': {description: Deleted}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := FromOpenAPI(doc)
+
+	if api.Title != "Test API" || api.Version != "1.0.0" {
+		t.Errorf("Title, Version = %q, %q", api.Title, api.Version)
+	}
+	if len(api.Operations) != 2 || api.Operations[0].Method != "GET" || api.Operations[1].Method != "DELETE" {
+		t.Fatalf("Operations = %+v, want GET then DELETE", api.Operations)
+	}
+
+	get := api.Operation("get", "/nodes/{id}")
+	if len(get.Parameters) != 2 || get.Parameters[1].Name != "depth" || !get.Parameters[1].Required {
+		t.Errorf("Parameters = %+v, want the operation's depth overriding the path's", get.Parameters)
+	}
+	if len(get.Security) != 1 || get.Security[0]["bearer"][0] != "read" {
+		t.Errorf("GET Security = %v, want the document's", get.Security)
+	}
+	if del := api.Operation("DELETE", "/nodes/{id}"); len(del.Security) != 0 {
+		t.Errorf("DELETE Security = %v, want none", del.Security)
+	}
+
+	if len(get.Responses) != 2 || get.Responses[0].Status != "200" || get.Responses[1].Status != "default" {
+		t.Fatalf("Responses = %+v, want 200 then default", get.Responses)
+	}
+	ok := get.Response("200")
+	if len(ok.Headers) != 1 || ok.Headers[0].Name != "ETag" {
+		t.Errorf("Headers = %+v", ok.Headers)
+	}
+	node := ok.Content[0].Schema
+	if node.Name != "Node" || node != api.Schemas["Node"] {
+		t.Errorf("response schema = %+v, want the Node component", node)
+	}
+	if node.Properties["children"].Items != node {
+		t.Error("recursive schema not resolved to itself")
+	}
+	if names := node.PropertyNames(); len(names) != 2 || names[0] != "children" {
+		t.Errorf("PropertyNames() = %v", names)
+	}
+}
+
+func TestFind(t *testing.T) {
+	api := &API{Operations: []*Operation{
+		{Method: "GET", Path: "/nodes"},
+		{Method: "GET", Path: "/nodes/{id}"},
+	}}
+
+	for path, want := range map[string]string{
+		"/nodes":           "/nodes",
+		"/nodes/{id}":      "/nodes/{id}",
+		"/nodes/{node_id}": "/nodes/{id}",
+		"/edges":           "",
+	} {
+		got, ok := api.Find(path)
+		if got != want || ok != (want != "") {
+			t.Errorf("Find(%q) = %q, %v, want %q", path, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+// JSON schema types, as in Schema.Types.
+const (
+	TypeNull    = "null"
+	TypeBoolean = "boolean"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeString  = "string"
+	TypeArray   = "array"
+	TypeObject  = "object"
+)
+
+// JSONType returns the JSON schema type of a decoded example or JSON value:
+// TypeInteger for whole numbers, TypeNumber for others, and "" for a value
+// JSON can't hold.
+func JSONType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case string:
+		return TypeString
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return TypeInteger
+	case float32:
+		return numberType(float64(v))
+	case float64:
+		return numberType(v)
+	case []any:
+		return TypeArray
+	case map[string]any:
+		return TypeObject
+	}
+	return ""
+}
+
+func numberType(f float64) string {
+	if f == float64(int64(f)) {
+		return TypeInteger
+	}
+	return TypeNumber
+}
+
+// TypeAllows reports whether a value of JSON type actual, as JSONType
+// returns it, is valid for the declared schema type. Integers are numbers
+// too.
+func TypeAllows(declared, actual string) bool {
+	return declared == actual || declared == TypeNumber && actual == TypeInteger
+}
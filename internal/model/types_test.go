@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestJSONType(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, TypeNull},
+		{true, TypeBoolean},
+		{"1", TypeString},
+		{float64(3), TypeInteger},
+		{3, TypeInteger},
+		{1.5, TypeNumber},
+		{[]any{}, TypeArray},
+		{map[string]any{}, TypeObject},
+		{struct{}{}, ""},
+	}
+	for _, tt := range tests {
+		if got := JSONType(tt.value); got != tt.want {
+			t.Errorf("JSONType(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	if !TypeAllows(TypeNumber, JSONType(3.0)) || TypeAllows(TypeInteger, JSONType(1.5)) || TypeAllows(TypeString, TypeInteger) {
+		t.Error("Expected integers to be numbers, and nothing else to cross types")
+	}
+}
@@ -0,0 +1,141 @@
+// Package notes stores team notes about API operations in a YAML file kept
+// alongside the project, so knowledge that does not belong in the spec is
+// rendered with the docs.
+package notes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/findup"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the notes file looked up in the working directory and its parents.
+const FileName = ".docfinder-notes.yaml"
+
+// dateFormat is how note dates are stored.
+const dateFormat = "2006-01-02"
+
+// ErrNotFound is returned by Find when no notes file exists in the directory tree.
+var ErrNotFound = errors.New("no " + FileName + " found")
+
+// Note is a single note about an operation.
+type Note struct {
+	Text   string `yaml:"text"`
+	Author string `yaml:"author,omitempty"`
+	// Date is when the note was added, as YYYY-MM-DD.
+	Date string `yaml:"date,omitempty"`
+}
+
+// File is a notes file.
+type File struct {
+	// Path is where the file is saved.
+	Path string `yaml:"-"`
+	// Operations maps "METHOD /path" to the notes about that operation.
+	Operations map[string][]Note `yaml:"operations"`
+}
+
+// Find searches dir and its parents for FileName and returns the first match.
+func Find(dir string) (string, error) {
+	path, err := findup.Find(dir, FileName)
+	if errors.Is(err, findup.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return path, err
+}
+
+// Load reads the notes file at path. A missing file is an empty notes file
+// that Save creates.
+func Load(path string) (*File, error) {
+	f := &File{Path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("failed to read notes: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse notes %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Save writes the notes file, omitting operations without notes.
+func (f *File) Save() error {
+	for key, notes := range f.Operations {
+		if len(notes) == 0 {
+			delete(f.Operations, key)
+		}
+	}
+
+	var data bytes.Buffer
+	enc := yaml.NewEncoder(&data)
+	enc.SetIndent(2)
+	if err := enc.Encode(f); err != nil {
+		return fmt.Errorf("failed to encode notes: %w", err)
+	}
+	if err := os.WriteFile(f.Path, data.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write notes: %w", err)
+	}
+	return nil
+}
+
+// Add appends a note about an operation, dated now.
+func (f *File) Add(method, path, text, author string, now time.Time) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("note text is empty")
+	}
+	if f.Operations == nil {
+		f.Operations = make(map[string][]Note)
+	}
+	key := generator.NoteKey(method, path)
+	f.Operations[key] = append(f.Operations[key], Note{Text: text, Author: author, Date: now.Format(dateFormat)})
+	return nil
+}
+
+// Remove deletes the n-th note (1-based) about an operation.
+func (f *File) Remove(method, path string, n int) error {
+	key := generator.NoteKey(method, path)
+	notes := f.Operations[key]
+	if n < 1 || n > len(notes) {
+		return fmt.Errorf("%s has %d note(s); no note #%d", key, len(notes), n)
+	}
+	f.Operations[key] = append(notes[:n-1:n-1], notes[n:]...)
+	return nil
+}
+
+// Keys returns the operations with notes, sorted.
+func (f *File) Keys() []string {
+	keys := make([]string, 0, len(f.Operations))
+	for key, notes := range f.Operations {
+		if len(notes) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TeamNotes converts the notes for rendering with generator.WithTeamNotes.
+func (f *File) TeamNotes() map[string][]generator.TeamNote {
+	teamNotes := make(map[string][]generator.TeamNote, len(f.Operations))
+	for key, notes := range f.Operations {
+		method, path, _ := strings.Cut(key, " ")
+		normalized := generator.NoteKey(method, path)
+		for _, note := range notes {
+			teamNotes[normalized] = append(teamNotes[normalized], generator.TeamNote{Text: note.Text, Author: note.Author, Date: note.Date})
+		}
+	}
+	return teamNotes
+}
@@ -0,0 +1,80 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFile_AddRemoveSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if err := f.Add("get", "/events/{id}", "rate limited to 10 rps in prod", "alice", now); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := f.Add("GET", "/events/{id}", "cache for 30s", "", now); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := f.Add("POST", "/events", "  ", "", now); err == nil {
+		t.Error("Expected error for empty note")
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	expected := "operations:\n  GET /events/{id}:\n    - text: rate limited to 10 rps in prod\n      author: alice\n      date: \"2026-03-01\"\n"
+	if !strings.HasPrefix(string(data), expected) {
+		t.Errorf("Expected file to start with:\n%s\ngot:\n%s", expected, data)
+	}
+
+	f, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if err := f.Remove("GET", "/events/{id}", 3); err == nil {
+		t.Error("Expected error for out-of-range note")
+	}
+	if err := f.Remove("GET", "/events/{id}", 1); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+
+	teamNotes := f.TeamNotes()["GET /events/{id}"]
+	if len(teamNotes) != 1 || teamNotes[0].Text != "cache for 30s" || teamNotes[0].Date != "2026-03-01" {
+		t.Errorf("TeamNotes() = %+v", teamNotes)
+	}
+
+	if err := f.Remove("GET", "/events/{id}", 1); err != nil {
+		t.Fatalf("Remove() error: %v", err)
+	}
+	if keys := f.Keys(); len(keys) != 0 {
+		t.Errorf("Expected no operations with notes, got %v", keys)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Find(nested); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	want := filepath.Join(root, FileName)
+	if err := os.WriteFile(want, []byte("operations: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Find(nested); err != nil || got != want {
+		t.Errorf("Find() = %q, %v, want %q", got, err, want)
+	}
+}
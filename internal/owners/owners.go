@@ -0,0 +1,227 @@
+// Package owners maps API endpoints to the teams that own them, from
+// x-owner extensions in the spec and a CODEOWNERS-style OWNERS file of path
+// globs for endpoints without one.
+package owners
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/findup"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// FileName is the owners file looked up in the working directory and its parents.
+const FileName = "OWNERS"
+
+// ExtensionOwner names the team owning an operation or, on a path item,
+// all of its operations. The value is a string or a list of strings.
+const ExtensionOwner = "x-owner"
+
+// ErrNotFound is returned by Find when no owners file exists in the directory tree.
+var ErrNotFound = errors.New("no " + FileName + " found")
+
+// Rule assigns the endpoints matching a path glob to teams.
+type Rule struct {
+	// Pattern is the path glob, e.g. "/payments/**". "*" matches within
+	// one path segment and "**" any number of segments.
+	Pattern string
+	Teams   []string
+	// Line is the line of the rule in its file.
+	Line    int
+	pattern *regexp.Regexp
+}
+
+// File is an owners file. Later rules take precedence, as in CODEOWNERS.
+type File struct {
+	Path  string
+	Rules []Rule
+}
+
+// Find searches dir and its parents for FileName and returns the first match.
+func Find(dir string) (string, error) {
+	path, err := findup.Find(dir, FileName)
+	if errors.Is(err, findup.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return path, err
+}
+
+// Load reads the owners file at path.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read owners: %w", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	f.Path = path
+	return f, nil
+}
+
+// Parse parses owners file content: one "<path-glob> <team>..." rule per
+// line, with blank lines and "#" comments ignored.
+func Parse(data []byte) (*File, error) {
+	f := &File{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: rule %q has no team", line, fields[0])
+		}
+		if !strings.HasPrefix(fields[0], "/") {
+			return nil, fmt.Errorf("line %d: pattern %q must start with /", line, fields[0])
+		}
+		f.Rules = append(f.Rules, Rule{Pattern: fields[0], Teams: fields[1:], Line: line, pattern: compileGlob(fields[0])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// compileGlob converts a path glob to a regular expression. A trailing
+// "/**" also matches the path itself, so "/payments/**" owns "/payments".
+func compileGlob(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "/**"):
+			b.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("/?$")
+	return regexp.MustCompile(b.String())
+}
+
+// Match returns the last rule matching path, or nil.
+func (f *File) Match(path string) *Rule {
+	if f == nil {
+		return nil
+	}
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].pattern.MatchString(path) {
+			return &f.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Ownership is the owners of an operation and where they come from.
+type Ownership struct {
+	Method string
+	Path   string
+	Teams  []string
+	// Source is ExtensionOwner, or the file and line of the matching rule,
+	// e.g. "OWNERS:12".
+	Source string
+}
+
+// Resolve returns the owners of every operation in doc, ordered by path
+// and then method. x-owner on the operation, then on its path item, takes
+// precedence over f, which may be nil. Operations without owners are
+// included with no teams.
+func Resolve(doc *openapi3.T, f *File) []Ownership {
+	var out []Ownership
+	for _, op := range export.Operations(doc) {
+		o := Ownership{Method: op.Method, Path: op.Path}
+		if teams := extensionTeams(op.Operation.Extensions); len(teams) > 0 {
+			o.Teams, o.Source = teams, ExtensionOwner
+		} else if teams := extensionTeams(op.PathItem.Extensions); len(teams) > 0 {
+			o.Teams, o.Source = teams, ExtensionOwner
+		} else if rule := f.Match(op.Path); rule != nil {
+			o.Teams, o.Source = rule.Teams, fmt.Sprintf("%s:%d", filepath.Base(f.Path), rule.Line)
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// extensionTeams returns the teams of an x-owner extension.
+func extensionTeams(extensions map[string]any) []string {
+	switch value := extensions[ExtensionOwner].(type) {
+	case string:
+		if value = strings.TrimSpace(value); value != "" {
+			return []string{value}
+		}
+	case []any:
+		var teams []string
+		for _, v := range value {
+			if team := strings.TrimSpace(fmt.Sprint(v)); team != "" {
+				teams = append(teams, team)
+			}
+		}
+		return teams
+	}
+	return nil
+}
+
+// Map returns the teams of each owned operation, keyed by generator.NoteKey.
+func Map(ownerships []Ownership) map[string][]string {
+	m := make(map[string][]string)
+	for _, o := range ownerships {
+		if len(o.Teams) > 0 {
+			m[generator.NoteKey(o.Method, o.Path)] = o.Teams
+		}
+	}
+	return m
+}
+
+// OwnedBy returns the operations team owns. Teams are compared ignoring
+// case and a leading "@".
+func OwnedBy(ownerships []Ownership, team string) []Ownership {
+	var out []Ownership
+	for _, o := range ownerships {
+		for _, t := range o.Teams {
+			if sameTeam(t, team) {
+				out = append(out, o)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Teams returns every team owning an operation, sorted.
+func Teams(ownerships []Ownership) []string {
+	seen := make(map[string]bool)
+	var teams []string
+	for _, o := range ownerships {
+		for _, t := range o.Teams {
+			if !seen[t] {
+				seen[t] = true
+				teams = append(teams, t)
+			}
+		}
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+func sameTeam(a, b string) bool {
+	return strings.EqualFold(strings.TrimPrefix(a, "@"), strings.TrimPrefix(b, "@"))
+}
@@ -0,0 +1,122 @@
+package owners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParse(t *testing.T) {
+	f, err := Parse([]byte(`
+# Payments own everything under /payments
+/payments/**        @acme/payments-team
+/payments/*/refunds refunds-team   # split out in 2024
+
+/users/*            identity-team growth-team
+/**/health          sre-team
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/payments", "@acme/payments-team"},
+		{"/payments/{id}/capture", "@acme/payments-team"},
+		{"/payments/{id}/refunds", "refunds-team"},
+		{"/users/{id}", "identity-team,growth-team"},
+		{"/users/{id}/avatar", ""},
+		{"/v1/internal/health", "sre-team"},
+		{"/health", "sre-team"},
+		{"/orders", ""},
+	}
+	for _, tt := range tests {
+		var got string
+		if rule := f.Match(tt.path); rule != nil {
+			got = strings.Join(rule.Teams, ",")
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"/payments\n", "payments/** team\n"} {
+		if _, err := Parse([]byte(invalid)); err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("Parse(%q) error = %v, want one naming line 1", invalid, err)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /payments/{id}:
+    x-owner: ledger-team
+    get:
+      responses:
+        "200":
+          description: OK
+    delete:
+      x-owner: [payments-team, fraud-team]
+      responses:
+        "204":
+          description: Deleted
+  /payments:
+    post:
+      responses:
+        "201":
+          description: Created
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte("/payments/** payments-team\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, o := range Resolve(doc, f) {
+		got = append(got, o.Method+" "+o.Path+" "+strings.Join(o.Teams, ",")+" "+o.Source)
+	}
+	want := []string{
+		"GET /orders  ",
+		"POST /payments payments-team OWNERS:1",
+		"GET /payments/{id} ledger-team x-owner",
+		"DELETE /payments/{id} payments-team,fraud-team x-owner",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Resolve() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	owned := OwnedBy(Resolve(doc, f), "@Payments-Team")
+	if len(owned) != 2 || owned[0].Path != "/payments" || owned[1].Method != "DELETE" {
+		t.Errorf("OwnedBy() = %+v", owned)
+	}
+	if teams := strings.Join(Teams(Resolve(doc, nil)), ","); teams != "fraud-team,ledger-team,payments-team" {
+		t.Errorf("Teams() = %s", teams)
+	}
+	if m := Map(Resolve(doc, f)); len(m) != 3 || m["POST /payments"][0] != "payments-team" {
+		t.Errorf("Map() = %v", m)
+	}
+}
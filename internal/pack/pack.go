@@ -0,0 +1,172 @@
+// Package pack bundles the exported documentation of a spec into a
+// self-contained .tar.gz archive, for reading where the hosted docs portal
+// is unreachable.
+package pack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ContentsFile is the landing page of a pack, linking every page.
+const ContentsFile = "README.md"
+
+// AssetsDir is the directory of a pack holding example attachments and a
+// copy of the spec.
+const AssetsDir = "assets"
+
+// Options configures Write.
+type Options struct {
+	// Output is the path of the archive written.
+	Output string
+	// Export configures the exported pages. OutDir is ignored, and pages
+	// are laid out one file per operation at the top of the pack so links
+	// to assets work from each.
+	Export export.Options
+}
+
+// Write exports every operation of doc and writes the pages, the export
+// and search indexes, a contents page, and the assets they link to into
+// a gzipped tar archive at opts.Output. The files are stored under a
+// directory named after the archive, e.g. "docs/" for docs.tar.gz.
+func Write(doc *openapi3.T, opts Options) (*export.Index, error) {
+	dir, err := os.MkdirTemp("", "docfinder-pack-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	root := filepath.Join(dir, RootName(opts.Output))
+	exportOpts := opts.Export
+	exportOpts.OutDir = root
+	exportOpts.SplitBy, exportOpts.NameTemplate, exportOpts.Site = export.SplitByOperation, "", ""
+	exportOpts.Generate = append(slices.Clone(exportOpts.Generate), generator.WithExampleAttacher(&generator.DirAttacher{
+		Dir:        filepath.Join(root, AssetsDir),
+		LinkPrefix: AssetsDir,
+	}))
+
+	index, _, err := export.Export(doc, exportOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ContentsFile), []byte(contents(index)), 0o644); err != nil {
+		return nil, err
+	}
+	if err := copySpec(opts.Export.SpecPath, filepath.Join(root, AssetsDir)); err != nil {
+		return nil, err
+	}
+
+	if err := archive(dir, opts.Output); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// RootName returns the name of the directory the files of the archive at
+// output are stored under: its base name without archive extensions.
+func RootName(output string) string {
+	name := filepath.Base(output)
+	for _, ext := range []string{".gz", ".tgz", ".tar"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return "docs"
+	}
+	return name
+}
+
+// contents returns the landing page of a pack, listing every page.
+func contents(index *export.Index) string {
+	var md strings.Builder
+	title := strings.TrimSpace(index.Spec.Title + " " + index.Spec.Version)
+	if title == "" {
+		title = "API Documentation"
+	}
+	fmt.Fprintf(&md, "# %s\n\n", title)
+	for _, f := range index.Files {
+		operation := f.Method + " " + f.Endpoint
+		if f.Title == operation {
+			fmt.Fprintf(&md, "- [%s](%s)\n", f.Title, f.Path)
+		} else {
+			fmt.Fprintf(&md, "- [%s](%s) `%s`\n", f.Title, f.Path, operation)
+		}
+	}
+	fmt.Fprintf(&md, "\nSearch the pages with %s; %s describes every file.\n", export.SearchIndexFile, export.IndexFile)
+	return md.String()
+}
+
+// copySpec copies the spec at path into dir.
+func copySpec(path, dir string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, filepath.Base(path)), data, 0o644)
+}
+
+// archive writes every file under dir to a gzipped tar archive at output,
+// named relative to dir.
+func archive(dir, output string) (err error) {
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	modTime := time.Now()
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header := &tar.Header{Name: filepath.ToSlash(name), ModTime: modTime, Mode: 0o644, Typeflag: tar.TypeReg}
+		if d.IsDir() {
+			header.Name += "/"
+			header.Mode, header.Typeflag = 0o755, tar.TypeDir
+			return tw.WriteHeader(header)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		header.Size = int64(len(data))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,150 @@
+package pack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const packSpec = `openapi: 3.0.0
+info:
+  title: Events API
+  version: 2.1.0
+paths:
+  /events:
+    get:
+      summary: List events
+      tags: [events]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              example: [{id: 1}, {id: 2}, {id: 3}]
+  /health:
+    get:
+      responses:
+        '204':
+          description: Healthy
+`
+
+// readArchive returns the files of the gzipped tar archive at path by name.
+func readArchive(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(packSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := filepath.Join(dir, "api-docs.tar.gz")
+	index, err := Write(doc, Options{
+		Output: output,
+		Export: export.Options{
+			SpecPath: specPath,
+			// Truncate the example so it is attached
+			Generate: []generator.Option{generator.WithMaxExampleLines(2)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if len(index.Files) != 2 {
+		t.Fatalf("Expected 2 operations, got %d", len(index.Files))
+	}
+
+	files := readArchive(t, output)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	for _, name := range []string{
+		"api-docs/", "api-docs/README.md", "api-docs/index.json", "api-docs/search-index.json",
+		"api-docs/get-events.md", "api-docs/get-health.md", "api-docs/assets/openapi.yaml",
+	} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Expected %s in archive, got %v", name, names)
+		}
+	}
+
+	if page := files["api-docs/get-events.md"]; !strings.Contains(page, "](assets/") {
+		t.Errorf("Expected the truncated example linked in assets/:\n%s", page)
+	}
+	attached := false
+	for name := range files {
+		attached = attached || (strings.HasPrefix(name, "api-docs/assets/") && strings.HasSuffix(name, ".json"))
+	}
+	if !attached {
+		t.Errorf("Expected the example attachment in assets/, got %v", names)
+	}
+
+	contents := files["api-docs/README.md"]
+	for _, s := range []string{"# Events API 2.1.0\n", "- [List events](get-events.md) `GET /events`\n", "- [GET /health](get-health.md)\n"} {
+		if !strings.Contains(contents, s) {
+			t.Errorf("Expected %q in contents:\n%s", s, contents)
+		}
+	}
+
+	var search []export.SearchDocument
+	if err := json.Unmarshal([]byte(files["api-docs/"+export.SearchIndexFile]), &search); err != nil {
+		t.Fatal(err)
+	}
+	if len(search) != 2 || search[0].ID != "get-events.md" || search[1].ID != "get-health.md" {
+		t.Errorf("Expected a search document per page, got %+v", search)
+	}
+}
+
+func TestRootName(t *testing.T) {
+	tests := map[string]string{
+		"docs.tar.gz":         "docs",
+		"out/api-v2.tgz":      "api-v2",
+		"bundle.tar":          "bundle",
+		"release/docs-bundle": "docs-bundle",
+	}
+	for output, expected := range tests {
+		if got := RootName(output); got != expected {
+			t.Errorf("RootName(%q) = %q, want %q", output, got, expected)
+		}
+	}
+}
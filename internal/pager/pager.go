@@ -0,0 +1,98 @@
+// Package pager shows long terminal output through a pager such as less.
+package pager
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// defaultPagers are tried in order when $PAGER is unset.
+var defaultPagers = [][]string{
+	{"less", "-R"},
+	{"more"},
+}
+
+// Page writes content to w. When w is a terminal and content is taller than
+// it, content is piped through $PAGER or, if unset, less (which supports
+// scrolling and /search), whose errors go to stderr. If no pager can be
+// started, content is written directly.
+func Page(w, stderr io.Writer, content string, disabled bool) error {
+	f, ok := w.(*os.File)
+	if disabled || !ok || !isTerminal(f) || !exceedsHeight(content, terminalHeight(f)) {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+
+	for _, argv := range pagerCommands() {
+		path, err := exec.LookPath(argv[0])
+		if err != nil {
+			continue
+		}
+		return run(path, argv[1:], content, f, stderr)
+	}
+
+	_, err := io.WriteString(w, content)
+	return err
+}
+
+// pagerCommands returns the pager command lines to try.
+func pagerCommands() [][]string {
+	if env := strings.Fields(os.Getenv("PAGER")); len(env) > 0 {
+		return [][]string{env}
+	}
+	return defaultPagers
+}
+
+// run pipes content into the pager at path, which writes to the terminal tty
+// and its errors to stderr.
+func run(path string, args []string, content string, tty *os.File, stderr io.Writer) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = tty
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pager failed: %w", err)
+	}
+	return nil
+}
+
+// exceedsHeight reports whether content has more lines than height. A
+// non-positive height means the terminal size is unknown.
+func exceedsHeight(content string, height int) bool {
+	if height <= 0 {
+		return false
+	}
+	return strings.Count(content, "\n") >= height
+}
+
+// IsTerminal reports whether w is a terminal, as opposed to a file or pipe.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isTerminal(f)
+}
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalHeight returns the number of rows of the terminal tty, falling
+// back to $LINES, or 0 if unknown.
+func terminalHeight(tty *os.File) int {
+	if rows := ttyRows(tty); rows > 0 {
+		return rows
+	}
+	if rows, err := strconv.Atoi(os.Getenv("LINES")); err == nil {
+		return rows
+	}
+	return 0
+}
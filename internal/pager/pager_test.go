@@ -0,0 +1,54 @@
+package pager
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExceedsHeight(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		height   int
+		expected bool
+	}{
+		{"fits", "a\nb\n", 24, false},
+		{"taller", "a\nb\nc\n", 3, true},
+		{"unknown height", "a\nb\nc\n", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := exceedsHeight(tt.content, tt.height); result != tt.expected {
+				t.Errorf("exceedsHeight() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPagerCommands(t *testing.T) {
+	t.Setenv("PAGER", "most -s")
+	if got := pagerCommands(); !reflect.DeepEqual(got, [][]string{{"most", "-s"}}) {
+		t.Errorf("pagerCommands() = %v, want $PAGER", got)
+	}
+
+	t.Setenv("PAGER", "")
+	if got := pagerCommands(); !reflect.DeepEqual(got, defaultPagers) {
+		t.Errorf("pagerCommands() = %v, want defaults", got)
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if IsTerminal(f) || IsTerminal(&bytes.Buffer{}) {
+		t.Error("Expected files and buffers not to be terminals")
+	}
+}
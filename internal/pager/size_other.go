@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package pager
+
+import "os"
+
+// ttyRows is not supported on this platform; the terminal height falls back to $LINES.
+func ttyRows(f *os.File) int {
+	return 0
+}
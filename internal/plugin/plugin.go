@@ -0,0 +1,221 @@
+// Package plugin runs external docfinder-plugin-<name> executables that add
+// custom renderers, spec sources, and lint rules without forking docfinder.
+//
+// A plugin is any executable on PATH named with Prefix. docfinder starts it
+// once per call, writes a single JSON Request to its stdin, and reads a
+// single JSON Response from its stdout. Anything the plugin writes to stderr
+// is passed through to the user.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Prefix is the executable name prefix identifying plugins.
+const Prefix = "docfinder-plugin-"
+
+// ProtocolVersion is sent with every request so plugins can reject requests
+// they do not understand.
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds each plugin call.
+const DefaultTimeout = time.Minute
+
+// SourcePrefix marks a spec argument served by a source plugin, as in
+// "plugin:registry:payments@v2".
+const SourcePrefix = "plugin:"
+
+// Kind is what a plugin is asked to do.
+type Kind string
+
+// Request kinds.
+const (
+	// KindRender asks the plugin to render documentation for an endpoint.
+	KindRender Kind = "render"
+	// KindSource asks the plugin to return an OpenAPI document.
+	KindSource Kind = "source"
+	// KindLint asks the plugin to check a document and report problems.
+	KindLint Kind = "lint"
+)
+
+// Request is written to the plugin's stdin.
+type Request struct {
+	Protocol int  `json:"protocol"`
+	Kind     Kind `json:"kind"`
+	// Location is the source plugin argument, e.g. "payments@v2" for
+	// "plugin:registry:payments@v2".
+	Location string `json:"location,omitempty"`
+	// Spec is the loaded OpenAPI document, for render and lint requests.
+	Spec json.RawMessage `json:"spec,omitempty"`
+	// Path and Method select the endpoint to render. Method is empty for
+	// all methods.
+	Path   string `json:"path,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// Response is read from the plugin's stdout.
+type Response struct {
+	// Output is the rendered documentation, for render requests.
+	Output string `json:"output,omitempty"`
+	// Document is the OpenAPI document as YAML or JSON text, for source
+	// requests.
+	Document string `json:"document,omitempty"`
+	// Problems are the lint findings, for lint requests.
+	Problems []Problem `json:"problems,omitempty"`
+	// Error reports a failure the plugin handled itself.
+	Error string `json:"error,omitempty"`
+}
+
+// Problem is a lint finding reported by a plugin.
+type Problem struct {
+	Rule     string `json:"rule"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// Plugin is an installed plugin executable.
+type Plugin struct {
+	Name string
+	Path string
+	// Stderr receives what the plugin writes to stderr. If nil, it goes to
+	// os.Stderr.
+	Stderr io.Writer
+}
+
+// Find looks up the executable for the named plugin on PATH.
+func Find(name string) (*Plugin, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid plugin name: %q", name)
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q not found: install %s%s on your PATH", name, Prefix, name)
+	}
+	return &Plugin{Name: name, Path: path}, nil
+}
+
+// List returns the plugins installed on PATH, sorted by name. When several
+// directories provide the same plugin, the first one wins, as with exec.
+func List() []Plugin {
+	seen := make(map[string]bool)
+	var plugins []Plugin
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			name = strings.TrimSuffix(name, ".exe")
+			if !ok || name == "" || seen[name] || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if _, err := exec.LookPath(path); err != nil {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// ParseSource splits a "plugin:<name>:<location>" spec argument. ok is false
+// for ordinary file paths.
+func ParseSource(arg string) (name, location string, ok bool) {
+	rest, ok := strings.CutPrefix(arg, SourcePrefix)
+	if !ok {
+		return "", "", false
+	}
+	name, location, _ = strings.Cut(rest, ":")
+	return name, location, true
+}
+
+// Call runs the plugin with req and returns its response.
+func (p *Plugin) Call(ctx context.Context, req Request) (*Response, error) {
+	req.Protocol = ProtocolVersion
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = p.Stderr
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("plugin %s timed out after %s", p.Name, DefaultTimeout)
+		}
+		return nil, fmt.Errorf("plugin %s failed: %w", p.Name, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s returned invalid JSON: %w", p.Name, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", p.Name, resp.Error)
+	}
+	return &resp, nil
+}
+
+// Render asks the plugin to render documentation for an endpoint of doc.
+func (p *Plugin) Render(ctx context.Context, doc *openapi3.T, path, method string) (string, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode spec: %w", err)
+	}
+	resp, err := p.Call(ctx, Request{Kind: KindRender, Spec: data, Path: path, Method: method})
+	if err != nil {
+		return "", err
+	}
+	return resp.Output, nil
+}
+
+// Source asks the plugin for the OpenAPI document at location.
+func (p *Plugin) Source(ctx context.Context, location string) ([]byte, error) {
+	resp, err := p.Call(ctx, Request{Kind: KindSource, Location: location})
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(resp.Document) == "" {
+		return nil, fmt.Errorf("plugin %s returned no document for %q", p.Name, location)
+	}
+	return []byte(resp.Document), nil
+}
+
+// Lint asks the plugin to check doc.
+func (p *Plugin) Lint(ctx context.Context, doc *openapi3.T) ([]Problem, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	resp, err := p.Call(ctx, Request{Kind: KindLint, Spec: data})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Problems, nil
+}
@@ -0,0 +1,140 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// installPlugin writes a shell script plugin printing response into a
+// temporary directory on PATH. The request is saved next to it.
+func installPlugin(t *testing.T, name, response string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on Windows")
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncat > \"$(dirname \"$0\")/request.json\"\ncat <<'EOF'\n" + response + "\nEOF\n"
+	if err := os.WriteFile(filepath.Join(dir, Prefix+name), []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+func TestPlugin_Render(t *testing.T) {
+	dir := installPlugin(t, "html", `{"output": "<h1>Events</h1>"}`)
+
+	p, err := Find("html")
+	if err != nil {
+		t.Fatalf("Find() error: %v", err)
+	}
+	doc := &openapi3.T{OpenAPI: "3.0.3", Info: &openapi3.Info{Title: "Events API", Version: "1.0.0"}}
+	output, err := p.Render(context.Background(), doc, "/events", "GET")
+	if err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if output != "<h1>Events</h1>" {
+		t.Errorf("Render() = %q", output)
+	}
+
+	request, err := os.ReadFile(filepath.Join(dir, "request.json"))
+	if err != nil {
+		t.Fatalf("failed to read request: %v", err)
+	}
+	for _, s := range []string{`"protocol":1`, `"kind":"render"`, `"path":"/events"`, `"method":"GET"`, `"title":"Events API"`} {
+		if !strings.Contains(string(request), s) {
+			t.Errorf("Expected %q in request:\n%s", s, request)
+		}
+	}
+}
+
+func TestPlugin_SourceAndLint(t *testing.T) {
+	installPlugin(t, "registry", `{"document": "openapi: 3.0.3\ninfo: {title: T, version: '1'}\npaths: {}\n",
+  "problems": [{"rule": "kebab-case", "location": "paths./Users", "message": "use kebab-case"}]}`)
+
+	p, err := Find("registry")
+	if err != nil {
+		t.Fatalf("Find() error: %v", err)
+	}
+
+	data, err := p.Source(context.Background(), "payments@v2")
+	if err != nil {
+		t.Fatalf("Source() error: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "openapi: 3.0.3") {
+		t.Errorf("Source() = %q", data)
+	}
+
+	problems, err := p.Lint(context.Background(), &openapi3.T{})
+	if err != nil {
+		t.Fatalf("Lint() error: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Rule != "kebab-case" {
+		t.Errorf("Lint() = %+v", problems)
+	}
+}
+
+func TestPlugin_Errors(t *testing.T) {
+	installPlugin(t, "broken", `{"error": "registry unavailable"}`)
+
+	p, err := Find("broken")
+	if err != nil {
+		t.Fatalf("Find() error: %v", err)
+	}
+	if _, err := p.Source(context.Background(), "x"); err == nil || !strings.Contains(err.Error(), "registry unavailable") {
+		t.Errorf("Expected plugin error, got %v", err)
+	}
+
+	if _, err := Find("missing"); err == nil {
+		t.Error("Expected error for missing plugin")
+	}
+	if _, err := Find("../broken"); err == nil {
+		t.Error("Expected error for invalid plugin name")
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := installPlugin(t, "b", `{}`)
+	t.Setenv("PATH", dir)
+	if err := os.WriteFile(filepath.Join(dir, Prefix+"a"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, Prefix+"noexec"), []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, p := range List() {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "a,b" {
+		t.Errorf("List() = %s, want a,b", got)
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		arg, name, location string
+		ok                  bool
+	}{
+		{"plugin:registry:payments@v2", "registry", "payments@v2", true},
+		{"plugin:registry", "registry", "", true},
+		{"specs/openapi.yaml", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			name, location, ok := ParseSource(tt.arg)
+			if name != tt.name || location != tt.location || ok != tt.ok {
+				t.Errorf("ParseSource(%q) = %q, %q, %v", tt.arg, name, location, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Package policy checks operations against documentation requirements so CI
+// can reject spec changes that degrade the generated docs.
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Requirement is a piece of documentation an operation must have.
+type Requirement string
+
+// Supported requirements.
+const (
+	RequireSummary         Requirement = "summary"
+	RequireDescription     Requirement = "description"
+	RequireOperationID     Requirement = "operation-id"
+	RequireTags            Requirement = "tags"
+	RequireErrorResponse   Requirement = "4xx-response"
+	RequireRequestExample  Requirement = "request-example"
+	RequireResponseExample Requirement = "response-example"
+	RequireParamDocs       Requirement = "parameter-descriptions"
+)
+
+// AllRequirements lists every supported requirement.
+var AllRequirements = []Requirement{
+	RequireSummary, RequireDescription, RequireOperationID, RequireTags,
+	RequireErrorResponse, RequireRequestExample, RequireResponseExample, RequireParamDocs,
+}
+
+// DefaultRequirements are enforced when none are configured.
+var DefaultRequirements = []Requirement{RequireSummary, RequireErrorResponse, RequireRequestExample}
+
+// Config lists the requirements to enforce.
+type Config struct {
+	Require []Requirement `yaml:"require"`
+}
+
+// Requirements returns the configured requirements, or DefaultRequirements
+// when none are configured.
+func (c Config) Requirements() ([]Requirement, error) {
+	if len(c.Require) == 0 {
+		return DefaultRequirements, nil
+	}
+	names := make([]string, len(c.Require))
+	for i, r := range c.Require {
+		names[i] = string(r)
+	}
+	return ParseRequirements(strings.Join(names, ","))
+}
+
+// ParseRequirements parses a comma-separated list of requirement names.
+func ParseRequirements(s string) ([]Requirement, error) {
+	var reqs []Requirement
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !isKnown(Requirement(name)) {
+			names := make([]string, len(AllRequirements))
+			for i, r := range AllRequirements {
+				names[i] = string(r)
+			}
+			return nil, fmt.Errorf("unknown requirement: %s (expected one of %s)", name, strings.Join(names, ", "))
+		}
+		reqs = append(reqs, Requirement(name))
+	}
+	return reqs, nil
+}
+
+func isKnown(r Requirement) bool {
+	for _, known := range AllRequirements {
+		if r == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Violation is a requirement an operation fails.
+type Violation struct {
+	Method      string
+	Path        string
+	Requirement Requirement
+	Message     string
+}
+
+// String formats the violation for display.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s: %s [%s]", v.Method, v.Path, v.Message, v.Requirement)
+}
+
+// Check returns the requirements op fails, in the order given.
+func Check(method, path string, op *openapi3.Operation, reqs []Requirement) []Violation {
+	var violations []Violation
+	fail := func(r Requirement, format string, args ...any) {
+		violations = append(violations, Violation{Method: method, Path: path, Requirement: r, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, r := range reqs {
+		switch r {
+		case RequireSummary:
+			if strings.TrimSpace(op.Summary) == "" {
+				fail(r, "missing summary")
+			}
+		case RequireDescription:
+			if strings.TrimSpace(op.Description) == "" {
+				fail(r, "missing description")
+			}
+		case RequireOperationID:
+			if op.OperationID == "" {
+				fail(r, "missing operationId")
+			}
+		case RequireTags:
+			if len(op.Tags) == 0 {
+				fail(r, "no tags")
+			}
+		case RequireErrorResponse:
+			if !hasErrorResponse(op.Responses) {
+				fail(r, "no 4xx error response documented")
+			}
+		case RequireRequestExample:
+			if op.RequestBody != nil && op.RequestBody.Value != nil && !hasExample(op.RequestBody.Value.Content) {
+				fail(r, "request body has no example")
+			}
+		case RequireResponseExample:
+			for _, code := range successCodes(op.Responses) {
+				resp := op.Responses.Value(code).Value
+				if len(resp.Content) > 0 && !hasExample(resp.Content) {
+					fail(r, "response %s has no example", code)
+				}
+			}
+		case RequireParamDocs:
+			for _, ref := range op.Parameters {
+				if ref != nil && ref.Value != nil && strings.TrimSpace(ref.Value.Description) == "" {
+					fail(r, "%s parameter `%s` has no description", ref.Value.In, ref.Value.Name)
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// hasErrorResponse reports whether any 4xx response (or 4XX range) is documented.
+func hasErrorResponse(responses *openapi3.Responses) bool {
+	if responses == nil {
+		return false
+	}
+	for code := range responses.Map() {
+		if strings.HasPrefix(code, "4") {
+			return true
+		}
+	}
+	return false
+}
+
+// successCodes returns the documented 2xx status codes, sorted.
+func successCodes(responses *openapi3.Responses) []string {
+	if responses == nil {
+		return nil
+	}
+	var codes []string
+	for code, ref := range responses.Map() {
+		if strings.HasPrefix(code, "2") && ref != nil && ref.Value != nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// hasExample reports whether any media type carries an example, either
+// directly, as named examples, or on its schema.
+func hasExample(content openapi3.Content) bool {
+	for _, mt := range content {
+		if mt == nil {
+			continue
+		}
+		if mt.Example != nil || len(mt.Examples) > 0 {
+			return true
+		}
+		if mt.Schema != nil && mt.Schema.Value != nil && mt.Schema.Value.Example != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPathItem checks the operations of pathItem, or only the one for
+// method when it is non-empty.
+func CheckPathItem(path string, pathItem *openapi3.PathItem, method string, reqs []Requirement) []Violation {
+	var violations []Violation
+	for _, m := range model.MethodOrder {
+		if method != "" && m != method {
+			continue
+		}
+		if op := pathItem.GetOperation(m); op != nil {
+			violations = append(violations, Check(m, path, op, reqs)...)
+		}
+	}
+	return violations
+}
+
+// Error reports the violations of a failed policy check.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "documentation policy failed with %d problem(s):", len(e.Violations))
+	for _, v := range e.Violations {
+		fmt.Fprintf(&b, "\n  - %s", v)
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestParseRequirements(t *testing.T) {
+	reqs, err := ParseRequirements(" summary, 4XX-response ,,request-example")
+	if err != nil {
+		t.Fatalf("ParseRequirements() error = %v", err)
+	}
+	want := []Requirement{RequireSummary, RequireErrorResponse, RequireRequestExample}
+	if len(reqs) != len(want) {
+		t.Fatalf("ParseRequirements() = %v, want %v", reqs, want)
+	}
+	for i := range want {
+		if reqs[i] != want[i] {
+			t.Errorf("requirement %d = %q, want %q", i, reqs[i], want[i])
+		}
+	}
+
+	if _, err := ParseRequirements("summary,changelog"); err == nil || !strings.Contains(err.Error(), "changelog") {
+		t.Errorf("Expected error naming the unknown requirement, got %v", err)
+	}
+}
+
+func TestCheckPathItem(t *testing.T) {
+	body := openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema())
+
+	documented := &openapi3.Operation{
+		Summary:     "Create item",
+		OperationID: "createItem",
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+			WithContent(openapi3.Content{"application/json": &openapi3.MediaType{
+				Schema:  body.NewRef(),
+				Example: map[string]any{"name": "widget"},
+			}})},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(201, &openapi3.ResponseRef{Value: openapi3.NewResponse().
+				WithDescription("Created").
+				WithContent(openapi3.NewContentWithJSONSchema(body))}),
+			openapi3.WithName("4XX", openapi3.NewResponse().WithDescription("Client error")),
+		),
+	}
+
+	bare := &openapi3.Operation{
+		RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().
+			WithJSONSchema(body)},
+		Parameters: openapi3.Parameters{
+			{Value: openapi3.NewQueryParameter("dry_run").WithSchema(openapi3.NewBoolSchema())},
+		},
+		Responses: openapi3.NewResponses(
+			openapi3.WithStatus(200, &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("OK")}),
+		),
+	}
+
+	pathItem := &openapi3.PathItem{Post: documented, Put: bare}
+
+	tests := []struct {
+		name     string
+		method   string
+		reqs     []Requirement
+		expected []string
+	}{
+		{"documented operation passes defaults", "POST", DefaultRequirements, nil},
+		{"bare operation fails defaults", "PUT", DefaultRequirements, []string{
+			"PUT /items: missing summary [summary]",
+			"PUT /items: no 4xx error response documented [4xx-response]",
+			"PUT /items: request body has no example [request-example]",
+		}},
+		{"response example", "POST", []Requirement{RequireResponseExample}, []string{
+			"POST /items: response 201 has no example [response-example]",
+		}},
+		{"all methods", "", []Requirement{RequireOperationID, RequireParamDocs}, []string{
+			"PUT /items: missing operationId [operation-id]",
+			"PUT /items: query parameter `dry_run` has no description [parameter-descriptions]",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := CheckPathItem("/items", pathItem, tt.method, tt.reqs)
+			if len(violations) != len(tt.expected) {
+				t.Fatalf("CheckPathItem() = %v, want %v", violations, tt.expected)
+			}
+			for i, want := range tt.expected {
+				if got := violations[i].String(); got != want {
+					t.Errorf("violation %d = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Violations: []Violation{
+		{Method: "GET", Path: "/items", Requirement: RequireSummary, Message: "missing summary"},
+	}}
+	want := "documentation policy failed with 1 problem(s):\n  - GET /items: missing summary [summary]"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestConfigRequirements(t *testing.T) {
+	reqs, err := Config{}.Requirements()
+	if err != nil || len(reqs) != len(DefaultRequirements) {
+		t.Errorf("Expected default requirements for empty config, got %v, %v", reqs, err)
+	}
+
+	if _, err := (Config{Require: []Requirement{"summary", "examples"}}).Requirements(); err == nil {
+		t.Error("Expected error for unknown configured requirement")
+	}
+}
@@ -0,0 +1,459 @@
+// Package probe sends a request to a live server and compares the response
+// with the documented contract: its status, content type, and top-level
+// JSON fields. Differences are reported as drift.
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxBodySize bounds how much of a response body is read and compared.
+const maxBodySize = 1 << 20
+
+// Drift kinds.
+const (
+	// DriftStatus is a status code the operation doesn't document.
+	DriftStatus = "status"
+	// DriftContentType is a content type the response doesn't document.
+	DriftContentType = "content-type"
+	// DriftMissingField is a required top-level field absent from the body.
+	DriftMissingField = "missing-field"
+	// DriftUndocumentedField is a top-level field the schema doesn't declare.
+	DriftUndocumentedField = "undocumented-field"
+	// DriftFieldType is a top-level field whose JSON type contradicts the
+	// schema, or a body that is not the documented JSON type.
+	DriftFieldType = "field-type"
+)
+
+// Drift is a difference between the live response and the contract.
+type Drift struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// String formats the drift as a single line.
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: %s", d.Kind, d.Message)
+}
+
+// Request is the request a probe sends.
+type Request struct {
+	Method string
+	// Path is the documented path template, e.g. "/users/{id}".
+	Path string
+	// URL is the full request URL.
+	URL string
+	// Operation is the documented operation.
+	Operation *openapi3.Operation
+}
+
+// Result is the outcome of a probe.
+type Result struct {
+	Method      string  `json:"method"`
+	URL         string  `json:"url"`
+	Status      int     `json:"status"`
+	ContentType string  `json:"contentType,omitempty"`
+	Drift       []Drift `json:"drift"`
+}
+
+// IsSafe reports whether method is one probe sends without -allow-unsafe:
+// GET and HEAD.
+func IsSafe(method string) bool {
+	method = strings.ToUpper(method)
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// NewRequest resolves the operation of method at path, which is a
+// documented path template or a concrete path such as "/users/42", and the
+// URL to send it to. Path parameters left as templates are filled from
+// their examples. server is an absolute URL, an x-environment name to pick
+// a documented server by, or empty for the first documented server.
+func NewRequest(doc *openapi3.T, method, path, server string) (*Request, error) {
+	if doc.Paths == nil {
+		return nil, fmt.Errorf("OpenAPI document has no paths defined")
+	}
+	method = strings.ToUpper(method)
+
+	template, pathItem := matchPath(doc.Paths, path)
+	if pathItem == nil {
+		return nil, fmt.Errorf("endpoint not found: %s", path)
+	}
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return nil, fmt.Errorf("method '%s' not found for %s", method, template)
+	}
+
+	params := append(openapi3.Parameters{}, pathItem.Parameters...)
+	params = append(params, operation.Parameters...)
+	concrete, err := fillPath(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := doc.Servers
+	if len(pathItem.Servers) > 0 {
+		servers = pathItem.Servers
+	}
+	if operation.Servers != nil && len(*operation.Servers) > 0 {
+		servers = *operation.Servers
+	}
+	base, err := baseURL(servers, server)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{Method: method, Path: template, URL: strings.TrimSuffix(base, "/") + concrete, Operation: operation}, nil
+}
+
+// BodyContentType returns the first documented content type of the request
+// body, or the empty string if there is none.
+func (r *Request) BodyContentType() string {
+	if r.Operation.RequestBody == nil || r.Operation.RequestBody.Value == nil {
+		return ""
+	}
+	if types := sortedContentTypes(r.Operation.RequestBody.Value.Content); len(types) > 0 {
+		return types[0]
+	}
+	return ""
+}
+
+// matchPath finds the path item for path, either by its template or by
+// matching a concrete path against every template. Templates with fewer
+// parameters win, so "/users/me" matches itself before "/users/{id}".
+func matchPath(paths *openapi3.Paths, path string) (string, *openapi3.PathItem) {
+	if item := paths.Value(path); item != nil {
+		return path, item
+	}
+	if item := paths.Find(path); item != nil {
+		for _, template := range paths.InMatchingOrder() {
+			if paths.Value(template) == item {
+				return template, item
+			}
+		}
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestParams := "", -1
+	for _, template := range paths.InMatchingOrder() {
+		parts := strings.Split(strings.Trim(template, "/"), "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+		params := 0
+		for i, part := range parts {
+			if isTemplate(part) {
+				params++
+			} else if part != segments[i] {
+				params = -1
+				break
+			}
+		}
+		if params >= 0 && (bestParams < 0 || params < bestParams || params == bestParams && template < best) {
+			best, bestParams = template, params
+		}
+	}
+	if bestParams < 0 {
+		return "", nil
+	}
+	return best, paths.Value(best)
+}
+
+// isTemplate reports whether a path segment is a parameter template.
+func isTemplate(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// fillPath replaces the parameter templates left in path with the examples
+// of their parameters.
+func fillPath(path string, params openapi3.Parameters) (string, error) {
+	examples := make(map[string]string)
+	for _, ref := range params {
+		if ref != nil && ref.Value != nil && ref.Value.In == openapi3.ParameterInPath {
+			examples[ref.Value.Name] = generator.ParameterExample(ref.Value)
+		}
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !isTemplate(segment) {
+			continue
+		}
+		name := segment[1 : len(segment)-1]
+		value := examples[name]
+		if value == "" {
+			return "", fmt.Errorf("path parameter {%s} has no example; pass the concrete path instead", name)
+		}
+		segments[i] = url.PathEscape(value)
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// baseURL picks the server to probe.
+func baseURL(servers openapi3.Servers, server string) (string, error) {
+	if strings.Contains(server, "://") {
+		return server, nil
+	}
+
+	filtered, err := generator.FilterServers(servers, server)
+	if err != nil {
+		return "", err
+	}
+	if len(filtered) == 0 || filtered[0] == nil {
+		return "", fmt.Errorf("the spec lists no servers; pass -server with a URL")
+	}
+
+	base := filtered[0].URL
+	for name, variable := range filtered[0].Variables {
+		if variable != nil {
+			base = strings.ReplaceAll(base, "{"+name+"}", variable.Default)
+		}
+	}
+	if u, err := url.Parse(base); err != nil || !u.IsAbs() {
+		return "", fmt.Errorf("server %s is not an absolute URL; pass -server with a URL", base)
+	}
+	return base, nil
+}
+
+// Do sends req with the given headers and body and compares the response
+// with the contract.
+func Do(ctx context.Context, client *http.Client, req *Request, header http.Header, body []byte) (*Result, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	for name, values := range header {
+		for _, value := range values {
+			httpReq.Header.Add(name, value)
+		}
+	}
+	if httpReq.Header.Get("User-Agent") == "" {
+		httpReq.Header.Set("User-Agent", "docfinder")
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	return &Result{
+		Method:      req.Method,
+		URL:         req.URL,
+		Status:      resp.StatusCode,
+		ContentType: contentType,
+		Drift:       Compare(req.Operation, resp.StatusCode, contentType, data),
+	}, nil
+}
+
+// Compare compares a response with the operation's documented responses.
+// body is not checked when it is empty, as for HEAD requests, and only its
+// content type is when it is larger than maxBodySize.
+func Compare(operation *openapi3.Operation, status int, contentType string, body []byte) []Drift {
+	var respRef *openapi3.ResponseRef
+	if operation.Responses != nil {
+		respRef = operation.Responses.Status(status)
+		if respRef == nil {
+			respRef = operation.Responses.Default()
+		}
+	}
+	if respRef == nil || respRef.Value == nil {
+		var documented []string
+		if operation.Responses != nil {
+			for code := range operation.Responses.Map() {
+				documented = append(documented, code)
+			}
+		}
+		sort.Strings(documented)
+		return []Drift{{Kind: DriftStatus, Message: fmt.Sprintf("status %d is not documented (documented: %s)", status, strings.Join(documented, ", "))}}
+	}
+
+	content := respRef.Value.Content
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if len(content) == 0 {
+		if len(body) > 0 && mediaType != "" {
+			return []Drift{{Kind: DriftContentType, Message: fmt.Sprintf("status %d is documented without a body, got %s", status, mediaType)}}
+		}
+		return nil
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	documented, ok := matchContentType(content, mediaType)
+	if !ok {
+		return []Drift{{Kind: DriftContentType, Message: fmt.Sprintf("%s is not documented for status %d (documented: %s)",
+			orNone(mediaType), status, strings.Join(sortedContentTypes(content), ", "))}}
+	}
+	if len(body) > maxBodySize {
+		return nil
+	}
+	if media := content[documented]; media == nil || media.Schema == nil || media.Schema.Value == nil || !isJSON(mediaType) {
+		return nil
+	}
+	return compareJSON(content[documented].Schema.Value, body)
+}
+
+// matchContentType returns the documented content type matching mediaType,
+// exactly or through a "type/*" or "*/*" range.
+func matchContentType(content openapi3.Content, mediaType string) (string, bool) {
+	for _, candidate := range []string{mediaType, typeRange(mediaType), "*/*"} {
+		for documented := range content {
+			if base, _, err := mime.ParseMediaType(documented); err == nil && strings.EqualFold(base, candidate) {
+				return documented, true
+			}
+		}
+	}
+	return "", false
+}
+
+func typeRange(mediaType string) string {
+	major, _, _ := strings.Cut(mediaType, "/")
+	return major + "/*"
+}
+
+func isJSON(mediaType string) bool {
+	return strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json")
+}
+
+func orNone(mediaType string) string {
+	if mediaType == "" {
+		return "no content type"
+	}
+	return mediaType
+}
+
+func sortedContentTypes(content openapi3.Content) []string {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// compareJSON compares the top-level fields of a JSON body with schema.
+func compareJSON(schema *openapi3.Schema, body []byte) []Drift {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []Drift{{Kind: DriftFieldType, Message: fmt.Sprintf("body is not valid JSON: %v", err)}}
+	}
+
+	properties, required := topLevelFields(schema)
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		if expected := schemaTypes(schema); len(expected) > 0 && !typeMatches(value, schema) {
+			return []Drift{{Kind: DriftFieldType, Message: fmt.Sprintf("body is %s, documented %s", model.JSONType(value), strings.Join(expected, " or "))}}
+		}
+		return nil
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+
+	var drift []Drift
+	for _, name := range sortedKeys(required) {
+		if _, ok := object[name]; !ok {
+			drift = append(drift, Drift{Kind: DriftMissingField, Message: fmt.Sprintf("required field %q is missing", name)})
+		}
+	}
+	for _, name := range sortedKeys(object) {
+		prop, ok := properties[name]
+		if !ok {
+			drift = append(drift, Drift{Kind: DriftUndocumentedField, Message: fmt.Sprintf("field %q is not documented", name)})
+			continue
+		}
+		if expected := schemaTypes(prop); len(expected) > 0 && !typeMatches(object[name], prop) {
+			drift = append(drift, Drift{Kind: DriftFieldType, Message: fmt.Sprintf("field %q is %s, documented %s", name, model.JSONType(object[name]), strings.Join(expected, " or "))})
+		}
+	}
+	return drift
+}
+
+// topLevelFields returns the properties of schema and its allOf members,
+// and the required ones that may appear in a response.
+func topLevelFields(schema *openapi3.Schema) (map[string]*openapi3.Schema, map[string]bool) {
+	properties := make(map[string]*openapi3.Schema)
+	required := make(map[string]bool)
+
+	var collect func(s *openapi3.Schema, depth int)
+	collect = func(s *openapi3.Schema, depth int) {
+		if s == nil || depth > generator.MaxRecursionDepth {
+			return
+		}
+		for name, ref := range s.Properties {
+			if ref != nil && ref.Value != nil {
+				properties[name] = ref.Value
+			}
+		}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+		for _, member := range s.AllOf {
+			if member != nil {
+				collect(member.Value, depth+1)
+			}
+		}
+	}
+	collect(schema, 0)
+
+	for name := range required {
+		if prop, ok := properties[name]; ok && prop.WriteOnly {
+			delete(required, name)
+		}
+	}
+	return properties, required
+}
+
+// schemaTypes returns the JSON types schema allows, or nil for any.
+func schemaTypes(schema *openapi3.Schema) []string {
+	if schema.Type == nil {
+		return nil
+	}
+	return schema.Type.Slice()
+}
+
+// typeMatches reports whether value has one of the types schema allows.
+func typeMatches(value any, schema *openapi3.Schema) bool {
+	if value == nil {
+		return schema.Nullable || schema.Type.Includes(openapi3.TypeNull)
+	}
+	actual := model.JSONType(value)
+	for _, t := range schemaTypes(schema) {
+		if model.TypeAllows(t, actual) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,186 @@
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com
+    x-environment: prod
+  - url: https://{region}.staging.example.com
+    x-environment: staging
+    variables:
+      region:
+        default: eu
+paths:
+  /users/me:
+    get:
+      responses:
+        "200":
+          description: OK
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            example: u_1
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, name]
+                properties:
+                  id:
+                    type: string
+                  age:
+                    type: integer
+                    nullable: true
+                allOf:
+                  - properties:
+                      name:
+                        type: string
+        "404":
+          description: Missing
+        5XX:
+          description: Outage
+    delete:
+      responses:
+        "204":
+          description: Deleted
+`
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestNewRequest(t *testing.T) {
+	doc := loadTestSpec(t)
+
+	tests := []struct {
+		name, path, server string
+		wantPath, wantURL  string
+	}{
+		{"Template", "/users/{id}", "", "/users/{id}", "https://api.example.com/users/u_1"},
+		{"Concrete", "/users/42", "", "/users/{id}", "https://api.example.com/users/42"},
+		{"Literal wins", "/users/me", "", "/users/me", "https://api.example.com/users/me"},
+		{"Environment", "/users/42", "staging", "/users/{id}", "https://eu.staging.example.com/users/42"},
+		{"URL", "/users/42", "http://localhost:8080/", "/users/{id}", "http://localhost:8080/users/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := NewRequest(doc, "get", tt.path, tt.server)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.Path != tt.wantPath || req.URL != tt.wantURL {
+				t.Errorf("NewRequest() = %s %s, want %s %s", req.Path, req.URL, tt.wantPath, tt.wantURL)
+			}
+		})
+	}
+
+	for _, path := range []string{"/orders", "/users/42/posts"} {
+		if _, err := NewRequest(doc, "GET", path, ""); err == nil {
+			t.Errorf("Expected an error for %s", path)
+		}
+	}
+	if _, err := NewRequest(doc, "GET", "/users/42", "dev"); err == nil {
+		t.Error("Expected an error for an unknown environment")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	doc := loadTestSpec(t)
+	operation := doc.Paths.Value("/users/{id}").Get
+
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		want        []string
+	}{
+		{"Matches", 200, "application/json; charset=utf-8", `{"id": "u_1", "name": "Ada", "age": null}`, nil},
+		{"Range", 503, "", "", nil},
+		{"Undocumented status", 409, "application/json", `{}`, []string{"status: status 409 is not documented (documented: 200, 404, 5XX)"}},
+		{"Content type", 200, "text/html", "<html>", []string{"content-type: text/html is not documented for status 200 (documented: application/json)"}},
+		{"Unexpected body", 404, "application/json", `{"error": "gone"}`, []string{"content-type: status 404 is documented without a body, got application/json"}},
+		{"Fields", 200, "application/json", `{"id": 7, "age": 1.5, "email": "a@example.com"}`, []string{
+			`missing-field: required field "name" is missing`,
+			`field-type: field "age" is number, documented integer`,
+			`undocumented-field: field "email" is not documented`,
+			`field-type: field "id" is integer, documented string`,
+		}},
+		{"Not an object", 200, "application/json", `[1, 2]`, []string{"field-type: body is array, documented object"}},
+		{"Invalid JSON", 200, "application/json", `{`, []string{"field-type: body is not valid JSON: unexpected end of JSON input"}},
+		{"HEAD", 200, "application/json", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, drift := range Compare(operation, tt.status, tt.contentType, []byte(tt.body)) {
+				got = append(got, drift.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Compare() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "` + strings.TrimPrefix(r.URL.Path, "/users/") + `", "name": "Ada"}`))
+	}))
+	defer server.Close()
+
+	doc := loadTestSpec(t)
+	req, err := NewRequest(doc, "GET", "/users/42", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Do(context.Background(), server.Client(), req, http.Header{"Authorization": {"Bearer secret"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != http.StatusOK || result.ContentType != "application/json" || len(result.Drift) != 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	result, err = Do(context.Background(), server.Client(), req, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Drift) != 1 || result.Drift[0].Kind != DriftStatus {
+		t.Errorf("Expected the undocumented 401 as drift, got %+v", result.Drift)
+	}
+}
@@ -0,0 +1,104 @@
+// Package returns finds the operations whose success responses return a
+// named schema, answering "which call gives me an X".
+package returns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+)
+
+// Match is a success response of an operation returning the schema.
+type Match struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Status      string `json:"status"`
+	ContentType string `json:"contentType"`
+	// Via is how the response schema reaches the named one, e.g.
+	// "items" for an array of it or "allOf" for a composition, joined by
+	// " > " when nested; it is empty when the response is the schema itself.
+	Via string `json:"via,omitempty"`
+}
+
+// Find returns the success responses of the operations of api whose schema
+// is the schema named name, an array of it, or a composition including it,
+// ordered by path, method, status, and content type.
+func Find(api *model.API, name string) []Match {
+	matches := []Match{}
+	for _, op := range api.Operations {
+		for _, resp := range op.Responses {
+			if !strings.HasPrefix(resp.Status, "2") {
+				continue
+			}
+			for _, media := range resp.Content {
+				via, ok := reaches(media.Schema, name, make(map[*model.Schema]bool))
+				if !ok {
+					continue
+				}
+				matches = append(matches, Match{
+					Method:      op.Method,
+					Path:        op.Path,
+					OperationID: op.OperationID,
+					Summary:     op.Summary,
+					Status:      resp.Status,
+					ContentType: media.ContentType,
+					Via:         strings.Join(via, " > "),
+				})
+			}
+		}
+	}
+	return matches
+}
+
+// reaches reports whether schema is the schema named name or leads to it
+// through array items or allOf, oneOf, or anyOf, returning the steps taken.
+// Properties are not followed: an object with an X field doesn't return an X.
+func reaches(schema *model.Schema, name string, visited map[*model.Schema]bool) ([]string, bool) {
+	if schema == nil || visited[schema] {
+		return nil, false
+	}
+	if schema.Name == name {
+		return nil, true
+	}
+	visited[schema] = true
+
+	if via, ok := reaches(schema.Items, name, visited); ok {
+		return append([]string{"items"}, via...), true
+	}
+	for _, composition := range []struct {
+		keyword string
+		schemas []*model.Schema
+	}{{"allOf", schema.AllOf}, {"oneOf", schema.OneOf}, {"anyOf", schema.AnyOf}} {
+		for _, sub := range composition.schemas {
+			if via, ok := reaches(sub, name, visited); ok {
+				return append([]string{composition.keyword}, via...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Format renders the matches for the schema name as a markdown table.
+func Format(name string, matches []Match) string {
+	var md strings.Builder
+	fmt.Fprintf(&md, "# Operations returning `%s`\n\n", name)
+	if len(matches) == 0 {
+		md.WriteString("No operation returns it in a success response.\n")
+		return md.String()
+	}
+
+	md.WriteString("| Operation | Status | Content Type | Via | Summary |\n")
+	md.WriteString("|---|---|---|---|---|\n")
+	for _, m := range matches {
+		via := m.Via
+		if via == "" {
+			via = "direct"
+		}
+		summary, _, _ := strings.Cut(m.Summary, "\n")
+		fmt.Fprintf(&md, "| `%s %s` | %s | %s | %s | %s |\n", m.Method, m.Path, m.Status, m.ContentType, via, summary)
+	}
+	return md.String()
+}
@@ -0,0 +1,117 @@
+package returns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func testAPI(t *testing.T) *model.API {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+components:
+  schemas:
+    Event:
+      type: object
+      properties:
+        id: {type: string}
+        parent: {$ref: '#/components/schemas/Event'}
+    EventList:
+      type: array
+      items: {$ref: '#/components/schemas/Event'}
+    Page:
+      type: object
+      properties:
+        next: {type: string}
+    Audit:
+      type: object
+      properties:
+        event: {$ref: '#/components/schemas/Event'}
+paths:
+  /events:
+    get:
+      summary: List events
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/EventList'}
+    post:
+      operationId: createEvent
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Event'}
+        '400':
+          description: Invalid
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Event'}
+  /events/search:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                allOf:
+                  - {$ref: '#/components/schemas/Page'}
+                  - oneOf:
+                      - {$ref: '#/components/schemas/EventList'}
+  /audits:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Audit'}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model.FromOpenAPI(doc)
+}
+
+func TestFind(t *testing.T) {
+	var got []string
+	for _, m := range Find(testAPI(t), "Event") {
+		got = append(got, m.Method+" "+m.Path+" "+m.Status+" "+m.ContentType+" via "+m.Via)
+	}
+	expected := []string{
+		"GET /events 200 application/json via items",
+		"POST /events 201 application/json via ",
+		"GET /events/search 200 application/json via allOf > oneOf > items",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Find() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if matches := Find(testAPI(t), "EventList"); len(matches) != 2 || matches[0].Via != "" || matches[1].Via != "allOf > oneOf" {
+		t.Errorf("Find(EventList) = %+v", matches)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	out := Format("Event", Find(testAPI(t), "Event"))
+	for _, s := range []string{
+		"# Operations returning `Event`",
+		"| `GET /events` | 200 | application/json | items | List events |",
+		"| `POST /events` | 201 | application/json | direct |  |",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %q in output:\n%s", s, out)
+		}
+	}
+
+	if out := Format("Page", nil); !strings.Contains(out, "No operation returns it") {
+		t.Errorf("Format() with no matches =\n%s", out)
+	}
+}
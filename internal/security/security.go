@@ -0,0 +1,293 @@
+// Package security documents the security schemes of a spec: how clients
+// authenticate with each, the OAuth2 flows and scopes it offers, and which
+// operations require it.
+package security
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// flowOrder lists the OAuth2 flows in the order they are documented, with
+// their names.
+var flowOrder = []struct{ key, name string }{
+	{"authorizationCode", "Authorization Code"},
+	{"clientCredentials", "Client Credentials"},
+	{"implicit", "Implicit"},
+	{"password", "Password"},
+}
+
+// Scheme is a security scheme and the operations using it.
+type Scheme struct {
+	Name string `json:"name"`
+	// Type is apiKey, http, oauth2, openIdConnect, or mutualTLS. It is
+	// empty for schemes operations name that components.securitySchemes
+	// doesn't define.
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// In and ParamName locate the key of an apiKey scheme.
+	In        string `json:"in,omitempty"`
+	ParamName string `json:"paramName,omitempty"`
+	// HTTPScheme and BearerFormat describe an http scheme, e.g. "bearer"
+	// and "JWT".
+	HTTPScheme   string `json:"httpScheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	// OpenIDConnectURL is the discovery document of an openIdConnect
+	// scheme.
+	OpenIDConnectURL string `json:"openIdConnectUrl,omitempty"`
+	Flows            []Flow `json:"flows,omitempty"`
+	// Operations are the operations whose security requirements name the
+	// scheme, ordered by path and method.
+	Operations []Usage `json:"operations"`
+}
+
+// Flow is an OAuth2 flow of a scheme.
+type Flow struct {
+	// Type is authorizationCode, clientCredentials, implicit, or password.
+	Type             string  `json:"type"`
+	AuthorizationURL string  `json:"authorizationUrl,omitempty"`
+	TokenURL         string  `json:"tokenUrl,omitempty"`
+	RefreshURL       string  `json:"refreshUrl,omitempty"`
+	Scopes           []Scope `json:"scopes"`
+}
+
+// Scope is an OAuth2 scope and its description.
+type Scope struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Usage is an operation using a scheme.
+type Usage struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Scopes are the scopes the operation asks for.
+	Scopes []string `json:"scopes,omitempty"`
+	// Optional reports whether the operation also accepts a requirement
+	// without the scheme, such as another scheme or no credentials.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// Analyze returns the schemes defined in doc and those its operations name,
+// sorted by name.
+func Analyze(doc *openapi3.T) []Scheme {
+	byName := make(map[string]*Scheme)
+	if doc.Components != nil {
+		for name, ref := range doc.Components.SecuritySchemes {
+			if ref != nil && ref.Value != nil {
+				byName[name] = newScheme(name, ref.Value)
+			}
+		}
+	}
+
+	if doc.Paths != nil {
+		paths := doc.Paths.InMatchingOrder()
+		sort.Strings(paths)
+		for _, path := range paths {
+			item := doc.Paths.Value(path)
+			if item == nil {
+				continue
+			}
+			for _, method := range model.MethodOrder {
+				op := item.GetOperation(method)
+				if op == nil {
+					continue
+				}
+				security := doc.Security
+				if op.Security != nil {
+					security = *op.Security
+				}
+				addUsages(byName, method, path, security)
+			}
+		}
+	}
+
+	schemes := make([]Scheme, 0, len(byName))
+	for _, s := range byName {
+		schemes = append(schemes, *s)
+	}
+	sort.Slice(schemes, func(i, j int) bool { return schemes[i].Name < schemes[j].Name })
+	return schemes
+}
+
+// newScheme returns the documentation of a defined scheme.
+func newScheme(name string, v *openapi3.SecurityScheme) *Scheme {
+	s := &Scheme{
+		Name:             name,
+		Type:             v.Type,
+		Description:      v.Description,
+		In:               v.In,
+		ParamName:        v.Name,
+		HTTPScheme:       v.Scheme,
+		BearerFormat:     v.BearerFormat,
+		OpenIDConnectURL: v.OpenIdConnectUrl,
+		Operations:       []Usage{},
+	}
+	if v.Flows == nil {
+		return s
+	}
+
+	flows := map[string]*openapi3.OAuthFlow{
+		"authorizationCode": v.Flows.AuthorizationCode,
+		"clientCredentials": v.Flows.ClientCredentials,
+		"implicit":          v.Flows.Implicit,
+		"password":          v.Flows.Password,
+	}
+	for _, f := range flowOrder {
+		flow := flows[f.key]
+		if flow == nil {
+			continue
+		}
+		out := Flow{
+			Type:             f.key,
+			AuthorizationURL: flow.AuthorizationURL,
+			TokenURL:         flow.TokenURL,
+			RefreshURL:       flow.RefreshURL,
+			Scopes:           []Scope{},
+		}
+		names := make([]string, 0, len(flow.Scopes))
+		for scope := range flow.Scopes {
+			names = append(names, scope)
+		}
+		sort.Strings(names)
+		for _, scope := range names {
+			out.Scopes = append(out.Scopes, Scope{Name: scope, Description: flow.Scopes[scope]})
+		}
+		s.Flows = append(s.Flows, out)
+	}
+	return s
+}
+
+// addUsages records the operation method path under every scheme its
+// security requirements name.
+func addUsages(byName map[string]*Scheme, method, path string, security openapi3.SecurityRequirements) {
+	// Schemes named by every requirement are required; others are optional
+	count := make(map[string]int)
+	scopes := make(map[string][]string)
+	var names []string
+	for _, requirement := range security {
+		for name, requirementScopes := range requirement {
+			if count[name] == 0 {
+				names = append(names, name)
+			}
+			count[name]++
+			for _, scope := range requirementScopes {
+				if !slices.Contains(scopes[name], scope) {
+					scopes[name] = append(scopes[name], scope)
+				}
+			}
+		}
+	}
+
+	for _, name := range names {
+		s, ok := byName[name]
+		if !ok {
+			s = &Scheme{Name: name, Operations: []Usage{}}
+			byName[name] = s
+		}
+		sort.Strings(scopes[name])
+		s.Operations = append(s.Operations, Usage{Method: method, Path: path, Scopes: scopes[name], Optional: count[name] < len(security)})
+	}
+}
+
+// Format renders schemes as markdown, one section per scheme.
+func Format(schemes []Scheme) string {
+	var md strings.Builder
+	md.WriteString("# Security Schemes\n\n")
+	if len(schemes) == 0 {
+		md.WriteString("No security schemes are defined or used.\n")
+		return md.String()
+	}
+
+	for _, s := range schemes {
+		fmt.Fprintf(&md, "## %s\n\n", s.Name)
+		fmt.Fprintf(&md, "**Type:** %s\n\n", typeLine(s))
+		if s.Description != "" {
+			fmt.Fprintf(&md, "%s\n\n", strings.TrimSpace(s.Description))
+		}
+
+		for _, flow := range s.Flows {
+			fmt.Fprintf(&md, "### %s Flow\n\n", flowName(flow.Type))
+			var urls strings.Builder
+			for _, u := range []struct{ label, url string }{
+				{"Authorization URL", flow.AuthorizationURL},
+				{"Token URL", flow.TokenURL},
+				{"Refresh URL", flow.RefreshURL},
+			} {
+				if u.url != "" {
+					fmt.Fprintf(&urls, "- **%s:** %s\n", u.label, u.url)
+				}
+			}
+			if urls.Len() > 0 {
+				md.WriteString(urls.String() + "\n")
+			}
+			if len(flow.Scopes) > 0 {
+				md.WriteString("| Scope | Description |\n|---|---|\n")
+				for _, scope := range flow.Scopes {
+					fmt.Fprintf(&md, "| `%s` | %s |\n", scope.Name, strings.ReplaceAll(scope.Description, "|", "\\|"))
+				}
+				md.WriteString("\n")
+			}
+		}
+
+		md.WriteString("**Operations:**\n\n")
+		if len(s.Operations) == 0 {
+			md.WriteString("None.\n\n")
+			continue
+		}
+		for _, u := range s.Operations {
+			fmt.Fprintf(&md, "- `%s %s`", u.Method, u.Path)
+			var notes []string
+			if len(u.Scopes) > 0 {
+				notes = append(notes, "scopes: "+strings.Join(u.Scopes, ", "))
+			}
+			if u.Optional {
+				notes = append(notes, "optional")
+			}
+			if len(notes) > 0 {
+				fmt.Fprintf(&md, " (%s)", strings.Join(notes, "; "))
+			}
+			md.WriteString("\n")
+		}
+		md.WriteString("\n")
+	}
+	return md.String()
+}
+
+// typeLine describes how clients authenticate with s.
+func typeLine(s Scheme) string {
+	switch s.Type {
+	case "":
+		return "undefined (not in `components.securitySchemes`)"
+	case "apiKey":
+		return fmt.Sprintf("API key in %s `%s`", s.In, s.ParamName)
+	case "http":
+		line := fmt.Sprintf("HTTP `%s`", s.HTTPScheme)
+		if s.BearerFormat != "" {
+			line += fmt.Sprintf(" (%s)", s.BearerFormat)
+		}
+		return line
+	case "oauth2":
+		return "OAuth 2.0"
+	case "openIdConnect":
+		return "OpenID Connect, discovery at " + s.OpenIDConnectURL
+	case "mutualTLS":
+		return "Mutual TLS"
+	}
+	return s.Type
+}
+
+// flowName returns the display name of an OAuth2 flow type.
+func flowName(flowType string) string {
+	for _, f := range flowOrder {
+		if f.key == flowType {
+			return f.name
+		}
+	}
+	return flowType
+}
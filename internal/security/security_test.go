@@ -0,0 +1,134 @@
+package security
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const securitySpec = `
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+security:
+  - oauth: [events:read]
+components:
+  securitySchemes:
+    oauth:
+      type: oauth2
+      description: Tokens from the identity service.
+      flows:
+        clientCredentials:
+          tokenUrl: https://auth.example.com/token
+          scopes:
+            events:write: Create events
+            events:read: Read events
+        authorizationCode:
+          authorizationUrl: https://auth.example.com/authorize
+          tokenUrl: https://auth.example.com/token
+          refreshUrl: https://auth.example.com/refresh
+          scopes:
+            events:read: Read events
+    key:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    bearer:
+      type: http
+      scheme: bearer
+      bearerFormat: JWT
+paths:
+  /events:
+    get:
+      responses: {'200': {description: OK}}
+    post:
+      security:
+        - oauth: [events:write, events:read]
+        - key: []
+      responses: {'201': {description: Created}}
+  /health:
+    get:
+      security: [{}, {legacy: []}]
+      responses: {'200': {description: OK}}
+`
+
+func TestAnalyze(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(securitySpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemes := Analyze(doc)
+
+	var names []string
+	for _, s := range schemes {
+		names = append(names, s.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"bearer", "key", "legacy", "oauth"}) {
+		t.Fatalf("Analyze() schemes = %v, want bearer, key, legacy, oauth", names)
+	}
+
+	bearer, key, legacy, oauth := schemes[0], schemes[1], schemes[2], schemes[3]
+	if bearer.Type != "http" || bearer.HTTPScheme != "bearer" || bearer.BearerFormat != "JWT" || len(bearer.Operations) != 0 {
+		t.Errorf("Unexpected bearer scheme: %+v", bearer)
+	}
+	if key.In != "header" || key.ParamName != "X-API-Key" ||
+		!reflect.DeepEqual(key.Operations, []Usage{{Method: "POST", Path: "/events", Optional: true}}) {
+		t.Errorf("Unexpected key scheme: %+v", key)
+	}
+	if legacy.Type != "" || !reflect.DeepEqual(legacy.Operations, []Usage{{Method: "GET", Path: "/health", Optional: true}}) {
+		t.Errorf("Unexpected undefined scheme: %+v", legacy)
+	}
+
+	expectedFlows := []Flow{
+		{
+			Type:             "authorizationCode",
+			AuthorizationURL: "https://auth.example.com/authorize",
+			TokenURL:         "https://auth.example.com/token",
+			RefreshURL:       "https://auth.example.com/refresh",
+			Scopes:           []Scope{{"events:read", "Read events"}},
+		},
+		{
+			Type:     "clientCredentials",
+			TokenURL: "https://auth.example.com/token",
+			Scopes:   []Scope{{"events:read", "Read events"}, {"events:write", "Create events"}},
+		},
+	}
+	if !reflect.DeepEqual(oauth.Flows, expectedFlows) {
+		t.Errorf("oauth flows = %+v, want %+v", oauth.Flows, expectedFlows)
+	}
+	expectedUsages := []Usage{
+		{Method: "GET", Path: "/events", Scopes: []string{"events:read"}},
+		{Method: "POST", Path: "/events", Scopes: []string{"events:read", "events:write"}, Optional: true},
+	}
+	if !reflect.DeepEqual(oauth.Operations, expectedUsages) {
+		t.Errorf("oauth operations = %+v, want %+v", oauth.Operations, expectedUsages)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(securitySpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := Format(Analyze(doc))
+
+	for _, s := range []string{
+		"# Security Schemes\n\n## bearer\n\n**Type:** HTTP `bearer` (JWT)\n\n**Operations:**\n\nNone.\n",
+		"## key\n\n**Type:** API key in header `X-API-Key`\n",
+		"- `POST /events` (optional)\n",
+		"**Type:** undefined (not in `components.securitySchemes`)",
+		"## oauth\n\n**Type:** OAuth 2.0\n\nTokens from the identity service.\n\n### Authorization Code Flow\n\n",
+		"- **Authorization URL:** https://auth.example.com/authorize\n- **Token URL:** https://auth.example.com/token\n- **Refresh URL:** https://auth.example.com/refresh\n",
+		"### Client Credentials Flow\n\n- **Token URL:** https://auth.example.com/token\n\n| Scope | Description |\n|---|---|\n| `events:read` | Read events |\n| `events:write` | Create events |\n",
+		"- `GET /events` (scopes: events:read)\n- `POST /events` (scopes: events:read, events:write; optional)\n",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("Expected %q in output:\n%s", s, md)
+		}
+	}
+
+	if got := Format(nil); !strings.Contains(got, "No security schemes are defined or used.") {
+		t.Errorf("Format(nil) = %q", got)
+	}
+}
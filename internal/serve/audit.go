@@ -0,0 +1,176 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntry is a line of the audit log: a request for documentation served
+// by a Server.
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	// Remote is the address the request came from, and User the requester
+	// named by the log's UserHeader, e.g. set by an authenticating proxy.
+	Remote string `json:"remote"`
+	User   string `json:"user,omitempty"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Endpoint is the spec path whose documentation was requested.
+	Endpoint string `json:"endpoint,omitempty"`
+	Status   int    `json:"status"`
+	// Spec and Version are the title and version of the spec read.
+	Spec    string `json:"spec,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// AuditLog writes an AuditEntry as a JSON line for every request a Server
+// handles.
+type AuditLog struct {
+	// UserHeader names the request header identifying the requester, if any.
+	UserHeader string
+	// OnError, if set, is called with the error of an entry that couldn't
+	// be recorded.
+	OnError func(err error)
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAuditLog returns an audit log writing to w.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+// Record appends e to the log.
+func (a *AuditLog) Record(e AuditEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// handler records every request next handles for s.
+func (a *AuditLog) handler(s *Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		e := AuditEntry{
+			Time:     time.Now().UTC(),
+			Remote:   r.RemoteAddr,
+			Method:   r.Method,
+			URL:      r.URL.RequestURI(),
+			Endpoint: requestedEndpoint(r),
+			Status:   rec.status,
+		}
+		if a.UserHeader != "" {
+			e.User = r.Header.Get(a.UserHeader)
+		}
+		e.Spec, e.Version = s.loadedVersion()
+		if err := a.Record(e); err != nil && a.OnError != nil {
+			a.OnError(err)
+		}
+	})
+}
+
+// requestedEndpoint returns the spec path whose documentation r requests,
+// or "" for the listing routes.
+func requestedEndpoint(r *http.Request) string {
+	if path := r.URL.Query().Get("path"); path != "" && r.URL.Path == DocRoute {
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		return path
+	}
+	if path, ok := strings.CutPrefix(r.URL.Path, DocsPrefix); ok && path != "" {
+		return "/" + path
+	}
+	return ""
+}
+
+// statusRecorder remembers the status written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// RotatingFile is an append-only file that is moved aside to the same path
+// with a ".1" suffix, replacing any earlier one, once a write would grow it
+// past a size.
+type RotatingFile struct {
+	path    string
+	maxSize int64
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// OpenRotatingFile opens the file at path for appending, creating it, and
+// rotates it once it would exceed maxSize bytes. A maxSize of 0 never
+// rotates it.
+func OpenRotatingFile(path string, maxSize int64) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+// Write appends p to the file, rotating it first if p would take it past
+// its size.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		// If the file can't be moved aside it keeps growing, rather than
+		// losing what is written to it
+		r.f.Close()
+		os.Rename(r.path, r.path+".1")
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
@@ -0,0 +1,309 @@
+// Package serve exposes the rendered documentation of a spec over HTTP, as
+// markdown pages and a JSON API, for internal tools and dashboards. A spec
+// opened lazily is only loaded while it is being read, so a long-running
+// server holds little memory for a spec nobody is reading.
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Routes served by Handler.
+const (
+	// DocsPrefix serves the markdown documentation of the endpoint path
+	// following it, e.g. /docs/events/{id}; /docs/ lists every operation.
+	DocsPrefix = "/docs/"
+	// EndpointsRoute lists every operation as JSON.
+	EndpointsRoute = "/api/endpoints"
+	// DocRoute serves the JSON document of the endpoint given by the path
+	// query parameter, optionally restricted to the method parameter.
+	DocRoute = "/api/doc"
+)
+
+// Endpoint is an operation listed by EndpointsRoute.
+type Endpoint struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	OperationID string   `json:"operationId,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+	// Docs and Doc are the URLs of the operation's markdown and JSON
+	// documentation.
+	Docs string `json:"docs"`
+	Doc  string `json:"doc"`
+}
+
+// Server renders the documentation of one spec on request.
+type Server struct {
+	// lazy is the spec of a server returned by NewLazy, and options returns
+	// the options to render a document of it with.
+	lazy    *spec.Lazy
+	options func(doc *openapi3.T) ([]generator.Option, error)
+	audit   *AuditLog
+
+	mu     sync.Mutex
+	loaded *loaded
+}
+
+// loaded is the document a server currently renders and what it renders it
+// with.
+type loaded struct {
+	doc  *openapi3.T
+	api  *model.API
+	gen  *generator.Generator
+	opts []generator.Option
+}
+
+func newLoaded(doc *openapi3.T, opts []generator.Option) *loaded {
+	return &loaded{doc: doc, api: model.FromOpenAPI(doc), gen: generator.New(doc), opts: opts}
+}
+
+// New returns a server for doc, rendering with opts.
+func New(doc *openapi3.T, opts ...generator.Option) *Server {
+	return &Server{loaded: newLoaded(doc, opts)}
+}
+
+// NewLazy returns a server for the spec l, which is loaded on the first
+// request and again after ReleaseIdle released it. Each document loaded is
+// rendered with the options returned by options, which may depend on it.
+func NewLazy(l *spec.Lazy, options func(doc *openapi3.T) ([]generator.Option, error)) *Server {
+	return &Server{lazy: l, options: options}
+}
+
+// load returns the document to render, loading it if needed.
+func (s *Server) load() (*loaded, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lazy == nil {
+		return s.loaded, nil
+	}
+	doc, err := s.lazy.Document()
+	if err != nil {
+		return nil, err
+	}
+	if s.loaded == nil || s.loaded.doc != doc {
+		opts, err := s.options(doc)
+		if err != nil {
+			return nil, err
+		}
+		s.loaded = newLoaded(doc, opts)
+	}
+	return s.loaded, nil
+}
+
+// SetAuditLog records every request the handler serves in log.
+func (s *Server) SetAuditLog(log *AuditLog) {
+	s.audit = log
+}
+
+// loadedVersion returns the title and version of the document loaded, if
+// any, without loading it.
+func (s *Server) loadedVersion() (string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaded == nil {
+		return "", ""
+	}
+	return s.loaded.api.Title, s.loaded.api.Version
+}
+
+// ReleaseIdle releases the document of a server returned by NewLazy if no
+// request has read it for idle, reporting whether it did.
+func (s *Server) ReleaseIdle(idle time.Duration) bool {
+	if s.lazy == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.lazy.ReleaseIdle(idle) {
+		return false
+	}
+	s.loaded = nil
+	return true
+}
+
+// Handler returns the HTTP handler serving the routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+DocsPrefix+"{path...}", s.serveDocs)
+	mux.HandleFunc("GET "+EndpointsRoute, s.serveEndpoints)
+	mux.HandleFunc("GET "+DocRoute, s.serveDoc)
+	mux.Handle("GET /{$}", http.RedirectHandler(DocsPrefix, http.StatusFound))
+	if s.audit != nil {
+		return s.audit.handler(s, mux)
+	}
+	return mux
+}
+
+// Endpoints returns every operation of the spec, ordered by path and method.
+func (s *Server) Endpoints() ([]Endpoint, error) {
+	l, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return l.endpoints(), nil
+}
+
+func (l *loaded) endpoints() []Endpoint {
+	endpoints := []Endpoint{}
+	for _, op := range l.api.Operations {
+		query := "?method=" + op.Method
+		endpoints = append(endpoints, Endpoint{
+			Method:      op.Method,
+			Path:        op.Path,
+			OperationID: op.OperationID,
+			Summary:     op.Summary,
+			Tags:        op.Tags,
+			Deprecated:  op.Deprecated,
+			Docs:        docsURL(op.Path) + query,
+			Doc:         DocRoute + "?path=" + url.QueryEscape(op.Path) + "&method=" + op.Method,
+		})
+	}
+	return endpoints
+}
+
+// braces restores the braces of templated path segments that PathEscape
+// escaped, to keep links such as /docs/events/{id} readable.
+var braces = strings.NewReplacer("%7B", "{", "%7D", "}")
+
+// docsURL returns the DocsPrefix URL of the endpoint path, escaping each
+// segment so paths with spaces, %, ?, or # still link to their docs.
+func docsURL(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = braces.Replace(url.PathEscape(segment))
+	}
+	return strings.TrimSuffix(DocsPrefix, "/") + strings.Join(segments, "/")
+}
+
+// serveDocs writes the markdown documentation of an endpoint, or the list of
+// operations for the bare prefix.
+func (s *Server) serveDocs(w http.ResponseWriter, r *http.Request) {
+	path := r.PathValue("path")
+	if path == "" {
+		s.serveIndex(w)
+		return
+	}
+
+	markdown, status, err := s.render("/"+path, r.URL.Query().Get("method"), generator.FormatMarkdown)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	fmt.Fprint(w, markdown)
+}
+
+// serveIndex writes a markdown list of the operations linking to their docs.
+func (s *Server) serveIndex(w http.ResponseWriter) {
+	l, err := s.load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var md strings.Builder
+	md.WriteString("# ")
+	if l.api.Title != "" {
+		md.WriteString(strings.TrimSpace(l.api.Title + " " + l.api.Version))
+	} else {
+		md.WriteString("API Endpoints")
+	}
+	md.WriteString("\n\n")
+	for _, e := range l.endpoints() {
+		label := e.Method + " " + e.Path
+		if e.Summary != "" {
+			label = e.Summary
+		}
+		fmt.Fprintf(&md, "- [%s](%s) `%s %s`\n", label, e.Docs, e.Method, e.Path)
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	fmt.Fprint(w, md.String())
+}
+
+func (s *Server) serveEndpoints(w http.ResponseWriter, r *http.Request) {
+	endpoints, err := s.Endpoints()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, endpoints)
+}
+
+// serveDoc writes the JSON document of the endpoint given by the path and
+// method query parameters.
+func (s *Server) serveDoc(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Query().Get("path")
+	if path == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "missing path parameter"})
+		return
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	document, status, err := s.render(path, r.URL.Query().Get("method"), generator.FormatJSONDocument)
+	if err != nil {
+		writeJSON(w, status, map[string]string{"error": err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, document)
+}
+
+// render renders the endpoint at path in format, returning the HTTP status
+// to respond with on error. Paths missing from the index of a lazily opened
+// spec are reported without loading it.
+func (s *Server) render(path, method string, format generator.Format) (string, int, error) {
+	if s.lazy != nil {
+		if _, ok := s.lazy.Methods(path); !ok {
+			return "", http.StatusNotFound, fmt.Errorf("endpoint not found: %s", path)
+		}
+	}
+	l, err := s.load()
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+
+	var pathItem *openapi3.PathItem
+	if l.doc.Paths != nil {
+		pathItem = l.doc.Paths.Find(path)
+	}
+	if pathItem == nil {
+		return "", http.StatusNotFound, fmt.Errorf("endpoint not found: %s", path)
+	}
+	method = strings.ToUpper(method)
+	if method != "" && pathItem.GetOperation(method) == nil {
+		return "", http.StatusNotFound, fmt.Errorf("method %s not defined for %s", method, path)
+	}
+
+	opts := append(append([]generator.Option{}, l.opts...), generator.WithMethod(method), generator.WithFormat(format))
+	out, err := l.gen.Generate(path, pathItem, opts...)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	return out, http.StatusOK, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
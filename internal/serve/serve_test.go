@@ -0,0 +1,254 @@
+package serve
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/generator"
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const serveSpec = `
+openapi: 3.0.3
+info: {title: Events API, version: 1.0.0}
+paths:
+  /events:
+    get:
+      summary: List events
+      operationId: listEvents
+      responses: {'200': {description: OK}}
+  /events/{id}:
+    get:
+      summary: Get an event
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses: {'200': {description: OK}}
+    delete:
+      deprecated: true
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses: {'204': {description: Deleted}}
+`
+
+func get(t *testing.T, server *httptest.Server, path string) (int, string, string) {
+	t.Helper()
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+}
+
+func TestHandler(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(serveSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(New(doc, generator.WithQuickReference(false)).Handler())
+	defer server.Close()
+
+	t.Run("Docs", func(t *testing.T) {
+		status, contentType, body := get(t, server, "/docs/events/%7Bid%7D?method=delete")
+		if status != http.StatusOK || !strings.HasPrefix(contentType, "text/markdown") {
+			t.Fatalf("GET /docs/events/{id} = %d %s:\n%s", status, contentType, body)
+		}
+		if !strings.Contains(body, "## DELETE /events/{id}") || strings.Contains(body, "Get an event") {
+			t.Errorf("Expected only DELETE documented:\n%s", body)
+		}
+	})
+
+	t.Run("Index", func(t *testing.T) {
+		_, _, body := get(t, server, "/")
+		for _, s := range []string{"# Events API 1.0.0\n", "- [List events](/docs/events?method=GET) `GET /events`\n"} {
+			if !strings.Contains(body, s) {
+				t.Errorf("Expected %q in index:\n%s", s, body)
+			}
+		}
+	})
+
+	t.Run("Endpoints", func(t *testing.T) {
+		status, _, body := get(t, server, EndpointsRoute)
+		var endpoints []Endpoint
+		if err := json.Unmarshal([]byte(body), &endpoints); err != nil || status != http.StatusOK {
+			t.Fatalf("GET %s = %d: %v\n%s", EndpointsRoute, status, err, body)
+		}
+		if len(endpoints) != 3 || endpoints[0].OperationID != "listEvents" || !endpoints[2].Deprecated {
+			t.Errorf("Unexpected endpoints: %+v", endpoints)
+		}
+		if doc := endpoints[1].Doc; doc != "/api/doc?path=%2Fevents%2F%7Bid%7D&method=GET" {
+			t.Errorf("Doc URL = %q", doc)
+		}
+	})
+
+	t.Run("Doc", func(t *testing.T) {
+		status, contentType, body := get(t, server, DocRoute+"?path="+url.QueryEscape("/events/{id}")+"&method=GET")
+		var document generator.JSONDocument
+		if err := json.Unmarshal([]byte(body), &document); err != nil || status != http.StatusOK || contentType != "application/json" {
+			t.Fatalf("GET %s = %d %s: %v\n%s", DocRoute, status, contentType, err, body)
+		}
+		if len(document.Operations) != 1 || document.Operations[0].Summary != "Get an event" {
+			t.Errorf("Unexpected document: %+v", document)
+		}
+	})
+
+	for _, tt := range []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/docs/missing", http.StatusNotFound, "endpoint not found: /missing"},
+		{DocRoute, http.StatusBadRequest, `"error": "missing path parameter"`},
+		{DocRoute + "?path=events&method=PUT", http.StatusNotFound, `"error": "method PUT not defined for /events"`},
+	} {
+		if status, _, body := get(t, server, tt.path); status != tt.status || !strings.Contains(body, tt.body) {
+			t.Errorf("GET %s = %d %q, want %d %q", tt.path, status, body, tt.status, tt.body)
+		}
+	}
+}
+
+func TestHandler_EscapedDocsURL(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Files API, version: 1.0.0}
+paths:
+  /files/{name}/50% off?#draft copy:
+    get:
+      summary: Get a draft copy
+      parameters:
+        - {name: name, in: path, required: true, schema: {type: string}}
+      responses: {'200': {description: OK}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(doc)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	endpoints, err := s.Endpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "/docs/files/{name}/50%25%20off%3F%23draft%20copy?method=GET"
+	if len(endpoints) != 1 || endpoints[0].Docs != want {
+		t.Fatalf("Docs URL = %+v, want %q", endpoints, want)
+	}
+	if status, _, body := get(t, server, want); status != http.StatusOK || !strings.Contains(body, "Get a draft copy") {
+		t.Errorf("GET %s = %d:\n%s", want, status, body)
+	}
+}
+
+func TestNewLazy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(serveSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lazy, err := spec.Open(path, spec.LoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loads := 0
+	s := NewLazy(lazy, func(*openapi3.T) ([]generator.Option, error) {
+		loads++
+		return []generator.Option{generator.WithQuickReference(false)}, nil
+	})
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	if status, _, _ := get(t, server, "/docs/missing"); status != http.StatusNotFound || lazy.Loaded() {
+		t.Errorf("GET /docs/missing = %d, loaded %v; want 404 without loading", status, lazy.Loaded())
+	}
+	if status, _, body := get(t, server, "/docs/events"); status != http.StatusOK || !strings.Contains(body, "List events") {
+		t.Fatalf("GET /docs/events = %d:\n%s", status, body)
+	}
+	get(t, server, EndpointsRoute)
+	if loads != 1 {
+		t.Errorf("Expected the spec loaded once, got %d loads", loads)
+	}
+
+	if !s.ReleaseIdle(0) || lazy.Loaded() {
+		t.Fatal("Expected the idle spec to be released")
+	}
+	if status, _, _ := get(t, server, "/docs/events"); status != http.StatusOK || loads != 2 {
+		t.Errorf("GET /docs/events after release = %d with %d loads, want 200 after reloading", status, loads)
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(serveSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	log := NewAuditLog(&buf)
+	log.UserHeader = "X-Forwarded-User"
+	s := New(doc)
+	s.SetAuditLog(log)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/docs/events/%7Bid%7D?method=GET", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Forwarded-User", "alice")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	get(t, server, DocRoute+"?path=missing")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 audit entries, got:\n%s", buf.String())
+	}
+	var entries [2]AuditEntry
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &entries[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if e := entries[0]; e.User != "alice" || e.Endpoint != "/events/{id}" || e.Status != http.StatusOK ||
+		e.Spec != "Events API" || e.Version != "1.0.0" || e.Remote == "" || e.Time.IsZero() {
+		t.Errorf("Unexpected entry: %+v", e)
+	}
+	if e := entries[1]; e.User != "" || e.Endpoint != "/missing" || e.Status != http.StatusNotFound {
+		t.Errorf("Unexpected entry: %+v", e)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := OpenRotatingFile(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for file, want := range map[string]string{path: "third\n", path + ".1": "second\n"} {
+		if data, err := os.ReadFile(file); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", filepath.Base(file), data, err, want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package spec
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultFetchTimeout bounds fetching a spec URL when no timeout is configured.
+const DefaultFetchTimeout = 30 * time.Second
+
+// DefaultFetchRetries is how many times a spec fetch failing with a
+// transient error is retried when no count is configured.
+const DefaultFetchRetries = 3
+
+// DefaultFetchBackoff is the delay before the first retry of a spec fetch
+// when none is configured. It doubles with each retry.
+const DefaultFetchBackoff = 500 * time.Millisecond
+
+// FetchOptions configures fetching a spec from an HTTP(S) URL.
+type FetchOptions struct {
+	// Timeout bounds the request, redirects included. Zero means
+	// DefaultFetchTimeout.
+	Timeout time.Duration `yaml:"timeout"`
+	// Headers are sent with the request, e.g. for credentials. Values are
+	// expanded from the environment, so "Bearer ${SPEC_TOKEN}" keeps the
+	// token out of the configuration file. They are only sent to the spec's
+	// host, including when fetching its relative refs.
+	Headers map[string]string `yaml:"headers"`
+	// Insecure skips verifying the server's TLS certificate.
+	Insecure bool `yaml:"insecure"`
+	// Retries is how many times a fetch failing with a transient error,
+	// such as a timeout or a 503 status, is retried. Zero means
+	// DefaultFetchRetries; negative disables retrying.
+	Retries int `yaml:"retries"`
+	// Backoff is the delay before the first retry, doubling with each
+	// retry. A longer Retry-After from the server takes precedence. Zero
+	// means DefaultFetchBackoff. The waits between retries add up to at
+	// most Timeout: a fetch that would have to wait longer fails instead.
+	Backoff time.Duration `yaml:"backoff"`
+}
+
+// IsURL reports whether location is an HTTP(S) URL rather than a file path.
+func IsURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// LoadURL fetches and parses the OpenAPI document at an HTTP(S) URL,
+// following redirects and retrying transient failures. A copy of the spec
+// is kept in the ref cache and revalidated with its ETag or Last-Modified
+// date, so unchanged specs aren't downloaded again. Proxies are taken from
+// the HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables. Relative
+// $refs resolve against the URL the document was served from, and may be
+// fetched from its host even when the ref allowlist names other hosts.
+func LoadURL(rawURL string, opts LoadOptions) (*openapi3.T, error) {
+	if !IsURL(rawURL) {
+		return nil, fmt.Errorf("invalid spec URL: %s", rawURL)
+	}
+	if opts.Refs.Offline {
+		return nil, fmt.Errorf("offline mode: cannot fetch spec %s", rawURL)
+	}
+	location, _ := url.Parse(rawURL)
+
+	transport := opts.Fetch.transport(location.Hostname())
+	client := &http.Client{Timeout: opts.Fetch.timeout(), Transport: transport}
+	limits := opts.Refs.limits(false)
+	data, served, err := opts.Fetch.fetchSpec(client, location, limits, opts.Refs.cachePath(location))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenAPI spec %s: %w", rawURL, err)
+	}
+	if err := limits.add(served, data); err != nil {
+		return nil, err
+	}
+
+	refs := opts.Refs
+	if hosts, _ := refs.splitAllow(); len(hosts) > 0 {
+		refs.Allow = append(refs.Allow[:len(refs.Allow):len(refs.Allow)], served.Hostname())
+	}
+
+	src := newSources()
+	src.record(served, data)
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = refs.reader("", refs.client(transport), limits, src)
+	if isSwagger2(data) {
+		return loadSwagger2(loader, data, served)
+	}
+
+	doc, err := loader.LoadFromDataWithPath(data, served)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+
+	recordPropertyOrder(doc, served, src)
+	return doc, nil
+}
+
+// cachedSpec holds the validators of a spec kept in the cache.
+type cachedSpec struct {
+	// URL is where the spec was served from after redirects.
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// fetchSpec fetches the spec at location, retrying transient failures. If
+// cachePath holds a copy with validators, the request is conditional and
+// a 304 Not Modified response returns the copy.
+func (f FetchOptions) fetchSpec(client *http.Client, location *url.URL, limits *loadLimits, cachePath string) ([]byte, *url.URL, error) {
+	var cached *cachedSpec
+	var cachedData []byte
+	if cachePath != "" {
+		if meta, err := os.ReadFile(cachePath + ".json"); err == nil && json.Unmarshal(meta, &cached) == nil {
+			if cachedData, err = os.ReadFile(cachePath); err != nil {
+				cached = nil
+			}
+		}
+	}
+
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, location.String(), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cached != nil {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+
+		resp, err := client.Do(req)
+		if err == nil {
+			if resp.StatusCode == http.StatusNotModified {
+				resp.Body.Close()
+				if cached == nil {
+					return nil, nil, fmt.Errorf("server responded %s to a request that wasn't conditional", resp.Status)
+				}
+				if served, err := url.Parse(cached.URL); err == nil {
+					return cachedData, served, nil
+				}
+				return cachedData, resp.Request.URL, nil
+			}
+			var data []byte
+			data, err = limits.readResponse(resp, location, limits.maxBytes)
+			resp.Body.Close()
+			if err == nil {
+				writeCachedSpec(cachePath, data, resp)
+				return data, resp.Request.URL, nil
+			}
+			if resp.StatusCode < 400 {
+				// The status was fine but reading the body failed, which
+				// is retried like any other request error
+				resp = nil
+			}
+		}
+
+		delay, retry := f.retryDelay(attempt, waited, resp, err)
+		if !retry {
+			return nil, nil, err
+		}
+		time.Sleep(delay)
+		waited += delay
+	}
+}
+
+// retryDelay returns how long to wait before retrying a fetch that failed
+// with resp or err, having waited for waited before earlier retries, and
+// whether to retry at all.
+func (f FetchOptions) retryDelay(attempt int, waited time.Duration, resp *http.Response, err error) (time.Duration, bool) {
+	retries := f.Retries
+	if retries == 0 {
+		retries = DefaultFetchRetries
+	}
+	if attempt >= retries {
+		return 0, false
+	}
+
+	var retryAfter time.Duration
+	switch {
+	case resp != nil:
+		switch resp.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusInternalServerError:
+		default:
+			return 0, false
+		}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+	case !transient(err):
+		return 0, false
+	}
+
+	backoff := f.Backoff
+	if backoff <= 0 {
+		backoff = DefaultFetchBackoff
+	}
+	// Bounding the waits by the timeout also caps Retry-After, so a server
+	// asking to come back in a day fails the fetch instead of stalling it
+	delay := max(backoff<<attempt, retryAfter)
+	if waited+delay > f.timeout() {
+		return 0, false
+	}
+	return delay, true
+}
+
+// transient reports whether a request error may go away on its own, like
+// a timeout or a dropped connection, rather than, say, a bad certificate.
+func transient(err error) bool {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr):
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// writeCachedSpec keeps data, fetched with resp, in the cache if resp
+// carries validators to revalidate it with. Caching is best effort.
+func writeCachedSpec(cachePath string, data []byte, resp *http.Response) {
+	meta := cachedSpec{URL: resp.Request.URL.String(), ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	if cachePath == "" || (meta.ETag == "" && meta.LastModified == "") {
+		return
+	}
+	encoded, err := json.Marshal(meta)
+	if err != nil || os.MkdirAll(filepath.Dir(cachePath), 0o755) != nil {
+		return
+	}
+	if os.WriteFile(cachePath, data, 0o644) == nil {
+		_ = os.WriteFile(cachePath+".json", encoded, 0o644)
+	}
+}
+
+func (f FetchOptions) timeout() time.Duration {
+	if f.Timeout <= 0 {
+		return DefaultFetchTimeout
+	}
+	return f.Timeout
+}
+
+// transport returns a transport adding the configured headers to requests
+// for host.
+func (f FetchOptions) transport(host string) http.RoundTripper {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if f.Insecure {
+		base.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	header := make(http.Header, len(f.Headers))
+	for name, value := range f.Headers {
+		header.Set(name, os.ExpandEnv(value))
+	}
+	return &headerTransport{base: base, host: host, header: header}
+}
+
+// headerTransport adds header to requests for host. Adding them per
+// request, rather than to the first one, keeps them from following
+// redirects to other hosts.
+type headerTransport struct {
+	base   http.RoundTripper
+	host   string
+	header http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.header) == 0 || !strings.EqualFold(req.URL.Hostname(), t.host) {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,298 @@
+package spec
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const urlSpec = `openapi: 3.0.0
+info:
+  title: Remote API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/error.yaml'
+`
+
+// plainSpec is a spec without external refs.
+const plainSpec = "openapi: 3.0.0\ninfo: {title: Remote API, version: 1.0.0}\npaths: {}\n"
+
+// specServer serves urlSpec at /v1/openapi.yaml, redirected to from
+// /openapi.yaml, requiring the Authorization header if token is set.
+func specServer(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/v1/openapi.yaml", http.StatusFound)
+	})
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/openapi.yaml":
+			w.Write([]byte(urlSpec))
+		case "/v1/schemas/error.yaml":
+			w.Write([]byte(remoteSchema))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return mux
+}
+
+func TestIsURL(t *testing.T) {
+	tests := []struct {
+		location string
+		want     bool
+	}{
+		{"https://api.example.com/openapi.yaml", true},
+		{"http://localhost:8080/spec.json", true},
+		{"openapi.yaml", false},
+		{"./specs/openapi.yaml", false},
+		{"file:///specs/openapi.yaml", false},
+		{"plugin:vault:payments", false},
+	}
+	for _, tt := range tests {
+		if got := IsURL(tt.location); got != tt.want {
+			t.Errorf("IsURL(%q) = %v, want %v", tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestLoadURL(t *testing.T) {
+	server := httptest.NewServer(specServer("secret"))
+	defer server.Close()
+
+	t.Run("RedirectsHeadersAndRelativeRefs", func(t *testing.T) {
+		t.Setenv("SPEC_TOKEN", "secret")
+		opts := LoadOptions{
+			Refs:  RefPolicy{Allow: []string{"schemas.example.com"}, CacheDir: t.TempDir()},
+			Fetch: FetchOptions{Headers: map[string]string{"Authorization": "Bearer ${SPEC_TOKEN}"}},
+		}
+		doc, err := LoadURL(server.URL+"/openapi.yaml", opts)
+		if err != nil {
+			t.Fatalf("LoadURL() error: %v", err)
+		}
+		schema := doc.Paths.Find("/items").Get.Responses.Status(200).Value.Content["application/json"].Schema.Value
+		if schema == nil || schema.Properties["code"] == nil {
+			t.Error("Expected relative ref to resolve against the redirected URL")
+		}
+	})
+
+	t.Run("MissingCredentials", func(t *testing.T) {
+		_, err := LoadURL(server.URL+"/openapi.yaml", LoadOptions{})
+		if err == nil || !strings.Contains(err.Error(), "status code 401") {
+			t.Errorf("Expected 401 error, got %v", err)
+		}
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		_, err := LoadURL(server.URL+"/openapi.yaml", LoadOptions{Refs: RefPolicy{Offline: true}})
+		if err == nil || !strings.Contains(err.Error(), "offline mode") {
+			t.Errorf("Expected offline error, got %v", err)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer slow.Close()
+
+		_, err := LoadURL(slow.URL+"/openapi.yaml", LoadOptions{Fetch: FetchOptions{Timeout: 20 * time.Millisecond, Retries: -1}})
+		if err == nil {
+			t.Error("Expected timeout error")
+		}
+	})
+}
+
+func TestLoadURL_FileRef(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret.yaml")
+	if err := os.WriteFile(secret, []byte(remoteSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Replace(urlSpec, "schemas/error.yaml", "file://"+filepath.ToSlash(secret), 1)))
+	}))
+	defer server.Close()
+
+	for _, allow := range [][]string{nil, {"schemas.example.com"}} {
+		_, err := LoadURL(server.URL+"/openapi.yaml", LoadOptions{Refs: RefPolicy{Allow: allow}})
+		if err == nil || !strings.Contains(err.Error(), "blocked") {
+			t.Errorf("Expected the file ref of a fetched spec to be blocked with allowlist %v, got %v", allow, err)
+		}
+	}
+
+	if _, err := LoadURL(server.URL+"/openapi.yaml", LoadOptions{Refs: RefPolicy{Allow: []string{dir}}}); err != nil {
+		t.Errorf("Expected a file ref the allowlist names to be read, got %v", err)
+	}
+}
+
+func TestLoadURL_Retry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/missing.yaml":
+			requests.Add(1)
+			http.NotFound(w, r)
+		case requests.Add(1) < 3:
+			http.Error(w, "restarting", http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(plainSpec))
+		}
+	}))
+	defer server.Close()
+
+	opts := LoadOptions{Refs: RefPolicy{CacheDir: t.TempDir()}, Fetch: FetchOptions{Backoff: time.Millisecond}}
+	if _, err := LoadURL(server.URL+"/openapi.yaml", opts); err != nil {
+		t.Errorf("LoadURL() error = %v, want success after retrying 503s", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("Expected 3 requests, got %d", n)
+	}
+
+	requests.Store(0)
+	if _, err := LoadURL(server.URL+"/missing.yaml", opts); err == nil || !strings.Contains(err.Error(), "status code 404") {
+		t.Errorf("Expected 404 error, got %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected a 404 not to be retried, got %d requests", n)
+	}
+
+	requests.Store(0)
+	opts.Fetch.Retries = 1
+	if _, err := LoadURL(server.URL+"/openapi.yaml", opts); err == nil || !strings.Contains(err.Error(), "status code 503") {
+		t.Errorf("Expected 503 error after one retry, got %v", err)
+	}
+}
+
+func TestLoadURL_RetryBodyError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// The connection drops before the promised body is sent
+			w.Header().Set("Content-Length", "1000")
+			w.Write([]byte("openapi: 3.0.0\n"))
+			return
+		}
+		w.Write([]byte(plainSpec))
+	}))
+	defer server.Close()
+
+	opts := LoadOptions{Refs: RefPolicy{CacheDir: t.TempDir()}, Fetch: FetchOptions{Backoff: time.Millisecond}}
+	if _, err := LoadURL(server.URL+"/openapi.yaml", opts); err != nil {
+		t.Errorf("LoadURL() error = %v, want success after retrying the truncated body", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("Expected 2 requests, got %d", n)
+	}
+}
+
+func TestLoadURL_UnexpectedNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	opts := LoadOptions{Refs: RefPolicy{CacheDir: t.TempDir()}}
+	if _, err := LoadURL(server.URL+"/openapi.yaml", opts); err == nil || !strings.Contains(err.Error(), "304 Not Modified") {
+		t.Errorf("Expected an error for a 304 without a cached copy, got %v", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	unavailable := func(retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+	opts := FetchOptions{Timeout: 10 * time.Second, Backoff: time.Second}
+
+	tests := []struct {
+		name      string
+		attempt   int
+		waited    time.Duration
+		resp      *http.Response
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{"Backoff", 1, time.Second, unavailable(""), 2 * time.Second, true},
+		{"Retry-After", 0, 0, unavailable("5"), 5 * time.Second, true},
+		{"Retry-After beyond timeout", 0, 0, unavailable("86400"), 0, false},
+		{"Waits beyond timeout", 2, 7 * time.Second, unavailable(""), 0, false},
+		{"Not retryable", 0, 0, &http.Response{StatusCode: http.StatusNotFound}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := opts.retryDelay(tt.attempt, tt.waited, tt.resp, nil)
+			if delay != tt.wantDelay || retry != tt.wantRetry {
+				t.Errorf("retryDelay() = %s, %v, want %s, %v", delay, retry, tt.wantDelay, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestLoadURL_Revalidate(t *testing.T) {
+	var full, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/openapi.yaml" {
+			http.Redirect(w, r, "/v2/openapi.yaml", http.StatusFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v2"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v2"`)
+		w.Write([]byte(plainSpec))
+	}))
+	defer server.Close()
+
+	opts := LoadOptions{Refs: RefPolicy{CacheDir: t.TempDir()}}
+	for range 2 {
+		doc, err := LoadURL(server.URL+"/openapi.yaml", opts)
+		if err != nil {
+			t.Fatalf("LoadURL() error: %v", err)
+		}
+		if doc.Info == nil || doc.Info.Title == "" {
+			t.Errorf("Expected the spec, got %+v", doc.Info)
+		}
+	}
+	if full.Load() != 1 || notModified.Load() != 1 {
+		t.Errorf("Expected one full response and one 304, got %d and %d", full.Load(), notModified.Load())
+	}
+}
+
+func TestLoadURL_Insecure(t *testing.T) {
+	server := httptest.NewTLSServer(specServer(""))
+	defer server.Close()
+
+	opts := LoadOptions{Refs: RefPolicy{CacheDir: t.TempDir()}}
+	if _, err := LoadURL(server.URL+"/openapi.yaml", opts); err == nil {
+		t.Error("Expected self-signed certificate to be rejected")
+	}
+
+	opts.Fetch.Insecure = true
+	if _, err := LoadURL(server.URL+"/openapi.yaml", opts); err != nil {
+		t.Errorf("LoadURL() with Insecure error: %v", err)
+	}
+}
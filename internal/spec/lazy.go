@@ -0,0 +1,230 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Lazy is an OpenAPI document whose operations are indexed when it is
+// opened but whose schemas and components are only materialized when the
+// document is first needed, for long-running processes serving many specs
+// of which most are rarely used. The loaded document can be released again
+// to return to the index alone.
+type Lazy struct {
+	// Prepare, if set, is applied to the document each time it is loaded,
+	// e.g. to normalize its tags.
+	Prepare func(*openapi3.T)
+
+	path string
+	// load loads the document, or is nil for a document that can't be
+	// loaded again and so is never released.
+	load func() (*openapi3.T, error)
+
+	mu sync.Mutex
+	// paths maps each path template to its upper-case methods. A path item
+	// that is a $ref maps to nil: its methods are only known once loaded.
+	paths    map[string][]string
+	doc      *openapi3.T
+	lastUsed time.Time
+}
+
+// pathIndex is the part of a spec decoded when it is opened.
+type pathIndex struct {
+	Paths map[string]map[string]skipped `yaml:"paths"`
+}
+
+// skipped decodes nothing, so the index keeps only the keys of path items.
+type skipped struct{}
+
+func (*skipped) UnmarshalYAML(*yaml.Node) error { return nil }
+
+// Open indexes the paths and methods of the OpenAPI document at path without
+// loading it. External refs are not read until the document is loaded.
+func Open(path string, opts LoadOptions) (*Lazy, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OpenAPI file: %w", err)
+	}
+	if limits := opts.Refs.limits(true); info.Size() > limits.maxBytes {
+		return nil, &LimitError{Limit: "maxBytes", Max: limits.maxBytes, Location: path, Detail: "total size of the spec and its references is too large"}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OpenAPI file: %w", err)
+	}
+	var index pathIndex
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to index OpenAPI file: %w", err)
+	}
+
+	l := &Lazy{
+		path:  path,
+		load:  func() (*openapi3.T, error) { return Load(path, opts) },
+		paths: make(map[string][]string, len(index.Paths)),
+	}
+	for p, item := range index.Paths {
+		if _, ok := item["$ref"]; ok {
+			l.paths[p] = nil
+			continue
+		}
+		methods := []string{}
+		for _, method := range model.MethodOrder {
+			if _, ok := item[strings.ToLower(method)]; ok {
+				methods = append(methods, method)
+			}
+		}
+		l.paths[p] = methods
+	}
+	return l, nil
+}
+
+// FromDocument returns doc, loaded from path some other way than Open, such
+// as fetched from a URL, as a Lazy indexing its paths. It can't be loaded
+// again, so it is never released.
+func FromDocument(path string, doc *openapi3.T) *Lazy {
+	l := &Lazy{path: path, doc: doc}
+	l.index(doc)
+	return l
+}
+
+// Path returns the file the document was opened from.
+func (l *Lazy) Path() string {
+	return l.path
+}
+
+// Paths returns the indexed path templates, sorted.
+func (l *Lazy) Paths() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	paths := make([]string, 0, len(l.paths))
+	for p := range l.paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Methods returns the methods of the path template matching endpoint, as
+// openapi3.Paths.Find matches it, and whether the path exists. The methods
+// of a path item that is a $ref are nil until the document is loaded.
+func (l *Lazy) Methods(endpoint string) ([]string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if methods, ok := l.paths[endpoint]; ok {
+		return methods, true
+	}
+	normalized := normalizeTemplate(endpoint)
+	for p, methods := range l.paths {
+		if normalizeTemplate(p) == normalized {
+			return methods, true
+		}
+	}
+	return nil, false
+}
+
+// Has reports whether the document may have an operation for method on
+// endpoint, without loading it. It reports true for paths whose methods
+// are not indexed.
+func (l *Lazy) Has(endpoint, method string) bool {
+	methods, ok := l.Methods(endpoint)
+	if !ok {
+		return false
+	}
+	if methods == nil {
+		return true
+	}
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// Document returns the loaded document, loading it on first use and after
+// Release. Loading re-indexes the paths from the loaded document, so the
+// index follows changes to the file.
+func (l *Lazy) Document() (*openapi3.T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastUsed = time.Now()
+	if l.doc != nil {
+		return l.doc, nil
+	}
+
+	doc, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	if l.Prepare != nil {
+		l.Prepare(doc)
+	}
+	l.doc = doc
+	l.index(doc)
+	return doc, nil
+}
+
+// index indexes the paths and methods of doc.
+func (l *Lazy) index(doc *openapi3.T) {
+	l.paths = make(map[string][]string, doc.Paths.Len())
+	for p, item := range doc.Paths.Map() {
+		methods := []string{}
+		for _, method := range model.MethodOrder {
+			if item.GetOperation(method) != nil {
+				methods = append(methods, method)
+			}
+		}
+		l.paths[p] = methods
+	}
+}
+
+// Loaded reports whether the document is currently materialized.
+func (l *Lazy) Loaded() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.doc != nil
+}
+
+// Release drops the loaded document, keeping the index. Documents already
+// returned by Document stay valid.
+func (l *Lazy) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.load != nil {
+		l.doc = nil
+	}
+}
+
+// ReleaseIdle releases the loaded document if it has not been used for
+// idle, reporting whether it did.
+func (l *Lazy) ReleaseIdle(idle time.Duration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.doc == nil || l.load == nil || time.Since(l.lastUsed) < idle {
+		return false
+	}
+	l.doc = nil
+	return true
+}
+
+var templateParamPattern = regexp.MustCompile(`\{[^}/]*\}`)
+
+// normalizeTemplate replaces the parameter names of a path template, so
+// "/users/{id}" and "/users/{userId}" compare equal.
+func normalizeTemplate(p string) string {
+	return templateParamPattern.ReplaceAllString(p, "{}")
+}
@@ -0,0 +1,180 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const lazySpec = `openapi: 3.0.0
+info:
+  title: Lazy API
+  version: 1.0.0
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    delete:
+      responses:
+        '204':
+          description: Deleted
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: './missing.yaml'
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+    connect:
+      responses:
+        '200':
+          description: Tunnel
+`
+
+func TestOpen_IndexesWithoutLoading(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(lazySpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The broken ref is only noticed when the document is loaded
+	lazy, err := Open(path, LoadOptions{})
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if lazy.Loaded() {
+		t.Error("Expected document not to be loaded after Open")
+	}
+
+	if got, want := lazy.Paths(), []string{"/health", "/users/{id}"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Paths() = %v, want %v", got, want)
+	}
+	methods, ok := lazy.Methods("/users/{userId}")
+	if !ok || !reflect.DeepEqual(methods, []string{"GET", "DELETE"}) {
+		t.Errorf("Methods(/users/{userId}) = %v, %v, want [GET DELETE], true", methods, ok)
+	}
+
+	tests := []struct {
+		path, method string
+		want         bool
+	}{
+		{"/health", "get", true},
+		{"/health", "POST", false},
+		{"/health", "CONNECT", true},
+		{"/users/{id}", "DELETE", true},
+		{"/missing", "GET", false},
+	}
+	for _, tt := range tests {
+		if got := lazy.Has(tt.path, tt.method); got != tt.want {
+			t.Errorf("Has(%q, %q) = %v, want %v", tt.path, tt.method, got, tt.want)
+		}
+	}
+
+	if _, err := lazy.Document(); err == nil {
+		t.Error("Expected Document() to report the broken ref")
+	}
+}
+
+func TestLazy_DocumentAndRelease(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(lazySpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "missing.yaml"), []byte(remoteSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lazy, err := Open(path, LoadOptions{})
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	prepared := 0
+	lazy.Prepare = func(*openapi3.T) { prepared++ }
+	doc, err := lazy.Document()
+	if err != nil {
+		t.Fatalf("Document() error: %v", err)
+	}
+	if doc.Info.Title != "Lazy API" || !lazy.Loaded() {
+		t.Fatalf("Expected loaded document, got title %q, loaded %v", doc.Info.Title, lazy.Loaded())
+	}
+	if again, _ := lazy.Document(); again != doc {
+		t.Error("Expected Document() to reuse the loaded document")
+	}
+
+	if lazy.ReleaseIdle(time.Hour) {
+		t.Error("Expected recently used document to be kept")
+	}
+	if !lazy.ReleaseIdle(0) || lazy.Loaded() {
+		t.Error("Expected idle document to be released")
+	}
+	if !lazy.Has("/users/{id}", "GET") {
+		t.Error("Expected index to be kept after release")
+	}
+
+	lazy.Release()
+	if reloaded, err := lazy.Document(); err != nil || reloaded == doc {
+		t.Errorf("Expected document to be reloaded after release, err %v", err)
+	}
+	if prepared != 2 {
+		t.Errorf("Expected Prepare to run on each load, ran %d times", prepared)
+	}
+}
+
+func TestFromDocument(t *testing.T) {
+	doc, err := LoadData([]byte(`openapi: 3.0.0
+info: {title: Remote API, version: 1.0.0}
+paths:
+  /health:
+    connect:
+      responses:
+        '200': {description: OK}
+    get:
+      responses:
+        '200': {description: OK}
+`), LoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lazy := FromDocument("https://example.com/openapi.yaml", doc)
+	if !lazy.Has("/health", "GET") || lazy.Has("/health", "POST") {
+		t.Error("Expected the index of the document")
+	}
+	lazy.Release()
+	if lazy.ReleaseIdle(0) || !lazy.Loaded() {
+		t.Error("Expected a document that can't be reloaded to be kept")
+	}
+	if methods, _ := lazy.Methods("/health"); !reflect.DeepEqual(methods, []string{"GET", "CONNECT"}) {
+		t.Errorf("Methods(/health) = %v, want [GET CONNECT]", methods)
+	}
+	if got, err := lazy.Document(); err != nil || got != doc {
+		t.Errorf("Document() = %p, %v, want the given document", got, err)
+	}
+}
+
+func TestOpen_SizeLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(lazySpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Open(path, LoadOptions{Refs: RefPolicy{MaxBytes: 10}})
+	if _, ok := err.(*LimitError); !ok {
+		t.Errorf("Expected LimitError, got %v", err)
+	}
+}
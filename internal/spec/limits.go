@@ -0,0 +1,217 @@
+package spec
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults for the loading limits of a RefPolicy.
+const (
+	// DefaultMaxBytes bounds the total size of the documents read while
+	// loading one spec, the spec itself included.
+	DefaultMaxBytes = 100 << 20
+	// DefaultMaxRefs bounds the number of external documents read while
+	// loading one spec.
+	DefaultMaxRefs = 500
+	// DefaultMaxDepth bounds how many documents deep a chain of external
+	// references may go: a ref of the spec is depth 1, a ref of that
+	// document depth 2, and so on.
+	DefaultMaxDepth = 16
+)
+
+// LimitError reports a loading limit being exceeded.
+type LimitError struct {
+	// Limit is the RefPolicy setting exceeded, e.g. "maxBytes".
+	Limit string
+	// Max is its value.
+	Max int64
+	// Location is the document being read.
+	Location string
+	// Detail describes what exceeded the limit.
+	Detail string
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("refs.%s limit of %d exceeded reading %s: %s", e.Limit, e.Max, e.Location, e.Detail)
+}
+
+// loadLimits enforces the size, count, and depth limits of a RefPolicy
+// across the documents read while loading one spec.
+type loadLimits struct {
+	maxBytes int64
+	maxRefs  int
+	maxDepth int
+
+	mu    sync.Mutex
+	bytes int64
+	refs  int
+	// depth is the reference depth of each document seen referenced, keyed
+	// by documentKey.
+	depth map[string]int
+	// rootPending is set until the spec itself has been read.
+	rootPending bool
+}
+
+func (p RefPolicy) limits(rootPending bool) *loadLimits {
+	l := &loadLimits{
+		maxBytes:    p.MaxBytes,
+		maxRefs:     p.MaxRefs,
+		maxDepth:    p.MaxDepth,
+		depth:       make(map[string]int),
+		rootPending: rootPending,
+	}
+	if l.maxBytes <= 0 {
+		l.maxBytes = DefaultMaxBytes
+	}
+	if l.maxRefs <= 0 {
+		l.maxRefs = DefaultMaxRefs
+	}
+	if l.maxDepth <= 0 {
+		l.maxDepth = DefaultMaxDepth
+	}
+	return l
+}
+
+// enter checks that location may be read and returns the number of bytes
+// it may be at most.
+func (l *loadLimits) enter(location *url.URL) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rootPending {
+		l.rootPending = false
+		l.depth[documentKey(location)] = 0
+	} else {
+		l.refs++
+		if l.refs > l.maxRefs {
+			return 0, &LimitError{Limit: "maxRefs", Max: int64(l.maxRefs), Location: location.String(), Detail: fmt.Sprintf("more than %d external documents referenced", l.maxRefs)}
+		}
+		depth, ok := l.depth[documentKey(location)]
+		if !ok {
+			depth = 1
+		}
+		if depth > l.maxDepth {
+			return 0, &LimitError{Limit: "maxDepth", Max: int64(l.maxDepth), Location: location.String(), Detail: fmt.Sprintf("document is %d references deep", depth)}
+		}
+	}
+	return l.maxBytes - l.bytes, nil
+}
+
+// add counts data read from location and records the depth of the
+// documents it references.
+func (l *loadLimits) add(location *url.URL, data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bytes += int64(len(data))
+	if l.bytes > l.maxBytes {
+		return l.sizeError(location)
+	}
+
+	depth := l.depth[documentKey(location)]
+	for _, ref := range externalRefs(location, data) {
+		if known, ok := l.depth[ref]; !ok || depth+1 < known {
+			l.depth[ref] = depth + 1
+		}
+	}
+	return nil
+}
+
+func (l *loadLimits) sizeError(location *url.URL) error {
+	return &LimitError{Limit: "maxBytes", Max: l.maxBytes, Location: location.String(), Detail: "total size of the spec and its references is too large"}
+}
+
+// readHTTP fetches location, reading at most limit bytes of the response.
+func (l *loadLimits) readHTTP(client *http.Client, location *url.URL, limit int64) ([]byte, error) {
+	data, _, err := l.getHTTP(client, location, limit)
+	return data, err
+}
+
+// getHTTP is readHTTP also returning the URL the response was served from
+// after redirects.
+func (l *loadLimits) getHTTP(client *http.Client, location *url.URL, limit int64) ([]byte, *url.URL, error) {
+	resp, err := client.Get(location.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := l.readResponse(resp, location, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, resp.Request.URL, nil
+}
+
+// readResponse reads at most limit bytes of the body of resp, a response
+// for location, failing on error statuses.
+func (l *loadLimits) readResponse(resp *http.Response, location *url.URL, limit int64) ([]byte, error) {
+	if resp.StatusCode > 399 {
+		return nil, fmt.Errorf("request returned status code %d", resp.StatusCode)
+	}
+	if resp.ContentLength > limit {
+		return nil, l.sizeError(location)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, l.sizeError(location)
+	}
+	return data, nil
+}
+
+// documentKey identifies a document by its location without fragment.
+func documentKey(location *url.URL) string {
+	u := *location
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path != "" {
+		u.Path = path.Clean(u.Path)
+	}
+	return u.String()
+}
+
+// externalRefs returns the keys of the documents referenced by the $refs
+// in data, a YAML or JSON document read from location. Data that doesn't
+// parse yields none; the loader reports the error.
+func externalRefs(location *url.URL, data []byte) []string {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil
+	}
+
+	var refs []string
+	var walk func(node any)
+	walk = func(node any) {
+		switch v := node.(type) {
+		case map[string]any:
+			for key, value := range v {
+				if ref, ok := value.(string); ok && key == "$ref" {
+					if target, _, _ := strings.Cut(ref, "#"); target != "" {
+						if u, err := url.Parse(target); err == nil {
+							refs = append(refs, documentKey(location.ResolveReference(u)))
+						}
+					}
+					continue
+				}
+				walk(value)
+			}
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(doc)
+	return refs
+}
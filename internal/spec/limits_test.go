@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeChain writes a spec whose response schema refs a.yaml, which refs
+// b.yaml, which refs c.yaml.
+func writeChain(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.yaml": "type: object\nproperties:\n  next:\n    $ref: './b.yaml'\n",
+		"b.yaml": "type: object\nproperties:\n  next:\n    $ref: './c.yaml'\n",
+		"c.yaml": remoteSchema,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return writeSpec(t, dir, "./a.yaml")
+}
+
+func TestLoad_Limits(t *testing.T) {
+	path := writeChain(t)
+
+	tests := []struct {
+		name   string
+		policy RefPolicy
+		limit  string
+	}{
+		{"Defaults", RefPolicy{}, ""},
+		{"WithinLimits", RefPolicy{MaxRefs: 3, MaxDepth: 3, MaxBytes: 4096}, ""},
+		{"MaxRefs", RefPolicy{MaxRefs: 2}, "maxRefs"},
+		{"MaxDepth", RefPolicy{MaxDepth: 2}, "maxDepth"},
+		{"MaxBytes", RefPolicy{MaxBytes: 400}, "maxBytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(path, LoadOptions{Refs: tt.policy})
+			if tt.limit == "" {
+				if err != nil {
+					t.Errorf("Load() error: %v", err)
+				}
+				return
+			}
+
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.Limit != tt.limit {
+				t.Fatalf("Expected %s limit error, got %v", tt.limit, err)
+			}
+			if !strings.Contains(err.Error(), "refs."+tt.limit) {
+				t.Errorf("Expected the setting in the error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad_RemoteSizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Stream without a Content-Length
+		for i := 0; i < 100; i++ {
+			w.Write([]byte("# padding padding padding padding padding padding padding padding\n"))
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(remoteSchema))
+	}))
+	defer server.Close()
+
+	path := writeSpec(t, t.TempDir(), server.URL+"/large.yaml")
+
+	_, err := Load(path, LoadOptions{Refs: RefPolicy{MaxBytes: 2048, CacheDir: t.TempDir()}})
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != "maxBytes" {
+		t.Errorf("Expected maxBytes limit error, got %v", err)
+	}
+
+	if _, err := Load(path, LoadOptions{Refs: RefPolicy{CacheDir: t.TempDir()}}); err != nil {
+		t.Errorf("Load() error: %v", err)
+	}
+}
+
+func TestLoadData_Limits(t *testing.T) {
+	data := []byte("openapi: 3.0.0\ninfo:\n  title: Test API\n  version: 1.0.0\npaths: {}\n")
+
+	if _, err := LoadData(data, LoadOptions{Refs: RefPolicy{MaxBytes: 10}}); err == nil {
+		t.Error("Expected maxBytes limit error")
+	}
+	if _, err := LoadData(data, LoadOptions{}); err != nil {
+		t.Errorf("LoadData() error: %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package spec
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefHops bounds chains of $refs pointing at further $refs.
+const maxRefHops = 16
+
+// sources keeps the raw documents read while loading one spec, so the
+// declaration order of schema properties can be recovered afterwards.
+type sources struct {
+	mu    sync.Mutex
+	data  map[string][]byte
+	nodes map[string]*yaml.Node
+}
+
+func newSources() *sources {
+	return &sources{data: make(map[string][]byte), nodes: make(map[string]*yaml.Node)}
+}
+
+// record keeps data read from location.
+func (s *sources) record(location *url.URL, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[documentKey(location)] = data
+}
+
+// node returns the parsed document read from location, or nil.
+func (s *sources) node(location *url.URL) *yaml.Node {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := documentKey(location)
+	if node, ok := s.nodes[key]; ok {
+		return node
+	}
+	node := parseNode(s.data[key])
+	s.nodes[key] = node
+	return node
+}
+
+// parseNode parses a YAML or JSON document, returning nil if it doesn't parse.
+func parseNode(data []byte) *yaml.Node {
+	var doc yaml.Node
+	if len(data) == 0 || yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+// recordPropertyOrder sets the origin of each schema property of doc to
+// where the raw documents declare it, the way kin-openapi's origin tracking
+// does, so property lists can follow the spec's order. That tracking isn't
+// enabled instead because it also adds "__origin__" keys to example values.
+// The spec itself must have been recorded as read from location.
+func recordPropertyOrder(doc *openapi3.T, location *url.URL, src *sources) {
+	root := src.node(location)
+	if root == nil {
+		return
+	}
+	o := &orderRecorder{src: src, seen: make(map[*openapi3.Schema]bool)}
+	at := position{node: root, root: root, location: location}
+
+	if doc.Components != nil {
+		components := at.field("components")
+		for name, ref := range doc.Components.Schemas {
+			o.schema(ref, components.field("schemas").field(name))
+		}
+		for name, ref := range doc.Components.Parameters {
+			if ref != nil && ref.Value != nil {
+				o.parameter(ref.Value, o.resolve(components.field("parameters").field(name)))
+			}
+		}
+		for name, ref := range doc.Components.Headers {
+			if ref != nil && ref.Value != nil {
+				o.parameter(&ref.Value.Parameter, o.resolve(components.field("headers").field(name)))
+			}
+		}
+		for name, ref := range doc.Components.RequestBodies {
+			if ref != nil && ref.Value != nil {
+				o.content(ref.Value.Content, o.resolve(components.field("requestBodies").field(name)).field("content"))
+			}
+		}
+		for name, ref := range doc.Components.Responses {
+			o.response(ref, components.field("responses").field(name))
+		}
+	}
+
+	if doc.Paths == nil {
+		return
+	}
+	for path, pathItem := range doc.Paths.Map() {
+		item := o.resolve(at.field("paths").field(path))
+		o.parameters(pathItem.Parameters, item.field("parameters"))
+		for method, operation := range pathItem.Operations() {
+			op := o.resolve(item.field(strings.ToLower(method)))
+			o.parameters(operation.Parameters, op.field("parameters"))
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				o.content(operation.RequestBody.Value.Content, o.resolve(op.field("requestBody")).field("content"))
+			}
+			if operation.Responses != nil {
+				for status, ref := range operation.Responses.Map() {
+					o.response(ref, op.field("responses").field(status))
+				}
+			}
+		}
+	}
+}
+
+// position is a node of a raw document.
+type position struct {
+	node     *yaml.Node
+	root     *yaml.Node
+	location *url.URL
+}
+
+// field returns the value of key in a mapping.
+func (p position) field(key string) position {
+	if p.node == nil || p.node.Kind != yaml.MappingNode {
+		return position{}
+	}
+	for i := 0; i+1 < len(p.node.Content); i += 2 {
+		if p.node.Content[i].Value == key {
+			return position{node: p.node.Content[i+1], root: p.root, location: p.location}
+		}
+	}
+	return position{}
+}
+
+// index returns the i-th item of a sequence.
+func (p position) index(i int) position {
+	if p.node == nil || p.node.Kind != yaml.SequenceNode || i >= len(p.node.Content) {
+		return position{}
+	}
+	return position{node: p.node.Content[i], root: p.root, location: p.location}
+}
+
+// orderRecorder walks the loaded document alongside the raw documents.
+type orderRecorder struct {
+	src  *sources
+	seen map[*openapi3.Schema]bool
+}
+
+// resolve follows the $ref of a node, if any, to the node it points at.
+func (o *orderRecorder) resolve(p position) position {
+	for hop := 0; hop < maxRefHops; hop++ {
+		ref := p.field("$ref")
+		if ref.node == nil || ref.node.Kind != yaml.ScalarNode {
+			return p
+		}
+
+		target, fragment, _ := strings.Cut(ref.node.Value, "#")
+		next := position{root: p.root, location: p.location}
+		if target != "" {
+			u, err := url.Parse(target)
+			if err != nil || p.location == nil {
+				return position{}
+			}
+			next.location = p.location.ResolveReference(u)
+			next.root = o.src.node(next.location)
+		}
+		next.node = next.root
+		if fragment != "" {
+			for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+				token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+				if i, err := strconv.Atoi(token); err == nil && next.node != nil && next.node.Kind == yaml.SequenceNode {
+					next = next.index(i)
+				} else {
+					next = next.field(token)
+				}
+			}
+		}
+		p = next
+	}
+	return p
+}
+
+// schema records the property order of a schema and the schemas it nests.
+func (o *orderRecorder) schema(ref *openapi3.SchemaRef, p position) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	p = o.resolve(p)
+	schema := ref.Value
+	if p.node == nil || o.seen[schema] {
+		return
+	}
+	o.seen[schema] = true
+
+	if properties := p.field("properties"); properties.node != nil && properties.node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(properties.node.Content); i += 2 {
+			key := properties.node.Content[i]
+			prop := schema.Properties[key.Value]
+			if prop == nil {
+				continue
+			}
+			origin := &openapi3.Origin{Key: &openapi3.Location{Line: key.Line, Column: key.Column}}
+			if prop.Ref != "" {
+				if prop.Origin == nil {
+					prop.Origin = origin
+				}
+			} else if prop.Value != nil && prop.Value.Origin == nil {
+				prop.Value.Origin = origin
+			}
+			o.schema(prop, properties.field(key.Value))
+		}
+	}
+
+	o.schema(schema.Items, p.field("items"))
+	o.schema(schema.Not, p.field("not"))
+	o.schema(schema.AdditionalProperties.Schema, p.field("additionalProperties"))
+	for i, member := range schema.AllOf {
+		o.schema(member, p.field("allOf").index(i))
+	}
+	for i, member := range schema.OneOf {
+		o.schema(member, p.field("oneOf").index(i))
+	}
+	for i, member := range schema.AnyOf {
+		o.schema(member, p.field("anyOf").index(i))
+	}
+}
+
+// content records the schemas of a content map.
+func (o *orderRecorder) content(content openapi3.Content, p position) {
+	for contentType, mediaType := range content {
+		if mediaType != nil {
+			o.schema(mediaType.Schema, p.field(contentType).field("schema"))
+		}
+	}
+}
+
+// parameters records the schemas of a parameter list.
+func (o *orderRecorder) parameters(params openapi3.Parameters, p position) {
+	for i, ref := range params {
+		if ref != nil && ref.Value != nil {
+			o.parameter(ref.Value, o.resolve(p.index(i)))
+		}
+	}
+}
+
+// parameter records the schemas of a parameter or header.
+func (o *orderRecorder) parameter(param *openapi3.Parameter, p position) {
+	o.schema(param.Schema, p.field("schema"))
+	o.content(param.Content, p.field("content"))
+}
+
+// response records the schemas of a response's content and headers.
+func (o *orderRecorder) response(ref *openapi3.ResponseRef, p position) {
+	if ref == nil || ref.Value == nil {
+		return
+	}
+	p = o.resolve(p)
+	o.content(ref.Value.Content, p.field("content"))
+	for name, header := range ref.Value.Headers {
+		if header != nil && header.Value != nil {
+			o.parameter(&header.Value.Parameter, o.resolve(p.field("headers").field(name)))
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// declared returns the property names of schema in the order their
+// recorded origins place them.
+func declared(t *testing.T, schema *openapi3.Schema) string {
+	t.Helper()
+	names := make([]string, 0, len(schema.Properties))
+	lines := make(map[string]int)
+	for name, ref := range schema.Properties {
+		origin := ref.Origin
+		if ref.Ref == "" {
+			origin = ref.Value.Origin
+		}
+		if origin == nil || origin.Key == nil {
+			t.Fatalf("No origin recorded for property %q", name)
+		}
+		names = append(names, name)
+		lines[name] = origin.Key.Line
+	}
+	slices.SortFunc(names, func(a, b string) int { return lines[a] - lines[b] })
+	return strings.Join(names, ",")
+}
+
+func TestLoad_PropertyOrder(t *testing.T) {
+	dir := t.TempDir()
+	shared := "type: object\nproperties:\n  zone:\n    type: string\n  city:\n    type: string\n"
+	if err := os.WriteFile(filepath.Join(dir, "address.yaml"), []byte(shared), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	content := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                address:
+                  $ref: './address.yaml'
+                age:
+                  type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      allOf:
+        - type: object
+          properties:
+            id:
+              type: string
+            email:
+              type: string
+        - $ref: '#/components/schemas/Audit'
+    Audit:
+      type: object
+      properties:
+        updatedAt:
+          type: string
+        createdAt:
+          type: string
+`
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := Load(path, LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	op := doc.Paths.Value("/users").Post
+	body := op.RequestBody.Value.Content["application/json"].Schema.Value
+	user := doc.Components.Schemas["User"].Value
+
+	tests := []struct {
+		name     string
+		schema   *openapi3.Schema
+		expected string
+	}{
+		{"Inline", body, "name,address,age"},
+		{"ExternalRef", body.Properties["address"].Value, "zone,city"},
+		{"AllOfMember", user.AllOf[0].Value, "id,email"},
+		{"Component", doc.Components.Schemas["Audit"].Value, "updatedAt,createdAt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := declared(t, tt.schema); got != tt.expected {
+				t.Errorf("Property order = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
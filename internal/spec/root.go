@@ -0,0 +1,92 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoRoot is returned by FindRoot when no file in the directory is a root spec.
+var ErrNoRoot = errors.New("no root OpenAPI file found")
+
+// AmbiguousRootError is returned by FindRoot when several files look like root specs.
+type AmbiguousRootError struct {
+	Dir        string
+	Candidates []string
+}
+
+func (e *AmbiguousRootError) Error() string {
+	return fmt.Sprintf("multiple root OpenAPI files found in %s; pass one of them explicitly:\n  %s",
+		e.Dir, strings.Join(e.Candidates, "\n  "))
+}
+
+// FindRoot returns the root OpenAPI document in dir or its subdirectories:
+// the single .yaml, .yml, or .json file with top-level "openapi" (or
+// "swagger") and "paths" keys. Other files are assumed to be fragments referenced from the root.
+// Hidden directories are skipped.
+func FindRoot(dir string) (string, error) {
+	var candidates []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		if isRootDocument(path) {
+			candidates = append(candidates, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("%w in %s (looked for a file with top-level openapi: and paths: keys)", ErrNoRoot, dir)
+	case 1:
+		return candidates[0], nil
+	default:
+		sort.Strings(candidates)
+		return "", &AmbiguousRootError{Dir: dir, Candidates: candidates}
+	}
+}
+
+// isRootDocument reports whether the file at path has top-level "openapi",
+// or Swagger 2.0's "swagger", and "paths" keys. Unreadable or unparsable
+// files are not roots.
+func isRootDocument(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	// YAML is a superset of JSON, so this handles both formats.
+	var top map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &top); err != nil {
+		return false
+	}
+
+	_, hasOpenAPI := top["openapi"]
+	_, hasSwagger := top["swagger"]
+	_, hasPaths := top["paths"]
+	return (hasOpenAPI || hasSwagger) && hasPaths
+}
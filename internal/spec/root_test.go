@@ -0,0 +1,88 @@
+package spec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindRoot(t *testing.T) {
+	root := "openapi: 3.0.0\ninfo: {title: T, version: '1'}\npaths: {}\n"
+
+	t.Run("Single", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{
+			"api.yaml":           root,
+			"schemas/error.yaml": "type: object\n",
+			"paths/events.yaml":  "get:\n  responses: {}\n",
+			"components.json":    `{"openapi": "3.0.0", "components": {}}`,
+			".archive/old.yaml":  root,
+			"notes.txt":          root,
+		})
+
+		path, err := FindRoot(dir)
+		if err != nil {
+			t.Fatalf("FindRoot() error: %v", err)
+		}
+		if path != filepath.Join(dir, "api.yaml") {
+			t.Errorf("FindRoot() = %s, want api.yaml", path)
+		}
+	})
+
+	t.Run("JSONRoot", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"nested/openapi.json": `{"openapi": "3.1.0", "paths": {}}`})
+
+		path, err := FindRoot(dir)
+		if err != nil || path != filepath.Join(dir, "nested", "openapi.json") {
+			t.Errorf("FindRoot() = %s, %v", path, err)
+		}
+	})
+
+	t.Run("Swagger2Root", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"swagger.json": `{"swagger": "2.0", "paths": {}}`})
+
+		path, err := FindRoot(dir)
+		if err != nil || path != filepath.Join(dir, "swagger.json") {
+			t.Errorf("FindRoot() = %s, %v", path, err)
+		}
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"public.yaml": root, "internal.yaml": root})
+
+		_, err := FindRoot(dir)
+		var ambiguous *AmbiguousRootError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("Expected AmbiguousRootError, got %v", err)
+		}
+		if len(ambiguous.Candidates) != 2 || filepath.Base(ambiguous.Candidates[0]) != "internal.yaml" {
+			t.Errorf("Unexpected candidates: %v", ambiguous.Candidates)
+		}
+	})
+
+	t.Run("None", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"schemas/error.yaml": "type: object\n"})
+
+		if _, err := FindRoot(dir); !errors.Is(err, ErrNoRoot) {
+			t.Errorf("Expected ErrNoRoot, got %v", err)
+		}
+	})
+}
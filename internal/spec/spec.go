@@ -0,0 +1,305 @@
+// Package spec loads OpenAPI documents, enforcing the configured policy for
+// resolving external references.
+package spec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultRefTimeout bounds each remote reference fetch when no timeout is configured.
+const DefaultRefTimeout = 30 * time.Second
+
+// RefPolicy controls how external $refs are resolved.
+type RefPolicy struct {
+	// Allow restricts which external refs may be followed. Entries containing
+	// a path separator or starting with "." are local path prefixes; all other
+	// entries are host names, optionally with a leading "*." wildcard.
+	// Without host entries any host may be fetched; without path entries any
+	// local file may be read. The spec's own directory is always readable.
+	Allow []string `yaml:"allow"`
+	// Offline forbids network access; remote refs are served only from the cache.
+	Offline bool `yaml:"offline"`
+	// Timeout bounds each remote fetch. Zero means DefaultRefTimeout.
+	Timeout time.Duration `yaml:"timeout"`
+	// CacheDir stores fetched remote refs for offline use. Empty means the
+	// user cache directory.
+	CacheDir string `yaml:"cacheDir"`
+	// MaxBytes bounds the total size of the spec and the documents its refs
+	// read. Zero means DefaultMaxBytes.
+	MaxBytes int64 `yaml:"maxBytes"`
+	// MaxRefs bounds the number of external documents read. Zero means
+	// DefaultMaxRefs.
+	MaxRefs int `yaml:"maxRefs"`
+	// MaxDepth bounds how many documents deep chains of external refs may
+	// go. Zero means DefaultMaxDepth.
+	MaxDepth int `yaml:"maxDepth"`
+}
+
+// LoadOptions configures Load.
+type LoadOptions struct {
+	Refs RefPolicy
+	// Fetch configures fetching a spec given as an HTTP(S) URL.
+	Fetch FetchOptions
+}
+
+// Load loads and parses the OpenAPI document at path. Swagger 2.0
+// documents are converted to OpenAPI 3.
+func Load(path string, opts LoadOptions) (*openapi3.T, error) {
+	root, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve spec directory: %w", err)
+	}
+
+	src := newSources()
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = opts.Refs.reader(root, opts.Refs.client(nil), opts.Refs.limits(true), src)
+
+	// The reader caches what it reads, so the loader reuses this read
+	location := &url.URL{Path: filepath.ToSlash(path)}
+	data, err := loader.ReadFromURIFunc(loader, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI file: %w", err)
+	}
+	if isSwagger2(data) {
+		return loadSwagger2(loader, data, location)
+	}
+
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI file: %w", err)
+	}
+
+	if doc == nil {
+		return nil, fmt.Errorf("loaded document is nil")
+	}
+
+	recordPropertyOrder(doc, location, src)
+	return doc, nil
+}
+
+// LoadData parses an OpenAPI document held in memory, such as one returned
+// by a source plugin. Relative $refs resolve against the working directory.
+func LoadData(data []byte, opts LoadOptions) (*openapi3.T, error) {
+	root, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	location := &url.URL{Path: filepath.ToSlash(root) + "/"}
+	limits := opts.Refs.limits(false)
+	if err := limits.add(location, data); err != nil {
+		return nil, err
+	}
+
+	src := newSources()
+	src.record(location, data)
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = opts.Refs.reader(root, opts.Refs.client(nil), limits, src)
+	if isSwagger2(data) {
+		return loadSwagger2(loader, data, location)
+	}
+
+	doc, err := loader.LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+
+	recordPropertyOrder(doc, location, src)
+	return doc, nil
+}
+
+// client returns the HTTP client remote refs are fetched with, sending
+// requests through transport, or the default transport if nil.
+func (p RefPolicy) client(transport http.RoundTripper) *http.Client {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRefTimeout
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// reader returns a URI reader enforcing the policy and its limits, keeping
+// what it reads in src. root is the spec's directory, which is always
+// readable.
+func (p RefPolicy) reader(root string, client *http.Client, limits *loadLimits, src *sources) openapi3.ReadFromURIFunc {
+	hosts, paths := p.splitAllow()
+
+	return openapi3.URIMapCache(func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		remaining, err := limits.enter(location)
+		if err != nil {
+			return nil, err
+		}
+
+		var data []byte
+		if isRemote(location) {
+			if !hostAllowed(location.Hostname(), hosts) {
+				return nil, fmt.Errorf("remote ref %s blocked: host %q is not in the ref allowlist", location, location.Hostname())
+			}
+			data, err = p.fetch(location, func() ([]byte, error) {
+				return limits.readHTTP(client, location, remaining)
+			})
+		} else {
+			// root is empty for a spec fetched from a URL: a remote
+			// document may only read the files the allowlist names
+			if root == "" && len(paths) == 0 || !pathAllowed(location.Path, root, paths) {
+				return nil, fmt.Errorf("file ref %s blocked: path is outside the ref allowlist", location.Path)
+			}
+			// Check the size before reading, so huge files aren't loaded
+			if info, statErr := os.Stat(filepath.FromSlash(location.Path)); statErr == nil && info.Size() > remaining {
+				return nil, limits.sizeError(location)
+			}
+			data, err = openapi3.ReadFromFile(loader, location)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := limits.add(location, data); err != nil {
+			return nil, err
+		}
+		src.record(location, data)
+		return data, nil
+	})
+}
+
+// fetch reads a remote ref through the on-disk cache, calling remote on a
+// cache miss.
+func (p RefPolicy) fetch(location *url.URL, remote func() ([]byte, error)) ([]byte, error) {
+	cachePath := p.cachePath(location)
+
+	if p.Offline {
+		if cachePath == "" {
+			return nil, fmt.Errorf("offline mode: no cache directory available for %s", location)
+		}
+		data, err := os.ReadFile(cachePath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("offline mode: remote ref %s is not cached", location)
+			}
+			return nil, fmt.Errorf("offline mode: failed to read cached ref %s: %w", location, err)
+		}
+		return data, nil
+	}
+
+	data, err := remote()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch remote ref %s: %w", location, err)
+	}
+
+	// Caching is best effort; a read-only cache must not break loading.
+	if cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err == nil {
+			_ = os.WriteFile(cachePath, data, 0o644)
+		}
+	}
+
+	return data, nil
+}
+
+// CacheLocation returns the directory fetched remote refs are cached in,
+// or empty string if no cache directory is available.
+func (p RefPolicy) CacheLocation() string {
+	if p.CacheDir != "" {
+		return p.CacheDir
+	}
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(userCache, "docfinder", "refs")
+}
+
+// cachePath returns the cache file for a remote location, or empty string if
+// no cache directory is available.
+func (p RefPolicy) cachePath(location *url.URL) string {
+	dir := p.CacheLocation()
+	if dir == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(location.String()))
+	return filepath.Join(dir, hex.EncodeToString(sum[:]))
+}
+
+// splitAllow separates allowlist entries into host patterns and absolute path prefixes.
+func (p RefPolicy) splitAllow() (hosts, paths []string) {
+	for _, entry := range p.Allow {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.HasPrefix(entry, ".") || strings.ContainsAny(entry, `/\`) {
+			if abs, err := filepath.Abs(entry); err == nil {
+				paths = append(paths, abs)
+			}
+			continue
+		}
+
+		hosts = append(hosts, strings.ToLower(entry))
+	}
+	return hosts, paths
+}
+
+// isRemote reports whether a location must be fetched over the network.
+func isRemote(location *url.URL) bool {
+	return location.Host != "" && location.Scheme != "file"
+}
+
+// hostAllowed reports whether host matches the allowlist. An empty allowlist allows all hosts.
+func hostAllowed(host string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	host = strings.ToLower(host)
+	for _, pattern := range allowed {
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// pathAllowed reports whether a local file may be read. An empty allowlist
+// allows all paths; root is always allowed unless empty, as for specs
+// fetched from a URL.
+func pathAllowed(path, root string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	abs, err := filepath.Abs(filepath.FromSlash(path))
+	if err != nil {
+		return false
+	}
+
+	for _, prefix := range append([]string{root}, allowed...) {
+		if prefix == "" {
+			continue
+		}
+		if abs == prefix || strings.HasPrefix(abs, prefix+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package spec
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const remoteSchema = `type: object
+properties:
+  code:
+    type: string
+`
+
+// writeSpec writes a spec whose response schema is an external ref to ref.
+func writeSpec(t *testing.T, dir, ref string) string {
+	t.Helper()
+	content := `openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '` + ref + `'
+`
+	path := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_RemoteRefs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(remoteSchema))
+	}))
+	defer server.Close()
+
+	ref := server.URL + "/error.yaml"
+	host, _ := url.Parse(server.URL)
+
+	t.Run("AllowedAndCached", func(t *testing.T) {
+		cache := t.TempDir()
+		path := writeSpec(t, t.TempDir(), ref)
+
+		doc, err := Load(path, LoadOptions{Refs: RefPolicy{Allow: []string{host.Hostname()}, CacheDir: cache}})
+		if err != nil {
+			t.Fatalf("Load() error: %v", err)
+		}
+		if doc.Paths.Value("/items") == nil {
+			t.Fatal("Expected /items path")
+		}
+
+		entries, _ := os.ReadDir(cache)
+		if len(entries) != 1 {
+			t.Fatalf("Expected one cached ref, got %d", len(entries))
+		}
+
+		// The cached copy satisfies offline loads.
+		if _, err := Load(path, LoadOptions{Refs: RefPolicy{Offline: true, CacheDir: cache}}); err != nil {
+			t.Errorf("Expected offline load from cache to succeed: %v", err)
+		}
+	})
+
+	t.Run("HostNotAllowed", func(t *testing.T) {
+		path := writeSpec(t, t.TempDir(), ref)
+		_, err := Load(path, LoadOptions{Refs: RefPolicy{Allow: []string{"schemas.example.com"}, CacheDir: t.TempDir()}})
+		if err == nil || !strings.Contains(err.Error(), "not in the ref allowlist") {
+			t.Errorf("Expected allowlist error, got %v", err)
+		}
+	})
+
+	t.Run("OfflineWithoutCache", func(t *testing.T) {
+		path := writeSpec(t, t.TempDir(), ref)
+		_, err := Load(path, LoadOptions{Refs: RefPolicy{Offline: true, CacheDir: t.TempDir()}})
+		if err == nil || !strings.Contains(err.Error(), "not cached") {
+			t.Errorf("Expected offline cache miss error, got %v", err)
+		}
+	})
+}
+
+func TestLoad_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	path := writeSpec(t, t.TempDir(), server.URL+"/slow.yaml")
+	_, err := Load(path, LoadOptions{Refs: RefPolicy{Timeout: 50 * time.Millisecond, CacheDir: t.TempDir()}})
+	if err == nil {
+		t.Error("Expected timeout error")
+	}
+}
+
+func TestLoad_FileRefs(t *testing.T) {
+	root := t.TempDir()
+	specDir := filepath.Join(root, "api")
+	sharedDir := filepath.Join(root, "shared")
+	for _, dir := range []string{specDir, sharedDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(sharedDir, "error.yaml"), []byte(remoteSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := writeSpec(t, specDir, "../shared/error.yaml")
+
+	if _, err := Load(path, LoadOptions{}); err != nil {
+		t.Errorf("Expected unrestricted file refs by default: %v", err)
+	}
+
+	if _, err := Load(path, LoadOptions{Refs: RefPolicy{Allow: []string{sharedDir}}}); err != nil {
+		t.Errorf("Expected allowed path prefix to resolve: %v", err)
+	}
+
+	_, err := Load(path, LoadOptions{Refs: RefPolicy{Allow: []string{filepath.Join(root, "other")}}})
+	if err == nil || !strings.Contains(err.Error(), "outside the ref allowlist") {
+		t.Errorf("Expected path allowlist error, got %v", err)
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	tests := []struct {
+		host     string
+		allowed  []string
+		expected bool
+	}{
+		{"schemas.example.com", nil, true},
+		{"schemas.example.com", []string{"schemas.example.com"}, true},
+		{"Schemas.Example.com", []string{"schemas.example.com"}, true},
+		{"api.example.com", []string{"*.example.com"}, true},
+		{"example.com", []string{"*.example.com"}, false},
+		{"evil.com", []string{"schemas.example.com"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if result := hostAllowed(tt.host, tt.allowed); result != tt.expected {
+				t.Errorf("hostAllowed(%q, %v) = %v, want %v", tt.host, tt.allowed, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package spec
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	jsonyaml "github.com/oasdiff/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+// isSwagger2 reports whether data is a Swagger 2.0 (OpenAPI v2) document,
+// which declares "swagger: 2.0" instead of an openapi version.
+func isSwagger2(data []byte) bool {
+	var version struct {
+		Swagger string `yaml:"swagger"`
+	}
+	return yaml.Unmarshal(data, &version) == nil && strings.HasPrefix(version.Swagger, "2")
+}
+
+// loadSwagger2 converts a Swagger 2.0 document read from location to
+// OpenAPI 3, resolving its refs with loader. Definitions become component
+// schemas, body and formData parameters become request bodies, and
+// host, basePath, and schemes become servers.
+func loadSwagger2(loader *openapi3.Loader, data []byte, location *url.URL) (*openapi3.T, error) {
+	var doc2 openapi2.T
+	if err := jsonyaml.Unmarshal(data, &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse Swagger 2.0 document: %w", err)
+	}
+	doc, err := openapi2conv.ToV3WithLoader(&doc2, loader, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger 2.0 document: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,101 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const swaggerSpec = `swagger: "2.0"
+info:
+  title: Legacy API
+  version: 1.0.0
+host: legacy.example.com
+basePath: /api
+schemes: [https]
+consumes: [application/json]
+produces: [application/json]
+paths:
+  /widgets:
+    post:
+      operationId: createWidget
+      parameters:
+        - name: dryRun
+          in: query
+          type: boolean
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Widget'
+      responses:
+        201:
+          description: Created
+          schema:
+            $ref: '#/definitions/Widget'
+definitions:
+  Widget:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+`
+
+func TestIsSwagger2(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{swaggerSpec, true},
+		{`{"swagger": "2.0", "paths": {}}`, true},
+		{"swagger: 2.0\n", true},
+		{"openapi: 3.0.0\n", false},
+		{"not: [valid", false},
+	}
+	for _, tt := range tests {
+		if got := isSwagger2([]byte(tt.data)); got != tt.want {
+			t.Errorf("isSwagger2(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestLoad_Swagger2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swagger.yaml")
+	if err := os.WriteFile(path, []byte(swaggerSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fromFile, err := Load(path, LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	fromData, err := LoadData([]byte(swaggerSpec), LoadOptions{})
+	if err != nil {
+		t.Fatalf("LoadData() error: %v", err)
+	}
+
+	for name, doc := range map[string]*openapi3.T{"Load": fromFile, "LoadData": fromData} {
+		t.Run(name, func(t *testing.T) {
+			if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://legacy.example.com/api" {
+				t.Errorf("Expected server from host and basePath, got %v", doc.Servers)
+			}
+			op := doc.Paths.Value("/widgets").Post
+			if op == nil || op.OperationID != "createWidget" {
+				t.Fatal("Expected converted POST /widgets operation")
+			}
+			if len(op.Parameters) != 1 || op.Parameters[0].Value.Name != "dryRun" {
+				t.Errorf("Expected the body parameter to become the request body, got %d parameters", len(op.Parameters))
+			}
+			body := op.RequestBody.Value.Content["application/json"]
+			if body == nil || body.Schema.Value == nil || body.Schema.Value.Properties["name"] == nil {
+				t.Error("Expected request body schema resolved from definitions")
+			}
+			response := op.Responses.Status(201)
+			if response == nil || response.Value.Content["application/json"].Schema.Value == nil {
+				t.Error("Expected response schema resolved from definitions")
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Package stats measures the surface of an API spec and keeps snapshots of
+// it in a JSON Lines file, so its growth can be reported over time.
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Snapshot is the size of a spec at a point in time.
+type Snapshot struct {
+	Time       time.Time `json:"time"`
+	Title      string    `json:"title,omitempty"`
+	Version    string    `json:"version,omitempty"`
+	Paths      int       `json:"paths"`
+	Operations int       `json:"operations"`
+	Schemas    int       `json:"schemas"`
+	Deprecated int       `json:"deprecated"`
+}
+
+// Periods snapshots can be grouped by in a trend.
+const (
+	PeriodAll     = "all"
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// Collect measures doc. The snapshot's Time is left for the caller to set.
+func Collect(doc *openapi3.T) Snapshot {
+	var s Snapshot
+	if doc.Info != nil {
+		s.Title, s.Version = doc.Info.Title, doc.Info.Version
+	}
+	if doc.Paths != nil {
+		s.Paths = doc.Paths.Len()
+		for _, item := range doc.Paths.Map() {
+			for _, op := range item.Operations() {
+				s.Operations++
+				if op.Deprecated {
+					s.Deprecated++
+				}
+			}
+		}
+	}
+	if doc.Components != nil {
+		s.Schemas = len(doc.Components.Schemas)
+	}
+	return s
+}
+
+// Append adds s as a line to the snapshot file at path, creating it.
+func Append(path string, s Snapshot) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to record snapshot: %w", err)
+	}
+	return f.Close()
+}
+
+// Read returns the snapshots in the file at path, in the order recorded.
+func Read(path string) ([]Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	var snapshots []Snapshot
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var s Snapshot
+		if err := json.Unmarshal([]byte(text), &s); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid snapshot: %w", path, line, err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if len(snapshots) == 0 {
+		return nil, errors.New("no snapshots recorded in " + path)
+	}
+	return snapshots, nil
+}
+
+// ByPeriod keeps the last snapshot of each period, e.g. of each quarter,
+// so a trend shows where every period ended. PeriodAll keeps them all.
+func ByPeriod(snapshots []Snapshot, period string) ([]Snapshot, error) {
+	var key func(time.Time) string
+	switch period {
+	case PeriodAll, "":
+		return snapshots, nil
+	case PeriodMonth:
+		key = func(t time.Time) string { return t.Format("2006-01") }
+	case PeriodQuarter:
+		key = func(t time.Time) string { return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())+2)/3) }
+	case PeriodYear:
+		key = func(t time.Time) string { return t.Format("2006") }
+	default:
+		return nil, fmt.Errorf("unknown period: %s (expected %s, %s, %s, or %s)", period, PeriodAll, PeriodMonth, PeriodQuarter, PeriodYear)
+	}
+
+	var kept []Snapshot
+	for i, s := range snapshots {
+		if i+1 < len(snapshots) && key(snapshots[i+1].Time.UTC()) == key(s.Time.UTC()) {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	return kept, nil
+}
+
+// FormatSnapshot renders s as a markdown table.
+func FormatSnapshot(s Snapshot) string {
+	var md strings.Builder
+	md.WriteString("# API Statistics\n\n")
+	if s.Title != "" {
+		fmt.Fprintf(&md, "%s %s\n\n", s.Title, s.Version)
+	}
+	md.WriteString("| Metric | Count |\n")
+	md.WriteString("|--------|------:|\n")
+	fmt.Fprintf(&md, "| Paths | %d |\n", s.Paths)
+	fmt.Fprintf(&md, "| Operations | %d |\n", s.Operations)
+	fmt.Fprintf(&md, "| Schemas | %d |\n", s.Schemas)
+	fmt.Fprintf(&md, "| Deprecated operations | %d |\n", s.Deprecated)
+	return md.String()
+}
+
+// FormatTrend renders snapshots as a markdown table of each snapshot and
+// its change from the one before, followed by the growth over the whole
+// range.
+func FormatTrend(snapshots []Snapshot) string {
+	var md strings.Builder
+	md.WriteString("# API Growth\n\n")
+	md.WriteString("| Date | Version | Paths | Operations | Schemas | Deprecated |\n")
+	md.WriteString("|------|---------|------:|-----------:|--------:|-----------:|\n")
+
+	for i, s := range snapshots {
+		prev := s
+		if i > 0 {
+			prev = snapshots[i-1]
+		}
+		fmt.Fprintf(&md, "| %s | %s | %s | %s | %s | %s |\n",
+			s.Time.UTC().Format("2006-01-02"), s.Version,
+			withDelta(s.Paths, prev.Paths), withDelta(s.Operations, prev.Operations),
+			withDelta(s.Schemas, prev.Schemas), withDelta(s.Deprecated, prev.Deprecated))
+	}
+
+	if len(snapshots) > 1 {
+		first, last := snapshots[0], snapshots[len(snapshots)-1]
+		fmt.Fprintf(&md, "\nFrom %s to %s: operations %s, schemas %s, deprecated operations %s.\n",
+			first.Time.UTC().Format("2006-01-02"), last.Time.UTC().Format("2006-01-02"),
+			growth(first.Operations, last.Operations), growth(first.Schemas, last.Schemas),
+			growth(first.Deprecated, last.Deprecated))
+	}
+	return md.String()
+}
+
+// withDelta formats n with its change from prev, e.g. "42 (+3)".
+func withDelta(n, prev int) string {
+	if n == prev {
+		return fmt.Sprint(n)
+	}
+	return fmt.Sprintf("%d (%+d)", n, n-prev)
+}
+
+// growth formats the change from first to last, e.g. "+12 (+25%)".
+func growth(first, last int) string {
+	if first == 0 {
+		return fmt.Sprintf("%+d", last-first)
+	}
+	return fmt.Sprintf("%+d (%+.0f%%)", last-first, float64(last-first)*100/float64(first))
+}
@@ -0,0 +1,108 @@
+package stats
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestCollect(t *testing.T) {
+	doc := &openapi3.T{
+		Info: &openapi3.Info{Title: "Events API", Version: "2.1"},
+		Paths: openapi3.NewPaths(
+			openapi3.WithPath("/events", &openapi3.PathItem{
+				Get:  &openapi3.Operation{},
+				Post: &openapi3.Operation{Deprecated: true},
+			}),
+			openapi3.WithPath("/events/{id}", &openapi3.PathItem{Get: &openapi3.Operation{}}),
+		),
+		Components: &openapi3.Components{Schemas: openapi3.Schemas{
+			"Event": openapi3.NewSchemaRef("", openapi3.NewObjectSchema()),
+		}},
+	}
+
+	got := Collect(doc)
+	want := Snapshot{Title: "Events API", Version: "2.1", Paths: 2, Operations: 3, Schemas: 1, Deprecated: 1}
+	if got != want {
+		t.Errorf("Collect() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAppendRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+	first := Snapshot{Time: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Version: "1.0", Operations: 10}
+	second := Snapshot{Time: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC), Version: "1.1", Operations: 12}
+	for _, s := range []Snapshot{first, second} {
+		if err := Append(path, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if len(got) != 2 || !got[0].Time.Equal(first.Time) || got[1].Operations != 12 {
+		t.Errorf("Read() = %+v", got)
+	}
+
+	if _, err := Read(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestByPeriod(t *testing.T) {
+	day := func(month time.Month, d int) Snapshot {
+		return Snapshot{Time: time.Date(2026, month, d, 0, 0, 0, 0, time.UTC), Operations: int(month)*100 + d}
+	}
+	snapshots := []Snapshot{day(1, 5), day(2, 10), day(3, 31), day(4, 1), day(6, 15), day(7, 1)}
+
+	tests := []struct {
+		period string
+		want   []int
+	}{
+		{PeriodAll, []int{105, 210, 331, 401, 615, 701}},
+		{PeriodMonth, []int{105, 210, 331, 401, 615, 701}},
+		{PeriodQuarter, []int{331, 615, 701}},
+		{PeriodYear, []int{701}},
+	}
+
+	for _, tt := range tests {
+		kept, err := ByPeriod(snapshots, tt.period)
+		if err != nil {
+			t.Fatalf("ByPeriod(%s) error: %v", tt.period, err)
+		}
+		var got []int
+		for _, s := range kept {
+			got = append(got, s.Operations)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ByPeriod(%s) = %v, want %v", tt.period, got, tt.want)
+		}
+	}
+
+	if _, err := ByPeriod(snapshots, "week"); err == nil {
+		t.Error("Expected error for an unknown period")
+	}
+}
+
+func TestFormatTrend(t *testing.T) {
+	out := FormatTrend([]Snapshot{
+		{Time: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Version: "1.0", Paths: 8, Operations: 20, Schemas: 10},
+		{Time: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC), Version: "1.1", Paths: 9, Operations: 25, Schemas: 10, Deprecated: 2},
+	})
+
+	for _, want := range []string{
+		"| 2026-01-05 | 1.0 | 8 | 20 | 10 | 0 |",
+		"| 2026-04-02 | 1.1 | 9 (+1) | 25 (+5) | 10 | 2 (+2) |",
+		"From 2026-01-05 to 2026-04-02: operations +5 (+25%), schemas +0 (+0%), deprecated operations +2.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+// Package sunset reports which deprecated operations, parameters, and request
+// body properties each API consumer still uses, based on recorded traffic.
+package sunset
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/har"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Kinds of deprecated elements.
+const (
+	KindOperation = "operation"
+	KindParameter = "parameter"
+	KindProperty  = "property"
+)
+
+// UnknownConsumer identifies requests without the consumer header or parameter.
+const UnknownConsumer = "(unknown)"
+
+// Consumer identifies who sent a request: a header such as an API key or
+// User-Agent, or a query parameter.
+type Consumer struct {
+	// In is "header" or "query".
+	In   string
+	Name string
+}
+
+// DefaultConsumer identifies consumers by User-Agent.
+var DefaultConsumer = Consumer{In: "header", Name: "User-Agent"}
+
+// ParseConsumer parses "header:NAME" or "query:NAME". A bare name is a header.
+func ParseConsumer(s string) (Consumer, error) {
+	in, name, found := strings.Cut(s, ":")
+	if !found {
+		in, name = "header", s
+	}
+	in = strings.ToLower(strings.TrimSpace(in))
+	name = strings.TrimSpace(name)
+
+	if name == "" || (in != "header" && in != "query") {
+		return Consumer{}, fmt.Errorf("invalid consumer %q: expected header:NAME or query:NAME", s)
+	}
+	return Consumer{In: in, Name: name}, nil
+}
+
+// identify returns the consumer of req.
+func (c Consumer) identify(req har.Request) string {
+	var value string
+	var ok bool
+	if c.In == "query" {
+		value, ok = req.Query(c.Name)
+	} else {
+		value, ok = req.Header(c.Name)
+	}
+	if !ok || value == "" {
+		return UnknownConsumer
+	}
+	return value
+}
+
+// Usage records a consumer's use of one deprecated element.
+type Usage struct {
+	Consumer string
+	Method   string
+	Path     string
+	Kind     string
+	// Name is the parameter ("query dry_run") or property path ("items[].sku").
+	// It is empty for operations.
+	Name      string
+	Requests  int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Report matches entries to the operations of doc and returns the deprecated
+// elements each consumer used, sorted by consumer, path, method, kind, and
+// name. Requests that match no operation are ignored.
+func Report(doc *openapi3.T, entries []har.Entry, consumer Consumer) []Usage {
+	matcher := har.NewMatcher(doc)
+	usages := make(map[Usage]*Usage)
+
+	record := func(entry har.Entry, key Usage) {
+		u, ok := usages[key]
+		if !ok {
+			first := key
+			first.FirstSeen = entry.StartedDateTime
+			u = &first
+			usages[key] = u
+		}
+		u.Requests++
+		if entry.StartedDateTime.Before(u.FirstSeen) {
+			u.FirstSeen = entry.StartedDateTime
+		}
+		if entry.StartedDateTime.After(u.LastSeen) {
+			u.LastSeen = entry.StartedDateTime
+		}
+	}
+
+	for _, entry := range entries {
+		match, ok := matcher.Match(entry.Request)
+		if !ok {
+			continue
+		}
+		base := Usage{Consumer: consumer.identify(entry.Request), Method: match.Method, Path: match.Path}
+
+		if match.Operation.Deprecated {
+			key := base
+			key.Kind = KindOperation
+			record(entry, key)
+		}
+
+		for _, param := range deprecatedParameters(match.PathItem, match.Operation) {
+			if sentParameter(entry.Request, param) {
+				key := base
+				key.Kind, key.Name = KindParameter, param.In+" "+param.Name
+				record(entry, key)
+			}
+		}
+
+		for _, name := range deprecatedProperties(match.Operation, entry.Request) {
+			key := base
+			key.Kind, key.Name = KindProperty, name
+			record(entry, key)
+		}
+	}
+
+	result := make([]Usage, 0, len(usages))
+	for _, u := range usages {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Consumer != b.Consumer {
+			return a.Consumer < b.Consumer
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
+
+// deprecatedParameters returns the deprecated parameters of an operation,
+// including those inherited from its path item.
+func deprecatedParameters(pathItem *openapi3.PathItem, op *openapi3.Operation) []*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter)
+	var order []string
+	for _, list := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+		for _, ref := range list {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			key := ref.Value.In + " " + ref.Value.Name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = ref.Value
+		}
+	}
+
+	var deprecated []*openapi3.Parameter
+	for _, key := range order {
+		if params[key].Deprecated {
+			deprecated = append(deprecated, params[key])
+		}
+	}
+	return deprecated
+}
+
+// sentParameter reports whether req carries param.
+func sentParameter(req har.Request, param *openapi3.Parameter) bool {
+	switch param.In {
+	case openapi3.ParameterInQuery:
+		_, ok := req.Query(param.Name)
+		return ok
+	case openapi3.ParameterInHeader:
+		_, ok := req.Header(param.Name)
+		return ok
+	case openapi3.ParameterInCookie:
+		cookies, ok := req.Header("Cookie")
+		if !ok {
+			return false
+		}
+		_, err := (&http.Request{Header: http.Header{"Cookie": {cookies}}}).Cookie(param.Name)
+		return err == nil
+	default:
+		// Path parameters are always sent
+		return true
+	}
+}
+
+// deprecatedProperties returns the deprecated request body properties
+// present in the JSON body of req, as dotted paths.
+func deprecatedProperties(op *openapi3.Operation, req har.Request) []string {
+	if op.RequestBody == nil || op.RequestBody.Value == nil || req.PostData == nil || req.PostData.Text == "" {
+		return nil
+	}
+
+	schema := bodySchema(op.RequestBody.Value.Content, req.PostData.MimeType)
+	if schema == nil {
+		return nil
+	}
+
+	var body any
+	if err := json.Unmarshal([]byte(req.PostData.Text), &body); err != nil {
+		return nil
+	}
+
+	found := make(map[string]bool)
+	walk(schema, body, "", found, 0)
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bodySchema returns the request body schema for a recorded media type,
+// falling back to the first JSON media type.
+func bodySchema(content openapi3.Content, mimeType string) *openapi3.Schema {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		if mt := content.Get(mediaType); mt != nil && mt.Schema != nil {
+			return mt.Schema.Value
+		}
+	}
+
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	for _, contentType := range types {
+		mt := content[contentType]
+		if strings.Contains(contentType, "json") && mt != nil && mt.Schema != nil {
+			return mt.Schema.Value
+		}
+	}
+	return nil
+}
+
+// maxWalkDepth bounds recursion through nested bodies.
+const maxWalkDepth = 32
+
+// walk records the deprecated properties of schema present in value.
+func walk(schema *openapi3.Schema, value any, prefix string, found map[string]bool, depth int) {
+	if schema == nil || depth > maxWalkDepth {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for name, child := range v {
+			for _, prop := range properties(schema, name) {
+				path := name
+				if prefix != "" {
+					path = prefix + "." + name
+				}
+				if prop.Deprecated {
+					found[path] = true
+				}
+				walk(prop, child, path, found, depth+1)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			for _, s := range composed(schema) {
+				if s.Items != nil {
+					walk(s.Items.Value, item, prefix+"[]", found, depth+1)
+				}
+			}
+		}
+	}
+}
+
+// properties returns the schemas for a property name across schema and its
+// allOf, oneOf, and anyOf members.
+func properties(schema *openapi3.Schema, name string) []*openapi3.Schema {
+	var result []*openapi3.Schema
+	for _, s := range composed(schema) {
+		if ref := s.Properties[name]; ref != nil && ref.Value != nil {
+			result = append(result, ref.Value)
+		}
+	}
+	return result
+}
+
+// composed returns schema followed by its direct allOf, oneOf, and anyOf members.
+func composed(schema *openapi3.Schema) []*openapi3.Schema {
+	result := []*openapi3.Schema{schema}
+	for _, refs := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range refs {
+			if ref != nil && ref.Value != nil {
+				result = append(result, ref.Value)
+			}
+		}
+	}
+	return result
+}
+
+// WriteCSV writes usages as CSV with a header row.
+func WriteCSV(w io.Writer, usages []Usage) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"consumer", "method", "path", "kind", "name", "requests", "first_seen", "last_seen"}); err != nil {
+		return err
+	}
+	for _, u := range usages {
+		if err := cw.Write([]string{
+			u.Consumer, u.Method, u.Path, u.Kind, u.Name,
+			strconv.Itoa(u.Requests),
+			formatTime(u.FirstSeen), formatTime(u.LastSeen),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,143 @@
+package sunset
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/har"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func sunsetDoc(t *testing.T) *openapi3.T {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+paths:
+  /items:
+    get:
+      parameters:
+        - {name: page, in: query, deprecated: true, schema: {type: integer}}
+        - {name: cursor, in: query, schema: {type: string}}
+        - {name: legacy_session, in: cookie, deprecated: true, schema: {type: string}}
+      responses: {'200': {description: OK}}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name: {type: string}
+                colour: {type: string, deprecated: true}
+                lines:
+                  type: array
+                  items:
+                    allOf:
+                      - type: object
+                        properties:
+                          sku: {type: string}
+                          qty_legacy: {type: integer, deprecated: true}
+      responses: {'201': {description: Created}}
+  /items/{id}/archive:
+    post:
+      deprecated: true
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses: {'204': {description: Archived}}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+	return doc
+}
+
+func entry(at, method, url, key, body string, headers ...har.NameValue) har.Entry {
+	started, _ := time.Parse(time.RFC3339, at)
+	req := har.Request{Method: method, URL: url, Headers: append(headers, har.NameValue{Name: "X-API-Key", Value: key})}
+	if i := strings.Index(url, "?"); i >= 0 {
+		for _, pair := range strings.Split(url[i+1:], "&") {
+			name, value, _ := strings.Cut(pair, "=")
+			req.QueryString = append(req.QueryString, har.NameValue{Name: name, Value: value})
+		}
+	}
+	if body != "" {
+		req.PostData = &har.PostData{MimeType: "application/json; charset=utf-8", Text: body}
+	}
+	return har.Entry{StartedDateTime: started, Request: req}
+}
+
+func TestReport(t *testing.T) {
+	entries := []har.Entry{
+		entry("2026-03-02T10:00:00Z", "GET", "https://api.example.com/items?page=2", "acme", ""),
+		entry("2026-03-01T10:00:00Z", "GET", "https://api.example.com/items?page=1&cursor=x", "acme", ""),
+		entry("2026-03-01T11:00:00Z", "GET", "https://api.example.com/items?cursor=x", "globex", "",
+			har.NameValue{Name: "Cookie", Value: "theme=dark; legacy_session=abc"}),
+		entry("2026-03-01T12:00:00Z", "POST", "https://api.example.com/items", "globex",
+			`{"name": "w", "colour": "red", "lines": [{"sku": "a", "qty_legacy": 1}]}`),
+		entry("2026-03-01T13:00:00Z", "POST", "https://api.example.com/items/9/archive", "", ""),
+		entry("2026-03-01T14:00:00Z", "GET", "https://api.example.com/unknown?page=1", "acme", ""),
+	}
+
+	usages := Report(sunsetDoc(t), entries, Consumer{In: "header", Name: "x-api-key"})
+
+	expected := []string{
+		UnknownConsumer + " POST /items/{id}/archive operation  1",
+		"acme GET /items parameter query page 2",
+		"globex GET /items parameter cookie legacy_session 1",
+		"globex POST /items property colour 1",
+		"globex POST /items property lines[].qty_legacy 1",
+	}
+	if len(usages) != len(expected) {
+		t.Fatalf("Report() returned %d usages, want %d: %+v", len(usages), len(expected), usages)
+	}
+	for i, want := range expected {
+		u := usages[i]
+		got := strings.Join([]string{u.Consumer, u.Method, u.Path, u.Kind, u.Name, strconv.Itoa(u.Requests)}, " ")
+		if got != want {
+			t.Errorf("usage %d = %q, want %q", i, got, want)
+		}
+	}
+
+	if first, last := usages[1].FirstSeen.Day(), usages[1].LastSeen.Day(); first != 1 || last != 2 {
+		t.Errorf("Expected first/last seen on days 1 and 2, got %d and %d", first, last)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, usages[1:2]); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "consumer,method,path,kind,name,requests,first_seen,last_seen\n" +
+		"acme,GET,/items,parameter,query page,2,2026-03-01T10:00:00Z,2026-03-02T10:00:00Z\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestParseConsumer(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Consumer
+		wantErr  bool
+	}{
+		{"header:X-API-Key", Consumer{In: "header", Name: "X-API-Key"}, false},
+		{"query:api_key", Consumer{In: "query", Name: "api_key"}, false},
+		{"User-Agent", Consumer{In: "header", Name: "User-Agent"}, false},
+		{"cookie:session", Consumer{}, true},
+		{"header:", Consumer{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseConsumer(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConsumer(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseConsumer(%q) = %+v, want %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package tags checks the tags of a spec for drift from its top-level tag
+// list and normalizes them to canonical names from the configuration.
+package tags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/export"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Config is the tag taxonomy from the configuration file.
+type Config struct {
+	// Canonical maps tag names, compared ignoring case, to the name they
+	// are listed, grouped, and exported under, e.g. "Payment: payments".
+	Canonical map[string]string `yaml:"canonical"`
+}
+
+// Rule identifiers reported in problems.
+const (
+	// RuleUndeclared flags tags used on operations but missing from the
+	// top-level tags list.
+	RuleUndeclared = "undeclared"
+	// RuleCaseVariant flags tags that differ from another only in case.
+	RuleCaseVariant = "case-variant"
+)
+
+// Problem is a tag that drifted from the taxonomy.
+type Problem struct {
+	Rule    string `json:"rule"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+// String formats the problem as a single line.
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s (%s)", p.Tag, p.Message, p.Rule)
+}
+
+// Tag is a tag and the operations using it.
+type Tag struct {
+	Name string `json:"name"`
+	// Declared reports whether the tag is in the top-level tags list.
+	Declared   bool `json:"declared"`
+	Operations int  `json:"operations"`
+}
+
+// List returns every tag declared in doc or used by an operation, in the
+// order of the top-level tags list followed by undeclared tags by name.
+func List(doc *openapi3.T) []Tag {
+	var out []Tag
+	index := make(map[string]int)
+	for _, t := range doc.Tags {
+		if t == nil {
+			continue
+		}
+		if _, ok := index[t.Name]; !ok {
+			index[t.Name] = len(out)
+			out = append(out, Tag{Name: t.Name, Declared: true})
+		}
+	}
+
+	declared := len(out)
+	for _, op := range export.Operations(doc) {
+		for _, name := range op.Operation.Tags {
+			i, ok := index[name]
+			if !ok {
+				i = len(out)
+				index[name] = i
+				out = append(out, Tag{Name: name})
+			}
+			out[i].Operations++
+		}
+	}
+
+	undeclared := out[declared:]
+	sort.Slice(undeclared, func(i, j int) bool { return undeclared[i].Name < undeclared[j].Name })
+	return out
+}
+
+// Check reports tags used on operations but not declared at the top level,
+// and tags that differ from another only in case.
+func Check(doc *openapi3.T) []Problem {
+	list := List(doc)
+
+	var problems []Problem
+	variants := make(map[string][]Tag)
+	var folded []string
+	for _, t := range list {
+		key := strings.ToLower(t.Name)
+		if _, ok := variants[key]; !ok {
+			folded = append(folded, key)
+		}
+		variants[key] = append(variants[key], t)
+	}
+
+	for _, key := range folded {
+		if tags := variants[key]; len(tags) > 1 {
+			names := make([]string, len(tags))
+			for i, t := range tags {
+				names[i] = fmt.Sprintf("%q on %d operation(s)", t.Name, t.Operations)
+			}
+			problems = append(problems, Problem{
+				Rule:    RuleCaseVariant,
+				Tag:     tags[0].Name,
+				Message: "tags differ only in case: " + strings.Join(names, ", "),
+			})
+		}
+	}
+
+	for _, t := range list {
+		if !t.Declared {
+			problems = append(problems, Problem{
+				Rule:    RuleUndeclared,
+				Tag:     t.Name,
+				Message: fmt.Sprintf("used by %d operation(s) but missing from the top-level tags list", t.Operations),
+			})
+		}
+	}
+
+	return problems
+}
+
+// Normalize renames the tags of doc's operations and top-level tags list to
+// their canonical names, dropping the duplicates this creates. A renamed
+// top-level tag keeps its description unless the canonical one is also
+// declared. It returns the number of tags renamed.
+func Normalize(doc *openapi3.T, canonical map[string]string) int {
+	if len(canonical) == 0 || doc == nil {
+		return 0
+	}
+	folded := make(map[string]string, len(canonical))
+	for from, to := range canonical {
+		folded[strings.ToLower(strings.TrimSpace(from))] = strings.TrimSpace(to)
+	}
+	rename := func(name string) (string, bool) {
+		to, ok := folded[strings.ToLower(name)]
+		if !ok || to == "" || to == name {
+			return name, false
+		}
+		return to, true
+	}
+
+	renamed := 0
+	var declared openapi3.Tags
+	index := make(map[string]int)
+	wasRenamed := make(map[string]bool)
+	for _, t := range doc.Tags {
+		if t == nil {
+			continue
+		}
+		name, ok := rename(t.Name)
+		if ok {
+			renamed++
+			copied := *t
+			copied.Name = name
+			t = &copied
+		}
+		if i, dup := index[name]; !dup {
+			index[name] = len(declared)
+			wasRenamed[name] = ok
+			declared = append(declared, t)
+		} else if wasRenamed[name] && !ok {
+			declared[i] = t
+			wasRenamed[name] = false
+		}
+	}
+	doc.Tags = declared
+
+	for _, op := range export.Operations(doc) {
+		var names []string
+		seen := make(map[string]bool)
+		for _, name := range op.Operation.Tags {
+			name, ok := rename(name)
+			if ok {
+				renamed++
+			}
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		op.Operation.Tags = names
+	}
+
+	return renamed
+}
@@ -0,0 +1,92 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+tags:
+  - name: Payments
+    description: Moving money
+  - name: users
+paths:
+  /payments:
+    post:
+      tags: [payments]
+      responses:
+        "201":
+          description: Created
+  /payments/{id}:
+    get:
+      tags: [Payments, Billing]
+      responses:
+        "200":
+          description: OK
+`
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestCheck(t *testing.T) {
+	doc := loadTestSpec(t)
+
+	var got []string
+	for _, tag := range List(doc) {
+		got = append(got, tag.Name)
+	}
+	if strings.Join(got, ",") != "Payments,users,Billing,payments" {
+		t.Errorf("List() = %v", got)
+	}
+
+	got = nil
+	for _, p := range Check(doc) {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`Payments: tags differ only in case: "Payments" on 1 operation(s), "payments" on 1 operation(s) (case-variant)`,
+		"Billing: used by 1 operation(s) but missing from the top-level tags list (undeclared)",
+		"payments: used by 1 operation(s) but missing from the top-level tags list (undeclared)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Check() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	doc := loadTestSpec(t)
+
+	if n := Normalize(doc, map[string]string{"PAYMENTS": "payments", "billing": "payments"}); n != 3 {
+		t.Errorf("Normalize() renamed %d tags, want 3", n)
+	}
+	if problems := Check(doc); len(problems) != 0 {
+		t.Errorf("Expected no problems after normalizing, got %v", problems)
+	}
+
+	list := List(doc)
+	if len(list) != 2 || list[0].Name != "payments" || list[0].Operations != 2 || !list[0].Declared {
+		t.Errorf("List() = %+v", list)
+	}
+	if doc.Tags[0].Description != "Moving money" {
+		t.Errorf("Expected the renamed tag to keep its description, got %+v", doc.Tags[0])
+	}
+	if tags := doc.Paths.Value("/payments/{id}").Get.Tags; strings.Join(tags, ",") != "payments" {
+		t.Errorf("Expected duplicate tags to be dropped, got %v", tags)
+	}
+
+	if n := Normalize(doc, nil); n != 0 {
+		t.Errorf("Normalize(nil) renamed %d tags", n)
+	}
+}
@@ -0,0 +1,278 @@
+// Package testplan derives a checklist of test cases from the contract of
+// operations: credentials left out, each required parameter or body field
+// left out, values at and beyond the bounds of each enum or range, and each
+// documented response status.
+package testplan
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arthur-s/docfinder/internal/model"
+)
+
+// Kinds of test cases.
+const (
+	KindAuth     = "auth"
+	KindRequired = "required"
+	KindEnum     = "enum"
+	KindRange    = "range"
+	KindStatus   = "status"
+)
+
+// Case is a test case of an operation.
+type Case struct {
+	Method string
+	Path   string
+	Kind   string
+	// Description says what the request does, e.g. "Omit the required
+	// query parameter `limit`".
+	Description string
+	// Expect is the response status expected, e.g. "400", or "2xx" if the
+	// operation documents no success status.
+	Expect string
+}
+
+// Plan returns the test cases of the operations of path in api, all of them
+// if method is empty. path is a path template of the spec, whose parameter
+// names may differ from the spec's.
+func Plan(api *model.API, method, path string) ([]Case, error) {
+	template, ok := api.Find(path)
+	if !ok {
+		return nil, fmt.Errorf("endpoint not found: %s", path)
+	}
+	// Name the operations by the spec's template
+	path = template
+
+	method = strings.ToUpper(method)
+	var cases []Case
+	for _, op := range api.PathOperations(path) {
+		if method != "" && op.Method != method {
+			continue
+		}
+		cases = append(cases, operationCases(op)...)
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no %s operation for %s", method, path)
+	}
+	return cases, nil
+}
+
+// operationCases returns the test cases of one operation.
+func operationCases(op *model.Operation) []Case {
+	p := planner{method: op.Method, path: op.Path, invalid: invalidStatus(op), success: successStatus(op)}
+
+	if authRequired(op.Security) {
+		p.add(KindAuth, "Send no credentials", "401")
+	}
+
+	for _, param := range op.Parameters {
+		// Leaving out a path parameter requests another path
+		if param.Required && param.In != model.InPath {
+			p.add(KindRequired, fmt.Sprintf("Omit the required %s parameter `%s`", param.In, param.Name), p.invalid)
+		}
+	}
+
+	var body *model.Schema
+	if op.RequestBody != nil {
+		if op.RequestBody.Required {
+			p.add(KindRequired, "Send no request body", p.invalid)
+		}
+		if body = jsonSchema(op.RequestBody); body != nil {
+			for _, name := range body.Required {
+				p.add(KindRequired, fmt.Sprintf("Omit the required body field `%s`", name), p.invalid)
+			}
+		}
+	}
+
+	for _, param := range op.Parameters {
+		if param.Schema != nil {
+			p.valueCases(fmt.Sprintf("%s parameter `%s`", param.In, param.Name), param.Schema)
+		}
+	}
+	if body != nil {
+		for _, name := range body.PropertyNames() {
+			p.valueCases(fmt.Sprintf("body field `%s`", name), body.Properties[name])
+		}
+	}
+
+	for _, status := range statuses(op) {
+		description := fmt.Sprintf("Get a `%s` response", status)
+		if text := http.StatusText(atoi(status)); text != "" {
+			description = fmt.Sprintf("Get a `%s %s` response", status, text)
+		}
+		if resp := op.Response(status); resp != nil && resp.Description != "" {
+			description += ": " + firstLine(resp.Description)
+		}
+		p.add(KindStatus, description, status)
+	}
+	return p.cases
+}
+
+// planner collects the test cases of an operation.
+type planner struct {
+	method, path string
+	// invalid and success are the statuses expected for invalid and valid
+	// requests.
+	invalid, success string
+	cases            []Case
+}
+
+func (p *planner) add(kind, description, expect string) {
+	p.cases = append(p.cases, Case{Method: p.method, Path: p.path, Kind: kind, Description: description, Expect: expect})
+}
+
+// valueCases adds the cases of the allowed values of a parameter or body
+// field: each enum value and one outside the enum, or the bounds of a range
+// and the values just beyond them.
+func (p *planner) valueCases(name string, schema *model.Schema) {
+	if len(schema.Enum) == 0 && schema.Items != nil {
+		schema = schema.Items
+	}
+
+	if len(schema.Enum) > 0 {
+		for _, value := range schema.Enum {
+			p.add(KindEnum, fmt.Sprintf("Set %s to `%v`", name, value), p.success)
+		}
+		p.add(KindEnum, fmt.Sprintf("Set %s to a value outside the enum", name), p.invalid)
+		return
+	}
+
+	integer := schema.Is("integer")
+	if !integer && !schema.Is("number") {
+		return
+	}
+	if schema.Min != nil {
+		p.boundCases(name, "minimum", "below", *schema.Min, schema.ExclusiveMin, -1, integer)
+	}
+	if schema.Max != nil {
+		p.boundCases(name, "maximum", "above", *schema.Max, schema.ExclusiveMax, 1, integer)
+	}
+}
+
+// boundCases adds the cases of a minimum or maximum: the closest allowed
+// value and the closest value past it, which lies in direction dir. Past
+// bounds of numbers other than integers, the closest values are only named.
+func (p *planner) boundCases(name, bound, past string, value float64, exclusive bool, dir float64, integer bool) {
+	v := strconv.FormatFloat(value, 'f', -1, 64)
+	switch {
+	case integer:
+		inside, outside := value, value+dir
+		if exclusive {
+			inside, outside = value-dir, value
+		}
+		p.add(KindRange, fmt.Sprintf("Set %s to its %s `%s`", name, bound, strconv.FormatFloat(inside, 'f', -1, 64)), p.success)
+		p.add(KindRange, fmt.Sprintf("Set %s to `%s`, %s its %s", name, strconv.FormatFloat(outside, 'f', -1, 64), past, bound), p.invalid)
+	case exclusive:
+		p.add(KindRange, fmt.Sprintf("Set %s just inside its exclusive %s `%s`", name, bound, v), p.success)
+		p.add(KindRange, fmt.Sprintf("Set %s to its exclusive %s `%s`", name, bound, v), p.invalid)
+	default:
+		p.add(KindRange, fmt.Sprintf("Set %s to its %s `%s`", name, bound, v), p.success)
+		p.add(KindRange, fmt.Sprintf("Set %s just %s its %s `%s`", name, past, bound, v), p.invalid)
+	}
+}
+
+// jsonSchema returns the object schema of the JSON content of a request
+// body, or nil if it has none.
+func jsonSchema(body *model.Body) *model.Schema {
+	media := body.Media("application/json")
+	if media == nil {
+		for _, m := range body.Content {
+			if strings.HasSuffix(strings.Split(m.ContentType, ";")[0], "+json") {
+				media = m
+				break
+			}
+		}
+	}
+	if media == nil || media.Schema == nil || len(media.Schema.Properties) == 0 {
+		return nil
+	}
+	return media.Schema
+}
+
+// authRequired reports whether every security requirement names a scheme.
+func authRequired(security []model.SecurityRequirement) bool {
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			// An empty requirement allows anonymous calls
+			return false
+		}
+	}
+	return len(security) > 0
+}
+
+// statuses returns the documented response statuses, sorted, leaving out
+// "default".
+func statuses(op *model.Operation) []string {
+	var codes []string
+	for _, resp := range op.Responses {
+		if resp.Status != "default" {
+			codes = append(codes, resp.Status)
+		}
+	}
+	return codes
+}
+
+// successStatus returns the first documented 2xx status, or "2xx".
+func successStatus(op *model.Operation) string {
+	for _, status := range statuses(op) {
+		if strings.HasPrefix(status, "2") {
+			return status
+		}
+	}
+	return "2xx"
+}
+
+// invalidStatus returns the status expected for invalid requests: 400,
+// unless only 422 is documented.
+func invalidStatus(op *model.Operation) string {
+	if op.Response("400") == nil && op.Response("422") != nil {
+		return "422"
+	}
+	return "400"
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// Format renders cases as a markdown checklist, one section per operation.
+func Format(path string, cases []Case) string {
+	var md strings.Builder
+	fmt.Fprintf(&md, "# Test Plan: %s\n", path)
+
+	operation := ""
+	for _, c := range cases {
+		if key := c.Method + " " + c.Path; key != operation {
+			operation = key
+			fmt.Fprintf(&md, "\n## %s\n\n", key)
+		}
+		fmt.Fprintf(&md, "- [ ] %s → `%s` (%s)\n", c.Description, c.Expect, c.Kind)
+	}
+	return md.String()
+}
+
+// WriteCSV writes cases as CSV with a header row.
+func WriteCSV(w io.Writer, cases []Case) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"method", "path", "kind", "case", "expect"}); err != nil {
+		return err
+	}
+	for _, c := range cases {
+		if err := cw.Write([]string{c.Method, c.Path, c.Kind, c.Description, c.Expect}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
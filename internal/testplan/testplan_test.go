@@ -0,0 +1,126 @@
+package testplan
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/model"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func planAPI(t *testing.T) *model.API {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.3
+info: {title: Test API, version: 1.0.0}
+security:
+  - bearer: []
+components:
+  securitySchemes:
+    bearer: {type: http, scheme: bearer}
+paths:
+  /accounts/{id}/events:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: string}}
+      - {name: X-Tenant, in: header, required: true, schema: {type: string}}
+    get:
+      security: [{}, {bearer: []}]
+      parameters:
+        - {name: status, in: query, schema: {type: string, enum: [open, closed]}}
+        - {name: limit, in: query, required: true, schema: {type: integer, minimum: 1, maximum: 100, exclusiveMaximum: true}}
+      responses:
+        '200': {description: "The events.\nNewest first."}
+        '404': {description: Not found}
+    post:
+      requestBody:
+        required: true
+        content:
+          application/vnd.acme+json:
+            schema:
+              type: object
+              required: [kind]
+              properties:
+                kind: {type: string, enum: [created]}
+                weight: {type: number, minimum: 0.5}
+      responses:
+        '201': {description: Created}
+        '422': {description: Invalid}
+        default: {description: Error}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model.FromOpenAPI(doc)
+}
+
+func TestPlan(t *testing.T) {
+	cases, err := Plan(planAPI(t), "", "/accounts/{account_id}/events")
+	if err != nil {
+		t.Fatalf("Plan() error: %v", err)
+	}
+
+	var got []string
+	for _, c := range cases {
+		if c.Path != "/accounts/{id}/events" {
+			t.Errorf("Case %+v named by %s, want the spec's template", c, c.Path)
+		}
+		got = append(got, c.Method+" "+c.Kind+" "+c.Description+" -> "+c.Expect)
+	}
+	expected := []string{
+		"GET required Omit the required header parameter `X-Tenant` -> 400",
+		"GET required Omit the required query parameter `limit` -> 400",
+		"GET enum Set query parameter `status` to `open` -> 200",
+		"GET enum Set query parameter `status` to `closed` -> 200",
+		"GET enum Set query parameter `status` to a value outside the enum -> 400",
+		"GET range Set query parameter `limit` to its minimum `1` -> 200",
+		"GET range Set query parameter `limit` to `0`, below its minimum -> 400",
+		"GET range Set query parameter `limit` to its maximum `99` -> 200",
+		"GET range Set query parameter `limit` to `100`, above its maximum -> 400",
+		"GET status Get a `200 OK` response: The events. -> 200",
+		"GET status Get a `404 Not Found` response: Not found -> 404",
+		"POST auth Send no credentials -> 401",
+		"POST required Omit the required header parameter `X-Tenant` -> 422",
+		"POST required Send no request body -> 422",
+		"POST required Omit the required body field `kind` -> 422",
+		"POST enum Set body field `kind` to `created` -> 201",
+		"POST enum Set body field `kind` to a value outside the enum -> 422",
+		"POST range Set body field `weight` to its minimum `0.5` -> 201",
+		"POST range Set body field `weight` just below its minimum `0.5` -> 422",
+		"POST status Get a `201 Created` response: Created -> 201",
+		"POST status Get a `422 Unprocessable Entity` response: Invalid -> 422",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Plan() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+
+	if cases, err := Plan(planAPI(t), "post", "/accounts/{id}/events"); err != nil || cases[0].Method != "POST" || cases[len(cases)-1].Method != "POST" {
+		t.Errorf("Expected only POST cases, got %v, %v", cases, err)
+	}
+	if _, err := Plan(planAPI(t), "DELETE", "/accounts/{id}/events"); err == nil {
+		t.Error("Expected error for a method the path doesn't have")
+	}
+	if _, err := Plan(planAPI(t), "", "/users"); err == nil {
+		t.Error("Expected error for an unknown path")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []Case{
+		{Method: "GET", Path: "/items", Kind: KindAuth, Description: "Send no credentials", Expect: "401"},
+		{Method: "POST", Path: "/items", Kind: KindStatus, Description: "Get a `201 Created` response, with a \"quote\"", Expect: "201"},
+	}
+
+	expected := "# Test Plan: /items\n\n## GET /items\n\n- [ ] Send no credentials → `401` (auth)\n\n## POST /items\n\n- [ ] Get a `201 Created` response, with a \"quote\" → `201` (status)\n"
+	if got := Format("/items", cases); got != expected {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, expected)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, cases); err != nil {
+		t.Fatal(err)
+	}
+	expected = "method,path,kind,case,expect\nGET,/items,auth,Send no credentials,401\nPOST,/items,status,\"Get a `201 Created` response, with a \"\"quote\"\"\",201\n"
+	if buf.String() != expected {
+		t.Errorf("WriteCSV() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
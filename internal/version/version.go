@@ -0,0 +1,98 @@
+// Package version compares loosely formatted API version strings such as
+// "v2.3", "2.10.1", or date-based versions like "2023-10".
+package version
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Compare returns -1, 0, or +1 depending on whether a sorts before, equal to,
+// or after b. Versions are split into numeric and non-numeric segments;
+// numeric segments compare by value and the rest lexically, so "v2.10" sorts
+// after "v2.9". A leading "v" is ignored.
+func Compare(a, b string) int {
+	sa := segments(a)
+	sb := segments(b)
+
+	for i := 0; i < len(sa) && i < len(sb); i++ {
+		if c := compareSegment(sa[i], sb[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(sa) < len(sb):
+		return -1
+	case len(sa) > len(sb):
+		return 1
+	}
+	return 0
+}
+
+// segments splits a version into runs of digits and runs of letters,
+// dropping separators and a leading "v".
+func segments(v string) []string {
+	v = strings.TrimSpace(v)
+	if len(v) > 1 && (v[0] == 'v' || v[0] == 'V') && unicode.IsDigit(rune(v[1])) {
+		v = v[1:]
+	}
+
+	var result []string
+	var current strings.Builder
+	currentDigit := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			result = append(result, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range v {
+		switch {
+		case unicode.IsDigit(r):
+			if !currentDigit {
+				flush()
+			}
+			currentDigit = true
+			current.WriteRune(r)
+		case unicode.IsLetter(r):
+			if currentDigit {
+				flush()
+			}
+			currentDigit = false
+			current.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return result
+}
+
+// compareSegment compares two version segments numerically when both are numbers.
+func compareSegment(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		// Numbers sort after pre-release labels: "1.0.rc" < "1.0.1"
+		return 1
+	case errB == nil:
+		return -1
+	}
+
+	return strings.Compare(a, b)
+}
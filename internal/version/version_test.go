@@ -0,0 +1,29 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"v2.3", "2.3", 0},
+		{"2.9", "2.10", -1},
+		{"v4.0", "v2.3", 1},
+		{"2.3", "2.3.1", -1},
+		{"2023-10", "2024-04", -1},
+		{"2024-04", "2023-10", 1},
+		{"1.0.rc1", "1.0.1", -1},
+		{"1.0-beta", "1.0-alpha", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			result := Compare(tt.a, tt.b)
+			if result != tt.expected {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package watch polls OpenAPI specs for changes and summarizes how their
+// endpoints changed, for notifying webhooks from a long-running process.
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/diff"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// DefaultInterval is how often specs are checked when no interval is configured.
+const DefaultInterval = 5 * time.Second
+
+// Config holds the watch settings from the configuration file.
+type Config struct {
+	// Interval is how often specs are checked for changes.
+	Interval time.Duration `yaml:"interval"`
+	// Webhooks are notified of every change.
+	Webhooks []Webhook `yaml:"webhooks"`
+	// BreakingOnly notifies webhooks only of changes with breaking changes.
+	BreakingOnly bool `yaml:"breaking_only"`
+}
+
+// Event describes how a watched spec changed.
+type Event struct {
+	// Spec is the watched path.
+	Spec string `json:"spec"`
+	// Title and Version are the API title and version after the change.
+	Title   string    `json:"title,omitempty"`
+	Version string    `json:"version,omitempty"`
+	Time    time.Time `json:"time"`
+	// Endpoints lists the changed paths.
+	Endpoints []EndpointChange `json:"endpoints"`
+}
+
+// EndpointChange lists the changes to one path.
+type EndpointChange struct {
+	Path    string   `json:"path"`
+	Changes []Change `json:"changes"`
+}
+
+// Change is a single endpoint change.
+type Change struct {
+	Kind        diff.Kind `json:"kind"`
+	Description string    `json:"description"`
+	Breaking    bool      `json:"breaking"`
+}
+
+// Breaking returns the number of breaking changes.
+func (e Event) Breaking() int {
+	n := 0
+	for _, endpoint := range e.Endpoints {
+		for _, change := range endpoint.Changes {
+			if change.Breaking {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Summary returns a one-line description such as
+// "Events API 1.1.0 (openapi.yaml): 3 endpoint(s) changed, 1 breaking change(s)".
+func (e Event) Summary() string {
+	name := e.Spec
+	if e.Title != "" {
+		name = strings.TrimSpace(e.Title+" "+e.Version) + " (" + e.Spec + ")"
+	}
+	summary := fmt.Sprintf("%s: %d endpoint(s) changed", name, len(e.Endpoints))
+	if n := e.Breaking(); n > 0 {
+		summary += fmt.Sprintf(", %d breaking change(s)", n)
+	}
+	return summary
+}
+
+// NewEvent summarizes the endpoint changes between two versions of spec.
+// It returns false if no endpoint changed.
+func NewEvent(spec string, old, new *openapi3.T, now time.Time) (Event, bool) {
+	event := Event{Spec: spec, Time: now.UTC()}
+	if new != nil && new.Info != nil {
+		event.Title, event.Version = new.Info.Title, new.Info.Version
+	}
+
+	for _, endpoint := range diff.Documents(old, new) {
+		change := EndpointChange{Path: endpoint.Path}
+		for _, c := range endpoint.Changes {
+			change.Changes = append(change.Changes, Change{Kind: c.Kind, Description: c.String(), Breaking: c.Breaking})
+		}
+		event.Endpoints = append(event.Endpoints, change)
+	}
+	return event, len(event.Endpoints) > 0
+}
+
+// Watcher polls specs and reports endpoint changes.
+type Watcher struct {
+	// Specs are the watched spec files or directories of spec fragments.
+	Specs []string
+	// Interval is the polling interval. Zero means DefaultInterval.
+	Interval time.Duration
+	// Load parses a spec. It is called with each entry of Specs.
+	Load func(path string) (*openapi3.T, error)
+	// OnChange is called for each spec whose endpoints changed.
+	OnChange func(Event)
+	// OnError is called when a changed spec fails to load. The previous
+	// version is kept until the spec loads again.
+	OnError func(path string, err error)
+
+	states map[string]*state
+}
+
+// state is the last loaded version of a spec.
+type state struct {
+	fingerprint string
+	doc         *openapi3.T
+}
+
+// Run loads every spec, then polls them until ctx is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	if err := w.Init(); err != nil {
+		return err
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.Poll()
+		}
+	}
+}
+
+// Init loads the initial version of every spec. A spec that fails to load
+// is an error, so typos are caught at startup.
+func (w *Watcher) Init() error {
+	w.states = make(map[string]*state, len(w.Specs))
+	for _, path := range w.Specs {
+		fingerprint, err := Fingerprint(path)
+		if err != nil {
+			return err
+		}
+		doc, err := w.Load(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		w.states[path] = &state{fingerprint: fingerprint, doc: doc}
+	}
+	return nil
+}
+
+// Poll checks every spec once, reporting the specs whose endpoints changed
+// since the last successful load.
+func (w *Watcher) Poll() {
+	for _, path := range w.Specs {
+		s := w.states[path]
+		if s == nil {
+			continue
+		}
+
+		fingerprint, err := Fingerprint(path)
+		if err != nil {
+			w.reportError(path, err)
+			continue
+		}
+		if fingerprint == s.fingerprint {
+			continue
+		}
+
+		doc, err := w.Load(path)
+		if err != nil {
+			// Editors often write files in several steps; retry on the
+			// next poll rather than forgetting the last good version.
+			w.reportError(path, err)
+			continue
+		}
+
+		event, changed := NewEvent(path, s.doc, doc, time.Now())
+		s.fingerprint, s.doc = fingerprint, doc
+		if changed && w.OnChange != nil {
+			w.OnChange(event)
+		}
+	}
+}
+
+func (w *Watcher) reportError(path string, err error) {
+	if w.OnError != nil {
+		w.OnError(path, err)
+	}
+}
+
+// Fingerprint hashes the contents of a spec file, or of every YAML and JSON
+// file below a directory of spec fragments.
+func Fingerprint(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != path && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(p)) {
+			case ".yaml", ".yml", ".json":
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(files)
+	}
+
+	h := sha256.New()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00", file)
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,208 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const specV1 = `
+openapi: 3.0.3
+info: {title: Events API, version: 1.0.0}
+paths:
+  /events:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200': {description: OK}
+  /health:
+    get:
+      responses:
+        '200': {description: OK}
+`
+
+const specV2 = `
+openapi: 3.0.3
+info: {title: Events API, version: 1.1.0}
+paths:
+  /events:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: string}}
+        - {name: cursor, in: query, schema: {type: string}}
+      responses:
+        '200': {description: OK}
+  /health:
+    get:
+      responses:
+        '200': {description: OK}
+`
+
+// loadFile reads the file itself, since the loader's default reader caches
+// files by path.
+func loadFile(path string) (*openapi3.T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return openapi3.NewLoader().LoadFromData(data)
+}
+
+func writeSpec(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	writeSpec(t, path, specV1)
+
+	var events []Event
+	var errs []error
+	w := &Watcher{
+		Specs:    []string{path},
+		Load:     loadFile,
+		OnChange: func(e Event) { events = append(events, e) },
+		OnError:  func(_ string, err error) { errs = append(errs, err) },
+	}
+	if err := w.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+
+	w.Poll()
+	if len(events) != 0 {
+		t.Fatalf("Expected no events for an unchanged spec, got %+v", events)
+	}
+
+	writeSpec(t, path, "openapi: [broken")
+	w.Poll()
+	if len(events) != 0 || len(errs) != 1 {
+		t.Fatalf("Expected one load error and no events, got %+v, %v", events, errs)
+	}
+
+	writeSpec(t, path, specV2)
+	w.Poll()
+	if len(events) != 1 {
+		t.Fatalf("Expected one event, got %+v", events)
+	}
+
+	event := events[0]
+	if got := event.Summary(); !strings.HasSuffix(got, "Events API 1.1.0 ("+path+"): 1 endpoint(s) changed, 1 breaking change(s)") {
+		t.Errorf("Summary() = %q", got)
+	}
+	if len(event.Endpoints) != 1 || event.Endpoints[0].Path != "/events" || len(event.Endpoints[0].Changes) != 2 {
+		t.Errorf("Endpoints = %+v", event.Endpoints)
+	}
+}
+
+func TestFingerprint_Directory(t *testing.T) {
+	dir := t.TempDir()
+	writeSpec(t, filepath.Join(dir, "openapi.yaml"), specV1)
+	writeSpec(t, filepath.Join(dir, "notes.txt"), "a")
+
+	before, err := Fingerprint(dir)
+	if err != nil {
+		t.Fatalf("Fingerprint() error: %v", err)
+	}
+
+	writeSpec(t, filepath.Join(dir, "notes.txt"), "b")
+	if after, _ := Fingerprint(dir); after != before {
+		t.Error("Expected non-spec files to be ignored")
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "schemas"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeSpec(t, filepath.Join(dir, "schemas", "event.yaml"), "type: object")
+	if after, _ := Fingerprint(dir); after == before {
+		t.Error("Expected a new fragment to change the fingerprint")
+	}
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	event := Event{
+		Spec:  "openapi.yaml",
+		Title: "Events API",
+		Endpoints: []EndpointChange{{Path: "/events", Changes: []Change{
+			{Kind: "removed", Description: "Removed GET query parameter `limit`", Breaking: true},
+		}}},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatSlack} {
+		hook := Webhook{URL: server.URL + "/hooks/secret", Format: format}
+		if err := hook.Notify(context.Background(), server.Client(), event); err != nil {
+			t.Fatalf("Notify(%s) error: %v", format, err)
+		}
+	}
+
+	var decoded Event
+	if err := json.Unmarshal([]byte(bodies[0]), &decoded); err != nil || decoded.Breaking() != 1 {
+		t.Errorf("Expected the event as JSON, got %s", bodies[0])
+	}
+
+	var slack map[string]string
+	if err := json.Unmarshal([]byte(bodies[1]), &slack); err != nil {
+		t.Fatalf("Invalid Slack payload: %v", err)
+	}
+	expected := []string{":warning: *Events API (openapi.yaml): 1 endpoint(s) changed, 1 breaking change(s)*",
+		"• `/events` Removed GET query parameter `limit` *(breaking)*"}
+	for _, s := range expected {
+		if !strings.Contains(slack["text"], s) {
+			t.Errorf("Expected %q in Slack message:\n%s", s, slack["text"])
+		}
+	}
+}
+
+func TestWebhook_NotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := Webhook{URL: server.URL + "/hooks/secret"}.Notify(context.Background(), server.Client(), Event{})
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("Expected 403 error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "secret") {
+		t.Errorf("Expected the webhook path to be redacted: %v", err)
+	}
+}
+
+// failingTransport fails every request as an unreachable host would.
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestWebhook_NotifyDeliveryError(t *testing.T) {
+	client := &http.Client{Transport: failingTransport{}}
+	for _, url := range []string{"https://hooks.example.com/services/T0/B0/secret", "https://hooks.example.com/secret\x7f"} {
+		err := Webhook{URL: url}.Notify(context.Background(), client, Event{})
+		if err == nil || !strings.Contains(err.Error(), "hooks.example.com/…") {
+			t.Fatalf("Expected an error naming the redacted webhook, got %v", err)
+		}
+		if strings.Contains(err.Error(), "secret") {
+			t.Errorf("Expected the webhook path to be redacted: %v", err)
+		}
+	}
+}
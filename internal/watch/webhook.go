@@ -0,0 +1,143 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Format is the payload format of a webhook.
+type Format string
+
+// Webhook payload formats.
+const (
+	// FormatJSON posts the Event as JSON.
+	FormatJSON Format = "json"
+	// FormatSlack posts a Slack incoming-webhook message.
+	FormatSlack Format = "slack"
+)
+
+// ParseFormat parses a webhook format name. Empty means FormatJSON.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatSlack:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown webhook format: %s (expected json or slack)", s)
+}
+
+// webhookTimeout bounds each webhook delivery.
+const webhookTimeout = 10 * time.Second
+
+// maxSlackChanges caps the changes listed in a Slack message; the rest are
+// summarized as a count.
+const maxSlackChanges = 20
+
+// Webhook is an endpoint notified of spec changes.
+type Webhook struct {
+	URL string `yaml:"url"`
+	// Format is the payload format. Empty means FormatJSON.
+	Format Format `yaml:"format"`
+}
+
+// Notify posts event to the webhook.
+func (w Webhook) Notify(ctx context.Context, client *http.Client, event Event) error {
+	format, err := ParseFormat(string(w.Format))
+	if err != nil {
+		return err
+	}
+
+	var payload any = event
+	if format == FormatSlack {
+		payload = map[string]string{"text": SlackMessage(event)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL %s: %w", redactURL(w.URL), withoutURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "docfinder")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s delivery failed: %w", redactURL(w.URL), withoutURL(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded %s", redactURL(w.URL), resp.Status)
+	}
+	return nil
+}
+
+// SlackMessage formats event as Slack mrkdwn: the summary followed by the
+// changes of each endpoint, breaking ones flagged.
+func SlackMessage(event Event) string {
+	var msg strings.Builder
+	icon := ":memo:"
+	if event.Breaking() > 0 {
+		icon = ":warning:"
+	}
+	fmt.Fprintf(&msg, "%s *%s*\n", icon, event.Summary())
+
+	listed := 0
+	total := 0
+	for _, endpoint := range event.Endpoints {
+		for _, change := range endpoint.Changes {
+			total++
+			if listed == maxSlackChanges {
+				continue
+			}
+			listed++
+			marker := ""
+			if change.Breaking {
+				marker = " *(breaking)*"
+			}
+			fmt.Fprintf(&msg, "• `%s` %s%s\n", endpoint.Path, change.Description, marker)
+		}
+	}
+	if total > listed {
+		fmt.Fprintf(&msg, "…and %d more change(s)\n", total-listed)
+	}
+	return msg.String()
+}
+
+// redactURL hides the path and credentials of webhook URLs, which for Slack
+// and most chat tools are the secret.
+func redactURL(raw string) string {
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok {
+		return "(webhook)"
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	return scheme + "://" + host + "/…"
+}
+
+// withoutURL returns the cause of a *url.Error, whose message includes the
+// full webhook URL, or err itself.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
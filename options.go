@@ -0,0 +1,197 @@
+package docfinder
+
+import "github.com/arthur-s/docfinder/internal/generator"
+
+// Option configures rendering, e.g. WithMethod("GET").
+type Option = generator.Option
+
+// Format is an output format.
+type Format = generator.Format
+
+// Output formats.
+const (
+	// FormatMarkdown is the default output format.
+	FormatMarkdown = generator.FormatMarkdown
+	// FormatJSON renders a JSON document whose elements carry the JSON
+	// pointers of their source locations.
+	FormatJSON = generator.FormatJSONDocument
+	// FormatGitHubComment renders markdown for a GitHub comment, each
+	// operation in a collapsible block.
+	FormatGitHubComment = generator.FormatGitHubComment
+	// FormatMDX renders markdown that MDX, as used by Docusaurus, parses as
+	// written.
+	FormatMDX = generator.FormatMDX
+	// FormatTerm renders text for reading in a terminal.
+	FormatTerm = generator.FormatTerm
+)
+
+// Section identifies a part of an operation's documentation.
+type Section = generator.Section
+
+// Sections of an operation's documentation, in rendering order.
+const (
+	SectionMetadata    = generator.SectionMetadata
+	SectionParameters  = generator.SectionParameters
+	SectionRequestBody = generator.SectionRequestBody
+	SectionResponses   = generator.SectionResponses
+	SectionSecurity    = generator.SectionSecurity
+	SectionExamples    = generator.SectionExamples
+)
+
+// PropertyOrder is the order schema properties are listed in.
+type PropertyOrder = generator.PropertyOrder
+
+// Property orders.
+const (
+	// PropertyOrderSpec lists properties in the order the spec declares
+	// them, or alphabetically if that order is unknown.
+	PropertyOrderSpec = generator.PropertyOrderSpec
+	// PropertyOrderAlpha lists properties alphabetically.
+	PropertyOrderAlpha = generator.PropertyOrderAlpha
+)
+
+// NullableStyle is how types that accept null are displayed.
+type NullableStyle = generator.NullableStyle
+
+// Nullable styles.
+const (
+	// NullableSuffix displays e.g. "string (nullable)".
+	NullableSuffix = generator.NullableSuffix
+	// NullableField displays the type and a separate "Nullable: true" line.
+	NullableField = generator.NullableField
+	// NullableUnion displays e.g. "string | null".
+	NullableUnion = generator.NullableUnion
+)
+
+// Vocabulary overrides section headings and labels. Unset fields keep their
+// defaults.
+type Vocabulary = generator.Vocabulary
+
+// SnippetLanguage renders a SnippetRequest as code in one language.
+// Implement it to add languages beyond the built-in ones.
+type SnippetLanguage = generator.SnippetLanguage
+
+// SnippetRequest is the language-neutral request a snippet makes.
+type SnippetRequest = generator.SnippetRequest
+
+// SnippetHeader is a request header of a SnippetRequest.
+type SnippetHeader = generator.SnippetHeader
+
+// SnippetLanguages lists the built-in snippet languages: curl, Go, Python,
+// and JavaScript.
+var SnippetLanguages = generator.SnippetLanguages
+
+// ParseSnippets parses a comma-separated list of built-in snippet
+// languages, e.g. "curl,python".
+func ParseSnippets(s string) ([]SnippetLanguage, error) {
+	return generator.ParseSnippets(s)
+}
+
+// WithMethod restricts output to one HTTP method (case-insensitive).
+func WithMethod(method string) Option {
+	return generator.WithMethod(method)
+}
+
+// WithFormat sets the output format.
+func WithFormat(format Format) Option {
+	return generator.WithFormat(format)
+}
+
+// WithSections restricts output to the given sections.
+func WithSections(sections ...Section) Option {
+	return generator.WithSections(sections...)
+}
+
+// WithMaxDepth limits schema recursion depth.
+func WithMaxDepth(depth int) Option {
+	return generator.WithMaxDepth(depth)
+}
+
+// WithContentTypes restricts bodies to matching media types, e.g.
+// "application/json" or "image/*".
+func WithContentTypes(contentTypes ...string) Option {
+	return generator.WithContentTypes(contentTypes...)
+}
+
+// WithExtensions toggles rendering of x- vendor extensions.
+func WithExtensions(include bool) Option {
+	return generator.WithExtensions(include)
+}
+
+// WithTypeCaveats toggles the section listing body fields whose examples
+// contradict their declared types.
+func WithTypeCaveats(enabled bool) Option {
+	return generator.WithTypeCaveats(enabled)
+}
+
+// WithMaxExampleLines sets the line count after which examples are
+// truncated.
+func WithMaxExampleLines(lines int) Option {
+	return generator.WithMaxExampleLines(lines)
+}
+
+// WithTrimExamples removes null-valued fields and fields matching keys from
+// examples.
+func WithTrimExamples(keys ...string) Option {
+	return generator.WithTrimExamples(keys...)
+}
+
+// WithMarkdownDescriptions toggles rendering descriptions as markdown
+// blocks.
+func WithMarkdownDescriptions(enabled bool) Option {
+	return generator.WithMarkdownDescriptions(enabled)
+}
+
+// WithMinVersion hides elements not available in the given API version.
+func WithMinVersion(version string) Option {
+	return generator.WithMinVersion(version)
+}
+
+// WithVocabulary overrides section headings and labels.
+func WithVocabulary(v Vocabulary) Option {
+	return generator.WithVocabulary(v)
+}
+
+// WithEnvironment lists only the servers tagged with env via x-environment.
+func WithEnvironment(env string) Option {
+	return generator.WithEnvironment(env)
+}
+
+// WithSchemaPath renders only the sub-schema of each body addressed by
+// expr, such as "$.data.items[*].attributes".
+func WithSchemaPath(expr string) Option {
+	return generator.WithSchemaPath(expr)
+}
+
+// WithQuickReference toggles the list of required inputs at the start of
+// each operation.
+func WithQuickReference(enabled bool) Option {
+	return generator.WithQuickReference(enabled)
+}
+
+// WithPropertyOrder sets the order schema properties are listed in.
+func WithPropertyOrder(order PropertyOrder) Option {
+	return generator.WithPropertyOrder(order)
+}
+
+// WithNullable sets how types that accept null are displayed.
+func WithNullable(style NullableStyle) Option {
+	return generator.WithNullable(style)
+}
+
+// WithSnippets shows each operation's request as code in the given
+// languages, in order.
+func WithSnippets(languages ...SnippetLanguage) Option {
+	return generator.WithSnippets(languages...)
+}
+
+// WithFoldDepth folds schemas nested deeper than n levels into collapsible
+// blocks.
+func WithFoldDepth(n int) Option {
+	return generator.WithFoldDepth(n)
+}
+
+// WithOutline renders only the heading structure of the output.
+func WithOutline(enabled bool) Option {
+	return generator.WithOutline(enabled)
+}
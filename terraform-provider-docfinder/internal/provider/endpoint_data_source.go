@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arthur-s/docfinder/internal/endpoint"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// endpointDataSource is the docfinder_endpoint data source. Reading an
+// endpoint the spec lacks fails the plan, so routes cannot drift from the
+// spec unnoticed.
+type endpointDataSource struct {
+	specs *specCache
+}
+
+// endpointModel is the docfinder_endpoint data source.
+type endpointModel struct {
+	Spec           types.String `tfsdk:"spec"`
+	Method         types.String `tfsdk:"method"`
+	Path           types.String `tfsdk:"path"`
+	Template       types.String `tfsdk:"template"`
+	OperationID    types.String `tfsdk:"operation_id"`
+	Summary        types.String `tfsdk:"summary"`
+	Deprecated     types.Bool   `tfsdk:"deprecated"`
+	Tags           types.List   `tfsdk:"tags"`
+	AuthRequired   types.Bool   `tfsdk:"auth_required"`
+	AuthSchemes    types.List   `tfsdk:"auth_schemes"`
+	Scopes         types.List   `tfsdk:"scopes"`
+	Timeout        types.String `tfsdk:"timeout"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+	Servers        types.List   `tfsdk:"servers"`
+}
+
+func newEndpointDataSource() datasource.DataSource {
+	return &endpointDataSource{}
+}
+
+func (d *endpointDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint"
+}
+
+func (d *endpointDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "An operation of an OpenAPI spec. Reading fails if the spec has no such operation.",
+		Attributes: map[string]schema.Attribute{
+			"spec": schema.StringAttribute{
+				Description: "The OpenAPI or Swagger 2.0 spec: a file, a directory of split spec files, or an http(s) URL.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "The HTTP method, in any case.",
+				Required:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The path template, e.g. \"/orders/{id}\". Parameter names need not match the spec's.",
+				Required:    true,
+			},
+			"template": schema.StringAttribute{
+				Description: "The path template as the spec writes it.",
+				Computed:    true,
+			},
+			"operation_id": schema.StringAttribute{Computed: true},
+			"summary":      schema.StringAttribute{Computed: true},
+			"deprecated":   schema.BoolAttribute{Computed: true},
+			"tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"auth_required": schema.BoolAttribute{
+				Description: "Whether every security requirement names a scheme; false for public operations and those where authentication is optional.",
+				Computed:    true,
+			},
+			"auth_schemes": schema.ListAttribute{
+				Description: "The security schemes the operation accepts, sorted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: "The scopes the security requirements ask for, sorted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "The x-timeout of the operation, its path item, or the spec, as a duration such as \"30s\". Empty if unset.",
+				Computed:    true,
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "The timeout in whole seconds, rounded up; 0 if unset.",
+				Computed:    true,
+			},
+			"servers": schema.ListAttribute{
+				Description: "The base URLs the operation is served from.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *endpointDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		// Not configured yet, e.g. while validating
+		return
+	}
+	specs, ok := req.ProviderData.(*specCache)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *specCache, got %T.", req.ProviderData))
+		return
+	}
+	d.specs = specs
+}
+
+func (d *endpointDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state endpointModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	doc, err := d.specs.load(state.Spec.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("spec"), "Failed to load spec", err.Error())
+		return
+	}
+
+	info, err := endpoint.Lookup(doc, state.Method.ValueString(), state.Path.ValueString())
+	if errors.Is(err, endpoint.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Endpoint not in spec", fmt.Sprintf("%s: %v", state.Spec.ValueString(), err))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read endpoint", err.Error())
+		return
+	}
+
+	state.Template = types.StringValue(info.Path)
+	state.OperationID = types.StringValue(info.OperationID)
+	state.Summary = types.StringValue(info.Summary)
+	state.Deprecated = types.BoolValue(info.Deprecated)
+	state.AuthRequired = types.BoolValue(info.AuthRequired)
+	state.Timeout = types.StringValue("")
+	if info.Timeout > 0 {
+		state.Timeout = types.StringValue(info.Timeout.String())
+	}
+	state.TimeoutSeconds = types.Int64Value(int64((info.Timeout + time.Second - 1) / time.Second))
+	for _, list := range []struct {
+		dst    *types.List
+		values []string
+	}{
+		{&state.Tags, info.Tags},
+		{&state.AuthSchemes, info.AuthSchemes},
+		{&state.Scopes, info.Scopes},
+		{&state.Servers, info.Servers},
+	} {
+		values := list.values
+		if values == nil {
+			values = []string{}
+		}
+		v, diags := types.ListValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+		*list.dst = v
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testSpec = `openapi: 3.0.3
+info:
+  title: Orders
+  version: "1.0"
+servers:
+  - url: https://api.example.com
+security:
+  - oauth2: [orders:read]
+paths:
+  /orders/{order_id}:
+    get:
+      operationId: getOrder
+      summary: Get an order
+      tags: [orders]
+      x-timeout: 15s
+      responses:
+        "200":
+          description: OK
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        "200":
+          description: OK
+`
+
+// readEndpoint reads the docfinder_endpoint data source for method and
+// endpoint of the spec at specPath.
+func readEndpoint(t *testing.T, specPath, method, endpoint string) (endpointModel, *datasource.ReadResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	d := &endpointDataSource{specs: newSpecCache(spec.LoadOptions{})}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	schema := schemaResp.Schema
+
+	objectType := schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	values["spec"] = tftypes.NewValue(tftypes.String, specPath)
+	values["method"] = tftypes.NewValue(tftypes.String, method)
+	values["path"] = tftypes.NewValue(tftypes.String, endpoint)
+	raw := tftypes.NewValue(objectType, values)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Raw: raw, Schema: schema}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Raw: raw, Schema: schema}}
+	d.Read(ctx, req, resp)
+
+	var state endpointModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	}
+	return state, resp
+}
+
+func TestEndpointDataSource_Read(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(testSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	state, resp := readEndpoint(t, specPath, "get", "/orders/{id}")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics: %v", resp.Diagnostics)
+	}
+
+	if got := state.Template.ValueString(); got != "/orders/{order_id}" {
+		t.Errorf("template = %q, want /orders/{order_id}", got)
+	}
+	if got := state.OperationID.ValueString(); got != "getOrder" {
+		t.Errorf("operation_id = %q, want getOrder", got)
+	}
+	if !state.AuthRequired.ValueBool() {
+		t.Error("Expected auth_required")
+	}
+	if got := state.Timeout.ValueString(); got != "15s" {
+		t.Errorf("timeout = %q, want 15s", got)
+	}
+	if got := state.TimeoutSeconds.ValueInt64(); got != 15 {
+		t.Errorf("timeout_seconds = %d, want 15", got)
+	}
+	lists := map[string]types.List{
+		"auth_schemes": state.AuthSchemes,
+		"scopes":       state.Scopes,
+		"servers":      state.Servers,
+		"tags":         state.Tags,
+	}
+	want := map[string]string{
+		"auth_schemes": `["oauth2"]`,
+		"scopes":       `["orders:read"]`,
+		"servers":      `["https://api.example.com"]`,
+		"tags":         `["orders"]`,
+	}
+	for name, list := range lists {
+		if got := list.String(); got != want[name] {
+			t.Errorf("%s = %s, want %s", name, got, want[name])
+		}
+	}
+
+	state, resp = readEndpoint(t, specPath, "GET", "/health")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() diagnostics: %v", resp.Diagnostics)
+	}
+	if state.AuthRequired.ValueBool() || state.Timeout.ValueString() != "" || state.AuthSchemes.String() != "[]" {
+		t.Errorf("Expected a public endpoint without timeout, got auth_required=%v auth_schemes=%s timeout=%q",
+			state.AuthRequired, state.AuthSchemes, state.Timeout.ValueString())
+	}
+}
+
+func TestEndpointDataSource_ReadMissing(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(specPath, []byte(testSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{"POST", "/orders/{id}", "no POST operation"},
+		{"GET", "/invoices", "no path /invoices"},
+	}
+	for _, tt := range tests {
+		_, resp := readEndpoint(t, specPath, tt.method, tt.path)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected an error reading %s %s", tt.method, tt.path)
+			continue
+		}
+		if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.want) {
+			t.Errorf("Expected %q in error:\n%s", tt.want, detail)
+		}
+	}
+
+	if _, resp := readEndpoint(t, filepath.Join(t.TempDir(), "missing.yaml"), "GET", "/health"); !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for a missing spec")
+	}
+}
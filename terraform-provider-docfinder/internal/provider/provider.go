@@ -0,0 +1,146 @@
+// Package provider implements the docfinder Terraform provider. Specs are
+// loaded with the same code as the docfinder command, so a spec that
+// renders with docfinder resolves the same way here, and each spec is
+// loaded once per run however many data sources read it.
+package provider
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/arthur-s/docfinder/internal/spec"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Address is the registry address the provider is published under.
+const Address = "registry.terraform.io/arthur-s/docfinder"
+
+// docfinderProvider is the provider. It is configured once per run.
+type docfinderProvider struct {
+	version string
+}
+
+// providerModel is the provider configuration block.
+type providerModel struct {
+	Headers  types.Map  `tfsdk:"headers"`
+	Insecure types.Bool `tfsdk:"insecure"`
+	Offline  types.Bool `tfsdk:"offline"`
+}
+
+// New returns the constructor of the provider, for providerserver.Serve.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &docfinderProvider{version: version}
+	}
+}
+
+func (p *docfinderProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "docfinder"
+	resp.Version = p.version
+}
+
+func (p *docfinderProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads endpoints of OpenAPI specs, to check infrastructure such as API gateway routes against them.",
+		Attributes: map[string]schema.Attribute{
+			"headers": schema.MapAttribute{
+				Description: "Headers sent when fetching specs given as http(s) URLs, e.g. for credentials. Values are expanded from the environment, as in \"Bearer ${SPEC_TOKEN}\".",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Skip verifying the TLS certificate of servers specs are fetched from.",
+				Optional:    true,
+			},
+			"offline": schema.BoolAttribute{
+				Description: "Forbid network access: remote $refs are served only from docfinder's cache, and specs cannot be given as URLs.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (p *docfinderProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config providerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var opts spec.LoadOptions
+	opts.Refs.Offline = config.Offline.ValueBool()
+	opts.Fetch.Insecure = config.Insecure.ValueBool()
+	if !config.Headers.IsNull() {
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &opts.Fetch.Headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.DataSourceData = newSpecCache(opts)
+}
+
+func (p *docfinderProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{newEndpointDataSource}
+}
+
+func (p *docfinderProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+// specCache loads each spec once, however many data sources read it.
+type specCache struct {
+	opts spec.LoadOptions
+
+	mu   sync.Mutex
+	docs map[string]*cachedSpec
+}
+
+// cachedSpec is a spec loaded or being loaded. Data sources are read
+// concurrently, so the first to need a spec loads it and others wait.
+type cachedSpec struct {
+	once sync.Once
+	doc  *openapi3.T
+	err  error
+}
+
+func newSpecCache(opts spec.LoadOptions) *specCache {
+	return &specCache{opts: opts, docs: make(map[string]*cachedSpec)}
+}
+
+// load returns the spec at location: a file, a directory of split spec
+// files, or an http(s) URL.
+func (c *specCache) load(location string) (*openapi3.T, error) {
+	c.mu.Lock()
+	entry, ok := c.docs[location]
+	if !ok {
+		entry = &cachedSpec{}
+		c.docs[location] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.doc, entry.err = c.loadUncached(location)
+	})
+	return entry.doc, entry.err
+}
+
+func (c *specCache) loadUncached(location string) (*openapi3.T, error) {
+	if spec.IsURL(location) {
+		return spec.LoadURL(location, c.opts)
+	}
+	if info, err := os.Stat(location); err == nil && info.IsDir() {
+		root, err := spec.FindRoot(location)
+		if err != nil {
+			return nil, err
+		}
+		location = root
+	}
+	return spec.Load(location, c.opts)
+}
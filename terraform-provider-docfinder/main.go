@@ -0,0 +1,30 @@
+// Command terraform-provider-docfinder is a Terraform and OpenTofu provider
+// exposing the endpoints of OpenAPI specs as data sources, so configurations
+// provisioning API gateway routes can check them against the spec at plan
+// time. See the provider package for the implementation.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/arthur-s/docfinder/terraform-provider-docfinder/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+)
+
+// version is set at release time with -ldflags "-X main.version=...".
+var version = "dev"
+
+func main() {
+	debug := flag.Bool("debug", false, "Run the provider with support for debuggers like delve.")
+	flag.Parse()
+
+	err := providerserver.Serve(context.Background(), provider.New(version), providerserver.ServeOpts{
+		Address: provider.Address,
+		Debug:   *debug,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}